@@ -18,6 +18,8 @@ const ServiceName = "bookings"
 func main() {
 	cfg := config.Load(ServiceName)
 	cfg.SetMongo()
+	cfg.Client.SetBusinessUnitClient(cfg.BusinessUnitBaseUrl)
+	cfg.Client.SetScheduleClient(cfg.ScheduleBaseUrl)
 
 	cfg.Log.Info("Starting Bookings service")
 	bookingService := initServices(cfg)
@@ -30,9 +32,11 @@ func initServices(cfg *config.Config) service.BookingService {
 	bookingValidator := validator.NewBookingValidator(cfg.Log)
 	bookingRepo := repository.NewMongoBookingRepository(cfg)
 	bookingLockRepo := repository.NewBookingLockRepository(cfg)
+	bookingArchiveRepo := repository.NewMongoBookingArchiveRepository(cfg)
 	bookingService := service.NewBookingService(
 		bookingRepo,
 		bookingLockRepo,
+		bookingArchiveRepo,
 		bookingValidator,
 		cfg,
 	)
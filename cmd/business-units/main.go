@@ -18,23 +18,42 @@ const ServiceName = "business-units"
 func main() {
 	cfg := config.Load(ServiceName)
 	cfg.SetMongo()
+	cfg.Client.SetScheduleClient(cfg.ScheduleBaseUrl)
+	cfg.Client.SetBookingClient(cfg.BookingBaseUrl)
 
 	cfg.Log.Info("Starting Business Units service")
-	businessUnitService := initServices(cfg)
+	businessUnitService, deletionWorker := initServices(cfg)
 	serverApp := app.NewApplication(cfg)
 	serverApp.SetApp(handler.NewBusinessUnitHandler(businessUnitService, cfg.Log))
+	serverApp.AddWorker(deletionWorker)
 	serverApp.Run()
 }
 
-func initServices(cfg *config.Config) service.BusinessUnitService {
+func initServices(cfg *config.Config) (service.BusinessUnitService, *service.DeletionWorker) {
 	businessUnitValidator := validator.NewBusinessUnitValidator(cfg.Log)
 	businessUnitRepo := repository.NewMongoBusinessUnitRepository(cfg)
+	deletionRepo := repository.NewMongoDeletionRepository(cfg)
+	impressionRepo := repository.NewMongoImpressionRepository(cfg)
+	transferRepo := repository.NewMongoTransferRepository(cfg)
+	auditRepo := repository.NewMongoAuditRepository(cfg)
+	organizationRepo := repository.NewMongoOrganizationRepository(cfg)
+	planRepo := repository.NewMongoPlanRepository(cfg)
+	mergeRepo := repository.NewMongoMergeRepository(cfg)
+	deletionWorker := service.NewDeletionWorker(deletionRepo, cfg)
 	businessUnitService := service.NewBusinessUnitService(
 		businessUnitRepo,
+		deletionRepo,
+		impressionRepo,
+		transferRepo,
+		auditRepo,
+		organizationRepo,
+		planRepo,
+		mergeRepo,
+		deletionWorker,
 		businessUnitValidator,
 		cfg,
 	)
 
 	cfg.Log.Info("Business unit service initialized", "database", cfg.MongoDatabaseName)
-	return businessUnitService
+	return businessUnitService, deletionWorker
 }
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"skeji/internal/importer"
+	"skeji/pkg/auth"
+	"skeji/pkg/client"
+	"skeji/pkg/config"
+	"strings"
+)
+
+const JobName = "business-units-import"
+
+func main() {
+	file := flag.String("file", "", "CSV or JSONL file of business units to import, - for stdin")
+	format := flag.String("format", "", "csv or jsonl, detected from the file extension when empty")
+	dryRun := flag.Bool("dry-run", false, "validate the file and report what would be created without writing anything")
+	flag.Parse()
+
+	cfg := config.Load(JobName)
+	if *file == "" {
+		cfg.Log.Fatal("An import file is required, pass it with -file")
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+	}
+
+	var input io.Reader = os.Stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			cfg.Log.Fatal("Failed to open import file", "file", *file, "error", err)
+		}
+		defer f.Close()
+		input = f
+	}
+
+	records, rowErrors, err := importer.Parse(input, *format)
+	if err != nil {
+		cfg.Log.Fatal("Failed to parse import file", "file", *file, "error", err)
+	}
+
+	apiClient := client.NewClient()
+	apiClient.SetCaller(auth.SystemCaller, cfg.CallerSecret)
+	apiClient.SetBusinessUnitClient(cfg.BusinessUnitBaseUrl)
+	apiClient.SetScheduleClient(cfg.ScheduleBaseUrl)
+
+	cfg.Log.Info("Starting business units import", "file", *file, "records", len(records), "dry_run", *dryRun)
+	report := importer.NewImporter(cfg, apiClient.BusinessUnitClient, apiClient.ScheduleClient).Run(records, rowErrors, *dryRun)
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write import report: %v\n", err)
+		os.Exit(1)
+	}
+	if report.HasErrors() {
+		os.Exit(1)
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"net/http"
 	"os"
 	"skeji/internal/maestro/api"
+	"skeji/internal/maestro/declarative"
+	"skeji/pkg/auth"
 	"skeji/pkg/client"
 	"skeji/pkg/config"
+	"skeji/pkg/ranking"
 )
 
 const ServiceName = "maestro"
@@ -16,11 +19,20 @@ func main() {
 	cfg.Log.Info("Starting maestro service")
 
 	apiClient := client.NewClient()
+	apiClient.SetCaller(auth.SystemCaller, cfg.CallerSecret)
 	apiClient.SetBusinessUnitClient(cfg.BusinessUnitBaseUrl)
 	apiClient.SetScheduleClient(cfg.ScheduleBaseUrl)
 	apiClient.SetBookingClient(cfg.BookingBaseUrl)
 
-	router := api.SetupRouter(apiClient, cfg.Log)
+	ranker := ranking.NewRanker(cfg.RankingWeights).WithFairness(cfg.SearchFairness)
+	definitions, err := declarative.LoadDir(cfg.MaestroFlowsDir)
+	if err != nil {
+		cfg.Log.Fatal("failed to load flow definitions", "error", err)
+	}
+	router, err := api.SetupRouter(apiClient, ranker, definitions, cfg.Log)
+	if err != nil {
+		cfg.Log.Fatal("failed to set up router", "error", err)
+	}
 
 	if err := http.ListenAndServe(":"+cfg.Port, router); err != nil {
 		cfg.Log.Error("Server failed", "error", err)
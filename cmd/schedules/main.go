@@ -18,6 +18,8 @@ const ServiceName = "schedules"
 func main() {
 	cfg := config.Load(ServiceName)
 	cfg.SetMongo()
+	cfg.Client.SetBusinessUnitClient(cfg.BusinessUnitBaseUrl)
+	cfg.Client.SetBookingClient(cfg.BookingBaseUrl)
 
 	cfg.Log.Info("Starting Schedules service")
 	scheduleService := initServices(cfg)
@@ -29,8 +31,11 @@ func main() {
 func initServices(cfg *config.Config) service.ScheduleService {
 	businessUnitValidator := validator.NewScheduleValidator(cfg.Log)
 	businessUnitRepo := repository.NewMongoScheduleRepository(cfg)
+	templateRepo := repository.NewMongoScheduleTemplateRepository(cfg)
 	businessUnitService := service.NewScheduleService(
 		businessUnitRepo,
+		templateRepo,
+		service.NewLogNotifier(cfg.Log),
 		businessUnitValidator,
 		cfg,
 	)
@@ -73,6 +73,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.Booking"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -88,6 +101,161 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/bookings/archive": {
+            "post": {
+                "description": "Moves the schedule's bookings that ended by 'before' out of the live collection into the archive. Safe to repeat.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookings"
+                ],
+                "summary": "Archive ended bookings of a schedule",
+                "parameters": [
+                    {
+                        "description": "Schedule and cut-off time",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BookingArchiveRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.BookingArchiveResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/bookings/batch-search": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookings"
+                ],
+                "summary": "Batch search bookings across multiple schedules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "business_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of schedule IDs",
+                        "name": "schedule_ids",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start time (RFC3339)",
+                        "name": "start_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End time (RFC3339)",
+                        "name": "end_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -151,12 +319,37 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "204": {
                         "description": "No Content"
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -198,6 +391,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.BookingUpdate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -210,6 +416,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -225,6 +443,77 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/bookings/reassign": {
+            "post": {
+                "description": "Moves the schedule's bookings, live and archived, from one business unit to another when the schedule changes hands. Safe to repeat.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bookings"
+                ],
+                "summary": "Reassign bookings of a schedule to another business unit",
+                "parameters": [
+                    {
+                        "description": "Schedule and business units",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BookingReassignRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.BookingReassignResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/bookings/search": {
             "get": {
                 "produces": [
@@ -314,6 +603,9 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "data": {},
+                "facets": {
+                    "description": "Facets summarizes the whole result set, on searches that ask for it."
+                },
                 "limit": {
                     "type": "integer"
                 },
@@ -333,7 +625,6 @@ const docTemplate = `{
                 "end_time",
                 "managed_by",
                 "schedule_id",
-                "service_label",
                 "start_time",
                 "status"
             ],
@@ -375,6 +666,9 @@ const docTemplate = `{
                     "maxLength": 100,
                     "minLength": 2
                 },
+                "staff_id": {
+                    "type": "string"
+                },
                 "start_time": {
                     "type": "string"
                 },
@@ -388,6 +682,63 @@ const docTemplate = `{
                 }
             }
         },
+        "model.BookingArchiveRequest": {
+            "type": "object",
+            "required": [
+                "before",
+                "business_id",
+                "schedule_id"
+            ],
+            "properties": {
+                "before": {
+                    "type": "string"
+                },
+                "business_id": {
+                    "type": "string"
+                },
+                "schedule_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BookingArchiveResult": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.BookingReassignRequest": {
+            "type": "object",
+            "required": [
+                "from_business_id",
+                "schedule_id",
+                "to_business_id"
+            ],
+            "properties": {
+                "from_business_id": {
+                    "type": "string"
+                },
+                "schedule_id": {
+                    "type": "string"
+                },
+                "to_business_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BookingReassignResult": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "type": "integer"
+                },
+                "reassigned": {
+                    "type": "integer"
+                }
+            }
+        },
         "model.BookingUpdate": {
             "type": "object",
             "properties": {
@@ -411,11 +762,17 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "schedule_id": {
+                    "type": "string"
+                },
                 "service_label": {
                     "type": "string",
                     "maxLength": 100,
                     "minLength": 2
                 },
+                "staff_id": {
+                    "type": "string"
+                },
                 "start_time": {
                     "type": "string"
                 },
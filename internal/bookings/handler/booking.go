@@ -36,8 +36,12 @@ func NewBookingHandler(service service.BookingService, log *logger.Logger) *Book
 // @Accept json
 // @Produce json
 // @Param booking body model.Booking true "Booking data"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
 // @Success 201 {object} model.Booking
 // @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
 // @Failure 500 {object} httputil.ErrorResponse
 // @Router /api/v1/bookings [post]
 func (h *BookingHandler) Create(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
@@ -122,9 +126,13 @@ func (h *BookingHandler) GetAll(w http.ResponseWriter, r *http.Request, _ httpro
 // @Produce json
 // @Param id path string true "Booking ID"
 // @Param booking body model.BookingUpdate true "Booking update"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
 // @Success 204 "No Content"
 // @Failure 400 {object} httputil.ErrorResponse
 // @Failure 404 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
 // @Failure 500 {object} httputil.ErrorResponse
 // @Router /api/v1/bookings/id/{id} [patch]
 func (h *BookingHandler) Update(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
@@ -154,8 +162,12 @@ func (h *BookingHandler) Update(w http.ResponseWriter, r *http.Request, ps httpr
 // @Tags Bookings
 // @Produce json
 // @Param id path string true "Booking ID"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
 // @Success 204 "No Content"
 // @Failure 404 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
 // @Failure 500 {object} httputil.ErrorResponse
 // @Router /api/v1/bookings/id/{id} [delete]
 func (h *BookingHandler) Delete(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
@@ -339,6 +351,82 @@ func (h *BookingHandler) BatchSearch(w http.ResponseWriter, r *http.Request, _ h
 	}
 }
 
+// @Summary Archive ended bookings of a schedule
+// @Description Moves the schedule's bookings that ended by 'before' out of the live collection into the archive. Safe to repeat.
+// @Tags Bookings
+// @Accept json
+// @Produce json
+// @Param request body model.BookingArchiveRequest true "Schedule and cut-off time"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 200 {object} model.BookingArchiveResult
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/bookings/archive [post]
+func (h *BookingHandler) Archive(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req model.BookingArchiveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if writeErr := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "Invalid request body",
+		}); writeErr != nil {
+			h.log.Error("failed to write JSON response", "handler", "Archive", "operation", "WriteJSON", "error", writeErr)
+		}
+		return
+	}
+
+	result, err := h.service.Archive(r.Context(), &req)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "Archive", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteSuccess(w, result); err != nil {
+		h.log.Error("failed to write success response", "handler", "Archive", "operation", "WriteSuccess", "error", err)
+	}
+}
+
+// @Summary Reassign bookings of a schedule to another business unit
+// @Description Moves the schedule's bookings, live and archived, from one business unit to another when the schedule changes hands. Safe to repeat.
+// @Tags Bookings
+// @Accept json
+// @Produce json
+// @Param request body model.BookingReassignRequest true "Schedule and business units"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 200 {object} model.BookingReassignResult
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/bookings/reassign [post]
+func (h *BookingHandler) Reassign(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req model.BookingReassignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if writeErr := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "Invalid request body",
+		}); writeErr != nil {
+			h.log.Error("failed to write JSON response", "handler", "Reassign", "operation", "WriteJSON", "error", writeErr)
+		}
+		return
+	}
+
+	result, err := h.service.Reassign(r.Context(), &req)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "Reassign", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteSuccess(w, result); err != nil {
+		h.log.Error("failed to write success response", "handler", "Reassign", "operation", "WriteSuccess", "error", err)
+	}
+}
+
 func (h *BookingHandler) RegisterRoutes(router *httprouter.Router) {
 	// Swagger UI routes
 	router.Handler("GET", "/swagger/*any", httpSwagger.WrapHandler)
@@ -351,4 +439,6 @@ func (h *BookingHandler) RegisterRoutes(router *httprouter.Router) {
 	router.GET("/api/v1/bookings/id/:id", h.GetByID)
 	router.PATCH("/api/v1/bookings/id/:id", h.Update)
 	router.DELETE("/api/v1/bookings/id/:id", h.Delete)
+	router.POST("/api/v1/bookings/archive", h.Archive)
+	router.POST("/api/v1/bookings/reassign", h.Reassign)
 }
@@ -36,6 +36,9 @@ type BookingRepository interface {
 	FindByBusinessAndSchedule(ctx context.Context, businessID string, scheduleID string, startTime *time.Time, endTime *time.Time, limit int, offset int64) ([]*model.Booking, error)
 	BatchFindByBusinessAndSchedules(ctx context.Context, businessID string, scheduleIDs []string, startTime *time.Time, endTime *time.Time, limit int, offset int64) (map[string][]*model.Booking, error)
 	CountByBusinessAndSchedule(ctx context.Context, businessID string, scheduleID string, startTime *time.Time, endTime *time.Time) (int64, error)
+	FindEndedBefore(ctx context.Context, businessID string, scheduleID string, before time.Time, limit int) ([]*model.Booking, error)
+	DeleteByIDs(ctx context.Context, ids []string) (int64, error)
+	ReassignBusiness(ctx context.Context, fromBusinessID string, scheduleID string, toBusinessID string) (int64, error)
 	Count(ctx context.Context) (int64, error)
 	ExecuteTransaction(ctx context.Context, fn mongotx.TransactionFunc) error
 }
@@ -148,7 +151,9 @@ func (r *mongoBookingRepository) Update(ctx context.Context, id string, booking
 	filter := bson.M{"_id": objectID}
 	update := bson.M{
 		"$set": bson.M{
+			"schedule_id":   booking.ScheduleID,
 			"service_label": booking.ServiceLabel,
+			"staff_id":      booking.StaffID,
 			"start_time":    booking.StartTime,
 			"end_time":      booking.EndTime,
 			"capacity":      booking.Capacity,
@@ -332,6 +337,67 @@ func (r *mongoBookingRepository) buildSearchFilter(businessID string, scheduleID
 	return filter
 }
 
+// FindEndedBefore returns up to limit bookings of the schedule whose end time
+// is not after before, oldest first.
+func (r *mongoBookingRepository) FindEndedBefore(ctx context.Context, businessID string, scheduleID string, before time.Time, limit int) ([]*model.Booking, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	filter := bson.M{
+		"business_id": businessID,
+		"schedule_id": scheduleID,
+		"end_time":    bson.M{"$lte": before},
+	}
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "start_time", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find ended bookings: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var bookings []*model.Booking
+	if err = cursor.All(ctx, &bookings); err != nil {
+		return nil, fmt.Errorf("failed to decode ended bookings: %w", err)
+	}
+
+	return bookings, nil
+}
+
+func (r *mongoBookingRepository) DeleteByIDs(ctx context.Context, ids []string) (int64, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	objectIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s", bookingserrors.ErrInvalidID, id)
+		}
+		objectIDs = append(objectIDs, objectID)
+	}
+
+	result, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": objectIDs}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete bookings: %w", err)
+	}
+	return result.DeletedCount, nil
+}
+
+func (r *mongoBookingRepository) ReassignBusiness(ctx context.Context, fromBusinessID string, scheduleID string, toBusinessID string) (int64, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	filter := bson.M{"business_id": fromBusinessID, "schedule_id": scheduleID}
+	result, err := r.collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"business_id": toBusinessID}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to reassign bookings: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
+
 func (r *mongoBookingRepository) Count(ctx context.Context) (int64, error) {
 	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
 	defer cancel()
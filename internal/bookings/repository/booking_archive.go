@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"skeji/pkg/config"
+	"skeji/pkg/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	ArchiveCollectionName = "Bookings_archive"
+)
+
+// BookingArchiveRepository stores bookings removed from the live collection,
+// e.g. the history of a schedule that was closed.
+type BookingArchiveRepository interface {
+	Archive(ctx context.Context, bookings []*model.Booking) error
+	ReassignBusiness(ctx context.Context, fromBusinessID string, scheduleID string, toBusinessID string) (int64, error)
+}
+
+type mongoBookingArchiveRepository struct {
+	cfg        *config.Config
+	collection *mongo.Collection
+}
+
+type archivedBooking struct {
+	model.Booking `bson:",inline"`
+	ArchivedAt    time.Time `bson:"archived_at"`
+}
+
+func NewMongoBookingArchiveRepository(cfg *config.Config) BookingArchiveRepository {
+	db := cfg.Client.Mongo.Client.Database(cfg.MongoDatabaseName)
+	return &mongoBookingArchiveRepository{
+		cfg:        cfg,
+		collection: db.Collection(ArchiveCollectionName),
+	}
+}
+
+// Archive upserts the bookings by ID, so archiving the same booking twice is
+// harmless.
+func (r *mongoBookingArchiveRepository) Archive(ctx context.Context, bookings []*model.Booking) error {
+	if len(bookings) == 0 {
+		return nil
+	}
+	now := time.Now()
+	writes := make([]mongo.WriteModel, 0, len(bookings))
+	for _, b := range bookings {
+		objectID, err := primitive.ObjectIDFromHex(b.ID)
+		if err != nil {
+			return fmt.Errorf("invalid booking ID %s: %w", b.ID, err)
+		}
+		doc := archivedBooking{Booking: *b, ArchivedAt: now}
+		doc.ID = ""
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": objectID}).
+			SetReplacement(doc).
+			SetUpsert(true))
+	}
+
+	if _, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to archive bookings: %w", err)
+	}
+	return nil
+}
+
+func (r *mongoBookingArchiveRepository) ReassignBusiness(ctx context.Context, fromBusinessID string, scheduleID string, toBusinessID string) (int64, error) {
+	filter := bson.M{"business_id": fromBusinessID, "schedule_id": scheduleID}
+	result, err := r.collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"business_id": toBusinessID}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to reassign archived bookings: %w", err)
+	}
+	return result.ModifiedCount, nil
+}
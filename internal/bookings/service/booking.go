@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	bookingserrors "skeji/internal/bookings/errors"
 	"skeji/internal/bookings/repository"
 	"skeji/internal/bookings/validator"
+	"skeji/pkg/availability"
 	"skeji/pkg/config"
 	apperrors "skeji/pkg/errors"
 	"skeji/pkg/model"
@@ -25,26 +27,31 @@ type BookingService interface {
 	Delete(ctx context.Context, id string) error
 	SearchBySchedule(ctx context.Context, businessID string, scheduleID string, startTime, endTime *time.Time, limit int, offset int64) ([]*model.Booking, int64, error)
 	BatchSearchBySchedules(ctx context.Context, businessID string, scheduleIDs []string, startTime, endTime *time.Time, limit int, offset int64) (map[string][]*model.Booking, error)
+	Archive(ctx context.Context, req *model.BookingArchiveRequest) (*model.BookingArchiveResult, error)
+	Reassign(ctx context.Context, req *model.BookingReassignRequest) (*model.BookingReassignResult, error)
 }
 
 type bookingService struct {
-	repo      repository.BookingRepository
-	lockRepo  repository.BookingLockRepository
-	validator *validator.BookingValidator
-	cfg       *config.Config
+	repo        repository.BookingRepository
+	lockRepo    repository.BookingLockRepository
+	archiveRepo repository.BookingArchiveRepository
+	validator   *validator.BookingValidator
+	cfg         *config.Config
 }
 
 func NewBookingService(
 	repo repository.BookingRepository,
 	lockRepo repository.BookingLockRepository,
+	archiveRepo repository.BookingArchiveRepository,
 	validator *validator.BookingValidator,
 	cfg *config.Config,
 ) BookingService {
 	return &bookingService{
-		repo:      repo,
-		lockRepo:  lockRepo,
-		validator: validator,
-		cfg:       cfg,
+		repo:        repo,
+		lockRepo:    lockRepo,
+		archiveRepo: archiveRepo,
+		validator:   validator,
+		cfg:         cfg,
 	}
 }
 
@@ -55,8 +62,28 @@ func (s *bookingService) Create(ctx context.Context, booking *model.Booking) err
 	if err != nil {
 		return err
 	}
+	if err := s.authorizeCreate(ctx, booking); err != nil {
+		return err
+	}
+	if err := s.verifyBusinessOpen(booking); err != nil {
+		return err
+	}
+	sc, err := s.fetchSchedule(booking)
+	if err != nil {
+		return err
+	}
+	err = s.verifyScheduleHours(booking, sc)
+	if err != nil {
+		return err
+	}
 
-	lockID, err := s.acquireSlotLock(ctx, booking.BusinessID, booking.ScheduleID, booking.StartTime)
+	// Resolve "any" before locking so the lock covers the member who will
+	// actually serve the booking; the transaction checks them again.
+	err = s.assignStaff(ctx, booking, sc)
+	if err != nil {
+		return err
+	}
+	lockID, err := s.acquireSlotLock(ctx, booking.BusinessID, booking.ScheduleID, booking.StaffID, booking.StartTime)
 	if err != nil {
 		return err
 	}
@@ -67,6 +94,10 @@ func (s *bookingService) Create(ctx context.Context, booking *model.Booking) err
 	}()
 
 	err = s.repo.ExecuteTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		err = s.assignStaff(sessCtx, booking, sc)
+		if err != nil {
+			return err
+		}
 		err = s.verifyDuplication(ctx, booking)
 		if err != nil {
 			return err
@@ -86,6 +117,7 @@ func (s *bookingService) Create(ctx context.Context, booking *model.Booking) err
 		"id", booking.ID,
 		"business_id", booking.BusinessID,
 		"schedule_id", booking.ScheduleID,
+		"staff_id", booking.StaffID,
 		"start_time", booking.StartTime,
 	)
 	return nil
@@ -165,13 +197,34 @@ func (s *bookingService) Update(ctx context.Context, id string, updates *model.B
 		s.cfg.Log.Warn("Booking update validation failed", "id", id, "error", err)
 		return apperrors.Validation("Invalid update input", map[string]any{"error": err.Error()})
 	}
+	if err := s.authorizeUpdate(ctx, existing, updates); err != nil {
+		return err
+	}
 	merged := s.mergeBookingUpdates(existing, updates)
 	s.sanitize(merged)
 	err = s.validate(merged)
 	if err != nil {
 		return err
 	}
+	// cancelling stays possible whatever became of the business
+	if merged.Status != config.Cancelled {
+		if err := s.verifyBusinessOpen(merged); err != nil {
+			return err
+		}
+	}
+	sc, err := s.fetchSchedule(merged)
+	if err != nil {
+		return err
+	}
+	err = s.verifyScheduleHours(merged, sc)
+	if err != nil {
+		return err
+	}
 	err = s.repo.ExecuteTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		err = s.assignStaff(sessCtx, merged, sc)
+		if err != nil {
+			return err
+		}
 		err = s.verifyDuplication(sessCtx, merged)
 		if err != nil {
 			return apperrors.Conflict(fmt.Sprintf("conflict appeared during update: %v", err))
@@ -193,8 +246,15 @@ func (s *bookingService) Delete(ctx context.Context, id string) error {
 	if id == "" {
 		return apperrors.InvalidInput("Booking ID cannot be empty")
 	}
+	existing, err := s.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeStaff(ctx, existing, "delete bookings"); err != nil {
+		return err
+	}
 
-	err := s.repo.ExecuteTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+	err = s.repo.ExecuteTransaction(ctx, func(sessCtx mongo.SessionContext) error {
 		if err := s.repo.Delete(sessCtx, id); err != nil {
 			if errors.Is(err, bookingserrors.ErrNotFound) {
 				return apperrors.NotFoundWithID("Booking", id)
@@ -311,6 +371,114 @@ func (s *bookingService) BatchSearchBySchedules(ctx context.Context, businessID
 
 // --- Helpers ---
 
+// Archive moves the schedule's bookings that ended by req.Before into the
+// archive, batch by batch. Each batch is copied and removed in one
+// transaction, so an interrupted run can simply be repeated.
+func (s *bookingService) Archive(ctx context.Context, req *model.BookingArchiveRequest) (*model.BookingArchiveResult, error) {
+	if err := s.authorizeSystem(ctx, "archive bookings"); err != nil {
+		return nil, err
+	}
+	if req.BusinessID == "" || req.ScheduleID == "" {
+		return nil, apperrors.InvalidInput("business_id and schedule_id are required")
+	}
+	if req.Before.IsZero() {
+		return nil, apperrors.InvalidInput("before is required")
+	}
+
+	const batchSize = 500
+	result := &model.BookingArchiveResult{}
+	for {
+		bookings, err := s.repo.FindEndedBefore(ctx, req.BusinessID, req.ScheduleID, req.Before, batchSize)
+		if err != nil {
+			s.cfg.Log.Error("Failed to find bookings to archive",
+				"business_id", req.BusinessID,
+				"schedule_id", req.ScheduleID,
+				"error", err,
+			)
+			return nil, apperrors.Internal("Failed to archive bookings", err)
+		}
+		if len(bookings) == 0 {
+			break
+		}
+
+		ids := make([]string, 0, len(bookings))
+		for _, b := range bookings {
+			ids = append(ids, b.ID)
+		}
+		var deleted int64
+		err = s.repo.ExecuteTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+			if err := s.archiveRepo.Archive(sessCtx, bookings); err != nil {
+				return err
+			}
+			deleted, err = s.repo.DeleteByIDs(sessCtx, ids)
+			return err
+		})
+		if err != nil {
+			s.cfg.Log.Error("Failed to archive bookings",
+				"business_id", req.BusinessID,
+				"schedule_id", req.ScheduleID,
+				"error", err,
+			)
+			return nil, apperrors.Internal("Failed to archive bookings", err)
+		}
+		result.Archived += deleted
+		if len(bookings) < batchSize {
+			break
+		}
+	}
+
+	s.cfg.Log.Info("Bookings archived",
+		"business_id", req.BusinessID,
+		"schedule_id", req.ScheduleID,
+		"before", req.Before,
+		"archived", result.Archived,
+	)
+	return result, nil
+}
+
+// Reassign moves the schedule's bookings, including archived ones, to
+// another business unit. Bookings already moved are left alone, so the call
+// can be repeated.
+func (s *bookingService) Reassign(ctx context.Context, req *model.BookingReassignRequest) (*model.BookingReassignResult, error) {
+	if err := s.authorizeSystem(ctx, "reassign bookings"); err != nil {
+		return nil, err
+	}
+	if req.FromBusinessID == "" || req.ScheduleID == "" || req.ToBusinessID == "" {
+		return nil, apperrors.InvalidInput("from_business_id, schedule_id and to_business_id are required")
+	}
+	if req.FromBusinessID == req.ToBusinessID {
+		return nil, apperrors.InvalidInput("from_business_id and to_business_id must differ")
+	}
+
+	result := &model.BookingReassignResult{}
+	err := s.repo.ExecuteTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		var err error
+		if result.Reassigned, err = s.repo.ReassignBusiness(sessCtx, req.FromBusinessID, req.ScheduleID, req.ToBusinessID); err != nil {
+			return err
+		}
+		result.Archived, err = s.archiveRepo.ReassignBusiness(sessCtx, req.FromBusinessID, req.ScheduleID, req.ToBusinessID)
+		return err
+	})
+	if err != nil {
+		s.cfg.Log.Error("Failed to reassign bookings",
+			"from_business_id", req.FromBusinessID,
+			"schedule_id", req.ScheduleID,
+			"to_business_id", req.ToBusinessID,
+			"error", err,
+		)
+		return nil, apperrors.Internal("Failed to reassign bookings", err)
+	}
+
+	s.cfg.Log.Info("Bookings reassigned",
+		"from_business_id", req.FromBusinessID,
+		"schedule_id", req.ScheduleID,
+		"to_business_id", req.ToBusinessID,
+		"reassigned", result.Reassigned,
+		"archived", result.Archived,
+	)
+	return result, nil
+}
+
 func (s *bookingService) sanitize(b *model.Booking) {
 	b.ServiceLabel = sanitizer.SanitizeCityOrLabel(b.ServiceLabel)
 	sanitizedParticipants := map[string]string{}
@@ -337,9 +505,15 @@ func (s *bookingService) applyDefaults(b *model.Booking) {
 func (s *bookingService) mergeBookingUpdates(existing *model.Booking, updates *model.BookingUpdate) *model.Booking {
 	merged := *existing
 
+	if updates.ScheduleID != "" {
+		merged.ScheduleID = updates.ScheduleID
+	}
 	if updates.ServiceLabel != "" {
 		merged.ServiceLabel = updates.ServiceLabel
 	}
+	if updates.StaffID != "" {
+		merged.StaffID = updates.StaffID
+	}
 	if updates.StartTime != nil {
 		merged.StartTime = *updates.StartTime
 	}
@@ -370,6 +544,149 @@ func (s *bookingService) validate(booking *model.Booking) error {
 	return nil
 }
 
+// verifyBusinessOpen rejects bookings for business units that do not exist or
+// are not active. A business units service that cannot tell makes the booking
+// wait rather than skip the check.
+func (s *bookingService) verifyBusinessOpen(booking *model.Booking) error {
+	if s.cfg.Client == nil || s.cfg.Client.BusinessUnitClient == nil {
+		return nil
+	}
+	resp, err := s.cfg.Client.BusinessUnitClient.GetByID(booking.BusinessID)
+	if err != nil {
+		s.cfg.Log.Error("Failed to fetch business unit for booking validation", "business_id", booking.BusinessID, "error", err)
+		return apperrors.Unavailable("Business units service")
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		s.cfg.Log.Warn("Booking rejected, business unit not found", "business_id", booking.BusinessID)
+		return apperrors.Validation("Booking validation failed", map[string]any{"error": "business_id: business unit not found"})
+	default:
+		s.cfg.Log.Error("Business unit unavailable for booking validation", "business_id", booking.BusinessID, "status", resp.StatusCode)
+		return apperrors.Unavailable("Business units service")
+	}
+	bu, err := s.cfg.Client.BusinessUnitClient.DecodeBusinessUnit(resp)
+	if err != nil {
+		return apperrors.Internal("Failed to decode business unit", err)
+	}
+	if !config.IsLiveBusinessStatus(bu.Status) {
+		s.cfg.Log.Warn("Booking rejected, business unit is not active", "business_id", booking.BusinessID, "status", bu.Status)
+		return apperrors.Conflict(fmt.Sprintf("Business unit is %s and does not take bookings", bu.Status))
+	}
+	return nil
+}
+
+// fetchSchedule loads the booking's schedule for the checks that depend on it.
+// A schedule that does not exist or belongs to another business fails
+// validation, which also covers updates moving a booking between schedules,
+// and a schedules service that cannot tell makes the booking wait rather than
+// skip the checks.
+func (s *bookingService) fetchSchedule(booking *model.Booking) (*model.Schedule, error) {
+	if s.cfg.Client == nil || s.cfg.Client.ScheduleClient == nil {
+		return nil, nil
+	}
+	resp, err := s.cfg.Client.ScheduleClient.GetByID(booking.ScheduleID)
+	if err != nil {
+		s.cfg.Log.Error("Failed to fetch schedule for booking validation", "schedule_id", booking.ScheduleID, "error", err)
+		return nil, apperrors.Unavailable("Schedules service")
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		s.cfg.Log.Warn("Booking rejected, schedule not found", "schedule_id", booking.ScheduleID)
+		return nil, apperrors.Validation("Booking validation failed", map[string]any{"error": "schedule_id: schedule not found"})
+	default:
+		s.cfg.Log.Error("Schedule unavailable for booking validation", "schedule_id", booking.ScheduleID, "status", resp.StatusCode)
+		return nil, apperrors.Unavailable("Schedules service")
+	}
+	sc, err := s.cfg.Client.ScheduleClient.DecodeSchedule(resp)
+	if err != nil {
+		return nil, apperrors.Internal("Failed to decode schedule", err)
+	}
+	if sc.BusinessID != booking.BusinessID {
+		s.cfg.Log.Warn("Booking rejected, schedule belongs to another business",
+			"schedule_id", booking.ScheduleID,
+			"business_id", booking.BusinessID,
+			"schedule_business_id", sc.BusinessID,
+		)
+		return nil, apperrors.Validation("Booking validation failed", map[string]any{"error": "schedule_id: schedule belongs to another business"})
+	}
+	return sc, nil
+}
+
+// verifyScheduleHours rejects bookings that fall on a date the schedule is
+// closed, outside the hours in effect or shorter than its meeting duration.
+func (s *bookingService) verifyScheduleHours(booking *model.Booking, sc *model.Schedule) error {
+	if sc == nil || booking.Status == config.Cancelled {
+		return nil
+	}
+	if err := s.validator.ValidateAgainstSchedule(booking, sc); err != nil {
+		s.cfg.Log.Warn("Booking rejected by schedule hours", "schedule_id", booking.ScheduleID, "error", err)
+		return apperrors.Validation("Booking validation failed", map[string]any{"error": err.Error()})
+	}
+	return nil
+}
+
+// assignStaff resolves the staff member serving the booking. A requested member
+// must exist, work throughout the booking, offer its service and have room
+// for the booking's participants;
+// "any" (or no preference on a schedule with staff) picks the first member who
+// qualifies. Schedules without staff only take unassigned bookings.
+func (s *bookingService) assignStaff(ctx context.Context, booking *model.Booking, sc *model.Schedule) error {
+	if sc == nil || len(sc.Staff) == 0 {
+		if booking.StaffID == config.AnyStaff {
+			booking.StaffID = ""
+		}
+		if sc != nil && booking.StaffID != "" {
+			return apperrors.Validation("Booking validation failed", map[string]any{"error": "staff_id: schedule has no staff members"})
+		}
+		return nil
+	}
+	if booking.Status == config.Cancelled {
+		if booking.StaffID == config.AnyStaff {
+			booking.StaffID = ""
+		}
+		return nil
+	}
+
+	const maxOverlapCheck = 30
+	existing, err := s.repo.FindByBusinessAndSchedule(ctx, booking.BusinessID, booking.ScheduleID, &booking.StartTime, &booking.EndTime, maxOverlapCheck, 0)
+	if err != nil {
+		return apperrors.Internal("Failed to check existing bookings", err)
+	}
+	others := make([]*model.Booking, 0, len(existing))
+	for _, b := range existing {
+		if b.ID != booking.ID {
+			others = append(others, b)
+		}
+	}
+
+	if booking.StaffID != "" && booking.StaffID != config.AnyStaff {
+		member := sc.StaffByID(booking.StaffID)
+		if member == nil {
+			return apperrors.NotFoundWithID("Staff member", booking.StaffID)
+		}
+		if !member.Offers(booking.ServiceLabel) {
+			return apperrors.Validation("Booking validation failed", map[string]any{
+				"error": fmt.Sprintf("staff_id: %s does not offer %s", member.Name, booking.ServiceLabel),
+			})
+		}
+		if !availability.StaffAvailable(sc, member, others, booking.StartTime, booking.EndTime, booking.Capacity) {
+			return apperrors.Conflict(fmt.Sprintf("Staff member %s is not available at the requested time", member.Name))
+		}
+		return nil
+	}
+
+	for i := range sc.Staff {
+		member := &sc.Staff[i]
+		if member.Offers(booking.ServiceLabel) && availability.StaffAvailable(sc, member, others, booking.StartTime, booking.EndTime, booking.Capacity) {
+			booking.StaffID = member.ID
+			return nil
+		}
+	}
+	return apperrors.Conflict("No staff member is available at the requested time")
+}
+
 func (s *bookingService) verifyDuplication(ctx context.Context, booking *model.Booking) error {
 	// For overlap checking, we fetch with a reasonable limit
 	// In practice, checking up to 30 overlapping bookings should be sufficient
@@ -383,6 +700,12 @@ func (s *bookingService) verifyDuplication(ctx context.Context, booking *model.B
 		if b.ID == booking.ID {
 			continue
 		}
+		// Bookings with staff members run side by side up to each member's
+		// capacity, which assignStaff has checked; unassigned bookings hold
+		// the whole schedule.
+		if b.StaffID != "" && booking.StaffID != "" {
+			continue
+		}
 		if overlaps(b.StartTime, b.EndTime, booking.StartTime, booking.EndTime) {
 			return apperrors.Conflict(fmt.Sprintf(
 				"Booking time overlaps with existing booking (%s - %s)",
@@ -399,10 +722,15 @@ func overlaps(start1, end1, start2, end2 time.Time) bool {
 }
 
 // acquireSlotLock creates an advisory lock to prevent concurrent booking creation
-// Returns the lock ID if successful, or conflict error if lock already exists
-func (s *bookingService) acquireSlotLock(ctx context.Context, businessID, scheduleID string, startTime time.Time) (string, error) {
+// Returns the lock ID if successful, or conflict error if lock already exists.
+// staffID must already be resolved by assignStaff, so bookings for the same
+// member share a lock whether they asked for them by ID or for "any".
+func (s *bookingService) acquireSlotLock(ctx context.Context, businessID, scheduleID, staffID string, startTime time.Time) (string, error) {
 	// Create lock ID from booking slot coordinates
 	lockID := fmt.Sprintf("booking_lock_%s_%s_%d", businessID, scheduleID, startTime.Unix())
+	if staffID != "" {
+		lockID = fmt.Sprintf("%s_%s", lockID, staffID)
+	}
 
 	lock := &model.BookingLock{
 		ID:        lockID,
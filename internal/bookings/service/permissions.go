@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"skeji/pkg/auth"
+	"skeji/pkg/config"
+	apperrors "skeji/pkg/errors"
+	"skeji/pkg/model"
+)
+
+// authorizeCreate lets the owner and maintainers book anything, and anyone
+// else book a pending slot for themselves.
+func (s *bookingService) authorizeCreate(ctx context.Context, booking *model.Booking) error {
+	roles, err := s.roleResolver()
+	if err != nil {
+		return err
+	}
+	caller, role, err := auth.ResolveRole(ctx, roles, booking.BusinessID)
+	if err != nil {
+		return err
+	}
+	if caller.System || auth.IsStaff(role) {
+		return nil
+	}
+	if !isParticipant(booking, caller.Phone) {
+		return s.forbid(booking, caller, role, "Bookings can only be made for yourself")
+	}
+	if booking.Status != config.Pending {
+		return s.forbid(booking, caller, role, "Only the owner or a maintainer can confirm or cancel bookings")
+	}
+	return nil
+}
+
+// authorizeUpdate lets the owner and maintainers change anything, and a
+// participant only cancel the booking.
+func (s *bookingService) authorizeUpdate(ctx context.Context, existing *model.Booking, updates *model.BookingUpdate) error {
+	roles, err := s.roleResolver()
+	if err != nil {
+		return err
+	}
+	caller, role, err := auth.ResolveRole(ctx, roles, existing.BusinessID)
+	if err != nil {
+		return err
+	}
+	if caller.System || auth.IsStaff(role) {
+		return nil
+	}
+	if isParticipant(existing, caller.Phone) && isCancellation(updates) {
+		return nil
+	}
+	return s.forbid(existing, caller, role, "Only the owner or a maintainer can change bookings; participants can only cancel them")
+}
+
+func (s *bookingService) authorizeStaff(ctx context.Context, booking *model.Booking, action string) error {
+	roles, err := s.roleResolver()
+	if err != nil {
+		return err
+	}
+	err = auth.RequireStaff(ctx, roles, booking.BusinessID, action)
+	if err != nil {
+		s.cfg.Log.Warn("Booking action refused", "id", booking.ID, "business_id", booking.BusinessID, "action", action, "error", err)
+	}
+	return err
+}
+
+// roleResolver returns the business units client that tells callers' roles.
+// Without it no role can be checked, so the action is refused.
+func (s *bookingService) roleResolver() (auth.RoleResolver, error) {
+	if s.cfg.Client == nil || s.cfg.Client.BusinessUnitClient == nil {
+		s.cfg.Log.Error("Business units client is not configured, cannot check booking permissions")
+		return nil, apperrors.Unavailable("Business units service")
+	}
+	return s.cfg.Client.BusinessUnitClient, nil
+}
+
+// authorizeSystem checks that the call comes from another service.
+func (s *bookingService) authorizeSystem(ctx context.Context, action string) error {
+	caller, err := auth.RequireCaller(ctx)
+	if err != nil {
+		return err
+	}
+	if !caller.System {
+		return apperrors.Forbidden(fmt.Sprintf("Only the services can %s", action))
+	}
+	return nil
+}
+
+func (s *bookingService) forbid(booking *model.Booking, caller auth.Caller, role string, message string) error {
+	s.cfg.Log.Warn("Booking action forbidden",
+		"id", booking.ID,
+		"business_id", booking.BusinessID,
+		"caller", caller.Phone,
+		"role", role,
+	)
+	return apperrors.Forbidden(message)
+}
+
+func isParticipant(booking *model.Booking, phone string) bool {
+	for _, p := range booking.Participants {
+		if p == phone {
+			return true
+		}
+	}
+	return false
+}
+
+func isCancellation(updates *model.BookingUpdate) bool {
+	return updates.Status == config.Cancelled &&
+		updates.ScheduleID == "" &&
+		updates.ServiceLabel == "" &&
+		updates.StaffID == "" &&
+		updates.StartTime == nil &&
+		updates.EndTime == nil &&
+		updates.Capacity == nil &&
+		updates.Participants == nil &&
+		updates.ManagedBy == nil
+}
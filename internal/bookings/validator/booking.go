@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"skeji/pkg/availability"
+	"skeji/pkg/config"
 	"skeji/pkg/logger"
 	"skeji/pkg/model"
 	"strings"
@@ -13,7 +15,8 @@ import (
 )
 
 var (
-	phoneRegex = regexp.MustCompile(`^(?:|\+[1-9]\d{7,14})$`)
+	phoneRegex    = regexp.MustCompile(`^(?:|\+[1-9]\d{7,14})$`)
+	objectIDRegex = regexp.MustCompile(`^[0-9a-fA-F]{24}$`)
 )
 
 type ValidationError struct {
@@ -52,6 +55,12 @@ func NewBookingValidator(log *logger.Logger) *BookingValidator {
 		)
 	}
 
+	if err := v.RegisterValidation("staff_id", validateStaffID); err != nil {
+		log.Fatal("Failed to register 'staff_id' validator",
+			"error", err,
+		)
+	}
+
 	log.Info("Booking validator initialized successfully")
 
 	return &BookingValidator{
@@ -88,6 +97,16 @@ func validateParticipantsMap(fl validator.FieldLevel) bool {
 	return true
 }
 
+// validateStaffID accepts a staff member's ObjectID or "any" for whichever
+// member is free.
+func validateStaffID(fl validator.FieldLevel) bool {
+	id := fl.Field().String()
+	if id == config.AnyStaff {
+		return true
+	}
+	return objectIDRegex.MatchString(id)
+}
+
 func (v *BookingValidator) Validate(booking *model.Booking) error {
 	if err := v.validate.Struct(booking); err != nil {
 		var validationErrs validator.ValidationErrors
@@ -150,6 +169,88 @@ func (v *BookingValidator) ValidateUpdate(update *model.BookingUpdate) error {
 	return nil
 }
 
+// ValidateAgainstSchedule checks the booking against the schedule: closed
+// dates reject it, special hours bound it, and otherwise it must fall within
+// the working hours in effect, seasons included, and last at least the meeting
+// duration in effect on its start date. Schedules with staff leave the hours to
+// the staff member's, which are checked when the booking is assigned.
+func (v *BookingValidator) ValidateAgainstSchedule(booking *model.Booking, sc *model.Schedule) error {
+	loc := availability.Location(sc)
+	start := booking.StartTime.In(loc)
+	end := booking.EndTime.In(loc)
+
+	minDuration := time.Duration(sc.EffectiveOn(start).DefaultMeetingDurationMin) * time.Minute
+	if end.Sub(start) < minDuration {
+		return ValidationErrors{
+			ValidationError{
+				Field:   "EndTime",
+				Message: fmt.Sprintf("booking must last at least %d minutes", int(minDuration.Minutes())),
+			},
+		}
+	}
+
+	for day := dateOf(start); day.Before(end); day = day.AddDate(0, 0, 1) {
+		exception := sc.ExceptionOn(day)
+		if exception == nil {
+			continue
+		}
+		date := day.Format(time.DateOnly)
+		if exception.Type == config.ExceptionClosed {
+			message := fmt.Sprintf("schedule is closed on %s", date)
+			if exception.Reason != "" {
+				message = fmt.Sprintf("%s (%s)", message, exception.Reason)
+			}
+			return ValidationErrors{
+				ValidationError{
+					Field:   "StartTime",
+					Message: message,
+				},
+			}
+		}
+
+		open, errOpen := time.ParseInLocation("2006-01-02 15:04", date+" "+exception.StartOfDay, loc)
+		closing, errClose := time.ParseInLocation("2006-01-02 15:04", date+" "+exception.EndOfDay, loc)
+		if errOpen != nil || errClose != nil {
+			continue
+		}
+		if start.Before(open) || end.After(closing) {
+			return ValidationErrors{
+				ValidationError{
+					Field:   "StartTime",
+					Message: fmt.Sprintf("booking must be within special hours %s-%s on %s", exception.StartOfDay, exception.EndOfDay, date),
+				},
+			}
+		}
+	}
+
+	if len(sc.Staff) == 0 && !availability.Covers(availability.WorkingFrames(sc, start, end), start, end) {
+		return ValidationErrors{
+			ValidationError{
+				Field:   "StartTime",
+				Message: fmt.Sprintf("booking must be within working hours (%s on %s)", describeHours(availability.DayIntervals(sc, start)), start.Format(time.DateOnly)),
+			},
+		}
+	}
+
+	return nil
+}
+
+func describeHours(intervals []model.TimeRange) string {
+	if len(intervals) == 0 {
+		return "closed"
+	}
+	parts := make([]string, 0, len(intervals))
+	for _, interval := range intervals {
+		parts = append(parts, interval.Start+"-"+interval.End)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func dateOf(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
 func (v *BookingValidator) translateValidationErrors(errs validator.ValidationErrors) ValidationErrors {
 	var validationErrors ValidationErrors
 
@@ -171,6 +272,8 @@ func (v *BookingValidator) translateValidationErrors(errs validator.ValidationEr
 			message = fmt.Sprintf("%s must be one of: %s", err.Field(), err.Param())
 		case "gt":
 			message = fmt.Sprintf("%s must be greater than current time", err.Field())
+		case "staff_id":
+			message = fmt.Sprintf("%s must be a valid MongoDB ObjectID or %q", err.Field(), config.AnyStaff)
 		}
 
 		validationErrors = append(validationErrors, ValidationError{
@@ -15,6 +15,29 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/v1/attributes": {
+            "get": {
+                "description": "Lists the structured attributes business units and schedules can carry, with their type and options. Attributes marked schedules may also be set per schedule, overriding the business unit's value for that branch.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "BusinessUnits"
+                ],
+                "summary": "List attributes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AttributeDefinition"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/business-units": {
             "get": {
                 "produces": [
@@ -54,6 +77,7 @@ const docTemplate = `{
                 }
             },
             "post": {
+                "description": "Creates a unit owned by its admin phone. With organization_id the unit opens as a branch of the organization: its owner or admins create it for any admin phone, and its labels, time zone and website URLs fill in what is left out.",
                 "consumes": [
                     "application/json"
                 ],
@@ -73,6 +97,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.BusinessUnit"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -88,6 +125,111 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/business-units/duplicates": {
+            "get": {
+                "description": "Reports pairs of business units that look like the same business registered twice, scored in [0, 1] from name similarity, shared phones and overlapping cities, best first. Phone callers see the pairs among their own business units; other services may scan all units, optionally narrowed by phone and cities.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "BusinessUnits"
+                ],
+                "summary": "Find duplicate business units",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only units this phone is admin or maintainer of (defaults to the calling phone)",
+                        "name": "phone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only units in these cities",
+                        "name": "cities",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Lowest score reported, in (0, 1] (default 0.6)",
+                        "name": "min_score",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -99,6 +241,7 @@ const docTemplate = `{
         },
         "/api/v1/business-units/id/{id}": {
             "get": {
+                "description": "A business unit merged into another one redirects to it with 308 Permanent Redirect.",
                 "produces": [
                     "application/json"
                 ],
@@ -122,6 +265,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.BusinessUnit"
                         }
                     },
+                    "308": {
+                        "description": "Permanent Redirect",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -137,6 +286,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
+                "description": "Deletes the business unit and starts a background operation that deletes the unit's schedules, cancels their upcoming and ongoing bookings and archives the ones that ended. Poll the returned operation for progress.",
                 "produces": [
                     "application/json"
                 ],
@@ -151,11 +301,45 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/model.BusinessUnitDeletion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
                     },
                     "404": {
                         "description": "Not Found",
@@ -198,6 +382,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.BusinessUnitUpdate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -210,6 +407,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -225,21 +434,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/business-units/phone/{phone}": {
+        "/api/v1/business-units/id/{id}/audit": {
             "get": {
-                "description": "Returns all business units where the phone is either the admin or a maintainer",
+                "description": "Lists ownership transfers and status changes of the business unit, newest first. Only the owner and maintainers can read it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "BusinessUnits"
                 ],
-                "summary": "Get business units by phone",
+                "summary": "Get business unit audit log",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Phone Number",
-                        "name": "phone",
+                        "description": "Business Unit ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
@@ -254,6 +463,19 @@ const docTemplate = `{
                         "description": "Offset",
                         "name": "offset",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -269,6 +491,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -278,40 +518,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/business-units/search": {
+        "/api/v1/business-units/id/{id}/impressions": {
             "get": {
+                "description": "Reports how many times the business unit was shown per UTC day over the last days, today included.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "BusinessUnits"
                 ],
-                "summary": "Search business units by cities and labels",
+                "summary": "Get business unit impressions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Comma-separated cities",
-                        "name": "cities",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Comma-separated labels",
-                        "name": "labels",
-                        "in": "query",
+                        "description": "Business Unit ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Offset",
-                        "name": "offset",
+                        "description": "Number of days (default 7, max 90)",
+                        "name": "days",
                         "in": "query"
                     }
                 ],
@@ -319,7 +547,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/http.PaginatedResponse"
+                            "$ref": "#/definitions/model.ImpressionSummary"
                         }
                     },
                     "400": {
@@ -328,6 +556,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -336,63 +570,1828 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "http.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "details": {
-                    "type": "object",
-                    "additionalProperties": {}
-                },
-                "error": {
-                    "type": "string"
-                }
-            }
-        },
-        "http.PaginatedResponse": {
-            "type": "object",
-            "properties": {
-                "data": {},
-                "limit": {
-                    "type": "integer"
-                },
-                "offset": {
-                    "type": "integer"
-                },
-                "total_count": {
-                    "type": "integer"
-                }
-            }
         },
-        "model.BusinessUnit": {
-            "type": "object",
-            "required": [
-                "admin_phone",
-                "cities",
-                "labels",
-                "name"
-            ],
-            "properties": {
-                "admin_phone": {
-                    "type": "string"
-                },
-                "cities": {
-                    "type": "array",
-                    "maxItems": 50,
-                    "minItems": 1,
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "labels": {
+        "/api/v1/business-units/id/{id}/merge": {
+            "post": {
+                "description": "Merges the duplicate business unit into another one. Its schedules and their bookings move to the surviving unit, which also takes over its cities, labels, website URLs, maintainers and viewers; the duplicate's admin stays on as a maintainer. The duplicate is then deleted and its ID redirects to the surviving unit. The caller must own both units. A merge that stopped halfway is resumed by repeating the request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "BusinessUnits"
+                ],
+                "summary": "Merge business units",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the duplicate business unit",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Business unit to merge into",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BusinessUnitMergeRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.BusinessUnitMerge"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/business-units/id/{id}/quota": {
+            "get": {
+                "description": "Reports the plan of the unit's owner, its limits and the current usage: business units of the admin phone (or branches of the organization), and the unit's schedules, maintainers and cities.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plans"
+                ],
+                "summary": "Get business unit quota",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business Unit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Quota"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/business-units/id/{id}/status": {
+            "post": {
+                "description": "Moves the business unit between draft, active, suspended and archived. Suspended units are hidden from search and take no bookings, archived units are read-only for good. Suspending requires a reason. Only the owner can change the status.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "BusinessUnits"
+                ],
+                "summary": "Change business unit status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business Unit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "change",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.BusinessUnitStatusChange"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.BusinessUnit"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/business-units/id/{id}/transfers": {
+            "post": {
+                "description": "Offers the business unit to another phone, which must accept before the transfer expires. Only the owner can start it, and only one transfer per business unit can be pending.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "BusinessUnits"
+                ],
+                "summary": "Start ownership transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business Unit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New owner",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.OwnershipTransferRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.OwnershipTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/business-units/impressions": {
+            "post": {
+                "description": "Counts one impression for each business shown to a customer, so search fairness can balance exposure over time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "BusinessUnits"
+                ],
+                "summary": "Record business unit impressions",
+                "parameters": [
+                    {
+                        "description": "Businesses shown",
+                        "name": "impressions",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ImpressionRecord"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/business-units/operations/id/{id}": {
+            "get": {
+                "description": "Reports the status and per-schedule progress of a business unit deletion. Only the owner of the deleted business unit, or another service, may view it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "BusinessUnits"
+                ],
+                "summary": "Get business unit deletion operation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.BusinessUnitDeletion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/business-units/operations/id/{id}/resume": {
+            "post": {
+                "description": "Retries a waiting or failed deletion immediately, with a fresh attempt budget.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "BusinessUnits"
+                ],
+                "summary": "Resume business unit deletion operation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/model.BusinessUnitDeletion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/business-units/phone/{phone}": {
+            "get": {
+                "description": "Returns all business units where the phone is either the admin or a maintainer. Also supports optional filtering by cities and labels.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "BusinessUnits"
+                ],
+                "summary": "Get business units by phone",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Phone Number",
+                        "name": "phone",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by cities",
+                        "name": "cities",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Filter by labels",
+                        "name": "labels",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/business-units/search": {
+            "get": {
+                "description": "With q, matches business names and labels tolerating typos, partial words and Hebrew/English spelling, ordered by relevance then priority; cities optionally narrow the results and labels are ignored. Without q, both cities and labels are required; labels match through their synonyms and a category label also matches the labels below it.\nWith fairness=rotate, businesses that rank equally are shuffled in an order that is stable for the requester within a time bucket; fairness=balance puts the least shown of them first.\nattributes filters on structured attributes (see /api/v1/attributes) as key:value, e.g. languages:english; a boolean attribute alone means true. Values of one attribute are alternatives, different attributes must all match. With facets=true the response also counts the results per attribute value.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "BusinessUnits"
+                ],
+                "summary": "Search business units by cities and labels, or by text",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Free-text query",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated cities by name or any alias (required without q)",
+                        "name": "cities",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated labels (required without q)",
+                        "name": "labels",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated attribute filters, e.g. wheelchair_accessible,parking:free",
+                        "name": "attributes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include attribute facet counts",
+                        "name": "facets",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tie order: none (default), rotate or balance",
+                        "name": "fairness",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Seeds the rotation, e.g. the customer's phone",
+                        "name": "requester",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/business-units/transfers/id/{id}": {
+            "get": {
+                "description": "Returns a transfer to its current or new owner. A pending transfer past its deadline is reported as expired.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "BusinessUnits"
+                ],
+                "summary": "Get ownership transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OwnershipTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/business-units/transfers/id/{id}/accept": {
+            "post": {
+                "description": "Makes the caller, the new phone, the owner. Business unit limits are checked again, and the previous owner becomes a maintainer if the transfer asked for it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "BusinessUnits"
+                ],
+                "summary": "Accept ownership transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OwnershipTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/business-units/transfers/id/{id}/cancel": {
+            "post": {
+                "description": "Withdraws the offer; only the current owner can cancel.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "BusinessUnits"
+                ],
+                "summary": "Cancel ownership transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OwnershipTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/business-units/transfers/id/{id}/decline": {
+            "post": {
+                "description": "Refuses the business unit; only the new phone can decline.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "BusinessUnits"
+                ],
+                "summary": "Decline ownership transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.OwnershipTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organizations": {
+            "post": {
+                "description": "Creates a franchise or brand that groups business units. Its admin phone owns every unit in it and its admins maintain them; its labels, time zone, website URLs and hours fill in new branches.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Create organization",
+                "parameters": [
+                    {
+                        "description": "Organization",
+                        "name": "organization",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Organization"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organizations/id/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Get organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes an organization without business units; move its units out or delete them first.",
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Delete organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Changes the organization's branding and defaults; existing units keep their settings. The owner and admins can update it, only the owner changes the admins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Update organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Organization update",
+                        "name": "organization",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.OrganizationUpdate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organizations/id/{id}/business-units": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "List organization business units",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/organizations/search": {
+            "get": {
+                "description": "Searches business units like /business-units/search but returns one result per brand: the branches of an organization collapse into the nearest one, in the earliest listed city, then by priority. Units outside organizations are their own brand.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Search brands",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated cities by name or any alias, nearest first",
+                        "name": "cities",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated labels",
+                        "name": "labels",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/plans": {
+            "get": {
+                "description": "Lists the plans and their limits, from the smallest to the largest.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plans"
+                ],
+                "summary": "List plans",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Plan"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/plans/assignments": {
+            "post": {
+                "description": "Puts an admin phone or an organization on a plan, replacing its previous plan. Phones default to the free plan and organizations to the enterprise plan. Only other services can assign plans.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Plans"
+                ],
+                "summary": "Assign plan",
+                "parameters": [
+                    {
+                        "description": "Phone or organization_id, and plan",
+                        "name": "assignment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PlanAssignment"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PlanAssignment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "http.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.PaginatedResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "facets": {
+                    "description": "Facets summarizes the whole result set, on searches that ask for it."
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AttributeDefinition": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "schedules": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Attributes": {
+            "type": "object",
+            "additionalProperties": {}
+        },
+        "model.BusinessUnit": {
+            "type": "object",
+            "required": [
+                "admin_phone",
+                "cities",
+                "labels",
+                "name"
+            ],
+            "properties": {
+                "admin_phone": {
+                    "type": "string"
+                },
+                "attributes": {
+                    "$ref": "#/definitions/model.Attributes"
+                },
+                "cities": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "maintainers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "active",
+                        "suspended",
+                        "archived"
+                    ]
+                },
+                "status_changed_at": {
+                    "type": "string"
+                },
+                "status_reason": {
+                    "type": "string"
+                },
+                "time_zone": {
+                    "type": "string"
+                },
+                "viewers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "website_urls": {
+                    "type": "array",
+                    "maxItems": 5,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.BusinessUnitDeletion": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "business_id": {
+                    "type": "string"
+                },
+                "business_name": {
+                    "type": "string"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "schedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ScheduleTeardown"
+                    }
+                },
+                "schedules_deleted": {
+                    "type": "integer"
+                },
+                "schedules_listed": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BusinessUnitMerge": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "requested_by": {
+                    "type": "string"
+                },
+                "schedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ScheduleHandover"
+                    }
+                },
+                "schedules_moved": {
+                    "type": "integer"
+                },
+                "source_id": {
+                    "type": "string"
+                },
+                "source_name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BusinessUnitMergeRequest": {
+            "type": "object",
+            "required": [
+                "into_id"
+            ],
+            "properties": {
+                "into_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BusinessUnitStatusChange": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "active",
+                        "suspended",
+                        "archived"
+                    ]
+                }
+            }
+        },
+        "model.BusinessUnitUpdate": {
+            "type": "object",
+            "required": [
+                "cities",
+                "labels"
+            ],
+            "properties": {
+                "admin_phone": {
+                    "type": "string"
+                },
+                "attributes": {
+                    "$ref": "#/definitions/model.Attributes"
+                },
+                "cities": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "labels": {
                     "type": "array",
                     "maxItems": 10,
                     "minItems": 1,
@@ -411,6 +2410,9 @@ const docTemplate = `{
                     "maxLength": 100,
                     "minLength": 2
                 },
+                "organization_id": {
+                    "type": "string"
+                },
                 "priority": {
                     "type": "integer",
                     "minimum": 0
@@ -418,6 +2420,12 @@ const docTemplate = `{
                 "time_zone": {
                     "type": "string"
                 },
+                "viewers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "website_urls": {
                     "type": "array",
                     "maxItems": 5,
@@ -427,46 +2435,163 @@ const docTemplate = `{
                 }
             }
         },
-        "model.BusinessUnitUpdate": {
+        "model.DailyImpressions": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "day": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ImpressionRecord": {
+            "type": "object",
+            "properties": {
+                "business_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ImpressionSummary": {
+            "type": "object",
+            "properties": {
+                "business_id": {
+                    "type": "string"
+                },
+                "daily": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DailyImpressions"
+                    }
+                },
+                "since": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Organization": {
             "type": "object",
             "required": [
-                "cities",
-                "labels"
+                "admin_phone",
+                "name"
             ],
             "properties": {
                 "admin_phone": {
                     "type": "string"
                 },
-                "cities": {
+                "admins": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "hours": {
+                    "$ref": "#/definitions/model.OrganizationHours"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "services": {
                     "type": "array",
                     "maxItems": 50,
-                    "minItems": 1,
                     "items": {
                         "type": "string"
                     }
                 },
-                "labels": {
+                "time_zone": {
+                    "type": "string"
+                },
+                "website_urls": {
                     "type": "array",
-                    "maxItems": 10,
-                    "minItems": 1,
+                    "maxItems": 5,
                     "items": {
                         "type": "string"
                     }
+                }
+            }
+        },
+        "model.OrganizationHours": {
+            "type": "object",
+            "required": [
+                "end_of_day",
+                "start_of_day",
+                "working_days"
+            ],
+            "properties": {
+                "default_meeting_duration_min": {
+                    "type": "integer",
+                    "maximum": 480,
+                    "minimum": 5
                 },
-                "maintainers": {
+                "end_of_day": {
+                    "type": "string"
+                },
+                "start_of_day": {
+                    "type": "string"
+                },
+                "working_days": {
+                    "type": "array",
+                    "maxItems": 7,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.OrganizationUpdate": {
+            "type": "object",
+            "properties": {
+                "admins": {
                     "type": "object",
                     "additionalProperties": {
                         "type": "string"
                     }
                 },
+                "hours": {
+                    "$ref": "#/definitions/model.OrganizationHours"
+                },
+                "labels": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "name": {
                     "type": "string",
                     "maxLength": 100,
                     "minLength": 2
                 },
-                "priority": {
-                    "type": "integer",
-                    "minimum": 0
+                "services": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "time_zone": {
                     "type": "string"
@@ -479,6 +2604,191 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "model.OwnershipTransfer": {
+            "type": "object",
+            "properties": {
+                "business_id": {
+                    "type": "string"
+                },
+                "business_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "from_phone": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "keep_as_maintainer": {
+                    "type": "boolean"
+                },
+                "maintainer_name": {
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "to_phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.OwnershipTransferRequest": {
+            "type": "object",
+            "required": [
+                "to_phone"
+            ],
+            "properties": {
+                "keep_as_maintainer": {
+                    "type": "boolean"
+                },
+                "maintainer_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "to_phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Plan": {
+            "type": "object",
+            "properties": {
+                "limits": {
+                    "$ref": "#/definitions/model.PlanLimits"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PlanAssignment": {
+            "type": "object",
+            "required": [
+                "plan"
+            ],
+            "properties": {
+                "assigned_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "plan": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PlanLimits": {
+            "type": "object",
+            "properties": {
+                "business_units": {
+                    "type": "integer"
+                },
+                "cities_per_business_unit": {
+                    "type": "integer"
+                },
+                "maintainers_per_business_unit": {
+                    "type": "integer"
+                },
+                "schedules_per_business_unit": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PlanUsage": {
+            "type": "object",
+            "properties": {
+                "business_units": {
+                    "type": "integer"
+                },
+                "cities": {
+                    "type": "integer"
+                },
+                "maintainers": {
+                    "type": "integer"
+                },
+                "schedules": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Quota": {
+            "type": "object",
+            "properties": {
+                "business_id": {
+                    "type": "string"
+                },
+                "limits": {
+                    "$ref": "#/definitions/model.PlanLimits"
+                },
+                "plan": {
+                    "type": "string"
+                },
+                "usage": {
+                    "$ref": "#/definitions/model.PlanUsage"
+                }
+            }
+        },
+        "model.ScheduleHandover": {
+            "type": "object",
+            "properties": {
+                "bookings_archived": {
+                    "type": "integer"
+                },
+                "bookings_moved": {
+                    "type": "integer"
+                },
+                "moved": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "schedule_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ScheduleTeardown": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "type": "boolean"
+                },
+                "bookings_archived": {
+                    "type": "integer"
+                },
+                "bookings_cancelled": {
+                    "type": "integer"
+                },
+                "deleted": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "schedule_id": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
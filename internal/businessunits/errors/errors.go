@@ -6,4 +6,14 @@ var (
 	ErrNotFound = errors.New("business unit not found")
 
 	ErrInvalidID = errors.New("invalid business unit ID format")
+
+	ErrDeletionNotFound = errors.New("deletion operation not found")
+
+	ErrTransferNotFound = errors.New("ownership transfer not found")
+
+	ErrOrganizationNotFound = errors.New("organization not found")
+
+	ErrPlanAssignmentNotFound = errors.New("plan assignment not found")
+
+	ErrMergeNotFound = errors.New("business unit merge not found")
 )
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	httputil "skeji/pkg/http"
+)
+
+// @Summary List attributes
+// @Description Lists the structured attributes business units and schedules can carry, with their type and options. Attributes marked schedules may also be set per schedule, overriding the business unit's value for that branch.
+// @Tags BusinessUnits
+// @Produce json
+// @Success 200 {array} model.AttributeDefinition
+// @Router /api/v1/attributes [get]
+func (h *BusinessUnitHandler) ListAttributes(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if err := httputil.WriteSuccess(w, h.service.ListAttributes(r.Context())); err != nil {
+		h.log.Error("failed to write success response", "handler", "ListAttributes", "operation", "WriteSuccess", "error", err)
+	}
+}
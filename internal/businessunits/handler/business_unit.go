@@ -2,7 +2,10 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/julienschmidt/httprouter"
@@ -10,6 +13,8 @@ import (
 
 	_ "skeji/internal/businessunits/docs" // Import generated swagger docs
 	"skeji/internal/businessunits/service"
+	"skeji/pkg/attributes"
+	apperrors "skeji/pkg/errors"
 	httputil "skeji/pkg/http"
 	"skeji/pkg/logger"
 	"skeji/pkg/model"
@@ -28,12 +33,17 @@ func NewBusinessUnitHandler(service service.BusinessUnitService, log *logger.Log
 }
 
 // @Summary Create a new business unit
+// @Description Creates a unit owned by its admin phone. With organization_id the unit opens as a branch of the organization: its owner or admins create it for any admin phone, and its labels, time zone and website URLs fill in what is left out.
 // @Tags BusinessUnits
 // @Accept json
 // @Produce json
 // @Param business_unit body model.BusinessUnit true "Business unit data"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
 // @Success 201 {object} model.BusinessUnit
 // @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
 // @Failure 500 {object} httputil.ErrorResponse
 // @Router /api/v1/business-units [post]
 func (h *BusinessUnitHandler) Create(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
@@ -63,7 +73,9 @@ func (h *BusinessUnitHandler) Create(w http.ResponseWriter, r *http.Request, _ h
 // @Tags BusinessUnits
 // @Produce json
 // @Param id path string true "Business Unit ID"
+// @Description A business unit merged into another one redirects to it with 308 Permanent Redirect.
 // @Success 200 {object} model.BusinessUnit
+// @Failure 308 {object} httputil.ErrorResponse
 // @Failure 404 {object} httputil.ErrorResponse
 // @Failure 500 {object} httputil.ErrorResponse
 // @Router /api/v1/business-units/id/{id} [get]
@@ -72,6 +84,13 @@ func (h *BusinessUnitHandler) GetByID(w http.ResponseWriter, r *http.Request, ps
 
 	bu, err := h.service.GetByID(r.Context(), id)
 	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) && appErr.Code == apperrors.CodeMoved {
+			if writeErr := h.writeMoved(w, appErr); writeErr != nil {
+				h.log.Error("failed to write redirect response", "handler", "GetByID", "operation", "WriteJSON", "error", writeErr)
+			}
+			return
+		}
 		if writeErr := httputil.WriteError(w, err); writeErr != nil {
 			h.log.Error("failed to write error response", "handler", "GetByID", "operation", "WriteError", "error", writeErr)
 		}
@@ -158,9 +177,13 @@ func (h *BusinessUnitHandler) GetAll(w http.ResponseWriter, r *http.Request, _ h
 // @Produce json
 // @Param id path string true "Business Unit ID"
 // @Param business_unit body model.BusinessUnitUpdate true "Business unit update"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
 // @Success 204 "No Content"
 // @Failure 400 {object} httputil.ErrorResponse
 // @Failure 404 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
 // @Failure 500 {object} httputil.ErrorResponse
 // @Router /api/v1/business-units/id/{id} [patch]
 func (h *BusinessUnitHandler) Update(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
@@ -187,31 +210,109 @@ func (h *BusinessUnitHandler) Update(w http.ResponseWriter, r *http.Request, ps
 }
 
 // @Summary Delete business unit
+// @Description Deletes the business unit and starts a background operation that deletes the unit's schedules, cancels their upcoming and ongoing bookings and archives the ones that ended. Poll the returned operation for progress.
 // @Tags BusinessUnits
 // @Produce json
 // @Param id path string true "Business Unit ID"
-// @Success 204 "No Content"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 202 {object} model.BusinessUnitDeletion
+// @Failure 400 {object} httputil.ErrorResponse
 // @Failure 404 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
 // @Failure 500 {object} httputil.ErrorResponse
 // @Router /api/v1/business-units/id/{id} [delete]
 func (h *BusinessUnitHandler) Delete(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	id := ps.ByName("id")
 
-	if err := h.service.Delete(r.Context(), id); err != nil {
+	op, err := h.service.Delete(r.Context(), id)
+	if err != nil {
 		if writeErr := httputil.WriteError(w, err); writeErr != nil {
 			h.log.Error("failed to write error response", "handler", "Delete", "operation", "WriteError", "error", writeErr)
 		}
 		return
 	}
 
-	httputil.WriteNoContent(w)
+	if err := httputil.WriteAccepted(w, op); err != nil {
+		h.log.Error("failed to write accepted response", "handler", "Delete", "operation", "WriteAccepted", "error", err)
+	}
+}
+
+// @Summary Get business unit deletion operation
+// @Description Reports the status and per-schedule progress of a business unit deletion. Only the owner of the deleted business unit, or another service, may view it.
+// @Tags BusinessUnits
+// @Produce json
+// @Param id path string true "Operation ID"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 200 {object} model.BusinessUnitDeletion
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/business-units/operations/id/{id} [get]
+func (h *BusinessUnitHandler) GetDeletion(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+
+	op, err := h.service.GetDeletion(r.Context(), id)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "GetDeletion", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteSuccess(w, op); err != nil {
+		h.log.Error("failed to write success response", "handler", "GetDeletion", "operation", "WriteSuccess", "error", err)
+	}
+}
+
+// @Summary Resume business unit deletion operation
+// @Description Retries a waiting or failed deletion immediately, with a fresh attempt budget.
+// @Tags BusinessUnits
+// @Produce json
+// @Param id path string true "Operation ID"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 202 {object} model.BusinessUnitDeletion
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 409 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/business-units/operations/id/{id}/resume [post]
+func (h *BusinessUnitHandler) ResumeDeletion(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+
+	op, err := h.service.ResumeDeletion(r.Context(), id)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "ResumeDeletion", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteAccepted(w, op); err != nil {
+		h.log.Error("failed to write accepted response", "handler", "ResumeDeletion", "operation", "WriteAccepted", "error", err)
+	}
 }
 
-// @Summary Search business units by cities and labels
+// @Summary Search business units by cities and labels, or by text
+// @Description With q, matches business names and labels tolerating typos, partial words and Hebrew/English spelling, ordered by relevance then priority; cities optionally narrow the results and labels are ignored. Without q, both cities and labels are required; labels match through their synonyms and a category label also matches the labels below it.
+// @Description With fairness=rotate, businesses that rank equally are shuffled in an order that is stable for the requester within a time bucket; fairness=balance puts the least shown of them first.
+// @Description attributes filters on structured attributes (see /api/v1/attributes) as key:value, e.g. languages:english; a boolean attribute alone means true. Values of one attribute are alternatives, different attributes must all match. With facets=true the response also counts the results per attribute value.
 // @Tags BusinessUnits
 // @Produce json
-// @Param cities query string true "Comma-separated cities"
-// @Param labels query string true "Comma-separated labels"
+// @Param q query string false "Free-text query"
+// @Param cities query string false "Comma-separated cities by name or any alias (required without q)"
+// @Param labels query string false "Comma-separated labels (required without q)"
+// @Param attributes query string false "Comma-separated attribute filters, e.g. wheelchair_accessible,parking:free"
+// @Param facets query bool false "Include attribute facet counts"
+// @Param fairness query string false "Tie order: none (default), rotate or balance"
+// @Param requester query string false "Seeds the rotation, e.g. the customer's phone"
 // @Param limit query int false "Limit"
 // @Param offset query int false "Offset"
 // @Success 200 {object} httputil.PaginatedResponse
@@ -222,6 +323,23 @@ func (h *BusinessUnitHandler) Search(w http.ResponseWriter, r *http.Request, _ h
 	query := r.URL.Query()
 	cities := extractQueryParams(query, "cities")
 	labels := extractQueryParams(query, "labels")
+	fairness := model.SearchFairness{
+		Mode:      strings.TrimSpace(query.Get("fairness")),
+		Requester: strings.TrimSpace(query.Get("requester")),
+	}
+
+	attrs, err := attributes.ParseFilter(extractQueryParams(query, "attributes"))
+	if err != nil {
+		if writeErr := httputil.WriteError(w, apperrors.InvalidInput(err.Error())); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "Search", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if query.Has("q") {
+		h.textSearch(w, r, query.Get("q"), cities, attrs, fairness)
+		return
+	}
 
 	if len(cities) == 0 || len(labels) == 0 {
 		if writeErr := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
@@ -240,7 +358,7 @@ func (h *BusinessUnitHandler) Search(w http.ResponseWriter, r *http.Request, _ h
 		return
 	}
 
-	units, totalCount, err := h.service.Search(r.Context(), cities, labels, limit, offset)
+	units, totalCount, err := h.service.Search(r.Context(), cities, labels, attrs, fairness, limit, offset)
 	if err != nil {
 		if writeErr := httputil.WriteError(w, err); writeErr != nil {
 			h.log.Error("failed to write error response", "handler", "Search", "operation", "WriteError", "error", writeErr)
@@ -248,8 +366,122 @@ func (h *BusinessUnitHandler) Search(w http.ResponseWriter, r *http.Request, _ h
 		return
 	}
 
-	if err := httputil.WritePaginated(w, units, totalCount, limit, offset); err != nil {
-		h.log.Error("failed to write paginated response", "handler", "Search", "operation", "WritePaginated", "error", err)
+	h.writeSearchResults(w, r, "", cities, labels, attrs, units, totalCount, limit, offset)
+}
+
+func (h *BusinessUnitHandler) textSearch(w http.ResponseWriter, r *http.Request, q string, cities []string, attrs model.AttributeFilter, fairness model.SearchFairness) {
+	limit, offset, err := httputil.ExtractLimitOffset(r)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "Search", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	units, totalCount, err := h.service.TextSearch(r.Context(), q, cities, attrs, fairness, limit, offset)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "Search", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	h.writeSearchResults(w, r, q, cities, nil, attrs, units, totalCount, limit, offset)
+}
+
+// writeSearchResults writes a page of search results, with the facets of the
+// whole search when facets=true.
+func (h *BusinessUnitHandler) writeSearchResults(w http.ResponseWriter, r *http.Request, q string, cities []string, labels []string, attrs model.AttributeFilter, units []*model.BusinessUnit, totalCount int64, limit int, offset int64) {
+	if r.URL.Query().Get("facets") != "true" {
+		if err := httputil.WritePaginated(w, units, totalCount, limit, offset); err != nil {
+			h.log.Error("failed to write paginated response", "handler", "Search", "operation", "WritePaginated", "error", err)
+		}
+		return
+	}
+
+	facets, err := h.service.SearchFacets(r.Context(), q, cities, labels, attrs)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "Search", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteFacetedPaginated(w, units, facets, totalCount, limit, offset); err != nil {
+		h.log.Error("failed to write paginated response", "handler", "Search", "operation", "WriteFacetedPaginated", "error", err)
+	}
+}
+
+// @Summary Record business unit impressions
+// @Description Counts one impression for each business shown to a customer, so search fairness can balance exposure over time.
+// @Tags BusinessUnits
+// @Accept json
+// @Param impressions body model.ImpressionRecord true "Businesses shown"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 204 "No Content"
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/business-units/impressions [post]
+func (h *BusinessUnitHandler) RecordImpressions(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var record model.ImpressionRecord
+	if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+		if writeErr := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "Invalid request body",
+		}); writeErr != nil {
+			h.log.Error("failed to write JSON response", "handler", "RecordImpressions", "operation", "WriteJSON", "error", writeErr)
+		}
+		return
+	}
+
+	if err := h.service.RecordImpressions(r.Context(), &record); err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "RecordImpressions", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	httputil.WriteNoContent(w)
+}
+
+// @Summary Get business unit impressions
+// @Description Reports how many times the business unit was shown per UTC day over the last days, today included.
+// @Tags BusinessUnits
+// @Produce json
+// @Param id path string true "Business Unit ID"
+// @Param days query int false "Number of days (default 7, max 90)"
+// @Success 200 {object} model.ImpressionSummary
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/business-units/id/{id}/impressions [get]
+func (h *BusinessUnitHandler) GetImpressions(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+
+	days := 0
+	if raw := strings.TrimSpace(r.URL.Query().Get("days")); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			if writeErr := httputil.WriteError(w, apperrors.InvalidInput(fmt.Sprintf("invalid days %q, must be a positive number", raw))); writeErr != nil {
+				h.log.Error("failed to write error response", "handler", "GetImpressions", "operation", "WriteError", "error", writeErr)
+			}
+			return
+		}
+		days = parsed
+	}
+
+	summary, err := h.service.GetImpressions(r.Context(), id, days)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "GetImpressions", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteSuccess(w, summary); err != nil {
+		h.log.Error("failed to write success response", "handler", "GetImpressions", "operation", "WriteSuccess", "error", err)
 	}
 }
 
@@ -287,8 +519,31 @@ func (h *BusinessUnitHandler) RegisterRoutes(router *httprouter.Router) {
 	router.POST("/api/v1/business-units", h.Create)
 	router.GET("/api/v1/business-units", h.GetAll)
 	router.GET("/api/v1/business-units/search", h.Search)
+	router.GET("/api/v1/business-units/duplicates", h.FindDuplicates)
+	router.POST("/api/v1/business-units/impressions", h.RecordImpressions)
 	router.GET("/api/v1/business-units/phone/:phone", h.GetByPhone)
 	router.GET("/api/v1/business-units/id/:id", h.GetByID)
 	router.PATCH("/api/v1/business-units/id/:id", h.Update)
 	router.DELETE("/api/v1/business-units/id/:id", h.Delete)
+	router.GET("/api/v1/business-units/id/:id/impressions", h.GetImpressions)
+	router.POST("/api/v1/business-units/id/:id/status", h.ChangeStatus)
+	router.POST("/api/v1/business-units/id/:id/transfers", h.StartTransfer)
+	router.GET("/api/v1/business-units/id/:id/audit", h.GetAuditLog)
+	router.GET("/api/v1/business-units/id/:id/quota", h.GetQuota)
+	router.POST("/api/v1/business-units/id/:id/merge", h.Merge)
+	router.GET("/api/v1/business-units/transfers/id/:id", h.GetTransfer)
+	router.POST("/api/v1/business-units/transfers/id/:id/accept", h.AcceptTransfer)
+	router.POST("/api/v1/business-units/transfers/id/:id/decline", h.DeclineTransfer)
+	router.POST("/api/v1/business-units/transfers/id/:id/cancel", h.CancelTransfer)
+	router.GET("/api/v1/business-units/operations/id/:id", h.GetDeletion)
+	router.POST("/api/v1/business-units/operations/id/:id/resume", h.ResumeDeletion)
+	router.POST("/api/v1/organizations", h.CreateOrganization)
+	router.GET("/api/v1/organizations/search", h.SearchBrands)
+	router.GET("/api/v1/organizations/id/:id", h.GetOrganization)
+	router.PATCH("/api/v1/organizations/id/:id", h.UpdateOrganization)
+	router.DELETE("/api/v1/organizations/id/:id", h.DeleteOrganization)
+	router.GET("/api/v1/organizations/id/:id/business-units", h.GetOrganizationUnits)
+	router.GET("/api/v1/plans", h.ListPlans)
+	router.GET("/api/v1/attributes", h.ListAttributes)
+	router.POST("/api/v1/plans/assignments", h.AssignPlan)
 }
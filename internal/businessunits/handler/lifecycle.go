@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	httputil "skeji/pkg/http"
+	"skeji/pkg/model"
+)
+
+// @Summary Change business unit status
+// @Description Moves the business unit between draft, active, suspended and archived. Suspended units are hidden from search and take no bookings, archived units are read-only for good. Suspending requires a reason. Only the owner can change the status.
+// @Tags BusinessUnits
+// @Accept json
+// @Produce json
+// @Param id path string true "Business Unit ID"
+// @Param change body model.BusinessUnitStatusChange true "New status"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 200 {object} model.BusinessUnit
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 409 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/business-units/id/{id}/status [post]
+func (h *BusinessUnitHandler) ChangeStatus(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+
+	var change model.BusinessUnitStatusChange
+	if err := json.NewDecoder(r.Body).Decode(&change); err != nil {
+		if writeErr := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "Invalid request body",
+		}); writeErr != nil {
+			h.log.Error("failed to write JSON response", "handler", "ChangeStatus", "operation", "WriteJSON", "error", writeErr)
+		}
+		return
+	}
+
+	bu, err := h.service.ChangeStatus(r.Context(), id, &change)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "ChangeStatus", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteSuccess(w, bu); err != nil {
+		h.log.Error("failed to write success response", "handler", "ChangeStatus", "operation", "WriteSuccess", "error", err)
+	}
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+
+	apperrors "skeji/pkg/errors"
+	httputil "skeji/pkg/http"
+	"skeji/pkg/model"
+)
+
+// @Summary Find duplicate business units
+// @Description Reports pairs of business units that look like the same business registered twice, scored in [0, 1] from name similarity, shared phones and overlapping cities, best first. Phone callers see the pairs among their own business units; other services may scan all units, optionally narrowed by phone and cities.
+// @Tags BusinessUnits
+// @Produce json
+// @Param phone query string false "Only units this phone is admin or maintainer of (defaults to the calling phone)"
+// @Param cities query []string false "Only units in these cities"
+// @Param min_score query number false "Lowest score reported, in (0, 1] (default 0.6)"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 200 {object} httputil.PaginatedResponse
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/business-units/duplicates [get]
+func (h *BusinessUnitHandler) FindDuplicates(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	query := r.URL.Query()
+	phone := query.Get("phone")
+	cities := extractQueryParams(query, "cities")
+
+	minScore := 0.0
+	if raw := strings.TrimSpace(query.Get("min_score")); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed <= 0 || parsed > 1 {
+			if writeErr := httputil.WriteError(w, apperrors.InvalidInput(fmt.Sprintf("invalid min_score %q, must be a number in (0, 1]", raw))); writeErr != nil {
+				h.log.Error("failed to write error response", "handler", "FindDuplicates", "operation", "WriteError", "error", writeErr)
+			}
+			return
+		}
+		minScore = parsed
+	}
+
+	limit, offset, err := httputil.ExtractLimitOffset(r)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "FindDuplicates", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	candidates, totalCount, err := h.service.FindDuplicates(r.Context(), phone, cities, minScore, limit, offset)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "FindDuplicates", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WritePaginated(w, candidates, totalCount, limit, offset); err != nil {
+		h.log.Error("failed to write paginated response", "handler", "FindDuplicates", "operation", "WritePaginated", "error", err)
+	}
+}
+
+// @Summary Merge business units
+// @Description Merges the duplicate business unit into another one. Its schedules and their bookings move to the surviving unit, which also takes over its cities, labels, website URLs, maintainers and viewers; the duplicate's admin stays on as a maintainer. The duplicate is then deleted and its ID redirects to the surviving unit. The caller must own both units. A merge that stopped halfway is resumed by repeating the request.
+// @Tags BusinessUnits
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the duplicate business unit"
+// @Param merge body model.BusinessUnitMergeRequest true "Business unit to merge into"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 200 {object} model.BusinessUnitMerge
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 409 {object} httputil.ErrorResponse
+// @Failure 410 {object} httputil.ErrorResponse
+// @Failure 422 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Failure 503 {object} httputil.ErrorResponse
+// @Router /api/v1/business-units/id/{id}/merge [post]
+func (h *BusinessUnitHandler) Merge(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+
+	var req model.BusinessUnitMergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if writeErr := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "Invalid request body",
+		}); writeErr != nil {
+			h.log.Error("failed to write JSON response", "handler", "Merge", "operation", "WriteJSON", "error", writeErr)
+		}
+		return
+	}
+
+	merge, err := h.service.Merge(r.Context(), id, &req)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "Merge", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteSuccess(w, merge); err != nil {
+		h.log.Error("failed to write success response", "handler", "Merge", "operation", "WriteSuccess", "error", err)
+	}
+}
+
+// writeMoved redirects a read of a business unit merged away to the unit it
+// was merged into.
+func (h *BusinessUnitHandler) writeMoved(w http.ResponseWriter, err *apperrors.AppError) error {
+	movedTo, _ := err.Details["moved_to"].(string)
+	w.Header().Set("Location", "/api/v1/business-units/id/"+movedTo)
+	return httputil.WriteJSON(w, http.StatusPermanentRedirect, httputil.ErrorResponse{
+		Error:   err.Message,
+		Details: err.Details,
+	})
+}
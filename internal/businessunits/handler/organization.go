@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	httputil "skeji/pkg/http"
+	"skeji/pkg/model"
+)
+
+// @Summary Create organization
+// @Description Creates a franchise or brand that groups business units. Its admin phone owns every unit in it and its admins maintain them; its labels, time zone, website URLs and hours fill in new branches.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param organization body model.Organization true "Organization"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 201 {object} model.Organization
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/organizations [post]
+func (h *BusinessUnitHandler) CreateOrganization(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var org model.Organization
+	if err := json.NewDecoder(r.Body).Decode(&org); err != nil {
+		if writeErr := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "Invalid request body",
+		}); writeErr != nil {
+			h.log.Error("failed to write JSON response", "handler", "CreateOrganization", "operation", "WriteJSON", "error", writeErr)
+		}
+		return
+	}
+
+	if err := h.service.CreateOrganization(r.Context(), &org); err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "CreateOrganization", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteCreated(w, org); err != nil {
+		h.log.Error("failed to write created response", "handler", "CreateOrganization", "operation", "WriteCreated", "error", err)
+	}
+}
+
+// @Summary Get organization
+// @Tags Organizations
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Success 200 {object} model.Organization
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/organizations/id/{id} [get]
+func (h *BusinessUnitHandler) GetOrganization(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+
+	org, err := h.service.GetOrganization(r.Context(), id)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "GetOrganization", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteSuccess(w, org); err != nil {
+		h.log.Error("failed to write success response", "handler", "GetOrganization", "operation", "WriteSuccess", "error", err)
+	}
+}
+
+// @Summary Update organization
+// @Description Changes the organization's branding and defaults; existing units keep their settings. The owner and admins can update it, only the owner changes the admins.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param organization body model.OrganizationUpdate true "Organization update"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 200 {object} model.Organization
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/organizations/id/{id} [patch]
+func (h *BusinessUnitHandler) UpdateOrganization(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+
+	var updates model.OrganizationUpdate
+	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		if writeErr := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "Invalid request body",
+		}); writeErr != nil {
+			h.log.Error("failed to write JSON response", "handler", "UpdateOrganization", "operation", "WriteJSON", "error", writeErr)
+		}
+		return
+	}
+
+	org, err := h.service.UpdateOrganization(r.Context(), id, &updates)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "UpdateOrganization", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteSuccess(w, org); err != nil {
+		h.log.Error("failed to write success response", "handler", "UpdateOrganization", "operation", "WriteSuccess", "error", err)
+	}
+}
+
+// @Summary Delete organization
+// @Description Deletes an organization without business units; move its units out or delete them first.
+// @Tags Organizations
+// @Param id path string true "Organization ID"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 204 "No Content"
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 409 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/organizations/id/{id} [delete]
+func (h *BusinessUnitHandler) DeleteOrganization(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+
+	if err := h.service.DeleteOrganization(r.Context(), id); err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "DeleteOrganization", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	httputil.WriteNoContent(w)
+}
+
+// @Summary List organization business units
+// @Tags Organizations
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} httputil.PaginatedResponse
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/organizations/id/{id}/business-units [get]
+func (h *BusinessUnitHandler) GetOrganizationUnits(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+
+	limit, offset, err := httputil.ExtractLimitOffset(r)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "GetOrganizationUnits", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	units, totalCount, err := h.service.GetOrganizationUnits(r.Context(), id, limit, offset)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "GetOrganizationUnits", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WritePaginated(w, units, totalCount, limit, offset); err != nil {
+		h.log.Error("failed to write paginated response", "handler", "GetOrganizationUnits", "operation", "WritePaginated", "error", err)
+	}
+}
+
+// @Summary Search brands
+// @Description Searches business units like /business-units/search but returns one result per brand: the branches of an organization collapse into the nearest one, in the earliest listed city, then by priority. Units outside organizations are their own brand.
+// @Tags Organizations
+// @Produce json
+// @Param cities query string true "Comma-separated cities by name or any alias, nearest first"
+// @Param labels query string true "Comma-separated labels"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} httputil.PaginatedResponse
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/organizations/search [get]
+func (h *BusinessUnitHandler) SearchBrands(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	query := r.URL.Query()
+	cities := extractQueryParams(query, "cities")
+	labels := extractQueryParams(query, "labels")
+
+	if len(cities) == 0 || len(labels) == 0 {
+		if writeErr := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "Both 'cities' and 'labels' query parameters are required",
+		}); writeErr != nil {
+			h.log.Error("failed to write JSON response", "handler", "SearchBrands", "operation", "WriteJSON", "error", writeErr)
+		}
+		return
+	}
+
+	limit, offset, err := httputil.ExtractLimitOffset(r)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "SearchBrands", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	matches, totalCount, err := h.service.SearchBrands(r.Context(), cities, labels, limit, offset)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "SearchBrands", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WritePaginated(w, matches, totalCount, limit, offset); err != nil {
+		h.log.Error("failed to write paginated response", "handler", "SearchBrands", "operation", "WritePaginated", "error", err)
+	}
+}
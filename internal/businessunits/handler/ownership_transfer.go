@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	httputil "skeji/pkg/http"
+	"skeji/pkg/model"
+)
+
+// @Summary Start ownership transfer
+// @Description Offers the business unit to another phone, which must accept before the transfer expires. Only the owner can start it, and only one transfer per business unit can be pending.
+// @Tags BusinessUnits
+// @Accept json
+// @Produce json
+// @Param id path string true "Business Unit ID"
+// @Param transfer body model.OwnershipTransferRequest true "New owner"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 201 {object} model.OwnershipTransfer
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 409 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/business-units/id/{id}/transfers [post]
+func (h *BusinessUnitHandler) StartTransfer(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+
+	var req model.OwnershipTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if writeErr := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "Invalid request body",
+		}); writeErr != nil {
+			h.log.Error("failed to write JSON response", "handler", "StartTransfer", "operation", "WriteJSON", "error", writeErr)
+		}
+		return
+	}
+
+	transfer, err := h.service.StartTransfer(r.Context(), id, &req)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "StartTransfer", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteCreated(w, transfer); err != nil {
+		h.log.Error("failed to write created response", "handler", "StartTransfer", "operation", "WriteCreated", "error", err)
+	}
+}
+
+// @Summary Get ownership transfer
+// @Description Returns a transfer to its current or new owner. A pending transfer past its deadline is reported as expired.
+// @Tags BusinessUnits
+// @Produce json
+// @Param id path string true "Transfer ID"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 200 {object} model.OwnershipTransfer
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/business-units/transfers/id/{id} [get]
+func (h *BusinessUnitHandler) GetTransfer(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+
+	transfer, err := h.service.GetTransfer(r.Context(), id)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "GetTransfer", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteSuccess(w, transfer); err != nil {
+		h.log.Error("failed to write success response", "handler", "GetTransfer", "operation", "WriteSuccess", "error", err)
+	}
+}
+
+// @Summary Accept ownership transfer
+// @Description Makes the caller, the new phone, the owner. Business unit limits are checked again, and the previous owner becomes a maintainer if the transfer asked for it.
+// @Tags BusinessUnits
+// @Produce json
+// @Param id path string true "Transfer ID"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 200 {object} model.OwnershipTransfer
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 409 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/business-units/transfers/id/{id}/accept [post]
+func (h *BusinessUnitHandler) AcceptTransfer(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+
+	transfer, err := h.service.AcceptTransfer(r.Context(), id)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "AcceptTransfer", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteSuccess(w, transfer); err != nil {
+		h.log.Error("failed to write success response", "handler", "AcceptTransfer", "operation", "WriteSuccess", "error", err)
+	}
+}
+
+// @Summary Decline ownership transfer
+// @Description Refuses the business unit; only the new phone can decline.
+// @Tags BusinessUnits
+// @Produce json
+// @Param id path string true "Transfer ID"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 200 {object} model.OwnershipTransfer
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 409 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/business-units/transfers/id/{id}/decline [post]
+func (h *BusinessUnitHandler) DeclineTransfer(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+
+	transfer, err := h.service.DeclineTransfer(r.Context(), id)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "DeclineTransfer", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteSuccess(w, transfer); err != nil {
+		h.log.Error("failed to write success response", "handler", "DeclineTransfer", "operation", "WriteSuccess", "error", err)
+	}
+}
+
+// @Summary Cancel ownership transfer
+// @Description Withdraws the offer; only the current owner can cancel.
+// @Tags BusinessUnits
+// @Produce json
+// @Param id path string true "Transfer ID"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 200 {object} model.OwnershipTransfer
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 409 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/business-units/transfers/id/{id}/cancel [post]
+func (h *BusinessUnitHandler) CancelTransfer(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+
+	transfer, err := h.service.CancelTransfer(r.Context(), id)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "CancelTransfer", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteSuccess(w, transfer); err != nil {
+		h.log.Error("failed to write success response", "handler", "CancelTransfer", "operation", "WriteSuccess", "error", err)
+	}
+}
+
+// @Summary Get business unit audit log
+// @Description Lists ownership transfers and status changes of the business unit, newest first. Only the owner and maintainers can read it.
+// @Tags BusinessUnits
+// @Produce json
+// @Param id path string true "Business Unit ID"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 200 {object} httputil.PaginatedResponse
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/business-units/id/{id}/audit [get]
+func (h *BusinessUnitHandler) GetAuditLog(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+
+	limit, offset, err := httputil.ExtractLimitOffset(r)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "GetAuditLog", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	entries, totalCount, err := h.service.GetAuditLog(r.Context(), id, limit, offset)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "GetAuditLog", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WritePaginated(w, entries, totalCount, limit, offset); err != nil {
+		h.log.Error("failed to write paginated response", "handler", "GetAuditLog", "operation", "WritePaginated", "error", err)
+	}
+}
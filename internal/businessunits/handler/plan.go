@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	httputil "skeji/pkg/http"
+	"skeji/pkg/model"
+)
+
+// @Summary List plans
+// @Description Lists the plans and their limits, from the smallest to the largest.
+// @Tags Plans
+// @Produce json
+// @Success 200 {array} model.Plan
+// @Router /api/v1/plans [get]
+func (h *BusinessUnitHandler) ListPlans(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if err := httputil.WriteSuccess(w, h.service.ListPlans(r.Context())); err != nil {
+		h.log.Error("failed to write success response", "handler", "ListPlans", "operation", "WriteSuccess", "error", err)
+	}
+}
+
+// @Summary Assign plan
+// @Description Puts an admin phone or an organization on a plan, replacing its previous plan. Phones default to the free plan and organizations to the enterprise plan. Only other services can assign plans.
+// @Tags Plans
+// @Accept json
+// @Produce json
+// @Param assignment body model.PlanAssignment true "Phone or organization_id, and plan"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 200 {object} model.PlanAssignment
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/plans/assignments [post]
+func (h *BusinessUnitHandler) AssignPlan(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var assignment model.PlanAssignment
+	if err := json.NewDecoder(r.Body).Decode(&assignment); err != nil {
+		if writeErr := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "Invalid request body",
+		}); writeErr != nil {
+			h.log.Error("failed to write JSON response", "handler", "AssignPlan", "operation", "WriteJSON", "error", writeErr)
+		}
+		return
+	}
+
+	if err := h.service.AssignPlan(r.Context(), &assignment); err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "AssignPlan", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteSuccess(w, assignment); err != nil {
+		h.log.Error("failed to write success response", "handler", "AssignPlan", "operation", "WriteSuccess", "error", err)
+	}
+}
+
+// @Summary Get business unit quota
+// @Description Reports the plan of the unit's owner, its limits and the current usage: business units of the admin phone (or branches of the organization), and the unit's schedules, maintainers and cities.
+// @Tags Plans
+// @Produce json
+// @Param id path string true "Business Unit ID"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 200 {object} model.Quota
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/business-units/id/{id}/quota [get]
+func (h *BusinessUnitHandler) GetQuota(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+
+	quota, err := h.service.GetQuota(r.Context(), id)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "GetQuota", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteSuccess(w, quota); err != nil {
+		h.log.Error("failed to write success response", "handler", "GetQuota", "operation", "WriteSuccess", "error", err)
+	}
+}
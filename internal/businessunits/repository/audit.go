@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"skeji/pkg/config"
+	"skeji/pkg/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	AuditCollectionName = "Business_unit_audit_log"
+)
+
+// AuditRepository is an append-only log of changes to who controls a
+// business unit.
+type AuditRepository interface {
+	Record(ctx context.Context, entry *model.AuditEntry) error
+	FindByBusiness(ctx context.Context, businessID string, limit int, offset int64) ([]*model.AuditEntry, error)
+	CountByBusiness(ctx context.Context, businessID string) (int64, error)
+}
+
+type mongoAuditRepository struct {
+	cfg        *config.Config
+	collection *mongo.Collection
+}
+
+func NewMongoAuditRepository(cfg *config.Config) AuditRepository {
+	db := cfg.Client.Mongo.Client.Database(cfg.MongoDatabaseName)
+	return &mongoAuditRepository{
+		cfg:        cfg,
+		collection: db.Collection(AuditCollectionName),
+	}
+}
+
+func (r *mongoAuditRepository) withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.(mongo.SessionContext); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (r *mongoAuditRepository) Record(ctx context.Context, entry *model.AuditEntry) error {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	entry.CreatedAt = time.Now().UTC().Truncate(time.Millisecond)
+	result, err := r.collection.InsertOne(ctx, entry)
+	if err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		entry.ID = oid.Hex()
+	}
+
+	return nil
+}
+
+// FindByBusiness returns the business unit's entries, newest first.
+func (r *mongoAuditRepository) FindByBusiness(ctx context.Context, businessID string, limit int, offset int64) ([]*model.AuditEntry, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetSkip(offset)
+
+	cursor, err := r.collection.Find(ctx, bson.M{"business_id": businessID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find audit entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	entries := []*model.AuditEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("failed to decode audit entries: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *mongoAuditRepository) CountByBusiness(ctx context.Context, businessID string) (int64, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"business_id": businessID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+	return count, nil
+}
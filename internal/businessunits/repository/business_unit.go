@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	businessunitserrors "skeji/internal/businessunits/errors"
+	"skeji/pkg/attributes"
 	"skeji/pkg/config"
 	mongotx "skeji/pkg/db/mongo"
 	"skeji/pkg/model"
@@ -20,6 +21,10 @@ const (
 	CollectionName = "Business_units"
 )
 
+// liveStatus matches units that are searchable: active, or stored before
+// statuses existed.
+var liveStatus = bson.M{"$in": bson.A{config.BusinessStatusActive, nil}}
+
 type mongoBusinessUnitRepository struct {
 	cfg        *config.Config
 	db         *mongo.Database
@@ -36,8 +41,20 @@ type BusinessUnitRepository interface {
 
 	GetByPhone(ctx context.Context, phone string, cities []string, labels []string, limit int, offset int64) ([]*model.BusinessUnit, error)
 	CountByPhone(ctx context.Context, phone string, cities []string, labels []string) (int64, error)
-	SearchByCityLabelPairs(ctx context.Context, pairs []string, limit int, offset int64) ([]*model.BusinessUnit, error)
-	CountByCityLabelPairs(ctx context.Context, pairs []string) (int64, error)
+	// CountByAdminPhone counts the units the phone owns outside
+	// organizations, which have their own limit.
+	CountByAdminPhone(ctx context.Context, phone string) (int64, error)
+	FindByOrganization(ctx context.Context, organizationID string, limit int, offset int64) ([]*model.BusinessUnit, error)
+	CountByOrganization(ctx context.Context, organizationID string) (int64, error)
+	// UpdateStatus moves the unit from status from to change.Status. It
+	// returns ErrNotFound when the unit is missing or no longer in from.
+	UpdateStatus(ctx context.Context, id string, from string, change *model.BusinessUnitStatusChange, at time.Time) error
+	SearchByCityLabelPairs(ctx context.Context, pairs []string, attrs model.AttributeFilter, limit int, offset int64) ([]*model.BusinessUnit, error)
+	CountByCityLabelPairs(ctx context.Context, pairs []string, attrs model.AttributeFilter) (int64, error)
+	// CountAttributeTerms counts, per attribute term, the live units matching
+	// the pairs and the attribute filter.
+	CountAttributeTerms(ctx context.Context, pairs []string, attrs model.AttributeFilter) (map[string]int64, error)
+	FindBySearchKeys(ctx context.Context, keys []string, cities []string, attrs model.AttributeFilter, limit int) ([]*model.BusinessUnit, error)
 	Count(ctx context.Context) (int64, error)
 
 	ExecuteTransaction(ctx context.Context, fn mongotx.TransactionFunc) error
@@ -148,18 +165,35 @@ func (r *mongoBusinessUnitRepository) Update(ctx context.Context, id string, bu
 	}
 
 	filter := bson.M{"_id": objectID}
-	update := bson.M{
-		"$set": bson.M{
-			"name":             bu.Name,
-			"cities":           bu.Cities,
-			"labels":           bu.Labels,
-			"admin_phone":      bu.AdminPhone,
-			"maintainers":      bu.Maintainers,
-			"priority":         bu.Priority,
-			"time_zone":        bu.TimeZone,
-			"website_urls":     bu.WebsiteURLs,
-			"city_label_pairs": bu.CityLabelPairs,
-		},
+	set := bson.M{
+		"name":             bu.Name,
+		"cities":           bu.Cities,
+		"labels":           bu.Labels,
+		"admin_phone":      bu.AdminPhone,
+		"maintainers":      bu.Maintainers,
+		"viewers":          bu.Viewers,
+		"priority":         bu.Priority,
+		"time_zone":        bu.TimeZone,
+		"website_urls":     bu.WebsiteURLs,
+		"city_label_pairs": bu.CityLabelPairs,
+		"search_keys":      bu.SearchKeys,
+	}
+	unset := bson.M{}
+	if bu.OrganizationID != "" {
+		set["organization_id"] = bu.OrganizationID
+	} else {
+		unset["organization_id"] = ""
+	}
+	if len(bu.Attributes) > 0 {
+		set["attributes"] = bu.Attributes
+		set["attribute_terms"] = bu.AttributeTerms
+	} else {
+		unset["attributes"] = ""
+		unset["attribute_terms"] = ""
+	}
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
 	}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
@@ -174,6 +208,40 @@ func (r *mongoBusinessUnitRepository) Update(ctx context.Context, id string, bu
 	return result, nil
 }
 
+func (r *mongoBusinessUnitRepository) UpdateStatus(ctx context.Context, id string, from string, change *model.BusinessUnitStatusChange, at time.Time) error {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("%w: %s", businessunitserrors.ErrInvalidID, id)
+	}
+
+	filter := bson.M{"_id": objectID, "status": from}
+	if from == config.BusinessStatusActive {
+		filter["status"] = liveStatus
+	}
+	set := bson.M{
+		"status":            change.Status,
+		"status_changed_at": at.UTC().Truncate(time.Millisecond),
+	}
+	update := bson.M{"$set": set}
+	if change.Reason != "" {
+		set["status_reason"] = change.Reason
+	} else {
+		update["$unset"] = bson.M{"status_reason": ""}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update business unit status: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%w: %s", businessunitserrors.ErrNotFound, id)
+	}
+	return nil
+}
+
 func (r *mongoBusinessUnitRepository) Delete(ctx context.Context, id string) error {
 	ctx, cancel := r.withTimeout(ctx, r.cfg.WriteTimeout)
 	defer cancel()
@@ -195,16 +263,17 @@ func (r *mongoBusinessUnitRepository) Delete(ctx context.Context, id string) err
 	return nil
 }
 
-func (r *mongoBusinessUnitRepository) SearchByCityLabelPairs(ctx context.Context, pairs []string, limit int, offset int64) ([]*model.BusinessUnit, error) {
+func (r *mongoBusinessUnitRepository) SearchByCityLabelPairs(ctx context.Context, pairs []string, attrs model.AttributeFilter, limit int, offset int64) ([]*model.BusinessUnit, error) {
 	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
 	defer cancel()
 
-	filter := bson.M{"city_label_pairs": bson.M{"$in": pairs}}
+	filter := bson.M{"city_label_pairs": bson.M{"$in": pairs}, "status": liveStatus}
+	matchAttributes(filter, attrs)
 
 	opts := options.Find().
 		SetLimit(int64(limit)).
 		SetSkip(int64(offset)).
-		SetSort(bson.D{{Key: "priority", Value: -1}})
+		SetSort(bson.D{{Key: "priority", Value: -1}, {Key: "_id", Value: 1}})
 
 	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -220,11 +289,12 @@ func (r *mongoBusinessUnitRepository) SearchByCityLabelPairs(ctx context.Context
 	return results, nil
 }
 
-func (r *mongoBusinessUnitRepository) CountByCityLabelPairs(ctx context.Context, pairs []string) (int64, error) {
+func (r *mongoBusinessUnitRepository) CountByCityLabelPairs(ctx context.Context, pairs []string, attrs model.AttributeFilter) (int64, error) {
 	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
 	defer cancel()
 
-	filter := bson.M{"city_label_pairs": bson.M{"$in": pairs}}
+	filter := bson.M{"city_label_pairs": bson.M{"$in": pairs}, "status": liveStatus}
+	matchAttributes(filter, attrs)
 
 	count, err := r.collection.CountDocuments(ctx, filter)
 	if err != nil {
@@ -233,6 +303,94 @@ func (r *mongoBusinessUnitRepository) CountByCityLabelPairs(ctx context.Context,
 	return count, nil
 }
 
+func (r *mongoBusinessUnitRepository) CountAttributeTerms(ctx context.Context, pairs []string, attrs model.AttributeFilter) (map[string]int64, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	filter := bson.M{"city_label_pairs": bson.M{"$in": pairs}, "status": liveStatus}
+	matchAttributes(filter, attrs)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$project", Value: bson.M{"attribute_terms": 1}}},
+		{{Key: "$unwind", Value: "$attribute_terms"}},
+		{{Key: "$group", Value: bson.M{"_id": "$attribute_terms", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count attribute terms: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Term  string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode attribute term counts: %w", err)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Term] = row.Count
+	}
+	return counts, nil
+}
+
+// matchAttributes narrows the filter to units having, for every filtered
+// attribute, one of the wanted values.
+func matchAttributes(filter bson.M, attrs model.AttributeFilter) {
+	groups := attributes.FilterTerms(attrs)
+	if len(groups) == 0 {
+		return
+	}
+	all := make(bson.A, 0, len(groups))
+	for _, terms := range groups {
+		all = append(all, bson.M{"attribute_terms": bson.M{"$in": terms}})
+	}
+	filter["$and"] = all
+}
+
+// FindBySearchKeys returns text search candidates: units sharing at least one
+// key with the query, most shared keys first, then by priority.
+func (r *mongoBusinessUnitRepository) FindBySearchKeys(ctx context.Context, keys []string, cities []string, attrs model.AttributeFilter, limit int) ([]*model.BusinessUnit, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	filter := bson.M{"search_keys": bson.M{"$in": keys}, "status": liveStatus}
+	if len(cities) > 0 {
+		filter["cities"] = bson.M{"$in": cities}
+	}
+	matchAttributes(filter, attrs)
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: bson.M{
+			"shared_keys": bson.M{"$size": bson.M{"$setIntersection": bson.A{"$search_keys", keys}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "shared_keys", Value: -1},
+			{Key: "priority", Value: -1},
+		}}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$project", Value: bson.M{"shared_keys": 0, "search_keys": 0}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find business units by search_keys: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []*model.BusinessUnit
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode business units: %w", err)
+	}
+
+	return results, nil
+}
+
 func (r *mongoBusinessUnitRepository) GetByPhone(
 	ctx context.Context,
 	phone string,
@@ -249,7 +407,9 @@ func (r *mongoBusinessUnitRepository) GetByPhone(
 		"$or": []bson.M{
 			{"admin_phone": phone},
 			{fmt.Sprintf("maintainers.%s", phone): bson.M{"$exists": true}},
+			{fmt.Sprintf("viewers.%s", phone): bson.M{"$exists": true}},
 		},
+		"status": bson.M{"$ne": config.BusinessStatusArchived},
 	}
 
 	if len(cities) > 0 {
@@ -293,7 +453,9 @@ func (r *mongoBusinessUnitRepository) CountByPhone(
 		"$or": []bson.M{
 			{"admin_phone": phone},
 			{fmt.Sprintf("maintainers.%s", phone): bson.M{"$exists": true}},
+			{fmt.Sprintf("viewers.%s", phone): bson.M{"$exists": true}},
 		},
+		"status": bson.M{"$ne": config.BusinessStatusArchived},
 	}
 
 	if len(cities) > 0 {
@@ -312,6 +474,55 @@ func (r *mongoBusinessUnitRepository) CountByPhone(
 	return count, nil
 }
 
+func (r *mongoBusinessUnitRepository) CountByAdminPhone(ctx context.Context, phone string) (int64, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	filter := bson.M{
+		"admin_phone":     phone,
+		"status":          bson.M{"$ne": config.BusinessStatusArchived},
+		"organization_id": bson.M{"$exists": false},
+	}
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count business units for admin phone [%s]: %w", phone, err)
+	}
+	return count, nil
+}
+
+func (r *mongoBusinessUnitRepository) FindByOrganization(ctx context.Context, organizationID string, limit int, offset int64) ([]*model.BusinessUnit, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(offset).
+		SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"organization_id": organizationID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query business units of organization [%s]: %w", organizationID, err)
+	}
+	defer cursor.Close(ctx)
+
+	var businessUnits []*model.BusinessUnit
+	if err = cursor.All(ctx, &businessUnits); err != nil {
+		return nil, fmt.Errorf("failed to decode business units: %w", err)
+	}
+	return businessUnits, nil
+}
+
+func (r *mongoBusinessUnitRepository) CountByOrganization(ctx context.Context, organizationID string) (int64, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"organization_id": organizationID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count business units of organization [%s]: %w", organizationID, err)
+	}
+	return count, nil
+}
+
 func (r *mongoBusinessUnitRepository) Count(ctx context.Context) (int64, error) {
 	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
 	defer cancel()
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	businessunitserrors "skeji/internal/businessunits/errors"
+	"skeji/pkg/config"
+	"skeji/pkg/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DeletionCollectionName = "Business_unit_deletions"
+)
+
+// DeletionRepository persists the progress of business unit deletions so a
+// cascade interrupted by a failing service or a restart can be resumed.
+type DeletionRepository interface {
+	Create(ctx context.Context, op *model.BusinessUnitDeletion) error
+	FindByID(ctx context.Context, id string) (*model.BusinessUnitDeletion, error)
+	Save(ctx context.Context, op *model.BusinessUnitDeletion) error
+
+	// Claim leases a due, unfinished operation until lockedUntil so only one
+	// runner works on it. An empty id claims any due operation.
+	Claim(ctx context.Context, id string, now time.Time, lockedUntil time.Time) (*model.BusinessUnitDeletion, error)
+
+	// Reschedule makes a retrying or failed operation due at now with a fresh
+	// attempt budget.
+	Reschedule(ctx context.Context, id string, now time.Time) (*model.BusinessUnitDeletion, error)
+}
+
+type mongoDeletionRepository struct {
+	cfg        *config.Config
+	collection *mongo.Collection
+}
+
+func NewMongoDeletionRepository(cfg *config.Config) DeletionRepository {
+	db := cfg.Client.Mongo.Client.Database(cfg.MongoDatabaseName)
+	return &mongoDeletionRepository{
+		cfg:        cfg,
+		collection: db.Collection(DeletionCollectionName),
+	}
+}
+
+func (r *mongoDeletionRepository) withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.(mongo.SessionContext); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (r *mongoDeletionRepository) Create(ctx context.Context, op *model.BusinessUnitDeletion) error {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	op.CreatedAt = now
+	op.UpdatedAt = now
+	result, err := r.collection.InsertOne(ctx, op)
+	if err != nil {
+		return fmt.Errorf("failed to create deletion operation: %w", err)
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		op.ID = oid.Hex()
+	}
+
+	return nil
+}
+
+func (r *mongoDeletionRepository) FindByID(ctx context.Context, id string) (*model.BusinessUnitDeletion, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", businessunitserrors.ErrInvalidID, id)
+	}
+
+	var op model.BusinessUnitDeletion
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&op)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%w: %s", businessunitserrors.ErrDeletionNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to find deletion operation: %w", err)
+	}
+	return &op, nil
+}
+
+func (r *mongoDeletionRepository) Save(ctx context.Context, op *model.BusinessUnitDeletion) error {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(op.ID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", businessunitserrors.ErrInvalidID, op.ID)
+	}
+
+	op.UpdatedAt = time.Now().UTC().Truncate(time.Millisecond)
+	doc := *op
+	doc.ID = ""
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": objectID}, doc)
+	if err != nil {
+		return fmt.Errorf("failed to save deletion operation: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%w: %s", businessunitserrors.ErrDeletionNotFound, op.ID)
+	}
+	return nil
+}
+
+func (r *mongoDeletionRepository) Claim(ctx context.Context, id string, now time.Time, lockedUntil time.Time) (*model.BusinessUnitDeletion, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	filter := bson.M{
+		"status":          bson.M{"$in": []string{config.OperationPending, config.OperationRunning, config.OperationRetrying}},
+		"next_attempt_at": bson.M{"$lte": now},
+		"locked_until":    bson.M{"$lte": now},
+	}
+	if id != "" {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", businessunitserrors.ErrInvalidID, id)
+		}
+		filter["_id"] = objectID
+	}
+	update := bson.M{"$set": bson.M{
+		"status":       config.OperationRunning,
+		"locked_until": lockedUntil,
+		"updated_at":   now,
+	}}
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}})
+
+	var op model.BusinessUnitDeletion
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&op)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, businessunitserrors.ErrDeletionNotFound
+		}
+		return nil, fmt.Errorf("failed to claim deletion operation: %w", err)
+	}
+	return &op, nil
+}
+
+func (r *mongoDeletionRepository) Reschedule(ctx context.Context, id string, now time.Time) (*model.BusinessUnitDeletion, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", businessunitserrors.ErrInvalidID, id)
+	}
+	filter := bson.M{
+		"_id":    objectID,
+		"status": bson.M{"$in": []string{config.OperationRetrying, config.OperationFailed}},
+	}
+	update := bson.M{"$set": bson.M{
+		"status":          config.OperationRetrying,
+		"next_attempt_at": now,
+		"attempts":        0,
+		"updated_at":      now,
+	}}
+
+	var op model.BusinessUnitDeletion
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&op)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%w: %s", businessunitserrors.ErrDeletionNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to reschedule deletion operation: %w", err)
+	}
+	return &op, nil
+}
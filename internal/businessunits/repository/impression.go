@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"skeji/pkg/config"
+	"skeji/pkg/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	ImpressionCollectionName = "Business_unit_impressions"
+)
+
+// ImpressionRepository counts how often each business unit is shown in search
+// results, one counter per business per UTC day.
+type ImpressionRepository interface {
+	Record(ctx context.Context, businessIDs []string, at time.Time) error
+	Totals(ctx context.Context, businessIDs []string, since time.Time) (map[string]int64, error)
+	Daily(ctx context.Context, businessID string, since time.Time) ([]model.DailyImpressions, error)
+}
+
+type mongoImpressionRepository struct {
+	cfg        *config.Config
+	collection *mongo.Collection
+}
+
+func NewMongoImpressionRepository(cfg *config.Config) ImpressionRepository {
+	db := cfg.Client.Mongo.Client.Database(cfg.MongoDatabaseName)
+	return &mongoImpressionRepository{
+		cfg:        cfg,
+		collection: db.Collection(ImpressionCollectionName),
+	}
+}
+
+func (r *mongoImpressionRepository) withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.(mongo.SessionContext); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// Record adds one impression for each id; an id listed twice counts twice.
+func (r *mongoImpressionRepository) Record(ctx context.Context, businessIDs []string, at time.Time) error {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	day := at.UTC().Truncate(24 * time.Hour)
+	writes := make([]mongo.WriteModel, 0, len(businessIDs))
+	for _, id := range businessIDs {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"business_id": id, "day": day}).
+			SetUpdate(bson.M{"$inc": bson.M{"count": 1}}).
+			SetUpsert(true))
+	}
+
+	if _, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to record impressions: %w", err)
+	}
+	return nil
+}
+
+func (r *mongoImpressionRepository) Totals(ctx context.Context, businessIDs []string, since time.Time) (map[string]int64, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"business_id": bson.M{"$in": businessIDs},
+			"day":         bson.M{"$gte": since.UTC().Truncate(24 * time.Hour)},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$business_id",
+			"total": bson.M{"$sum": "$count"},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count impressions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		BusinessID string `bson:"_id"`
+		Total      int64  `bson:"total"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil {
+		return nil, fmt.Errorf("failed to decode impressions: %w", err)
+	}
+
+	totals := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		totals[row.BusinessID] = row.Total
+	}
+	return totals, nil
+}
+
+func (r *mongoImpressionRepository) Daily(ctx context.Context, businessID string, since time.Time) ([]model.DailyImpressions, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	filter := bson.M{
+		"business_id": businessID,
+		"day":         bson.M{"$gte": since.UTC().Truncate(24 * time.Hour)},
+	}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "day", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find impressions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	daily := []model.DailyImpressions{}
+	if err := cursor.All(ctx, &daily); err != nil {
+		return nil, fmt.Errorf("failed to decode impressions: %w", err)
+	}
+	return daily, nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	businessunitserrors "skeji/internal/businessunits/errors"
+	"skeji/pkg/config"
+	"skeji/pkg/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	MergeCollectionName = "Business_unit_merges"
+)
+
+// MergeRepository persists business unit merges. A unit is merged away at
+// most once, so merges are also looked up by their source to resume an
+// interrupted one or to redirect the merged ID.
+type MergeRepository interface {
+	Create(ctx context.Context, merge *model.BusinessUnitMerge) error
+	FindByID(ctx context.Context, id string) (*model.BusinessUnitMerge, error)
+	FindBySource(ctx context.Context, sourceID string) (*model.BusinessUnitMerge, error)
+	Save(ctx context.Context, merge *model.BusinessUnitMerge) error
+}
+
+type mongoMergeRepository struct {
+	cfg        *config.Config
+	collection *mongo.Collection
+}
+
+func NewMongoMergeRepository(cfg *config.Config) MergeRepository {
+	db := cfg.Client.Mongo.Client.Database(cfg.MongoDatabaseName)
+	return &mongoMergeRepository{
+		cfg:        cfg,
+		collection: db.Collection(MergeCollectionName),
+	}
+}
+
+func (r *mongoMergeRepository) withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.(mongo.SessionContext); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (r *mongoMergeRepository) Create(ctx context.Context, merge *model.BusinessUnitMerge) error {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	merge.CreatedAt = now
+	merge.UpdatedAt = now
+	result, err := r.collection.InsertOne(ctx, merge)
+	if err != nil {
+		return fmt.Errorf("failed to create business unit merge: %w", err)
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		merge.ID = oid.Hex()
+	}
+
+	return nil
+}
+
+func (r *mongoMergeRepository) FindByID(ctx context.Context, id string) (*model.BusinessUnitMerge, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", businessunitserrors.ErrInvalidID, id)
+	}
+	return r.findOne(ctx, bson.M{"_id": objectID}, id)
+}
+
+func (r *mongoMergeRepository) FindBySource(ctx context.Context, sourceID string) (*model.BusinessUnitMerge, error) {
+	return r.findOne(ctx, bson.M{"source_id": sourceID}, sourceID)
+}
+
+func (r *mongoMergeRepository) findOne(ctx context.Context, filter bson.M, id string) (*model.BusinessUnitMerge, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	var merge model.BusinessUnitMerge
+	err := r.collection.FindOne(ctx, filter).Decode(&merge)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%w: %s", businessunitserrors.ErrMergeNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to find business unit merge: %w", err)
+	}
+	return &merge, nil
+}
+
+func (r *mongoMergeRepository) Save(ctx context.Context, merge *model.BusinessUnitMerge) error {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(merge.ID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", businessunitserrors.ErrInvalidID, merge.ID)
+	}
+
+	merge.UpdatedAt = time.Now().UTC().Truncate(time.Millisecond)
+	doc := *merge
+	doc.ID = ""
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": objectID}, doc)
+	if err != nil {
+		return fmt.Errorf("failed to save business unit merge: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%w: %s", businessunitserrors.ErrMergeNotFound, merge.ID)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	businessunitserrors "skeji/internal/businessunits/errors"
+	"skeji/pkg/config"
+	"skeji/pkg/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	OrganizationCollectionName = "Organizations"
+)
+
+// OrganizationRepository stores organizations. Their business units point at
+// them through organization_id.
+type OrganizationRepository interface {
+	Create(ctx context.Context, org *model.Organization) error
+	FindByID(ctx context.Context, id string) (*model.Organization, error)
+	FindByIDs(ctx context.Context, ids []string) ([]*model.Organization, error)
+	Update(ctx context.Context, id string, org *model.Organization) error
+	Delete(ctx context.Context, id string) error
+}
+
+type mongoOrganizationRepository struct {
+	cfg        *config.Config
+	collection *mongo.Collection
+}
+
+func NewMongoOrganizationRepository(cfg *config.Config) OrganizationRepository {
+	db := cfg.Client.Mongo.Client.Database(cfg.MongoDatabaseName)
+	return &mongoOrganizationRepository{
+		cfg:        cfg,
+		collection: db.Collection(OrganizationCollectionName),
+	}
+}
+
+func (r *mongoOrganizationRepository) withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.(mongo.SessionContext); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (r *mongoOrganizationRepository) Create(ctx context.Context, org *model.Organization) error {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	org.CreatedAt = time.Now().UTC().Truncate(time.Millisecond)
+	result, err := r.collection.InsertOne(ctx, org)
+	if err != nil {
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		org.ID = oid.Hex()
+	}
+
+	return nil
+}
+
+func (r *mongoOrganizationRepository) FindByID(ctx context.Context, id string) (*model.Organization, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", businessunitserrors.ErrInvalidID, id)
+	}
+
+	var org model.Organization
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&org)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%w: %s", businessunitserrors.ErrOrganizationNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to find organization: %w", err)
+	}
+	return &org, nil
+}
+
+func (r *mongoOrganizationRepository) FindByIDs(ctx context.Context, ids []string) ([]*model.Organization, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	objectIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if objectID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objectIDs = append(objectIDs, objectID)
+		}
+	}
+	if len(objectIDs) == 0 {
+		return []*model.Organization{}, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": objectIDs}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query organizations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var orgs []*model.Organization
+	if err = cursor.All(ctx, &orgs); err != nil {
+		return nil, fmt.Errorf("failed to decode organizations: %w", err)
+	}
+	return orgs, nil
+}
+
+func (r *mongoOrganizationRepository) Update(ctx context.Context, id string, org *model.Organization) error {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("%w: %s", businessunitserrors.ErrInvalidID, id)
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":         org.Name,
+			"admins":       org.Admins,
+			"labels":       org.Labels,
+			"services":     org.Services,
+			"hours":        org.Hours,
+			"time_zone":    org.TimeZone,
+			"website_urls": org.WebsiteURLs,
+		},
+	}
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objectID}, update)
+	if err != nil {
+		return fmt.Errorf("failed to update organization: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%w: %s", businessunitserrors.ErrOrganizationNotFound, id)
+	}
+	return nil
+}
+
+func (r *mongoOrganizationRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("%w: %s", businessunitserrors.ErrInvalidID, id)
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("%w: %s", businessunitserrors.ErrOrganizationNotFound, id)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	businessunitserrors "skeji/internal/businessunits/errors"
+	"skeji/pkg/config"
+	"skeji/pkg/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	TransferCollectionName = "Business_unit_transfers"
+)
+
+// TransferRepository stores ownership transfers. At most one transfer per
+// business unit is pending at a time.
+type TransferRepository interface {
+	Create(ctx context.Context, transfer *model.OwnershipTransfer) error
+	FindByID(ctx context.Context, id string) (*model.OwnershipTransfer, error)
+	FindPending(ctx context.Context, businessID string) (*model.OwnershipTransfer, error)
+
+	// Resolve moves a pending transfer to status. It returns
+	// ErrTransferNotFound when the transfer is no longer pending, so two
+	// concurrent answers cannot both win.
+	Resolve(ctx context.Context, id string, status string, at time.Time) (*model.OwnershipTransfer, error)
+}
+
+type mongoTransferRepository struct {
+	cfg        *config.Config
+	collection *mongo.Collection
+}
+
+func NewMongoTransferRepository(cfg *config.Config) TransferRepository {
+	db := cfg.Client.Mongo.Client.Database(cfg.MongoDatabaseName)
+	return &mongoTransferRepository{
+		cfg:        cfg,
+		collection: db.Collection(TransferCollectionName),
+	}
+}
+
+func (r *mongoTransferRepository) withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.(mongo.SessionContext); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (r *mongoTransferRepository) Create(ctx context.Context, transfer *model.OwnershipTransfer) error {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	transfer.CreatedAt = time.Now().UTC().Truncate(time.Millisecond)
+	result, err := r.collection.InsertOne(ctx, transfer)
+	if err != nil {
+		return fmt.Errorf("failed to create ownership transfer: %w", err)
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		transfer.ID = oid.Hex()
+	}
+
+	return nil
+}
+
+func (r *mongoTransferRepository) FindByID(ctx context.Context, id string) (*model.OwnershipTransfer, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", businessunitserrors.ErrInvalidID, id)
+	}
+
+	var transfer model.OwnershipTransfer
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&transfer)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%w: %s", businessunitserrors.ErrTransferNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to find ownership transfer: %w", err)
+	}
+	return &transfer, nil
+}
+
+func (r *mongoTransferRepository) FindPending(ctx context.Context, businessID string) (*model.OwnershipTransfer, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	var transfer model.OwnershipTransfer
+	filter := bson.M{"business_id": businessID, "status": config.TransferPending}
+	err := r.collection.FindOne(ctx, filter).Decode(&transfer)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, businessunitserrors.ErrTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to find pending ownership transfer: %w", err)
+	}
+	return &transfer, nil
+}
+
+func (r *mongoTransferRepository) Resolve(ctx context.Context, id string, status string, at time.Time) (*model.OwnershipTransfer, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", businessunitserrors.ErrInvalidID, id)
+	}
+	filter := bson.M{"_id": objectID, "status": config.TransferPending}
+	update := bson.M{"$set": bson.M{
+		"status":      status,
+		"resolved_at": at.UTC().Truncate(time.Millisecond),
+	}}
+
+	var transfer model.OwnershipTransfer
+	err = r.collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&transfer)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%w: %s", businessunitserrors.ErrTransferNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to resolve ownership transfer: %w", err)
+	}
+	return &transfer, nil
+}
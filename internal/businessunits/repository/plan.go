@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	businessunitserrors "skeji/internal/businessunits/errors"
+	"skeji/pkg/config"
+	"skeji/pkg/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	PlanAssignmentCollectionName = "Plan_assignments"
+)
+
+// PlanRepository stores which plan admin phones and organizations are on.
+// Those without an assignment are on the default plans.
+type PlanRepository interface {
+	// Assign puts the phone or organization of the assignment on its plan,
+	// replacing any previous assignment.
+	Assign(ctx context.Context, assignment *model.PlanAssignment) error
+	FindByPhone(ctx context.Context, phone string) (*model.PlanAssignment, error)
+	FindByOrganization(ctx context.Context, organizationID string) (*model.PlanAssignment, error)
+}
+
+type mongoPlanRepository struct {
+	cfg        *config.Config
+	collection *mongo.Collection
+}
+
+func NewMongoPlanRepository(cfg *config.Config) PlanRepository {
+	db := cfg.Client.Mongo.Client.Database(cfg.MongoDatabaseName)
+	return &mongoPlanRepository{
+		cfg:        cfg,
+		collection: db.Collection(PlanAssignmentCollectionName),
+	}
+}
+
+func (r *mongoPlanRepository) withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.(mongo.SessionContext); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (r *mongoPlanRepository) Assign(ctx context.Context, assignment *model.PlanAssignment) error {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	filter := bson.M{"phone": assignment.Phone}
+	if assignment.OrganizationID != "" {
+		filter = bson.M{"organization_id": assignment.OrganizationID}
+	}
+	assignment.AssignedAt = time.Now().UTC().Truncate(time.Millisecond)
+	update := bson.M{"$set": bson.M{
+		"plan":        assignment.Plan,
+		"assigned_at": assignment.AssignedAt,
+	}}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var stored model.PlanAssignment
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&stored); err != nil {
+		return fmt.Errorf("failed to assign plan: %w", err)
+	}
+	assignment.ID = stored.ID
+	return nil
+}
+
+func (r *mongoPlanRepository) FindByPhone(ctx context.Context, phone string) (*model.PlanAssignment, error) {
+	return r.findOne(ctx, bson.M{"phone": phone}, phone)
+}
+
+func (r *mongoPlanRepository) FindByOrganization(ctx context.Context, organizationID string) (*model.PlanAssignment, error) {
+	return r.findOne(ctx, bson.M{"organization_id": organizationID}, organizationID)
+}
+
+func (r *mongoPlanRepository) findOne(ctx context.Context, filter bson.M, subject string) (*model.PlanAssignment, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	var assignment model.PlanAssignment
+	err := r.collection.FindOne(ctx, filter).Decode(&assignment)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%w: %s", businessunitserrors.ErrPlanAssignmentNotFound, subject)
+		}
+		return nil, fmt.Errorf("failed to find plan assignment: %w", err)
+	}
+	return &assignment, nil
+}
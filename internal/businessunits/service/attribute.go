@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"skeji/pkg/attributes"
+	"skeji/pkg/config"
+	apperrors "skeji/pkg/errors"
+	"skeji/pkg/locale"
+	"skeji/pkg/model"
+	"skeji/pkg/textsearch"
+)
+
+// scoredUnit is a text search match and its relevance to the query.
+type scoredUnit struct {
+	unit      *model.BusinessUnit
+	relevance float64
+}
+
+func (s *businessUnitService) ListAttributes(ctx context.Context) []model.AttributeDefinition {
+	return attributes.All()
+}
+
+// SearchFacets counts, per attribute value, the units a search finds: the
+// text search with a query, the cities and labels search without one.
+func (s *businessUnitService) SearchFacets(ctx context.Context, query string, cities []string, labels []string, attrs model.AttributeFilter) ([]model.AttributeFacet, error) {
+	counts := map[string]int64{}
+
+	if query != "" {
+		tokens := textsearch.Tokens(query)
+		if len(tokens) == 0 {
+			return nil, apperrors.InvalidInput("Search query must contain at least one letter or digit")
+		}
+		matches, _, err := s.textMatches(ctx, query, tokens, locale.CanonicalCities(cities), attrs)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			for _, term := range match.unit.AttributeTerms {
+				counts[term]++
+			}
+		}
+		return attributes.Facets(counts), nil
+	}
+
+	labels, cities = s.sanitizeSearchRequest(labels, cities)
+	if len(cities) == 0 || len(labels) == 0 {
+		return nil, apperrors.InvalidInput("Search criteria resulted in no valid items after normalization")
+	}
+	counts, err := s.repo.CountAttributeTerms(ctx, searchPairs(cities, labels), attrs)
+	if err != nil {
+		s.cfg.Log.Error("Failed to count attribute facets",
+			"cities", cities,
+			"labels", labels,
+			"error", err,
+		)
+		return nil, apperrors.Internal("Failed to count search facets", err)
+	}
+	return attributes.Facets(counts), nil
+}
+
+// textMatches returns the text search candidates relevant enough to the
+// query, and how many candidates were considered.
+func (s *businessUnitService) textMatches(ctx context.Context, query string, tokens []string, cities []string, attrs model.AttributeFilter) ([]scoredUnit, int, error) {
+	candidates, err := s.repo.FindBySearchKeys(ctx, textsearch.Keys(query), cities, attrs, config.MaxTextSearchCandidates)
+	if err != nil {
+		s.cfg.Log.Error("Failed to search business units by text",
+			"query", query,
+			"cities", cities,
+			"error", err,
+		)
+		return nil, 0, apperrors.Internal("Failed to search business units", err)
+	}
+
+	matches := make([]scoredUnit, 0, len(candidates))
+	for _, unit := range candidates {
+		relevance := textsearch.Relevance(tokens, unit.Name, unit.Labels)
+		if relevance >= textsearch.MinRelevance {
+			matches = append(matches, scoredUnit{unit: unit, relevance: relevance})
+		}
+	}
+	return matches, len(candidates), nil
+}
+
+// searchPairs returns the city_label_pairs a search matches.
+func searchPairs(cities, labels []string) []string {
+	pairs := make([]string, 0, len(cities)*len(labels))
+	for _, city := range cities {
+		for _, label := range labels {
+			pairs = append(pairs, fmt.Sprintf("%s|%s", city, label))
+		}
+	}
+	return pairs
+}
@@ -7,12 +7,18 @@ import (
 	businessunitserrors "skeji/internal/businessunits/errors"
 	"skeji/internal/businessunits/repository"
 	"skeji/internal/businessunits/validator"
+	"skeji/pkg/attributes"
+	"skeji/pkg/auth"
 	"skeji/pkg/config"
 	apperrors "skeji/pkg/errors"
 	"skeji/pkg/locale"
 	"skeji/pkg/model"
 	"skeji/pkg/sanitizer"
+	"skeji/pkg/taxonomy"
+	"skeji/pkg/textsearch"
+	"sort"
 	"sync"
+	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -22,42 +28,123 @@ type BusinessUnitService interface {
 	GetByID(ctx context.Context, id string) (*model.BusinessUnit, error)
 	GetAll(ctx context.Context, limit int, offset int64) ([]*model.BusinessUnit, int64, error)
 	Update(ctx context.Context, id string, updates *model.BusinessUnitUpdate) error
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string) (*model.BusinessUnitDeletion, error)
+	GetDeletion(ctx context.Context, id string) (*model.BusinessUnitDeletion, error)
+	ResumeDeletion(ctx context.Context, id string) (*model.BusinessUnitDeletion, error)
 
 	GetByPhone(ctx context.Context, phone string, cities []string, labels []string, limit int, offset int64) ([]*model.BusinessUnit, int64, error)
-	Search(ctx context.Context, cities []string, labels []string, limit int, offset int64) ([]*model.BusinessUnit, int64, error)
+	Search(ctx context.Context, cities []string, labels []string, attrs model.AttributeFilter, fairness model.SearchFairness, limit int, offset int64) ([]*model.BusinessUnit, int64, error)
+	TextSearch(ctx context.Context, query string, cities []string, attrs model.AttributeFilter, fairness model.SearchFairness, limit int, offset int64) ([]*model.BusinessUnit, int64, error)
+	SearchFacets(ctx context.Context, query string, cities []string, labels []string, attrs model.AttributeFilter) ([]model.AttributeFacet, error)
+	ListAttributes(ctx context.Context) []model.AttributeDefinition
+
+	RecordImpressions(ctx context.Context, record *model.ImpressionRecord) error
+	GetImpressions(ctx context.Context, id string, days int) (*model.ImpressionSummary, error)
+
+	ChangeStatus(ctx context.Context, id string, change *model.BusinessUnitStatusChange) (*model.BusinessUnit, error)
+
+	StartTransfer(ctx context.Context, businessID string, req *model.OwnershipTransferRequest) (*model.OwnershipTransfer, error)
+	GetTransfer(ctx context.Context, id string) (*model.OwnershipTransfer, error)
+	AcceptTransfer(ctx context.Context, id string) (*model.OwnershipTransfer, error)
+	DeclineTransfer(ctx context.Context, id string) (*model.OwnershipTransfer, error)
+	CancelTransfer(ctx context.Context, id string) (*model.OwnershipTransfer, error)
+	GetAuditLog(ctx context.Context, businessID string, limit int, offset int64) ([]*model.AuditEntry, int64, error)
+
+	CreateOrganization(ctx context.Context, org *model.Organization) error
+	GetOrganization(ctx context.Context, id string) (*model.Organization, error)
+	UpdateOrganization(ctx context.Context, id string, updates *model.OrganizationUpdate) (*model.Organization, error)
+	DeleteOrganization(ctx context.Context, id string) error
+	GetOrganizationUnits(ctx context.Context, id string, limit int, offset int64) ([]*model.BusinessUnit, int64, error)
+	SearchBrands(ctx context.Context, cities []string, labels []string, limit int, offset int64) ([]*model.BrandMatch, int64, error)
+
+	ListPlans(ctx context.Context) []model.Plan
+	AssignPlan(ctx context.Context, assignment *model.PlanAssignment) error
+	GetQuota(ctx context.Context, businessID string) (*model.Quota, error)
+
+	FindDuplicates(ctx context.Context, phone string, cities []string, minScore float64, limit int, offset int64) ([]model.DuplicateCandidate, int64, error)
+	Merge(ctx context.Context, sourceID string, req *model.BusinessUnitMergeRequest) (*model.BusinessUnitMerge, error)
 }
 
 type businessUnitService struct {
-	repo      repository.BusinessUnitRepository
-	validator *validator.BusinessUnitValidator
-	cfg       *config.Config
+	repo           repository.BusinessUnitRepository
+	deletionRepo   repository.DeletionRepository
+	impressionRepo repository.ImpressionRepository
+	transferRepo   repository.TransferRepository
+	auditRepo      repository.AuditRepository
+	orgRepo        repository.OrganizationRepository
+	planRepo       repository.PlanRepository
+	mergeRepo      repository.MergeRepository
+	deletions      *DeletionWorker
+	validator      *validator.BusinessUnitValidator
+	cfg            *config.Config
 }
 
 func NewBusinessUnitService(
 	repo repository.BusinessUnitRepository,
+	deletionRepo repository.DeletionRepository,
+	impressionRepo repository.ImpressionRepository,
+	transferRepo repository.TransferRepository,
+	auditRepo repository.AuditRepository,
+	orgRepo repository.OrganizationRepository,
+	planRepo repository.PlanRepository,
+	mergeRepo repository.MergeRepository,
+	deletions *DeletionWorker,
 	validator *validator.BusinessUnitValidator,
 	cfg *config.Config,
 ) BusinessUnitService {
 	return &businessUnitService{
-		repo:      repo,
-		validator: validator,
-		cfg:       cfg,
+		repo:           repo,
+		deletionRepo:   deletionRepo,
+		impressionRepo: impressionRepo,
+		transferRepo:   transferRepo,
+		auditRepo:      auditRepo,
+		orgRepo:        orgRepo,
+		planRepo:       planRepo,
+		mergeRepo:      mergeRepo,
+		deletions:      deletions,
+		validator:      validator,
+		cfg:            cfg,
 	}
 }
 
 func (s *businessUnitService) Create(ctx context.Context, bu *model.BusinessUnit) error {
-	s.applyDefaults(bu)
-	s.sanitize(bu)
-	err := s.verifyLimitPerPhoneAdmin(ctx, bu)
+	caller, err := auth.RequireCaller(ctx)
 	if err != nil {
 		return err
 	}
+	var org *model.Organization
+	if bu.OrganizationID != "" {
+		// organization owners and admins open branches for any admin phone
+		if org, err = s.joinOrganization(ctx, bu.OrganizationID); err != nil {
+			return err
+		}
+		applyOrganizationDefaults(bu, org)
+	} else if !caller.System && caller.Phone != bu.AdminPhone {
+		return apperrors.Forbidden("A business unit can only be created by its admin phone")
+	}
+
+	s.applyDefaults(bu)
+	if bu.Status != config.BusinessStatusActive && bu.Status != config.BusinessStatusDraft {
+		return apperrors.InvalidInput("A business unit can only be created as active or draft")
+	}
+	bu.StatusReason = ""
+	bu.StatusChangedAt = nil
+	s.sanitize(bu)
+	if org == nil {
+		if err := s.verifyLimitPerPhoneAdmin(ctx, bu.AdminPhone); err != nil {
+			return err
+		}
+	}
 	err = s.validate(bu)
 	if err != nil {
 		return err
 	}
+	if err := s.verifyPlanLimits(ctx, bu); err != nil {
+		return err
+	}
 	s.populateCityLabelPairs(bu)
+	s.populateSearchKeys(bu)
+	s.populateAttributeTerms(bu)
 	err = s.repo.ExecuteTransaction(ctx, func(sessCtx mongo.SessionContext) error {
 		err = s.verifyDuplication(sessCtx, bu)
 		if err != nil {
@@ -82,6 +169,7 @@ func (s *businessUnitService) Create(ctx context.Context, bu *model.BusinessUnit
 		"id", bu.ID,
 		"name", bu.Name,
 		"admin_phone", bu.AdminPhone,
+		"organization_id", bu.OrganizationID,
 		"timezone", bu.TimeZone,
 	)
 
@@ -96,6 +184,9 @@ func (s *businessUnitService) GetByID(ctx context.Context, id string) (*model.Bu
 	bu, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, businessunitserrors.ErrNotFound) {
+			if merge, mergeErr := s.mergeRepo.FindBySource(ctx, id); mergeErr == nil && merge.Status == config.OperationCompleted {
+				return nil, apperrors.Moved("Business unit", id, merge.TargetID)
+			}
 			return nil, apperrors.NotFoundWithID("Business unit", id)
 		}
 		if errors.Is(err, businessunitserrors.ErrInvalidID) {
@@ -166,13 +257,53 @@ func (s *businessUnitService) Update(ctx context.Context, id string, updates *mo
 		}
 		return apperrors.Internal("Failed to check business unit existence", err)
 	}
+	if err := s.authorize(ctx, existing, "update the business unit", auth.RoleOwner, auth.RoleMaintainer); err != nil {
+		return err
+	}
+	if err := verifyWritable(existing); err != nil {
+		return err
+	}
+	if changesRoles(existing, updates) {
+		if err := s.authorize(ctx, existing, "change the maintainers or viewers", auth.RoleOwner); err != nil {
+			return err
+		}
+	}
+	changesAdmin := updates.AdminPhone != "" && sanitizer.SanitizePhone(updates.AdminPhone) != existing.AdminPhone
+	if changesAdmin {
+		// owners hand the business over through an ownership transfer
+		if err := s.authorizeSystem(ctx, "change the admin phone directly; start an ownership transfer instead"); err != nil {
+			return err
+		}
+	}
+	changesOrganization := updates.OrganizationID != nil && *updates.OrganizationID != existing.OrganizationID
+	if changesOrganization {
+		if err := s.authorize(ctx, existing, "move the business unit between organizations", auth.RoleOwner); err != nil {
+			return err
+		}
+		if *updates.OrganizationID != "" {
+			if _, err := s.joinOrganization(ctx, *updates.OrganizationID); err != nil {
+				return err
+			}
+		}
+	}
 	merged := s.mergeBusinessUnitUpdates(existing, updates)
 	s.sanitize(merged)
+	leavesOrganization := changesOrganization && merged.OrganizationID == ""
+	if (changesAdmin && merged.OrganizationID == "") || leavesOrganization {
+		if err := s.verifyLimitPerPhoneAdmin(ctx, merged.AdminPhone); err != nil {
+			return err
+		}
+	}
 	err = s.validate(merged)
 	if err != nil {
 		return err
 	}
+	if err := s.verifyPlanLimits(ctx, merged); err != nil {
+		return err
+	}
 	s.populateCityLabelPairs(merged)
+	s.populateSearchKeys(merged)
+	s.populateAttributeTerms(merged)
 	err = s.repo.ExecuteTransaction(ctx, func(sessCtx mongo.SessionContext) error {
 		err = s.verifyDuplication(sessCtx, merged)
 		if err != nil {
@@ -194,11 +325,28 @@ func (s *businessUnitService) Update(ctx context.Context, id string, updates *mo
 	return nil
 }
 
-func (s *businessUnitService) Delete(ctx context.Context, id string) error {
-	if id == "" {
-		return apperrors.InvalidInput("Business unit ID cannot be empty")
+// Delete removes the business unit and records a deletion operation for its
+// schedules and bookings, which runs in the background. The returned
+// operation is polled through GetDeletion.
+func (s *businessUnitService) Delete(ctx context.Context, id string) (*model.BusinessUnitDeletion, error) {
+	bu, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
-	err := s.repo.ExecuteTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+	if err := s.authorize(ctx, bu, "delete the business unit", auth.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	op := &model.BusinessUnitDeletion{
+		BusinessID:    id,
+		BusinessName:  bu.Name,
+		AdminPhone:    bu.AdminPhone,
+		Status:        config.OperationPending,
+		Schedules:     []model.ScheduleTeardown{},
+		NextAttemptAt: now,
+	}
+	err = s.repo.ExecuteTransaction(ctx, func(sessCtx mongo.SessionContext) error {
 		if err := s.repo.Delete(sessCtx, id); err != nil {
 			if errors.Is(err, businessunitserrors.ErrNotFound) {
 				return apperrors.NotFoundWithID("Business unit", id)
@@ -212,12 +360,101 @@ func (s *businessUnitService) Delete(ctx context.Context, id string) error {
 			)
 			return apperrors.Internal("Failed to delete business unit", err)
 		}
+		if err := s.deletionRepo.Create(sessCtx, op); err != nil {
+			s.cfg.Log.Error("Failed to create business unit deletion",
+				"id", id,
+				"error", err,
+			)
+			return apperrors.Internal("Failed to delete business unit", err)
+		}
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	s.cfg.Log.Info("Business unit deleted successfully", "id", id, "operation_id", op.ID)
+	s.deletions.Trigger(op.ID)
+	return op, nil
+}
+
+// GetDeletion returns a deletion operation to the owner of the deleted
+// business unit, or to another service.
+func (s *businessUnitService) GetDeletion(ctx context.Context, id string) (*model.BusinessUnitDeletion, error) {
+	op, err := s.findDeletion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeDeletion(ctx, op, "view"); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (s *businessUnitService) findDeletion(ctx context.Context, id string) (*model.BusinessUnitDeletion, error) {
+	if id == "" {
+		return nil, apperrors.InvalidInput("Operation ID cannot be empty")
+	}
+
+	op, err := s.deletionRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, businessunitserrors.ErrDeletionNotFound) {
+			return nil, apperrors.NotFoundWithID("Deletion operation", id)
+		}
+		if errors.Is(err, businessunitserrors.ErrInvalidID) {
+			return nil, apperrors.InvalidInput("Invalid operation ID format")
+		}
+		s.cfg.Log.Error("Failed to get business unit deletion",
+			"id", id,
+			"error", err,
+		)
+		return nil, apperrors.Internal("Failed to retrieve deletion operation", err)
+	}
+
+	return op, nil
+}
+
+// ResumeDeletion retries a waiting or failed deletion now instead of at its
+// next scheduled attempt. Pending and running operations are returned as is.
+func (s *businessUnitService) ResumeDeletion(ctx context.Context, id string) (*model.BusinessUnitDeletion, error) {
+	op, err := s.findDeletion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeDeletion(ctx, op, "resume"); err != nil {
+		return nil, err
+	}
+	if op.Status == config.OperationCompleted {
+		return nil, apperrors.Conflict("Deletion operation is already completed")
+	}
+
+	rescheduled, err := s.deletionRepo.Reschedule(ctx, id, time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, businessunitserrors.ErrDeletionNotFound) {
+			// pending or running: it is already in progress
+			return op, nil
+		}
+		s.cfg.Log.Error("Failed to resume business unit deletion",
+			"id", id,
+			"error", err,
+		)
+		return nil, apperrors.Internal("Failed to resume deletion operation", err)
+	}
+
+	s.cfg.Log.Info("Business unit deletion resumed", "operation_id", id, "business_id", rescheduled.BusinessID)
+	s.deletions.Trigger(id)
+	return rescheduled, nil
+}
+
+// authorizeDeletion lets only the owner of the deleted business unit, or
+// another service, act on its deletion operation.
+func (s *businessUnitService) authorizeDeletion(ctx context.Context, op *model.BusinessUnitDeletion, action string) error {
+	caller, err := auth.RequireCaller(ctx)
 	if err != nil {
 		return err
 	}
-	s.cfg.Log.Info("Business unit deleted successfully", "id", id)
+	if !caller.System && caller.Phone != op.AdminPhone {
+		return apperrors.Forbidden(fmt.Sprintf("Only the owner can %s the deletion", action))
+	}
 	return nil
 }
 
@@ -271,10 +508,14 @@ func (s *businessUnitService) GetByPhone(ctx context.Context, phone string, citi
 	return units, count, nil
 }
 
-func (s *businessUnitService) Search(ctx context.Context, cities []string, labels []string, limit int, offset int64) ([]*model.BusinessUnit, int64, error) {
+func (s *businessUnitService) Search(ctx context.Context, cities []string, labels []string, attrs model.AttributeFilter, fairness model.SearchFairness, limit int, offset int64) ([]*model.BusinessUnit, int64, error) {
 	if len(cities) == 0 || len(labels) == 0 {
 		return nil, 0, apperrors.InvalidInput("Both search criteria (cities and labels) must be provided")
 	}
+	fairness, err := normalizeFairness(fairness)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	originalCities := append([]string(nil), cities...)
 	originalLabels := append([]string(nil), labels...)
@@ -291,12 +532,7 @@ func (s *businessUnitService) Search(ctx context.Context, cities []string, label
 		return nil, 0, apperrors.InvalidInput("Search criteria resulted in no valid items after normalization")
 	}
 
-	pairs := make([]string, 0, len(cities)*len(labels))
-	for _, city := range cities {
-		for _, label := range labels {
-			pairs = append(pairs, fmt.Sprintf("%s|%s", city, label))
-		}
-	}
+	pairs := searchPairs(cities, labels)
 
 	var count int64
 	var units []*model.BusinessUnit
@@ -307,7 +543,7 @@ func (s *businessUnitService) Search(ctx context.Context, cities []string, label
 	go func() {
 		defer wg.Done()
 		var err error
-		count, err = s.repo.CountByCityLabelPairs(ctx, pairs)
+		count, err = s.repo.CountByCityLabelPairs(ctx, pairs, attrs)
 		if err != nil {
 			s.cfg.Log.Error("Failed to count business units by city_label_pairs",
 				"cities", cities,
@@ -322,12 +558,13 @@ func (s *businessUnitService) Search(ctx context.Context, cities []string, label
 	go func() {
 		defer wg.Done()
 		var err error
-		units, err = s.repo.SearchByCityLabelPairs(ctx, pairs, limit, offset)
+		units, err = s.searchByPairs(ctx, pairs, attrs, fairness, limit, offset)
 		if err != nil {
 			s.cfg.Log.Error("Failed to search business units by city_label_pairs",
 				"cities", cities,
 				"labels", labels,
 				"pairs", pairs,
+				"fairness", fairness.Mode,
 				"limit", limit,
 				"offset", offset,
 				"error", err,
@@ -356,6 +593,56 @@ func (s *businessUnitService) Search(ctx context.Context, cities []string, label
 	return units, count, nil
 }
 
+// TextSearch matches the query against business names and labels, tolerating
+// typos, partial words and Hebrew/English spelling. Results are ordered by
+// relevance, then priority, then by the fairness mode.
+func (s *businessUnitService) TextSearch(ctx context.Context, query string, cities []string, attrs model.AttributeFilter, fairness model.SearchFairness, limit int, offset int64) ([]*model.BusinessUnit, int64, error) {
+	tokens := textsearch.Tokens(query)
+	if len(tokens) == 0 {
+		return nil, 0, apperrors.InvalidInput("Search query must contain at least one letter or digit")
+	}
+	fairness, err := normalizeFairness(fairness)
+	if err != nil {
+		return nil, 0, err
+	}
+	cities = locale.CanonicalCities(cities)
+
+	matches, candidates, err := s.textMatches(ctx, query, tokens, cities, attrs)
+	if err != nil {
+		return nil, 0, err
+	}
+	matched := make([]*model.BusinessUnit, len(matches))
+	for i, match := range matches {
+		matched[i] = match.unit
+	}
+	fairBefore := s.fairOrder(ctx, matched, fairness)
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].relevance != matches[j].relevance {
+			return matches[i].relevance > matches[j].relevance
+		}
+		if matches[i].unit.Priority != matches[j].unit.Priority {
+			return matches[i].unit.Priority > matches[j].unit.Priority
+		}
+		return fairBefore(matches[i].unit, matches[j].unit)
+	})
+
+	count := int64(len(matches))
+	units := []*model.BusinessUnit{}
+	for i := offset; i < count && len(units) < limit; i++ {
+		units = append(units, matches[i].unit)
+	}
+
+	s.cfg.Log.Debug("Business units text search completed",
+		"query", query,
+		"cities", cities,
+		"candidates_count", candidates,
+		"results_count", len(units),
+		"total_count", count,
+	)
+
+	return units, count, nil
+}
+
 func (s *businessUnitService) applyDefaults(bu *model.BusinessUnit) {
 	if bu.TimeZone == "" {
 		bu.TimeZone = locale.InferTimezoneFromPhone(bu.AdminPhone)
@@ -369,21 +656,29 @@ func (s *businessUnitService) applyDefaults(bu *model.BusinessUnit) {
 	if bu.WebsiteURLs == nil {
 		bu.WebsiteURLs = []string{}
 	}
+	if bu.Status == "" {
+		bu.Status = config.BusinessStatusActive
+	}
 }
 
 func (s *businessUnitService) sanitize(bu *model.BusinessUnit) {
 	bu.Name = sanitizer.SanitizeNameOrAddress(bu.Name)
 	bu.AdminPhone = sanitizer.SanitizePhone(bu.AdminPhone)
-	bu.Cities = sanitizer.SanitizeSlice(bu.Cities, sanitizer.SanitizeCityOrLabel)
-	bu.Labels = sanitizer.SanitizeSlice(bu.Labels, sanitizer.SanitizeCityOrLabel)
+	bu.Cities = locale.CanonicalCities(bu.Cities)
+	bu.Labels = taxonomy.Canonicalize(bu.Labels)
 	bu.Maintainers = sanitizer.SanitizeMaintainersMap(bu.Maintainers, bu.AdminPhone)
+	bu.Viewers = sanitizer.SanitizeMaintainersMap(bu.Viewers, bu.AdminPhone)
+	for phone := range bu.Maintainers {
+		delete(bu.Viewers, phone)
+	}
 	bu.WebsiteURLs = sanitizer.SanitizeSlice(bu.WebsiteURLs, sanitizer.SanitizeURL)
 	bu.Priority = sanitizer.SanitizePriority(s.cfg, bu.Priority)
+	bu.Attributes = attributes.Normalize(bu.Attributes)
 }
 
 func (s *businessUnitService) sanitizeSearchRequest(labels, cities []string) (l []string, c []string) {
-	labels = sanitizer.SanitizeSlice(labels, sanitizer.SanitizeCityOrLabel)
-	cities = sanitizer.SanitizeSlice(cities, sanitizer.SanitizeCityOrLabel)
+	labels = taxonomy.Canonicalize(labels)
+	cities = locale.CanonicalCities(cities)
 	return labels, cities
 }
 
@@ -398,6 +693,15 @@ func (s *businessUnitService) validate(bu *model.BusinessUnit) error {
 			"error": err.Error(),
 		})
 	}
+	if s.cfg.CityValidation == config.CityValidationStrict {
+		if unknown := locale.UnknownCities(bu.Cities); len(unknown) > 0 {
+			s.cfg.Log.Warn("Business unit has unknown cities", "name", bu.Name, "cities", unknown)
+			return apperrors.Validation("Business unit validation failed", map[string]any{
+				"error":  "unknown cities",
+				"cities": unknown,
+			})
+		}
+	}
 	return nil
 }
 
@@ -424,6 +728,10 @@ func (s *businessUnitService) mergeBusinessUnitUpdates(existing *model.BusinessU
 		merged.Maintainers = *updates.Maintainers
 	}
 
+	if updates.Viewers != nil {
+		merged.Viewers = *updates.Viewers
+	}
+
 	if updates.Priority != nil {
 		merged.Priority = *updates.Priority
 	}
@@ -436,6 +744,14 @@ func (s *businessUnitService) mergeBusinessUnitUpdates(existing *model.BusinessU
 		merged.WebsiteURLs = *updates.WebsiteURLs
 	}
 
+	if updates.OrganizationID != nil {
+		merged.OrganizationID = *updates.OrganizationID
+	}
+
+	if updates.Attributes != nil {
+		merged.Attributes = *updates.Attributes
+	}
+
 	merged.ID = existing.ID
 	merged.CreatedAt = existing.CreatedAt
 
@@ -474,16 +790,29 @@ func (s *businessUnitService) isSubset(subset, superset []string) bool {
 	return true
 }
 
+// populateCityLabelPairs indexes the unit under its labels and their parent
+// categories, so searching for a category finds the units below it.
 func (s *businessUnitService) populateCityLabelPairs(bu *model.BusinessUnit) {
-	pairs := make([]string, 0, len(bu.Cities)*len(bu.Labels))
+	labels := taxonomy.Expand(bu.Labels)
+	pairs := make([]string, 0, len(bu.Cities)*len(labels))
 	for _, city := range bu.Cities {
-		for _, label := range bu.Labels {
+		for _, label := range labels {
 			pairs = append(pairs, fmt.Sprintf("%s|%s", city, label))
 		}
 	}
 	bu.CityLabelPairs = pairs
 }
 
+func (s *businessUnitService) populateSearchKeys(bu *model.BusinessUnit) {
+	bu.SearchKeys = textsearch.Keys(append([]string{bu.Name}, bu.Labels...)...)
+}
+
+// populateAttributeTerms indexes the unit's attributes for filtering and
+// facet counts.
+func (s *businessUnitService) populateAttributeTerms(bu *model.BusinessUnit) {
+	bu.AttributeTerms = attributes.Terms(bu.Attributes)
+}
+
 func (s *businessUnitService) verifyDuplication(ctx context.Context, bu *model.BusinessUnit) (err error) {
 	total, err := s.repo.CountByPhone(ctx, bu.AdminPhone, bu.Cities, bu.Labels)
 	if err != nil {
@@ -518,16 +847,29 @@ func (s *businessUnitService) verifyDuplication(ctx context.Context, bu *model.B
 	return nil
 }
 
-func (s *businessUnitService) verifyLimitPerPhoneAdmin(ctx context.Context, bu *model.BusinessUnit) (err error) {
-	_, total, err := s.GetByPhone(ctx, bu.AdminPhone, nil, nil, 10, 0)
+func (s *businessUnitService) verifyLimitPerPhoneAdmin(ctx context.Context, adminPhone string) (err error) {
+	plan, err := s.planForPhone(ctx, adminPhone)
 	if err != nil {
 		return err
 	}
-	if total >= int64(config.DefaultMaxBusinessUnitsPerAdminPhone) {
+	// only units the phone owns count; maintaining or viewing others' does
+	// not, nor do archived units
+	total, err := s.repo.CountByAdminPhone(ctx, adminPhone)
+	if err != nil {
+		s.cfg.Log.Error("Failed to count business units by admin phone",
+			"admin_phone", adminPhone,
+			"error", err,
+		)
+		return apperrors.Internal("Failed to count business units", err)
+	}
+	if total >= int64(plan.Limits.BusinessUnits) {
 		return apperrors.Conflict(fmt.Sprintf(
 			"Phone num exceeded maximum business units allowed (%s)",
-			bu.AdminPhone,
-		))
+			adminPhone,
+		)).WithDetails(map[string]any{
+			"plan":  plan.Name,
+			"limit": plan.Limits.BusinessUnits,
+		})
 	}
 	return nil
 }
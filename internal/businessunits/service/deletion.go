@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	businessunitserrors "skeji/internal/businessunits/errors"
+	"skeji/internal/businessunits/repository"
+	"skeji/pkg/client"
+	"skeji/pkg/config"
+	"skeji/pkg/model"
+	"sync"
+	"time"
+)
+
+var errDeletionInterrupted = errors.New("deletion interrupted by shutdown")
+
+// DeletionWorker carries out the cascade of a deleted business unit: each of
+// its schedules is deleted with its unfinished bookings cancelled, then the
+// bookings that ended are archived. Archiving after the deletion leaves no
+// booking in between the two steps. Progress is saved after every step, so
+// an operation that fails because a service is down is retried with backoff
+// from where it stopped, by this process or by another replica.
+type DeletionWorker struct {
+	repo     repository.DeletionRepository
+	cfg      *config.Config
+	interval time.Duration
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+}
+
+func NewDeletionWorker(repo repository.DeletionRepository, cfg *config.Config) *DeletionWorker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &DeletionWorker{
+		repo:     repo,
+		cfg:      cfg,
+		interval: config.DefaultDeletionWorkerInterval,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Start polls for due operations in the background until Stop is called.
+func (w *DeletionWorker) Start() {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				w.drain()
+			case <-w.ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop waits for in-flight operations to save their progress and exit.
+func (w *DeletionWorker) Stop() {
+	w.stopOnce.Do(func() {
+		w.cancel()
+		w.wg.Wait()
+	})
+}
+
+// Trigger runs the operation right away if it is due and not leased by
+// another runner.
+func (w *DeletionWorker) Trigger(id string) {
+	if w.ctx.Err() != nil {
+		return
+	}
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		op, err := w.claim(id)
+		if err != nil {
+			return
+		}
+		w.run(op)
+	}()
+}
+
+func (w *DeletionWorker) drain() {
+	for w.ctx.Err() == nil {
+		op, err := w.claim("")
+		if err != nil {
+			return
+		}
+		w.run(op)
+	}
+}
+
+func (w *DeletionWorker) claim(id string) (*model.BusinessUnitDeletion, error) {
+	now := time.Now().UTC()
+	op, err := w.repo.Claim(w.ctx, id, now, now.Add(config.DefaultDeletionLease))
+	if err != nil && !errors.Is(err, businessunitserrors.ErrDeletionNotFound) {
+		w.cfg.Log.Error("Failed to claim business unit deletion", "operation_id", id, "error", err)
+	}
+	return op, err
+}
+
+func (w *DeletionWorker) run(op *model.BusinessUnitDeletion) {
+	err := w.cascade(op)
+	now := time.Now().UTC()
+	op.LockedUntil = time.Time{}
+
+	switch {
+	case err == nil:
+		op.Status = config.OperationCompleted
+		op.LastError = ""
+		op.CompletedAt = &now
+		w.cfg.Log.Info("Business unit deletion completed",
+			"operation_id", op.ID,
+			"business_id", op.BusinessID,
+			"schedules_deleted", op.SchedulesDeleted,
+		)
+	case errors.Is(err, errDeletionInterrupted):
+		op.Status = config.OperationRetrying
+		op.NextAttemptAt = now
+	default:
+		op.Attempts++
+		op.LastError = err.Error()
+		if op.Attempts >= config.DefaultDeletionMaxAttempts {
+			op.Status = config.OperationFailed
+			w.cfg.Log.Error("Business unit deletion failed, giving up",
+				"operation_id", op.ID,
+				"business_id", op.BusinessID,
+				"attempts", op.Attempts,
+				"error", err,
+			)
+		} else {
+			op.Status = config.OperationRetrying
+			op.NextAttemptAt = now.Add(deletionBackoff(op.Attempts))
+			w.cfg.Log.Warn("Business unit deletion step failed, will retry",
+				"operation_id", op.ID,
+				"business_id", op.BusinessID,
+				"attempts", op.Attempts,
+				"next_attempt_at", op.NextAttemptAt,
+				"error", err,
+			)
+		}
+	}
+
+	if err := w.repo.Save(context.Background(), op); err != nil {
+		w.cfg.Log.Error("Failed to save business unit deletion", "operation_id", op.ID, "error", err)
+	}
+}
+
+func (w *DeletionWorker) cascade(op *model.BusinessUnitDeletion) error {
+	if !op.SchedulesListed {
+		schedules, err := w.listSchedules(op.BusinessID)
+		if err != nil {
+			return err
+		}
+		op.Schedules = schedules
+		op.SchedulesListed = true
+		if err := w.checkpoint(op); err != nil {
+			return err
+		}
+	}
+
+	for i := range op.Schedules {
+		teardown := &op.Schedules[i]
+		if teardown.Deleted && teardown.Archived {
+			continue
+		}
+		if w.ctx.Err() != nil {
+			return errDeletionInterrupted
+		}
+
+		if !teardown.Deleted {
+			cancelled, err := w.deleteSchedule(teardown.ScheduleID)
+			if err != nil {
+				return fmt.Errorf("schedule %s: %w", teardown.ScheduleID, err)
+			}
+			teardown.BookingsCancelled = cancelled
+			teardown.Deleted = true
+			op.SchedulesDeleted++
+			if err := w.checkpoint(op); err != nil {
+				return err
+			}
+		}
+
+		// every booking that had not ended when the schedule was deleted
+		// was cancelled, so the rest have ended by now
+		archived, err := w.archiveBookings(op.BusinessID, teardown.ScheduleID)
+		if err != nil {
+			return fmt.Errorf("schedule %s: %w", teardown.ScheduleID, err)
+		}
+		teardown.BookingsArchived = archived
+		teardown.Archived = true
+		if err := w.checkpoint(op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkpoint saves the progress and renews the lease.
+func (w *DeletionWorker) checkpoint(op *model.BusinessUnitDeletion) error {
+	op.LockedUntil = time.Now().UTC().Add(config.DefaultDeletionLease)
+	if err := w.repo.Save(context.Background(), op); err != nil {
+		return fmt.Errorf("failed to save progress: %w", err)
+	}
+	return nil
+}
+
+func (w *DeletionWorker) listSchedules(businessID string) ([]model.ScheduleTeardown, error) {
+	scheduleClient := w.cfg.Client.ScheduleClient
+	limit := config.DefaultPaginationLimit
+	teardowns := []model.ScheduleTeardown{}
+
+	for offset := int64(0); ; offset += int64(limit) {
+		resp, err := scheduleClient.Search(businessID, "", limit, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list schedules: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, serviceError("schedules", resp)
+		}
+		schedules, meta, err := scheduleClient.DecodeSchedules(resp)
+		if err != nil {
+			return nil, err
+		}
+		for _, sc := range schedules {
+			teardowns = append(teardowns, model.ScheduleTeardown{ScheduleID: sc.ID, Name: sc.Name})
+		}
+		if len(schedules) < limit || offset+int64(len(schedules)) >= meta.TotalCount {
+			return teardowns, nil
+		}
+	}
+}
+
+func (w *DeletionWorker) archiveBookings(businessID, scheduleID string) (int64, error) {
+	bookingClient := w.cfg.Client.BookingClient
+	resp, err := bookingClient.Archive(businessID, scheduleID, time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to archive bookings: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, serviceError("bookings", resp)
+	}
+	result, err := bookingClient.DecodeArchiveResult(resp)
+	if err != nil {
+		return 0, err
+	}
+	return result.Archived, nil
+}
+
+// deleteSchedule deletes the schedule, cancelling its bookings that have not
+// ended, including the ones in progress, and
+// returns how many were cancelled. A schedule that is already gone counts as
+// deleted.
+func (w *DeletionWorker) deleteSchedule(scheduleID string) (int, error) {
+	scheduleClient := w.cfg.Client.ScheduleClient
+	resp, err := scheduleClient.DeleteWithMode(scheduleID, config.DeleteModeCancel, "")
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete schedule: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		deletion, err := scheduleClient.DecodeScheduleDeletion(resp)
+		if err != nil {
+			return 0, err
+		}
+		cancelled := 0
+		for _, affected := range deletion.AffectedBookings {
+			if affected.Outcome == config.OutcomeCancelled {
+				cancelled++
+			}
+		}
+		return cancelled, nil
+	case http.StatusNoContent, http.StatusNotFound:
+		return 0, nil
+	default:
+		return 0, serviceError("schedules", resp)
+	}
+}
+
+func serviceError(service string, resp *client.Response) error {
+	return fmt.Errorf("%s service returned status %d: %s", service, resp.StatusCode, client.GetErrorMessage(resp))
+}
+
+func deletionBackoff(attempts int) time.Duration {
+	delay := config.DefaultDeletionRetryBaseDelay
+	for i := 1; i < attempts && delay < config.DefaultDeletionRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, config.DefaultDeletionRetryMaxDelay)
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"skeji/pkg/config"
+	apperrors "skeji/pkg/errors"
+	"skeji/pkg/model"
+	"skeji/pkg/ranking"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func normalizeFairness(fairness model.SearchFairness) (model.SearchFairness, error) {
+	if fairness.Mode == "" {
+		fairness.Mode = config.FairnessNone
+	}
+	if !config.IsFairnessMode(fairness.Mode) {
+		return fairness, apperrors.InvalidInput(fmt.Sprintf("Invalid fairness mode %q: must be %s, %s or %s",
+			fairness.Mode, config.FairnessNone, config.FairnessRotate, config.FairnessBalance))
+	}
+	return fairness, nil
+}
+
+// searchByPairs returns one page of units matching the city/label pairs. With
+// a fairness mode the first MaxFairSearchCandidates matches are reordered so
+// units of equal priority take turns; pages past them keep storage order.
+func (s *businessUnitService) searchByPairs(ctx context.Context, pairs []string, attrs model.AttributeFilter, fairness model.SearchFairness, limit int, offset int64) ([]*model.BusinessUnit, error) {
+	if fairness.Mode == config.FairnessNone || offset >= config.MaxFairSearchCandidates {
+		return s.repo.SearchByCityLabelPairs(ctx, pairs, attrs, limit, offset)
+	}
+
+	pool, err := s.repo.SearchByCityLabelPairs(ctx, pairs, attrs, config.MaxFairSearchCandidates, 0)
+	if err != nil {
+		return nil, err
+	}
+	fairBefore := s.fairOrder(ctx, pool, fairness)
+	sort.SliceStable(pool, func(i, j int) bool {
+		if pool[i].Priority != pool[j].Priority {
+			return pool[i].Priority > pool[j].Priority
+		}
+		return fairBefore(pool[i], pool[j])
+	})
+
+	units := []*model.BusinessUnit{}
+	for i := int(offset); i < len(pool) && len(units) < limit; i++ {
+		units = append(units, pool[i])
+	}
+	if len(units) < limit && len(pool) == config.MaxFairSearchCandidates {
+		rest, err := s.repo.SearchByCityLabelPairs(ctx, pairs, attrs, limit-len(units), int64(len(pool)))
+		if err != nil {
+			return nil, err
+		}
+		units = append(units, rest...)
+	}
+	return units, nil
+}
+
+// fairOrder returns how to order units that otherwise rank equally. Rotate
+// shuffles them stably for the requester within the rotation bucket; balance
+// first puts the units shown least over the impression window. Without a
+// fairness mode the order is left as is.
+func (s *businessUnitService) fairOrder(ctx context.Context, units []*model.BusinessUnit, fairness model.SearchFairness) func(a, b *model.BusinessUnit) bool {
+	if fairness.Mode == config.FairnessNone {
+		return func(a, b *model.BusinessUnit) bool { return false }
+	}
+
+	now := time.Now().UTC()
+	rotation := ranking.NewRotation(fairness.Requester, now, s.cfg.FairnessRotationBucket)
+	rotate := func(a, b *model.BusinessUnit) bool {
+		return rotation.Order(a.ID) < rotation.Order(b.ID)
+	}
+	if fairness.Mode != config.FairnessBalance || len(units) == 0 {
+		return rotate
+	}
+
+	ids := make([]string, len(units))
+	for i, unit := range units {
+		ids[i] = unit.ID
+	}
+	since := now.AddDate(0, 0, -(config.DefaultImpressionWindowDays - 1))
+	impressions, err := s.impressionRepo.Totals(ctx, ids, since)
+	if err != nil {
+		// exposure data is a refinement; search still answers without it
+		s.cfg.Log.Warn("Failed to load impressions, falling back to rotation",
+			"units_count", len(units),
+			"error", err,
+		)
+		return rotate
+	}
+	return func(a, b *model.BusinessUnit) bool {
+		if impressions[a.ID] != impressions[b.ID] {
+			return impressions[a.ID] < impressions[b.ID]
+		}
+		return rotate(a, b)
+	}
+}
+
+// RecordImpressions counts one impression for each business shown to a
+// customer. It is reported by the caller that displays results, not by
+// search, since a search page is not necessarily shown in full.
+func (s *businessUnitService) RecordImpressions(ctx context.Context, record *model.ImpressionRecord) error {
+	if err := s.authorizeSystem(ctx, "record impressions"); err != nil {
+		return err
+	}
+	if len(record.BusinessIDs) == 0 {
+		return apperrors.InvalidInput("At least one business ID must be provided")
+	}
+	if len(record.BusinessIDs) > config.MaxImpressionsPerRecord {
+		return apperrors.InvalidInput(fmt.Sprintf("At most %d business IDs can be recorded at once", config.MaxImpressionsPerRecord))
+	}
+	for _, id := range record.BusinessIDs {
+		if !primitive.IsValidObjectID(id) {
+			return apperrors.InvalidInput(fmt.Sprintf("Invalid business unit ID format: %s", id))
+		}
+	}
+
+	if err := s.impressionRepo.Record(ctx, record.BusinessIDs, time.Now().UTC()); err != nil {
+		s.cfg.Log.Error("Failed to record impressions",
+			"business_ids", record.BusinessIDs,
+			"error", err,
+		)
+		return apperrors.Internal("Failed to record impressions", err)
+	}
+	return nil
+}
+
+// GetImpressions reports how often the business unit was shown over the last
+// days, today included.
+func (s *businessUnitService) GetImpressions(ctx context.Context, id string, days int) (*model.ImpressionSummary, error) {
+	if days <= 0 {
+		days = config.DefaultImpressionWindowDays
+	}
+	if days > config.MaxImpressionWindowDays {
+		return nil, apperrors.InvalidInput(fmt.Sprintf("days cannot exceed %d", config.MaxImpressionWindowDays))
+	}
+
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -(days - 1))
+	daily, err := s.impressionRepo.Daily(ctx, id, since)
+	if err != nil {
+		s.cfg.Log.Error("Failed to get impressions",
+			"id", id,
+			"error", err,
+		)
+		return nil, apperrors.Internal("Failed to retrieve impressions", err)
+	}
+
+	summary := &model.ImpressionSummary{
+		BusinessID: id,
+		Since:      since,
+		Daily:      daily,
+	}
+	for _, day := range daily {
+		summary.Total += day.Count
+	}
+	return summary, nil
+}
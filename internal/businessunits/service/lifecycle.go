@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	businessunitserrors "skeji/internal/businessunits/errors"
+	"skeji/pkg/auth"
+	"skeji/pkg/config"
+	apperrors "skeji/pkg/errors"
+	"skeji/pkg/model"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// statusTransitions lists the statuses a business unit may move to from each
+// status. Archived is final.
+var statusTransitions = map[string][]string{
+	config.BusinessStatusDraft:     {config.BusinessStatusActive, config.BusinessStatusArchived},
+	config.BusinessStatusActive:    {config.BusinessStatusSuspended, config.BusinessStatusArchived},
+	config.BusinessStatusSuspended: {config.BusinessStatusActive, config.BusinessStatusArchived},
+	config.BusinessStatusArchived:  {},
+}
+
+// ChangeStatus moves the business unit through its lifecycle. Only the owner
+// can do it, and suspending requires a reason.
+func (s *businessUnitService) ChangeStatus(ctx context.Context, id string, change *model.BusinessUnitStatusChange) (*model.BusinessUnit, error) {
+	bu, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, bu, "change the status of the business unit", auth.RoleOwner); err != nil {
+		return nil, err
+	}
+	caller, err := auth.RequireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	change.Status = strings.ToLower(strings.TrimSpace(change.Status))
+	change.Reason = strings.TrimSpace(change.Reason)
+	if err := s.validator.ValidateStatusChange(change); err != nil {
+		s.cfg.Log.Warn("Business unit status change validation failed", "id", id, "error", err)
+		return nil, apperrors.Validation("Invalid status change", map[string]any{"error": err.Error()})
+	}
+	if change.Status == config.BusinessStatusSuspended && change.Reason == "" {
+		return nil, apperrors.InvalidInput("A reason is required to suspend a business unit")
+	}
+
+	from := bu.Status
+	if from == "" {
+		from = config.BusinessStatusActive
+	}
+	if !slices.Contains(statusTransitions[from], change.Status) {
+		return nil, apperrors.Conflict(fmt.Sprintf("A %s business unit cannot become %s", from, change.Status)).WithDetails(map[string]any{
+			"allowed": statusTransitions[from],
+		})
+	}
+
+	now := time.Now().UTC()
+	err = s.repo.ExecuteTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		if err := s.repo.UpdateStatus(sessCtx, id, from, change, now); err != nil {
+			if errors.Is(err, businessunitserrors.ErrNotFound) {
+				return apperrors.Conflict("The business unit status changed meanwhile, retry")
+			}
+			return apperrors.Internal("Failed to change business unit status", err)
+		}
+		entry := &model.AuditEntry{
+			BusinessID: id,
+			Action:     config.AuditStatusChanged,
+			Actor:      caller.String(),
+			Details: map[string]string{
+				"from":   from,
+				"to":     change.Status,
+				"reason": change.Reason,
+			},
+		}
+		if err := s.auditRepo.Record(sessCtx, entry); err != nil {
+			return apperrors.Internal("Failed to record audit entry", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.cfg.Log.Error("Failed to change business unit status", "id", id, "error", err)
+		return nil, err
+	}
+
+	s.cfg.Log.Info("Business unit status changed",
+		"id", id,
+		"from", from,
+		"to", change.Status,
+		"reason", change.Reason,
+	)
+	return s.GetByID(ctx, id)
+}
+
+// verifyWritable rejects changes to archived business units, which are
+// read-only.
+func verifyWritable(bu *model.BusinessUnit) error {
+	if bu.Status == config.BusinessStatusArchived {
+		return apperrors.Conflict("Archived business units are read-only")
+	}
+	return nil
+}
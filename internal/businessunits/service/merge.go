@@ -0,0 +1,507 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	businessunitserrors "skeji/internal/businessunits/errors"
+	"skeji/pkg/auth"
+	"skeji/pkg/client"
+	"skeji/pkg/config"
+	"skeji/pkg/dedup"
+	apperrors "skeji/pkg/errors"
+	"skeji/pkg/locale"
+	"skeji/pkg/model"
+	"skeji/pkg/sanitizer"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FindDuplicates reports pairs of business units that look like the same
+// business: similar names, shared phones and overlapping cities. Phone
+// callers get the pairs among the units they are on; the services may scan
+// every unit, optionally only those in the given cities.
+func (s *businessUnitService) FindDuplicates(ctx context.Context, phone string, cities []string, minScore float64, limit int, offset int64) ([]model.DuplicateCandidate, int64, error) {
+	caller, err := auth.RequireCaller(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	phone = sanitizer.SanitizePhone(phone)
+	if !caller.System {
+		if phone == "" {
+			phone = caller.Phone
+		}
+		if phone != caller.Phone {
+			return nil, 0, apperrors.Forbidden("Only the services can report duplicates among other phones' business units")
+		}
+	}
+	if minScore == 0 {
+		minScore = dedup.DefaultMinScore
+	}
+	if minScore < 0 || minScore > 1 {
+		return nil, 0, apperrors.InvalidInput("min_score must be between 0 and 1")
+	}
+
+	units, err := s.duplicateScanUnits(ctx, phone, locale.CanonicalCities(cities))
+	if err != nil {
+		return nil, 0, err
+	}
+	candidates := dedup.Find(units, minScore)
+
+	total := int64(len(candidates))
+	start := min(offset, total)
+	end := min(start+int64(limit), total)
+	return candidates[start:end], total, nil
+}
+
+// duplicateScanUnits loads the units a duplicate report compares, up to
+// config.MaxDuplicateScanUnits. Archived units are left out.
+func (s *businessUnitService) duplicateScanUnits(ctx context.Context, phone string, cities []string) ([]*model.BusinessUnit, error) {
+	limit := config.DefaultPaginationLimit
+	units := []*model.BusinessUnit{}
+
+	for offset := int64(0); len(units) < config.MaxDuplicateScanUnits; offset += int64(limit) {
+		var page []*model.BusinessUnit
+		var err error
+		if phone != "" {
+			page, err = s.repo.GetByPhone(ctx, phone, cities, nil, limit, offset)
+		} else {
+			page, err = s.repo.FindAll(ctx, limit, offset)
+		}
+		if err != nil {
+			s.cfg.Log.Error("Failed to load business units for the duplicate report", "phone", phone, "error", err)
+			return nil, apperrors.Internal("Failed to load business units", err)
+		}
+
+		for _, bu := range page {
+			if bu.Status == config.BusinessStatusArchived {
+				continue
+			}
+			if len(cities) > 0 && !slices.ContainsFunc(bu.Cities, func(city string) bool {
+				return slices.Contains(cities, city)
+			}) {
+				continue
+			}
+			units = append(units, bu)
+		}
+		if len(page) < limit {
+			break
+		}
+	}
+	return units, nil
+}
+
+// Merge folds the duplicate business unit sourceID into req.IntoID: its
+// schedules and their bookings move to the target, which also takes over its
+// cities, maintainers and viewers, and the duplicate is deleted. The merge
+// record stays behind as a tombstone that redirects the old ID. A merge that
+// stopped halfway, e.g. because a service was down, resumes when repeated.
+func (s *businessUnitService) Merge(ctx context.Context, sourceID string, req *model.BusinessUnitMergeRequest) (*model.BusinessUnitMerge, error) {
+	caller, err := auth.RequireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.IntoID = strings.TrimSpace(req.IntoID)
+	if req.IntoID == "" {
+		return nil, apperrors.InvalidInput("into_id is required")
+	}
+	if req.IntoID == sourceID {
+		return nil, apperrors.InvalidInput("A business unit cannot be merged into itself")
+	}
+
+	source, err := s.GetByID(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.GetByID(ctx, req.IntoID)
+	if err != nil {
+		return nil, err
+	}
+	for _, bu := range []*model.BusinessUnit{source, target} {
+		if err := s.authorize(ctx, bu, "merge business units", auth.RoleOwner); err != nil {
+			return nil, err
+		}
+		if err := verifyWritable(bu); err != nil {
+			return nil, err
+		}
+	}
+	// refuse up front a merge whose result would not be a valid unit, or
+	// that would stop halfway on a schedule the target cannot take
+	if _, err := s.mergedBusinessUnit(ctx, target, source); err != nil {
+		return nil, err
+	}
+	if err := s.verifyScheduleHandover(ctx, source, target); err != nil {
+		return nil, err
+	}
+
+	merge, err := s.startMerge(ctx, source, target, caller)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.moveSchedules(ctx, merge); err != nil {
+		merge.Status = config.OperationFailed
+		merge.LastError = err.Error()
+		if saveErr := s.mergeRepo.Save(context.Background(), merge); saveErr != nil {
+			s.cfg.Log.Error("Failed to save business unit merge", "merge_id", merge.ID, "error", saveErr)
+		}
+		s.cfg.Log.Warn("Business unit merge stopped, repeat it to resume",
+			"merge_id", merge.ID,
+			"source_id", merge.SourceID,
+			"target_id", merge.TargetID,
+			"schedules_moved", merge.SchedulesMoved,
+			"error", err,
+		)
+		return nil, err
+	}
+
+	if err := s.completeMerge(ctx, merge, source, caller); err != nil {
+		return nil, err
+	}
+	s.cfg.Log.Info("Business units merged",
+		"merge_id", merge.ID,
+		"source_id", merge.SourceID,
+		"target_id", merge.TargetID,
+		"schedules_moved", merge.SchedulesMoved,
+	)
+	return merge, nil
+}
+
+// startMerge records a new merge of source into target, or picks up the
+// unfinished one.
+func (s *businessUnitService) startMerge(ctx context.Context, source, target *model.BusinessUnit, caller auth.Caller) (*model.BusinessUnitMerge, error) {
+	existing, err := s.mergeRepo.FindBySource(ctx, source.ID)
+	if err == nil {
+		if existing.TargetID != target.ID {
+			return nil, apperrors.Conflict(fmt.Sprintf("Business unit is already being merged into '%s'", existing.TargetID))
+		}
+		existing.Status = config.OperationRunning
+		existing.LastError = ""
+		return existing, nil
+	}
+	if !errors.Is(err, businessunitserrors.ErrMergeNotFound) {
+		s.cfg.Log.Error("Failed to look up business unit merge", "source_id", source.ID, "error", err)
+		return nil, apperrors.Internal("Failed to merge business units", err)
+	}
+
+	merge := &model.BusinessUnitMerge{
+		SourceID:    source.ID,
+		SourceName:  source.Name,
+		TargetID:    target.ID,
+		Status:      config.OperationRunning,
+		Schedules:   []model.ScheduleHandover{},
+		RequestedBy: caller.String(),
+	}
+	if err := s.mergeRepo.Create(ctx, merge); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, apperrors.Conflict("Business unit is already being merged")
+		}
+		s.cfg.Log.Error("Failed to create business unit merge", "source_id", source.ID, "target_id", target.ID, "error", err)
+		return nil, apperrors.Internal("Failed to merge business units", err)
+	}
+	return merge, nil
+}
+
+// verifyScheduleHandover checks, before anything moves, that the target can
+// take every schedule of the source: none clashes with the target's schedules
+// and together they stay within the target's plan.
+func (s *businessUnitService) verifyScheduleHandover(ctx context.Context, source, target *model.BusinessUnit) error {
+	scheduleClient := s.cfg.Client.ScheduleClient
+	limit := config.DefaultPaginationLimit
+	schedules := []*model.Schedule{}
+
+	for offset := int64(0); ; offset += int64(limit) {
+		resp, err := scheduleClient.Search(source.ID, "", limit, offset)
+		if err != nil {
+			return mergeUnavailable("Schedules service", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return mergeUnavailable("Schedules service", serviceError("schedules", resp))
+		}
+		page, meta, err := scheduleClient.DecodeSchedules(resp)
+		if err != nil {
+			return mergeUnavailable("Schedules service", err)
+		}
+		schedules = append(schedules, page...)
+		if len(page) == 0 || int64(len(schedules)) >= meta.TotalCount {
+			break
+		}
+	}
+	if len(schedules) == 0 {
+		return nil
+	}
+
+	existing, err := s.countSchedules(target.ID)
+	if err != nil {
+		return mergeUnavailable("Schedules service", err)
+	}
+	plan, err := s.planFor(ctx, target)
+	if err != nil {
+		return err
+	}
+	if allowed := int64(plan.Limits.SchedulesPerBusinessUnit); existing+int64(len(schedules)) > allowed {
+		return apperrors.Conflict(fmt.Sprintf(
+			"The business unit merged into has room for %d more schedules, the duplicate has %d",
+			max(allowed-existing, 0), len(schedules),
+		)).WithDetails(map[string]any{
+			"plan":  plan.Name,
+			"limit": plan.Limits.SchedulesPerBusinessUnit,
+		})
+	}
+
+	for _, sc := range schedules {
+		resp, err := scheduleClient.CheckReassign(sc.ID, target.ID)
+		if err != nil {
+			return mergeUnavailable("Schedules service", err)
+		}
+		if err := reassignError(sc, resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveSchedules hands every schedule of the source over to the target, the
+// schedule first and then its bookings, saving progress after each step.
+// Schedules that moved no longer show up under the source, so a repeated run
+// first finishes the bookings of a schedule that moved without them and then
+// continues with the rest.
+func (s *businessUnitService) moveSchedules(ctx context.Context, merge *model.BusinessUnitMerge) error {
+	scheduleClient := s.cfg.Client.ScheduleClient
+	limit := config.DefaultPaginationLimit
+	handled := map[string]bool{}
+
+	for i := range merge.Schedules {
+		if !merge.Schedules[i].Moved {
+			if err := s.moveBookings(ctx, merge, &merge.Schedules[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		resp, err := scheduleClient.Search(merge.SourceID, "", limit, 0)
+		if err != nil {
+			return mergeUnavailable("Schedules service", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return mergeUnavailable("Schedules service", serviceError("schedules", resp))
+		}
+		schedules, _, err := scheduleClient.DecodeSchedules(resp)
+		if err != nil {
+			return mergeUnavailable("Schedules service", err)
+		}
+		if len(schedules) == 0 {
+			return nil
+		}
+
+		for _, sc := range schedules {
+			if handled[sc.ID] {
+				return apperrors.Internal("Failed to move schedules", fmt.Errorf("schedule %s did not move", sc.ID))
+			}
+			handled[sc.ID] = true
+
+			resp, err := scheduleClient.Reassign(sc.ID, merge.TargetID)
+			if err != nil {
+				return mergeUnavailable("Schedules service", err)
+			}
+			if err := reassignError(sc, resp); err != nil {
+				return err
+			}
+			merge.Schedules = append(merge.Schedules, model.ScheduleHandover{ScheduleID: sc.ID, Name: sc.Name})
+			if err := s.mergeRepo.Save(ctx, merge); err != nil {
+				return apperrors.Internal("Failed to save merge progress", err)
+			}
+
+			if err := s.moveBookings(ctx, merge, &merge.Schedules[len(merge.Schedules)-1]); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// moveBookings moves the bookings of a schedule that already moved to the
+// target, completing its handover.
+func (s *businessUnitService) moveBookings(ctx context.Context, merge *model.BusinessUnitMerge, handover *model.ScheduleHandover) error {
+	bookingClient := s.cfg.Client.BookingClient
+	resp, err := bookingClient.Reassign(merge.SourceID, handover.ScheduleID, merge.TargetID)
+	if err != nil {
+		return mergeUnavailable("Bookings service", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return mergeUnavailable("Bookings service", serviceError("bookings", resp))
+	}
+	result, err := bookingClient.DecodeReassignResult(resp)
+	if err != nil {
+		return mergeUnavailable("Bookings service", err)
+	}
+
+	handover.BookingsMoved = result.Reassigned
+	handover.BookingsArchived = result.Archived
+	handover.Moved = true
+	merge.SchedulesMoved++
+	if err := s.mergeRepo.Save(ctx, merge); err != nil {
+		return apperrors.Internal("Failed to save merge progress", err)
+	}
+	return nil
+}
+
+// reassignError turns the schedules service's answer to a reassignment into
+// the merge's error, or nil when the schedule may move.
+func reassignError(sc *model.Schedule, resp *client.Response) error {
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusConflict:
+		return apperrors.Conflict(fmt.Sprintf(
+			"Schedule '%s' cannot move to the business unit merged into: %s",
+			sc.Name, client.GetErrorMessage(resp),
+		)).WithDetails(map[string]any{"schedule_id": sc.ID})
+	default:
+		return mergeUnavailable("Schedules service", serviceError("schedules", resp))
+	}
+}
+
+// completeMerge folds the source's details into the target and replaces the
+// source with its tombstone, in one transaction.
+func (s *businessUnitService) completeMerge(ctx context.Context, merge *model.BusinessUnitMerge, source *model.BusinessUnit, caller auth.Caller) error {
+	target, err := s.GetByID(ctx, merge.TargetID)
+	if err != nil {
+		return err
+	}
+	merged, err := s.mergedBusinessUnit(ctx, target, source)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	err = s.repo.ExecuteTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		if _, err := s.repo.Update(sessCtx, target.ID, merged); err != nil {
+			return err
+		}
+		if err := s.repo.Delete(sessCtx, source.ID); err != nil && !errors.Is(err, businessunitserrors.ErrNotFound) {
+			return err
+		}
+		merge.Status = config.OperationCompleted
+		merge.LastError = ""
+		merge.CompletedAt = &now
+		if err := s.mergeRepo.Save(sessCtx, merge); err != nil {
+			return err
+		}
+		entries := []*model.AuditEntry{
+			{
+				BusinessID: source.ID,
+				Action:     config.AuditMergedInto,
+				Actor:      caller.String(),
+				Details:    map[string]string{"target_id": target.ID, "merge_id": merge.ID},
+			},
+			{
+				BusinessID: target.ID,
+				Action:     config.AuditMergedFrom,
+				Actor:      caller.String(),
+				Details:    map[string]string{"source_id": source.ID, "source_name": source.Name, "merge_id": merge.ID},
+			},
+		}
+		for _, entry := range entries {
+			if err := s.auditRepo.Record(sessCtx, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.cfg.Log.Error("Failed to complete business unit merge",
+			"merge_id", merge.ID,
+			"source_id", source.ID,
+			"target_id", target.ID,
+			"error", err,
+		)
+		return apperrors.Internal("Failed to complete the merge", err)
+	}
+	return nil
+}
+
+// mergedBusinessUnit returns the target as it is after taking over the
+// source's cities, labels, website URLs, maintainers and viewers, and the
+// attributes it has no value for, checked like any update. The source's
+// admin stays on as a maintainer. Labels and website URLs are only added
+// while there is room for them; cities must all fit.
+func (s *businessUnitService) mergedBusinessUnit(ctx context.Context, target, source *model.BusinessUnit) (*model.BusinessUnit, error) {
+	merged := *target
+	merged.Cities = appendMissing(slices.Clone(target.Cities), source.Cities, len(target.Cities)+len(source.Cities))
+	if len(merged.Cities) > config.MaxCitiesForBusiness {
+		return nil, apperrors.Validation("Business unit validation failed", map[string]any{
+			"error": fmt.Sprintf("The merged business unit would have too many cities (%d), at most %d are allowed",
+				len(merged.Cities), config.MaxCitiesForBusiness),
+		})
+	}
+	merged.Labels = appendMissing(slices.Clone(target.Labels), source.Labels, config.MaxLabelsForBusiness)
+	merged.WebsiteURLs = appendMissing(slices.Clone(target.WebsiteURLs), source.WebsiteURLs, config.MaxWebsiteURLsForBusiness)
+
+	merged.Maintainers = maps.Clone(target.Maintainers)
+	if merged.Maintainers == nil {
+		merged.Maintainers = map[string]string{}
+	}
+	if source.AdminPhone != target.AdminPhone {
+		if _, ok := merged.Maintainers[source.AdminPhone]; !ok {
+			merged.Maintainers[source.AdminPhone] = source.Name
+		}
+	}
+	for phone, name := range source.Maintainers {
+		if _, ok := merged.Maintainers[phone]; !ok {
+			merged.Maintainers[phone] = name
+		}
+	}
+	merged.Viewers = maps.Clone(target.Viewers)
+	for phone, name := range source.Viewers {
+		if merged.Viewers == nil {
+			merged.Viewers = map[string]string{}
+		}
+		if _, ok := merged.Viewers[phone]; !ok {
+			merged.Viewers[phone] = name
+		}
+	}
+	merged.Attributes = maps.Clone(target.Attributes)
+	for key, value := range source.Attributes {
+		if merged.Attributes == nil {
+			merged.Attributes = model.Attributes{}
+		}
+		if _, ok := merged.Attributes[key]; !ok {
+			merged.Attributes[key] = value
+		}
+	}
+
+	s.sanitize(&merged)
+	if err := s.validate(&merged); err != nil {
+		return nil, err
+	}
+	if err := s.verifyPlanLimits(ctx, &merged); err != nil {
+		return nil, err
+	}
+	s.populateCityLabelPairs(&merged)
+	s.populateSearchKeys(&merged)
+	s.populateAttributeTerms(&merged)
+	return &merged, nil
+}
+
+// appendMissing appends the values of extra not in values, as long as
+// values holds fewer than limit.
+func appendMissing(values []string, extra []string, limit int) []string {
+	for _, v := range extra {
+		if len(values) >= limit {
+			break
+		}
+		if !slices.Contains(values, v) {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func mergeUnavailable(service string, err error) *apperrors.AppError {
+	return apperrors.Unavailable(service).WithDetails(map[string]any{"error": err.Error()})
+}
@@ -0,0 +1,383 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	businessunitserrors "skeji/internal/businessunits/errors"
+	"skeji/pkg/auth"
+	"skeji/pkg/config"
+	apperrors "skeji/pkg/errors"
+	"skeji/pkg/locale"
+	"skeji/pkg/model"
+	"skeji/pkg/sanitizer"
+	"skeji/pkg/taxonomy"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// CreateOrganization creates an organization owned by its admin phone.
+func (s *businessUnitService) CreateOrganization(ctx context.Context, org *model.Organization) error {
+	caller, err := auth.RequireCaller(ctx)
+	if err != nil {
+		return err
+	}
+	s.sanitizeOrganization(org)
+	if !caller.System && caller.Phone != org.AdminPhone {
+		return apperrors.Forbidden("An organization can only be created by its admin phone")
+	}
+	if org.Admins == nil {
+		org.Admins = map[string]string{}
+	}
+	if org.TimeZone == "" {
+		org.TimeZone = locale.InferTimezoneFromPhone(org.AdminPhone)
+	}
+	if err := s.validateOrganization(org); err != nil {
+		return err
+	}
+
+	if err := s.orgRepo.Create(ctx, org); err != nil {
+		s.cfg.Log.Error("Failed to create organization",
+			"name", org.Name,
+			"admin_phone", org.AdminPhone,
+			"error", err,
+		)
+		return apperrors.Internal("Failed to create organization", err)
+	}
+
+	s.cfg.Log.Info("Organization created successfully",
+		"id", org.ID,
+		"name", org.Name,
+		"admin_phone", org.AdminPhone,
+	)
+	return nil
+}
+
+func (s *businessUnitService) GetOrganization(ctx context.Context, id string) (*model.Organization, error) {
+	if id == "" {
+		return nil, apperrors.InvalidInput("Organization ID cannot be empty")
+	}
+
+	org, err := s.orgRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, businessunitserrors.ErrOrganizationNotFound) {
+			return nil, apperrors.NotFoundWithID("Organization", id)
+		}
+		if errors.Is(err, businessunitserrors.ErrInvalidID) {
+			return nil, apperrors.InvalidInput("Invalid organization ID format")
+		}
+		s.cfg.Log.Error("Failed to get organization by ID", "id", id, "error", err)
+		return nil, apperrors.Internal("Failed to retrieve organization", err)
+	}
+	return org, nil
+}
+
+// UpdateOrganization changes the organization's branding and defaults. Its
+// owner and admins can do it; only the owner changes the admins. Existing
+// units keep their settings, the defaults apply to new ones.
+func (s *businessUnitService) UpdateOrganization(ctx context.Context, id string, updates *model.OrganizationUpdate) (*model.Organization, error) {
+	org, err := s.GetOrganization(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeOrganization(ctx, org, "update the organization", auth.RoleOwner, auth.RoleMaintainer); err != nil {
+		return nil, err
+	}
+	if updates.Admins != nil && !maps.Equal(*updates.Admins, org.Admins) {
+		if err := s.authorizeOrganization(ctx, org, "change the organization admins", auth.RoleOwner); err != nil {
+			return nil, err
+		}
+	}
+
+	merged := mergeOrganizationUpdates(org, updates)
+	s.sanitizeOrganization(merged)
+	if err := s.validateOrganization(merged); err != nil {
+		return nil, err
+	}
+	if err := s.orgRepo.Update(ctx, id, merged); err != nil {
+		if errors.Is(err, businessunitserrors.ErrOrganizationNotFound) {
+			return nil, apperrors.NotFoundWithID("Organization", id)
+		}
+		s.cfg.Log.Error("Failed to update organization", "id", id, "error", err)
+		return nil, apperrors.Internal("Failed to update organization", err)
+	}
+
+	s.cfg.Log.Info("Organization updated successfully", "id", id, "name", merged.Name)
+	return merged, nil
+}
+
+// DeleteOrganization removes an organization that has no business units
+// left; units are moved out or deleted first.
+func (s *businessUnitService) DeleteOrganization(ctx context.Context, id string) error {
+	org, err := s.GetOrganization(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeOrganization(ctx, org, "delete the organization", auth.RoleOwner); err != nil {
+		return err
+	}
+
+	units, err := s.repo.CountByOrganization(ctx, id)
+	if err != nil {
+		s.cfg.Log.Error("Failed to count organization business units", "id", id, "error", err)
+		return apperrors.Internal("Failed to count business units", err)
+	}
+	if units > 0 {
+		return apperrors.Conflict(fmt.Sprintf("Organization still has %d business units", units))
+	}
+
+	if err := s.orgRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, businessunitserrors.ErrOrganizationNotFound) {
+			return apperrors.NotFoundWithID("Organization", id)
+		}
+		s.cfg.Log.Error("Failed to delete organization", "id", id, "error", err)
+		return apperrors.Internal("Failed to delete organization", err)
+	}
+
+	s.cfg.Log.Info("Organization deleted successfully", "id", id)
+	return nil
+}
+
+func (s *businessUnitService) GetOrganizationUnits(ctx context.Context, id string, limit int, offset int64) ([]*model.BusinessUnit, int64, error) {
+	if _, err := s.GetOrganization(ctx, id); err != nil {
+		return nil, 0, err
+	}
+
+	count, err := s.repo.CountByOrganization(ctx, id)
+	if err != nil {
+		s.cfg.Log.Error("Failed to count organization business units", "id", id, "error", err)
+		return nil, 0, apperrors.Internal("Failed to count business units", err)
+	}
+	units, err := s.repo.FindByOrganization(ctx, id, limit, offset)
+	if err != nil {
+		s.cfg.Log.Error("Failed to list organization business units", "id", id, "error", err)
+		return nil, 0, apperrors.Internal("Failed to retrieve business units", err)
+	}
+	return units, count, nil
+}
+
+// SearchBrands searches like Search but returns one result per brand: the
+// branches of an organization collapse into the one nearest to the customer,
+// i.e. in the earliest of the requested cities, then by priority.
+func (s *businessUnitService) SearchBrands(ctx context.Context, cities []string, labels []string, limit int, offset int64) ([]*model.BrandMatch, int64, error) {
+	if len(cities) == 0 || len(labels) == 0 {
+		return nil, 0, apperrors.InvalidInput("Both search criteria (cities and labels) must be provided")
+	}
+	labels, cities = s.sanitizeSearchRequest(labels, cities)
+	if len(cities) == 0 || len(labels) == 0 {
+		return nil, 0, apperrors.InvalidInput("Search criteria resulted in no valid items after normalization")
+	}
+
+	pairs := make([]string, 0, len(cities)*len(labels))
+	for _, city := range cities {
+		for _, label := range labels {
+			pairs = append(pairs, fmt.Sprintf("%s|%s", city, label))
+		}
+	}
+	candidates, err := s.repo.SearchByCityLabelPairs(ctx, pairs, nil, config.MaxTextSearchCandidates, 0)
+	if err != nil {
+		s.cfg.Log.Error("Failed to search brands",
+			"cities", cities,
+			"labels", labels,
+			"error", err,
+		)
+		return nil, 0, apperrors.Internal("Failed to search business units", err)
+	}
+
+	distance := func(bu *model.BusinessUnit) int {
+		for i, city := range cities {
+			if slices.Contains(bu.Cities, city) {
+				return i
+			}
+		}
+		return len(cities)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		di, dj := distance(candidates[i]), distance(candidates[j])
+		if di != dj {
+			return di < dj
+		}
+		return candidates[i].Priority > candidates[j].Priority
+	})
+
+	matches := []*model.BrandMatch{}
+	byBrand := map[string]*model.BrandMatch{}
+	orgIDs := []string{}
+	for _, unit := range candidates {
+		if unit.OrganizationID == "" {
+			matches = append(matches, &model.BrandMatch{Branch: unit, MatchedBranches: 1})
+			continue
+		}
+		if match, ok := byBrand[unit.OrganizationID]; ok {
+			match.MatchedBranches++
+			continue
+		}
+		match := &model.BrandMatch{OrganizationID: unit.OrganizationID, Branch: unit, MatchedBranches: 1}
+		byBrand[unit.OrganizationID] = match
+		matches = append(matches, match)
+		orgIDs = append(orgIDs, unit.OrganizationID)
+	}
+
+	if len(orgIDs) > 0 {
+		orgs, err := s.orgRepo.FindByIDs(ctx, orgIDs)
+		if err != nil {
+			s.cfg.Log.Error("Failed to load organizations", "ids", orgIDs, "error", err)
+			return nil, 0, apperrors.Internal("Failed to search business units", err)
+		}
+		for _, org := range orgs {
+			byBrand[org.ID].OrganizationName = org.Name
+		}
+	}
+
+	count := int64(len(matches))
+	page := []*model.BrandMatch{}
+	for i := offset; i < count && len(page) < limit; i++ {
+		page = append(page, matches[i])
+	}
+	return page, count, nil
+}
+
+// authorizeOrganization checks that the caller holds one of roles in the
+// organization. The system caller is always allowed.
+func (s *businessUnitService) authorizeOrganization(ctx context.Context, org *model.Organization, action string, roles ...string) error {
+	caller, err := auth.RequireCaller(ctx)
+	if err != nil {
+		return err
+	}
+	if caller.System {
+		return nil
+	}
+
+	role := auth.OrgRoleOf(org, caller.Phone)
+	if slices.Contains(roles, role) {
+		return nil
+	}
+	s.cfg.Log.Warn("Organization action forbidden",
+		"organization_id", org.ID,
+		"caller", caller.Phone,
+		"role", role,
+		"action", action,
+	)
+	return apperrors.Forbidden(fmt.Sprintf("Only the organization %s can %s", strings.Join(roles, " or "), action))
+}
+
+// organizationRole returns the role phone holds in the unit through its
+// organization, RoleNone for units outside organizations.
+func (s *businessUnitService) organizationRole(ctx context.Context, bu *model.BusinessUnit, phone string) (string, error) {
+	if bu.OrganizationID == "" {
+		return auth.RoleNone, nil
+	}
+	org, err := s.orgRepo.FindByID(ctx, bu.OrganizationID)
+	if err != nil {
+		if errors.Is(err, businessunitserrors.ErrOrganizationNotFound) {
+			return auth.RoleNone, nil
+		}
+		s.cfg.Log.Error("Failed to load organization", "id", bu.OrganizationID, "error", err)
+		return "", apperrors.Internal("Failed to retrieve organization", err)
+	}
+	return auth.OrgRoleOf(org, phone), nil
+}
+
+// joinOrganization loads the organization a unit is being attached to and
+// checks that the caller manages it and that it has room for another unit.
+func (s *businessUnitService) joinOrganization(ctx context.Context, organizationID string) (*model.Organization, error) {
+	org, err := s.GetOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeOrganization(ctx, org, "add business units to the organization", auth.RoleOwner, auth.RoleMaintainer); err != nil {
+		return nil, err
+	}
+
+	plan, err := s.planForOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.repo.CountByOrganization(ctx, organizationID)
+	if err != nil {
+		s.cfg.Log.Error("Failed to count organization business units", "id", organizationID, "error", err)
+		return nil, apperrors.Internal("Failed to count business units", err)
+	}
+	if total >= int64(plan.Limits.BusinessUnits) {
+		return nil, apperrors.Conflict(fmt.Sprintf(
+			"Organization exceeded maximum business units allowed (%d)",
+			plan.Limits.BusinessUnits,
+		)).WithDetails(map[string]any{
+			"plan":  plan.Name,
+			"limit": plan.Limits.BusinessUnits,
+		})
+	}
+	return org, nil
+}
+
+// applyOrganizationDefaults fills the settings a new unit left empty from its
+// organization.
+func applyOrganizationDefaults(bu *model.BusinessUnit, org *model.Organization) {
+	if len(bu.Labels) == 0 {
+		bu.Labels = slices.Clone(org.Labels)
+	}
+	if bu.TimeZone == "" {
+		bu.TimeZone = org.TimeZone
+	}
+	if len(bu.WebsiteURLs) == 0 {
+		bu.WebsiteURLs = slices.Clone(org.WebsiteURLs)
+	}
+}
+
+func (s *businessUnitService) sanitizeOrganization(org *model.Organization) {
+	org.Name = sanitizer.SanitizeNameOrAddress(org.Name)
+	org.AdminPhone = sanitizer.SanitizePhone(org.AdminPhone)
+	org.Admins = sanitizer.SanitizeMaintainersMap(org.Admins, org.AdminPhone)
+	org.Labels = taxonomy.Canonicalize(org.Labels)
+	org.Services = sanitizer.SanitizeSlice(org.Services, sanitizer.SanitizeCityOrLabel)
+	org.WebsiteURLs = sanitizer.SanitizeSlice(org.WebsiteURLs, sanitizer.SanitizeURL)
+	if org.Hours != nil {
+		for i, day := range org.Hours.WorkingDays {
+			org.Hours.WorkingDays[i] = strings.ToLower(strings.TrimSpace(day))
+		}
+	}
+}
+
+func (s *businessUnitService) validateOrganization(org *model.Organization) error {
+	if err := s.validator.ValidateOrganization(org); err != nil {
+		s.cfg.Log.Warn("Organization validation failed",
+			"name", org.Name,
+			"admin_phone", org.AdminPhone,
+			"error", err,
+		)
+		return apperrors.Validation("Organization validation failed", map[string]any{
+			"error": err.Error(),
+		})
+	}
+	return nil
+}
+
+func mergeOrganizationUpdates(existing *model.Organization, updates *model.OrganizationUpdate) *model.Organization {
+	merged := *existing
+
+	if updates.Name != "" {
+		merged.Name = updates.Name
+	}
+	if updates.Admins != nil {
+		merged.Admins = *updates.Admins
+	}
+	if updates.Labels != nil {
+		merged.Labels = *updates.Labels
+	}
+	if updates.Services != nil {
+		merged.Services = *updates.Services
+	}
+	if updates.Hours != nil {
+		merged.Hours = updates.Hours
+	}
+	if updates.TimeZone != "" {
+		merged.TimeZone = updates.TimeZone
+	}
+	if updates.WebsiteURLs != nil {
+		merged.WebsiteURLs = *updates.WebsiteURLs
+	}
+
+	return &merged
+}
@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	businessunitserrors "skeji/internal/businessunits/errors"
+	"skeji/pkg/auth"
+	"skeji/pkg/config"
+	apperrors "skeji/pkg/errors"
+	"skeji/pkg/model"
+	"skeji/pkg/sanitizer"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// StartTransfer offers the business unit to another phone. Nothing changes
+// until that phone accepts, before the transfer expires.
+func (s *businessUnitService) StartTransfer(ctx context.Context, businessID string, req *model.OwnershipTransferRequest) (*model.OwnershipTransfer, error) {
+	bu, err := s.GetByID(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, bu, "transfer ownership of the business unit", auth.RoleOwner); err != nil {
+		return nil, err
+	}
+	if err := verifyWritable(bu); err != nil {
+		return nil, err
+	}
+	caller, err := auth.RequireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req.ToPhone = sanitizer.SanitizePhone(req.ToPhone)
+	req.MaintainerName = sanitizer.SanitizeNameOrAddress(req.MaintainerName)
+	if err := s.validator.ValidateTransferRequest(req); err != nil {
+		s.cfg.Log.Warn("Ownership transfer validation failed", "business_id", businessID, "error", err)
+		return nil, apperrors.Validation("Invalid ownership transfer", map[string]any{"error": err.Error()})
+	}
+	if req.ToPhone == bu.AdminPhone {
+		return nil, apperrors.InvalidInput("to_phone is already the admin phone of the business unit")
+	}
+	// checked again on acceptance, the new owner may gain units meanwhile
+	if err := s.verifyLimitPerPhoneAdmin(ctx, req.ToPhone); err != nil {
+		return nil, err
+	}
+
+	pending, err := s.transferRepo.FindPending(ctx, businessID)
+	switch {
+	case err == nil:
+		pending, err = s.expireIfDue(ctx, pending)
+		if err != nil {
+			return nil, err
+		}
+		if pending.Status == config.TransferPending {
+			return nil, apperrors.Conflict("An ownership transfer of this business unit is already pending").WithDetails(map[string]any{
+				"transfer_id": pending.ID,
+			})
+		}
+	case !errors.Is(err, businessunitserrors.ErrTransferNotFound):
+		return nil, apperrors.Internal("Failed to check pending ownership transfers", err)
+	}
+
+	transfer := &model.OwnershipTransfer{
+		BusinessID:       bu.ID,
+		BusinessName:     bu.Name,
+		FromPhone:        bu.AdminPhone,
+		ToPhone:          req.ToPhone,
+		KeepAsMaintainer: req.KeepAsMaintainer,
+		Status:           config.TransferPending,
+		ExpiresAt:        time.Now().UTC().Add(config.DefaultOwnershipTransferTTL).Truncate(time.Millisecond),
+	}
+	if req.KeepAsMaintainer {
+		transfer.MaintainerName = req.MaintainerName
+		if transfer.MaintainerName == "" {
+			transfer.MaintainerName = config.DefaultFormerOwnerName
+		}
+	}
+
+	err = s.repo.ExecuteTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		if err := s.transferRepo.Create(sessCtx, transfer); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return apperrors.Conflict("An ownership transfer of this business unit is already pending")
+			}
+			return apperrors.Internal("Failed to start ownership transfer", err)
+		}
+		return s.audit(sessCtx, transfer, config.AuditTransferStarted, caller.String())
+	})
+	if err != nil {
+		s.cfg.Log.Error("Failed to start ownership transfer", "business_id", businessID, "error", err)
+		return nil, err
+	}
+
+	s.cfg.Log.Info("Ownership transfer started",
+		"id", transfer.ID,
+		"business_id", transfer.BusinessID,
+		"from_phone", transfer.FromPhone,
+		"to_phone", transfer.ToPhone,
+		"expires_at", transfer.ExpiresAt,
+	)
+	return transfer, nil
+}
+
+// GetTransfer returns a transfer to either side of it.
+func (s *businessUnitService) GetTransfer(ctx context.Context, id string) (*model.OwnershipTransfer, error) {
+	transfer, err := s.loadTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeTransfer(ctx, transfer, "view the ownership transfer", transfer.FromPhone, transfer.ToPhone); err != nil {
+		return nil, err
+	}
+	return s.expireIfDue(ctx, transfer)
+}
+
+// AcceptTransfer makes the new phone the owner. The old owner stays on as a
+// maintainer when the transfer asked for it, and the new owner stops being a
+// maintainer or viewer.
+func (s *businessUnitService) AcceptTransfer(ctx context.Context, id string) (*model.OwnershipTransfer, error) {
+	transfer, err := s.pendingTransfer(ctx, id, "accept the ownership transfer", func(t *model.OwnershipTransfer) string { return t.ToPhone })
+	if err != nil {
+		return nil, err
+	}
+	caller, err := auth.RequireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bu, err := s.GetByID(ctx, transfer.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+	if bu.AdminPhone != transfer.FromPhone {
+		return nil, apperrors.Conflict("The business unit changed owner since the transfer started")
+	}
+	if err := verifyWritable(bu); err != nil {
+		return nil, err
+	}
+	if err := s.verifyLimitPerPhoneAdmin(ctx, transfer.ToPhone); err != nil {
+		return nil, err
+	}
+
+	updated := *bu
+	updated.AdminPhone = transfer.ToPhone
+	updated.Maintainers = maps.Clone(bu.Maintainers)
+	updated.Viewers = maps.Clone(bu.Viewers)
+	if transfer.KeepAsMaintainer {
+		if updated.Maintainers == nil {
+			updated.Maintainers = map[string]string{}
+		}
+		updated.Maintainers[transfer.FromPhone] = transfer.MaintainerName
+	}
+	s.sanitize(&updated)
+	if err := s.validate(&updated); err != nil {
+		return nil, err
+	}
+
+	var accepted *model.OwnershipTransfer
+	err = s.repo.ExecuteTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		accepted, err = s.resolveTransfer(sessCtx, transfer.ID, config.TransferAccepted)
+		if err != nil {
+			return err
+		}
+		if _, err := s.repo.Update(sessCtx, bu.ID, &updated); err != nil {
+			return apperrors.Internal("Failed to transfer business unit ownership", err)
+		}
+		return s.audit(sessCtx, accepted, config.AuditTransferAccepted, caller.String())
+	})
+	if err != nil {
+		s.cfg.Log.Error("Failed to accept ownership transfer", "id", id, "error", err)
+		return nil, err
+	}
+
+	s.cfg.Log.Info("Business unit ownership transferred",
+		"id", accepted.ID,
+		"business_id", accepted.BusinessID,
+		"from_phone", accepted.FromPhone,
+		"to_phone", accepted.ToPhone,
+		"kept_as_maintainer", accepted.KeepAsMaintainer,
+	)
+	return accepted, nil
+}
+
+// DeclineTransfer lets the new phone refuse the business unit.
+func (s *businessUnitService) DeclineTransfer(ctx context.Context, id string) (*model.OwnershipTransfer, error) {
+	transfer, err := s.pendingTransfer(ctx, id, "decline the ownership transfer", func(t *model.OwnershipTransfer) string { return t.ToPhone })
+	if err != nil {
+		return nil, err
+	}
+	return s.closeTransfer(ctx, transfer, config.TransferDeclined, config.AuditTransferDeclined)
+}
+
+// CancelTransfer lets the current owner withdraw the offer.
+func (s *businessUnitService) CancelTransfer(ctx context.Context, id string) (*model.OwnershipTransfer, error) {
+	transfer, err := s.pendingTransfer(ctx, id, "cancel the ownership transfer", func(t *model.OwnershipTransfer) string { return t.FromPhone })
+	if err != nil {
+		return nil, err
+	}
+	return s.closeTransfer(ctx, transfer, config.TransferCancelled, config.AuditTransferCancelled)
+}
+
+// GetAuditLog lists the ownership changes of the business unit, newest first.
+func (s *businessUnitService) GetAuditLog(ctx context.Context, businessID string, limit int, offset int64) ([]*model.AuditEntry, int64, error) {
+	bu, err := s.GetByID(ctx, businessID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := s.authorize(ctx, bu, "view the audit log", auth.RoleOwner, auth.RoleMaintainer); err != nil {
+		return nil, 0, err
+	}
+
+	count, err := s.auditRepo.CountByBusiness(ctx, businessID)
+	if err != nil {
+		s.cfg.Log.Error("Failed to count audit entries", "business_id", businessID, "error", err)
+		return nil, 0, apperrors.Internal("Failed to count audit entries", err)
+	}
+	entries, err := s.auditRepo.FindByBusiness(ctx, businessID, limit, offset)
+	if err != nil {
+		s.cfg.Log.Error("Failed to list audit entries", "business_id", businessID, "error", err)
+		return nil, 0, apperrors.Internal("Failed to retrieve audit entries", err)
+	}
+	return entries, count, nil
+}
+
+func (s *businessUnitService) loadTransfer(ctx context.Context, id string) (*model.OwnershipTransfer, error) {
+	if id == "" {
+		return nil, apperrors.InvalidInput("Transfer ID cannot be empty")
+	}
+
+	transfer, err := s.transferRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, businessunitserrors.ErrTransferNotFound) {
+			return nil, apperrors.NotFoundWithID("Ownership transfer", id)
+		}
+		if errors.Is(err, businessunitserrors.ErrInvalidID) {
+			return nil, apperrors.InvalidInput("Invalid transfer ID format")
+		}
+		s.cfg.Log.Error("Failed to get ownership transfer", "id", id, "error", err)
+		return nil, apperrors.Internal("Failed to retrieve ownership transfer", err)
+	}
+	return transfer, nil
+}
+
+// pendingTransfer loads a transfer that party may still answer.
+func (s *businessUnitService) pendingTransfer(ctx context.Context, id string, action string, party func(*model.OwnershipTransfer) string) (*model.OwnershipTransfer, error) {
+	transfer, err := s.loadTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeTransfer(ctx, transfer, action, party(transfer)); err != nil {
+		return nil, err
+	}
+	transfer, err = s.expireIfDue(ctx, transfer)
+	if err != nil {
+		return nil, err
+	}
+	if transfer.Status != config.TransferPending {
+		return nil, apperrors.Conflict(fmt.Sprintf("Ownership transfer is already %s", transfer.Status))
+	}
+	return transfer, nil
+}
+
+// authorizeTransfer checks that the caller is one of phones. The system
+// caller is always allowed.
+func (s *businessUnitService) authorizeTransfer(ctx context.Context, transfer *model.OwnershipTransfer, action string, phones ...string) error {
+	caller, err := auth.RequireCaller(ctx)
+	if err != nil {
+		return err
+	}
+	if caller.System {
+		return nil
+	}
+	for _, phone := range phones {
+		if caller.Phone == phone {
+			return nil
+		}
+	}
+	s.cfg.Log.Warn("Ownership transfer action forbidden",
+		"id", transfer.ID,
+		"business_id", transfer.BusinessID,
+		"caller", caller.Phone,
+		"action", action,
+	)
+	return apperrors.Forbidden(fmt.Sprintf("You cannot %s", action))
+}
+
+// expireIfDue marks a pending transfer past its deadline as expired.
+func (s *businessUnitService) expireIfDue(ctx context.Context, transfer *model.OwnershipTransfer) (*model.OwnershipTransfer, error) {
+	if transfer.Status != config.TransferPending || time.Now().UTC().Before(transfer.ExpiresAt) {
+		return transfer, nil
+	}
+
+	expired, err := s.closeTransfer(ctx, transfer, config.TransferExpired, config.AuditTransferExpired)
+	if err != nil {
+		var appErr *apperrors.AppError
+		if errors.As(err, &appErr) && appErr.Code == apperrors.CodeConflict {
+			// answered just before it expired
+			return s.loadTransfer(ctx, transfer.ID)
+		}
+		return nil, err
+	}
+	return expired, nil
+}
+
+// closeTransfer ends a pending transfer without changing the business unit.
+func (s *businessUnitService) closeTransfer(ctx context.Context, transfer *model.OwnershipTransfer, status string, action string) (*model.OwnershipTransfer, error) {
+	actor := auth.SystemCaller
+	if status != config.TransferExpired {
+		caller, err := auth.RequireCaller(ctx)
+		if err != nil {
+			return nil, err
+		}
+		actor = caller.String()
+	}
+
+	var closed *model.OwnershipTransfer
+	err := s.repo.ExecuteTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		var err error
+		closed, err = s.resolveTransfer(sessCtx, transfer.ID, status)
+		if err != nil {
+			return err
+		}
+		return s.audit(sessCtx, closed, action, actor)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.cfg.Log.Info("Ownership transfer closed",
+		"id", closed.ID,
+		"business_id", closed.BusinessID,
+		"status", closed.Status,
+	)
+	return closed, nil
+}
+
+func (s *businessUnitService) resolveTransfer(ctx context.Context, id string, status string) (*model.OwnershipTransfer, error) {
+	resolved, err := s.transferRepo.Resolve(ctx, id, status, time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, businessunitserrors.ErrTransferNotFound) {
+			return nil, apperrors.Conflict("Ownership transfer is no longer pending")
+		}
+		return nil, apperrors.Internal("Failed to update ownership transfer", err)
+	}
+	return resolved, nil
+}
+
+func (s *businessUnitService) audit(ctx context.Context, transfer *model.OwnershipTransfer, action string, actor string) error {
+	entry := &model.AuditEntry{
+		BusinessID: transfer.BusinessID,
+		Action:     action,
+		Actor:      actor,
+		Details: map[string]string{
+			"transfer_id":        transfer.ID,
+			"from_phone":         transfer.FromPhone,
+			"to_phone":           transfer.ToPhone,
+			"keep_as_maintainer": fmt.Sprintf("%t", transfer.KeepAsMaintainer),
+		},
+	}
+	if err := s.auditRepo.Record(ctx, entry); err != nil {
+		return apperrors.Internal("Failed to record audit entry", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"skeji/pkg/auth"
+	apperrors "skeji/pkg/errors"
+	"skeji/pkg/model"
+	"slices"
+	"strings"
+)
+
+// authorize checks that the caller holds one of roles in the business unit,
+// directly or through its organization. The system caller is always allowed.
+func (s *businessUnitService) authorize(ctx context.Context, bu *model.BusinessUnit, action string, roles ...string) error {
+	caller, err := auth.RequireCaller(ctx)
+	if err != nil {
+		return err
+	}
+	if caller.System {
+		return nil
+	}
+
+	role := auth.RoleOf(bu, caller.Phone)
+	if !slices.Contains(roles, role) {
+		orgRole, err := s.organizationRole(ctx, bu, caller.Phone)
+		if err != nil {
+			return err
+		}
+		role = auth.StrongerRole(role, orgRole)
+	}
+	if slices.Contains(roles, role) {
+		return nil
+	}
+	s.cfg.Log.Warn("Business unit action forbidden",
+		"business_id", bu.ID,
+		"caller", caller.Phone,
+		"role", role,
+		"action", action,
+	)
+	return apperrors.Forbidden(fmt.Sprintf("Only the %s can %s", strings.Join(roles, " or "), action))
+}
+
+// authorizeSystem checks that the call comes from another service.
+func (s *businessUnitService) authorizeSystem(ctx context.Context, action string) error {
+	caller, err := auth.RequireCaller(ctx)
+	if err != nil {
+		return err
+	}
+	if !caller.System {
+		return apperrors.Forbidden(fmt.Sprintf("Only the services can %s", action))
+	}
+	return nil
+}
+
+// changesRoles reports whether the update changes the maintainers or
+// viewers of the business unit, which only its owner may do. The owner
+// itself changes through an ownership transfer.
+func changesRoles(existing *model.BusinessUnit, updates *model.BusinessUnitUpdate) bool {
+	if updates.Maintainers != nil && !maps.Equal(*updates.Maintainers, existing.Maintainers) {
+		return true
+	}
+	return updates.Viewers != nil && !maps.Equal(*updates.Viewers, existing.Viewers)
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	businessunitserrors "skeji/internal/businessunits/errors"
+	"skeji/pkg/auth"
+	"skeji/pkg/client"
+	"skeji/pkg/config"
+	apperrors "skeji/pkg/errors"
+	"skeji/pkg/model"
+	"skeji/pkg/plans"
+	"skeji/pkg/sanitizer"
+	"strings"
+)
+
+func (s *businessUnitService) ListPlans(ctx context.Context) []model.Plan {
+	return plans.All()
+}
+
+// AssignPlan puts an admin phone or an organization on a plan. Plans are
+// assigned by other services, e.g. billing, never by the tenants themselves.
+func (s *businessUnitService) AssignPlan(ctx context.Context, assignment *model.PlanAssignment) error {
+	if err := s.authorizeSystem(ctx, "assign plans"); err != nil {
+		return err
+	}
+
+	assignment.Phone = sanitizer.SanitizePhone(assignment.Phone)
+	assignment.OrganizationID = strings.TrimSpace(assignment.OrganizationID)
+	assignment.Plan = strings.ToLower(strings.TrimSpace(assignment.Plan))
+	if err := s.validator.ValidatePlanAssignment(assignment); err != nil {
+		s.cfg.Log.Warn("Plan assignment validation failed", "plan", assignment.Plan, "error", err)
+		return apperrors.Validation("Invalid plan assignment", map[string]any{"error": err.Error()})
+	}
+	if _, ok := plans.Get(assignment.Plan); !ok {
+		names := []string{}
+		for _, plan := range plans.All() {
+			names = append(names, plan.Name)
+		}
+		return apperrors.InvalidInput(fmt.Sprintf("Unknown plan '%s'", assignment.Plan)).WithDetails(map[string]any{
+			"plans": names,
+		})
+	}
+	if assignment.OrganizationID != "" {
+		if _, err := s.GetOrganization(ctx, assignment.OrganizationID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.planRepo.Assign(ctx, assignment); err != nil {
+		s.cfg.Log.Error("Failed to assign plan",
+			"phone", assignment.Phone,
+			"organization_id", assignment.OrganizationID,
+			"plan", assignment.Plan,
+			"error", err,
+		)
+		return apperrors.Internal("Failed to assign plan", err)
+	}
+
+	s.cfg.Log.Info("Plan assigned",
+		"phone", assignment.Phone,
+		"organization_id", assignment.OrganizationID,
+		"plan", assignment.Plan,
+	)
+	return nil
+}
+
+// GetQuota reports the usage of the business unit and its owner against
+// their plan.
+func (s *businessUnitService) GetQuota(ctx context.Context, businessID string) (*model.Quota, error) {
+	bu, err := s.GetByID(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, bu, "view the quota", auth.RoleOwner, auth.RoleMaintainer); err != nil {
+		return nil, err
+	}
+
+	plan, err := s.planFor(ctx, bu)
+	if err != nil {
+		return nil, err
+	}
+	var units int64
+	if bu.OrganizationID != "" {
+		units, err = s.repo.CountByOrganization(ctx, bu.OrganizationID)
+	} else {
+		units, err = s.repo.CountByAdminPhone(ctx, bu.AdminPhone)
+	}
+	if err != nil {
+		s.cfg.Log.Error("Failed to count business units", "business_id", businessID, "error", err)
+		return nil, apperrors.Internal("Failed to count business units", err)
+	}
+	quota := &model.Quota{
+		BusinessID: businessID,
+		Plan:       plan.Name,
+		Limits:     plan.Limits,
+		Usage: model.PlanUsage{
+			BusinessUnits: units,
+			Maintainers:   len(bu.Maintainers),
+			Cities:        len(bu.Cities),
+		},
+	}
+	if schedules, err := s.countSchedules(businessID); err != nil {
+		s.cfg.Log.Warn("Failed to count schedules for quota", "business_id", businessID, "error", err)
+	} else {
+		quota.Usage.Schedules = &schedules
+	}
+	return quota, nil
+}
+
+func (s *businessUnitService) countSchedules(businessID string) (int64, error) {
+	scheduleClient := s.cfg.Client.ScheduleClient
+	resp, err := scheduleClient.Search(businessID, "", 1, 0)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("schedules service returned status %d: %s", resp.StatusCode, client.GetErrorMessage(resp))
+	}
+	_, meta, err := scheduleClient.DecodeSchedules(resp)
+	if err != nil {
+		return 0, err
+	}
+	return meta.TotalCount, nil
+}
+
+// planFor returns the plan of the unit's owner: its organization, or its
+// admin phone for units outside organizations.
+func (s *businessUnitService) planFor(ctx context.Context, bu *model.BusinessUnit) (model.Plan, error) {
+	if bu.OrganizationID != "" {
+		return s.planForOrganization(ctx, bu.OrganizationID)
+	}
+	return s.planForPhone(ctx, bu.AdminPhone)
+}
+
+func (s *businessUnitService) planForPhone(ctx context.Context, phone string) (model.Plan, error) {
+	assignment, err := s.planRepo.FindByPhone(ctx, phone)
+	return s.resolvePlan(assignment, err, config.DefaultPlan)
+}
+
+func (s *businessUnitService) planForOrganization(ctx context.Context, organizationID string) (model.Plan, error) {
+	assignment, err := s.planRepo.FindByOrganization(ctx, organizationID)
+	return s.resolvePlan(assignment, err, config.DefaultOrganizationPlan)
+}
+
+func (s *businessUnitService) resolvePlan(assignment *model.PlanAssignment, err error, fallback string) (model.Plan, error) {
+	if err != nil {
+		if errors.Is(err, businessunitserrors.ErrPlanAssignmentNotFound) {
+			return plans.Resolve("", fallback), nil
+		}
+		s.cfg.Log.Error("Failed to load plan assignment", "error", err)
+		return model.Plan{}, apperrors.Internal("Failed to load plan", err)
+	}
+	return plans.Resolve(assignment.Plan, fallback), nil
+}
+
+// verifyPlanLimits checks the unit against the per-unit limits of its
+// owner's plan.
+func (s *businessUnitService) verifyPlanLimits(ctx context.Context, bu *model.BusinessUnit) error {
+	plan, err := s.planFor(ctx, bu)
+	if err != nil {
+		return err
+	}
+	if err := s.validator.ValidatePlanLimits(bu, plan); err != nil {
+		s.cfg.Log.Warn("Business unit exceeds plan limits",
+			"name", bu.Name,
+			"admin_phone", bu.AdminPhone,
+			"plan", plan.Name,
+			"error", err,
+		)
+		return apperrors.Validation("Business unit validation failed", map[string]any{
+			"error": err.Error(),
+			"plan":  plan.Name,
+		})
+	}
+	return nil
+}
@@ -3,13 +3,16 @@ package validator
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"net"
 	"net/url"
 	"regexp"
+	"skeji/pkg/attributes"
 	"skeji/pkg/config"
 	"skeji/pkg/locale"
 	"skeji/pkg/logger"
 	"skeji/pkg/model"
+	"slices"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
@@ -197,11 +200,11 @@ func (v *BusinessUnitValidator) Validate(bu *model.BusinessUnit) error {
 		}
 		return err
 	}
-	if len(bu.Maintainers) > config.DefaultMaxMaintainersPerBusiness {
+	if len(bu.Viewers) > config.DefaultMaxViewersPerBusiness {
 		return ValidationErrors{
 			ValidationError{
-				Field:   "Maintainers",
-				Message: fmt.Sprintf("Maintainers count (%d) exceeds capacity (%d)", len(bu.Maintainers), config.DefaultMaxMaintainersPerBusiness),
+				Field:   "Viewers",
+				Message: fmt.Sprintf("Viewers count (%d) exceeds capacity (%d)", len(bu.Viewers), config.DefaultMaxViewersPerBusiness),
 			},
 		}
 	}
@@ -236,6 +239,116 @@ func (v *BusinessUnitValidator) Validate(bu *model.BusinessUnit) error {
 		}
 	}
 
+	if errs := validateAttributes(bu.Attributes); len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// validateAttributes checks the unit's attributes against their definitions,
+// reporting every invalid one.
+func validateAttributes(attrs model.Attributes) ValidationErrors {
+	problems := attributes.Validate(attrs, false)
+	errs := ValidationErrors{}
+	for _, key := range slices.Sorted(maps.Keys(problems)) {
+		errs = append(errs, ValidationError{
+			Field:   "Attributes." + key,
+			Message: problems[key],
+		})
+	}
+	return errs
+}
+
+func (v *BusinessUnitValidator) ValidateOrganization(org *model.Organization) error {
+	if err := v.validate.Struct(org); err != nil {
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			return v.translateValidationErrors(validationErrs)
+		}
+		return err
+	}
+	if len(org.Admins) > config.DefaultMaxAdminsPerOrganization {
+		return ValidationErrors{
+			ValidationError{
+				Field:   "Admins",
+				Message: fmt.Sprintf("Admins count (%d) exceeds capacity (%d)", len(org.Admins), config.DefaultMaxAdminsPerOrganization),
+			},
+		}
+	}
+	if org.TimeZone != "" && !validateSupportedTimeZone(org.TimeZone) {
+		return ValidationError{
+			Field:   "TimeZone",
+			Message: fmt.Sprintf("TimeZone '%s' is not supported", org.TimeZone),
+		}
+	}
+	if org.Hours != nil && org.Hours.StartOfDay >= org.Hours.EndOfDay {
+		return ValidationError{
+			Field:   "Hours",
+			Message: "StartOfDay must be before EndOfDay",
+		}
+	}
+	return nil
+}
+
+func (v *BusinessUnitValidator) ValidateTransferRequest(req *model.OwnershipTransferRequest) error {
+	if err := v.validate.Struct(req); err != nil {
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			return v.translateValidationErrors(validationErrs)
+		}
+		return err
+	}
+	return nil
+}
+
+// ValidatePlanLimits checks the unit against the per-unit limits of its
+// owner's plan.
+func (v *BusinessUnitValidator) ValidatePlanLimits(bu *model.BusinessUnit, plan model.Plan) error {
+	if len(bu.Maintainers) > plan.Limits.MaintainersPerBusinessUnit {
+		return ValidationErrors{
+			ValidationError{
+				Field:   "Maintainers",
+				Message: fmt.Sprintf("Maintainers count (%d) exceeds capacity (%d) of the %s plan", len(bu.Maintainers), plan.Limits.MaintainersPerBusinessUnit, plan.Name),
+			},
+		}
+	}
+	if len(bu.Cities) > plan.Limits.CitiesPerBusinessUnit {
+		return ValidationErrors{
+			ValidationError{
+				Field:   "Cities",
+				Message: fmt.Sprintf("Cities count (%d) exceeds capacity (%d) of the %s plan", len(bu.Cities), plan.Limits.CitiesPerBusinessUnit, plan.Name),
+			},
+		}
+	}
+	return nil
+}
+
+func (v *BusinessUnitValidator) ValidatePlanAssignment(assignment *model.PlanAssignment) error {
+	if err := v.validate.Struct(assignment); err != nil {
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			return v.translateValidationErrors(validationErrs)
+		}
+		return err
+	}
+	if (assignment.Phone == "") == (assignment.OrganizationID == "") {
+		return ValidationError{
+			Field:   "Phone",
+			Message: "Exactly one of phone and organization_id must be set",
+		}
+	}
+	return nil
+}
+
+func (v *BusinessUnitValidator) ValidateStatusChange(change *model.BusinessUnitStatusChange) error {
+	if err := v.validate.Struct(change); err != nil {
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			return v.translateValidationErrors(validationErrs)
+		}
+		return err
+	}
 	return nil
 }
 
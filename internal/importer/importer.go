@@ -0,0 +1,332 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	buvalidator "skeji/internal/businessunits/validator"
+	scheduleservice "skeji/internal/schedules/service"
+	schedulevalidator "skeji/internal/schedules/validator"
+	"skeji/pkg/client"
+	"skeji/pkg/config"
+	"skeji/pkg/locale"
+	"skeji/pkg/model"
+	"skeji/pkg/sanitizer"
+	"skeji/pkg/taxonomy"
+	"strings"
+)
+
+const (
+	StatusCreated     = "created"
+	StatusWouldCreate = "would_create"
+	StatusExisting    = "existing"
+	StatusFailed      = "failed"
+)
+
+// placeholderBusinessID stands in for the ID of a business unit that is not
+// created yet while its schedules are validated.
+const placeholderBusinessID = "000000000000000000000000"
+
+// RecordResult is the outcome of importing one business and its schedules.
+// Re-running an import reports businesses and schedules created by an
+// earlier run as existing instead of creating them again.
+type RecordResult struct {
+	Line              int      `json:"line"`
+	Name              string   `json:"name"`
+	AdminPhone        string   `json:"admin_phone"`
+	BusinessID        string   `json:"business_id,omitempty"`
+	Status            string   `json:"status"`
+	SchedulesCreated  int      `json:"schedules_created"`
+	SchedulesExisting int      `json:"schedules_existing"`
+	Errors            []string `json:"errors,omitempty"`
+}
+
+// Report summarizes an import. On a dry run nothing is written and
+// SchedulesCreated counts the schedules that would be created.
+type Report struct {
+	DryRun      bool           `json:"dry_run"`
+	Created     int            `json:"created"`
+	WouldCreate int            `json:"would_create"`
+	Existing    int            `json:"existing"`
+	Failed      int            `json:"failed"`
+	Records     []RecordResult `json:"records"`
+	RowErrors   []RowError     `json:"row_errors,omitempty"`
+}
+
+// HasErrors reports whether any line of the file failed to import.
+func (r *Report) HasErrors() bool {
+	return r.Failed > 0 || len(r.RowErrors) > 0
+}
+
+// Importer creates business units and their schedules through the services'
+// APIs, so every row goes through the same authorization, limits and
+// duplicate checks as a single create.
+type Importer struct {
+	cfg                   *config.Config
+	businessUnitValidator *buvalidator.BusinessUnitValidator
+	scheduleValidator     *schedulevalidator.ScheduleValidator
+	businessUnits         *client.BusinessUnitClient
+	schedules             *client.ScheduleClient
+}
+
+func NewImporter(cfg *config.Config, businessUnits *client.BusinessUnitClient, schedules *client.ScheduleClient) *Importer {
+	return &Importer{
+		cfg:                   cfg,
+		businessUnitValidator: buvalidator.NewBusinessUnitValidator(cfg.Log),
+		scheduleValidator:     schedulevalidator.NewScheduleValidator(cfg.Log),
+		businessUnits:         businessUnits,
+		schedules:             schedules,
+	}
+}
+
+// Run validates every record and, unless dryRun is set, creates the ones
+// that do not exist yet. Invalid records are reported and skipped; they do
+// not stop the rest of the import.
+func (im *Importer) Run(records []Record, rowErrors []RowError, dryRun bool) *Report {
+	report := &Report{DryRun: dryRun, Records: []RecordResult{}, RowErrors: rowErrors}
+	firstLine := map[string]int{}
+
+	for i := range records {
+		record := &records[i]
+		im.normalize(record)
+		result := RecordResult{
+			Line:       record.Line,
+			Name:       record.BusinessUnit.Name,
+			AdminPhone: record.BusinessUnit.AdminPhone,
+		}
+
+		key := recordKey(&record.BusinessUnit)
+		if line, dup := firstLine[key]; dup {
+			result.Errors = []string{fmt.Sprintf("duplicate of the business on line %d", line)}
+		} else {
+			firstLine[key] = record.Line
+			result.Errors = im.validate(record)
+		}
+		if len(result.Errors) == 0 {
+			im.importRecord(record, &result, dryRun)
+		}
+
+		if len(result.Errors) > 0 {
+			result.Status = StatusFailed
+		}
+		switch result.Status {
+		case StatusCreated:
+			report.Created++
+		case StatusWouldCreate:
+			report.WouldCreate++
+		case StatusExisting:
+			report.Existing++
+		case StatusFailed:
+			report.Failed++
+		}
+		report.Records = append(report.Records, result)
+	}
+
+	im.cfg.Log.Info("Import finished",
+		"dry_run", dryRun,
+		"created", report.Created,
+		"would_create", report.WouldCreate,
+		"existing", report.Existing,
+		"failed", report.Failed,
+		"row_errors", len(report.RowErrors),
+	)
+	return report
+}
+
+// normalize cleans a record up the way the services do before validating,
+// so the local checks agree with theirs.
+func (im *Importer) normalize(record *Record) {
+	bu := &record.BusinessUnit
+	bu.Name = sanitizer.SanitizeNameOrAddress(bu.Name)
+	bu.AdminPhone = sanitizer.SanitizePhone(bu.AdminPhone)
+	bu.Cities = locale.CanonicalCities(bu.Cities)
+	bu.Labels = taxonomy.Canonicalize(bu.Labels)
+	bu.Maintainers = sanitizer.SanitizeMaintainersMap(bu.Maintainers, bu.AdminPhone)
+	bu.WebsiteURLs = sanitizer.SanitizeSlice(bu.WebsiteURLs, sanitizer.SanitizeURL)
+	if bu.TimeZone == "" {
+		bu.TimeZone = locale.InferTimezoneFromPhone(bu.AdminPhone)
+	}
+
+	for i := range record.Schedules {
+		sc := &record.Schedules[i].Schedule
+		if sc.City == "" && len(bu.Cities) > 0 {
+			sc.City = bu.Cities[0]
+		}
+		if sc.TimeZone == "" {
+			sc.TimeZone = bu.TimeZone
+		}
+		scheduleservice.ApplyDefaults(im.cfg, sc)
+		scheduleservice.Sanitize(sc)
+	}
+}
+
+// validate checks the business and each of its schedules and returns every
+// problem found, so one pass over a file lists all that needs fixing.
+func (im *Importer) validate(record *Record) []string {
+	var problems []string
+	if err := im.businessUnitValidator.Validate(&record.BusinessUnit); err != nil {
+		problems = append(problems, validationMessages(err)...)
+	}
+
+	seen := map[string]int{}
+	for _, sr := range record.Schedules {
+		sc := sr.Schedule
+		sc.BusinessID = placeholderBusinessID
+		prefix := fmt.Sprintf("schedule '%s' (line %d): ", sc.Name, sr.Line)
+		if line, dup := seen[scheduleKey(&sc)]; dup {
+			problems = append(problems, prefix+fmt.Sprintf("duplicate of the schedule on line %d", line))
+			continue
+		}
+		seen[scheduleKey(&sc)] = sr.Line
+		if err := im.scheduleValidator.Validate(&sc); err != nil {
+			for _, message := range validationMessages(err) {
+				problems = append(problems, prefix+message)
+			}
+		}
+	}
+	return problems
+}
+
+func validationMessages(err error) []string {
+	var buErrs buvalidator.ValidationErrors
+	if errors.As(err, &buErrs) {
+		messages := make([]string, 0, len(buErrs))
+		for _, e := range buErrs {
+			messages = append(messages, e.Error())
+		}
+		return messages
+	}
+	var scheduleErrs schedulevalidator.ValidationErrors
+	if errors.As(err, &scheduleErrs) {
+		messages := make([]string, 0, len(scheduleErrs))
+		for _, e := range scheduleErrs {
+			messages = append(messages, e.Error())
+		}
+		return messages
+	}
+	return []string{err.Error()}
+}
+
+func (im *Importer) importRecord(record *Record, result *RecordResult, dryRun bool) {
+	existing, err := im.findBusinessUnit(&record.BusinessUnit)
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+		return
+	}
+
+	var existingSchedules map[string]struct{}
+	switch {
+	case existing != nil:
+		result.Status = StatusExisting
+		result.BusinessID = existing.ID
+		if len(record.Schedules) == 0 {
+			return
+		}
+		if existingSchedules, err = im.findSchedules(existing.ID); err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			return
+		}
+	case dryRun:
+		result.Status = StatusWouldCreate
+	default:
+		resp, err := im.businessUnits.Create(record.BusinessUnit)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("failed to create business unit: %v", err))
+			return
+		}
+		if resp.StatusCode != http.StatusCreated {
+			result.Errors = append(result.Errors, fmt.Sprintf("failed to create business unit: %s", client.GetErrorMessage(resp)))
+			return
+		}
+		created, err := im.businessUnits.DecodeBusinessUnit(resp)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			return
+		}
+		result.Status = StatusCreated
+		result.BusinessID = created.ID
+	}
+
+	for _, sr := range record.Schedules {
+		sc := sr.Schedule
+		if _, ok := existingSchedules[scheduleKey(&sc)]; ok {
+			result.SchedulesExisting++
+			continue
+		}
+		if dryRun {
+			result.SchedulesCreated++
+			continue
+		}
+		sc.BusinessID = result.BusinessID
+		resp, err := im.schedules.Create(sc)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("schedule '%s' (line %d): %v", sc.Name, sr.Line, err))
+			continue
+		}
+		if resp.StatusCode != http.StatusCreated {
+			result.Errors = append(result.Errors, fmt.Sprintf("schedule '%s' (line %d): %s", sc.Name, sr.Line, client.GetErrorMessage(resp)))
+			continue
+		}
+		result.SchedulesCreated++
+	}
+}
+
+// findBusinessUnit returns the stored unit the record describes, if an
+// earlier import or a single create already added it.
+func (im *Importer) findBusinessUnit(bu *model.BusinessUnit) (*model.BusinessUnit, error) {
+	key := recordKey(bu)
+	var offset int64
+	for {
+		resp, err := im.businessUnits.GetByPhone(bu.AdminPhone, nil, nil, config.DefaultPaginationLimit, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up existing business units: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to look up existing business units: %s", client.GetErrorMessage(resp))
+		}
+		units, meta, err := im.businessUnits.DecodeBusinessUnits(resp)
+		if err != nil {
+			return nil, err
+		}
+		for _, unit := range units {
+			if unit.AdminPhone == bu.AdminPhone && recordKey(unit) == key {
+				return unit, nil
+			}
+		}
+		offset += int64(len(units))
+		if len(units) == 0 || offset >= meta.TotalCount {
+			return nil, nil
+		}
+	}
+}
+
+// findSchedules returns the keys of the schedules the business already has.
+func (im *Importer) findSchedules(businessID string) (map[string]struct{}, error) {
+	keys := map[string]struct{}{}
+	var offset int64
+	for {
+		resp, err := im.schedules.Search(businessID, "", config.DefaultPaginationLimit, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up existing schedules: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to look up existing schedules: %s", client.GetErrorMessage(resp))
+		}
+		schedules, meta, err := im.schedules.DecodeSchedules(resp)
+		if err != nil {
+			return nil, err
+		}
+		for _, sc := range schedules {
+			keys[scheduleKey(sc)] = struct{}{}
+		}
+		offset += int64(len(schedules))
+		if len(schedules) == 0 || offset >= meta.TotalCount {
+			return keys, nil
+		}
+	}
+}
+
+// scheduleKey identifies a schedule within its business: its name in a city.
+func scheduleKey(sc *model.Schedule) string {
+	return strings.ToLower(sc.City) + "/" + strings.ToLower(sc.Name)
+}
@@ -0,0 +1,328 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"skeji/pkg/model"
+	"skeji/pkg/sanitizer"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+
+	// listSeparator splits multi-valued CSV cells, e.g. "Tel Aviv|Haifa".
+	listSeparator = "|"
+	// maintainerSeparator splits a maintainer cell entry into phone and name,
+	// e.g. "+972501234567:Dana".
+	maintainerSeparator = ":"
+)
+
+// Record is one business unit of an import file with the schedules to open
+// for it. Line is where the business first appears in the file.
+type Record struct {
+	Line         int                `json:"-"`
+	BusinessUnit model.BusinessUnit `json:"business_unit"`
+	Schedules    []ScheduleRecord   `json:"schedules,omitempty"`
+}
+
+// ScheduleRecord is a schedule of a Record and the line it was read from.
+type ScheduleRecord struct {
+	model.Schedule
+	Line int `json:"-"`
+}
+
+// RowError reports a line of the file that could not be parsed.
+type RowError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// Parse reads the records of a CSV or JSONL file. Lines that cannot be parsed
+// are reported as RowErrors; the error is only set when the file as a whole
+// is unreadable.
+func Parse(r io.Reader, format string) ([]Record, []RowError, error) {
+	switch format {
+	case FormatCSV:
+		return ParseCSV(r)
+	case FormatJSONL:
+		return ParseJSONL(r)
+	default:
+		return nil, nil, fmt.Errorf("unsupported import format %q, expected %s or %s", format, FormatCSV, FormatJSONL)
+	}
+}
+
+// ParseJSONL reads one record per line in the shape of Record, e.g.
+// {"business_unit": {...}, "schedules": [{...}]}. Blank lines are skipped.
+func ParseJSONL(r io.Reader) ([]Record, []RowError, error) {
+	var records []Record
+	var rowErrors []RowError
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		var record Record
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&record); err != nil {
+			rowErrors = append(rowErrors, RowError{Line: line, Message: fmt.Sprintf("invalid JSON: %v", err)})
+			continue
+		}
+		record.Line = line
+		for i := range record.Schedules {
+			record.Schedules[i].Line = line
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+	return records, rowErrors, nil
+}
+
+var (
+	businessColumns = []string{
+		"name", "admin_phone", "cities", "labels", "maintainers", "priority",
+		"time_zone", "website_urls", "organization_id", "status",
+	}
+	scheduleColumns = []string{
+		"schedule_name", "schedule_city", "schedule_address", "schedule_time_zone",
+		"start_of_day", "end_of_day", "working_days", "meeting_duration_min",
+		"break_duration_min", "max_participants_per_slot",
+	}
+)
+
+// ParseCSV reads a spreadsheet export with a header row. Each row holds a
+// business unit and optionally one of its schedules; rows with the same
+// admin phone and name belong to the same business, whose details are taken
+// from its first row. Multi-valued cells are separated by "|", and
+// maintainers are written as phone:name.
+func ParseCSV(r io.Reader) ([]Record, []RowError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, fmt.Errorf("import file is empty")
+		}
+		return nil, nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns, err := csvColumns(header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var records []Record
+	var rowErrors []RowError
+	byKey := map[string]int{}
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrors = append(rowErrors, RowError{Line: parseErr.Line, Message: parseErr.Err.Error()})
+				continue
+			}
+			return nil, nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		row := csvRow{columns: columns, fields: fields}
+		if row.empty() {
+			continue
+		}
+
+		bu, err := row.businessUnit()
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{Line: line, Message: err.Error()})
+			continue
+		}
+		sc, err := row.schedule()
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{Line: line, Message: err.Error()})
+			continue
+		}
+
+		key := recordKey(&bu)
+		i, seen := byKey[key]
+		if !seen {
+			i = len(records)
+			byKey[key] = i
+			records = append(records, Record{Line: line, BusinessUnit: bu})
+		}
+		if sc != nil {
+			records[i].Schedules = append(records[i].Schedules, ScheduleRecord{Schedule: *sc, Line: line})
+		}
+	}
+	return records, rowErrors, nil
+}
+
+func csvColumns(header []string) (map[string]int, error) {
+	known := map[string]struct{}{}
+	for _, column := range append(append([]string{}, businessColumns...), scheduleColumns...) {
+		known[column] = struct{}{}
+	}
+
+	columns := map[string]int{}
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		if _, ok := known[column]; !ok {
+			return nil, fmt.Errorf("unknown CSV column %q", column)
+		}
+		if _, dup := columns[column]; dup {
+			return nil, fmt.Errorf("duplicate CSV column %q", column)
+		}
+		columns[column] = i
+	}
+	for _, required := range []string{"name", "admin_phone"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing required CSV column %q", required)
+		}
+	}
+	return columns, nil
+}
+
+type csvRow struct {
+	columns map[string]int
+	fields  []string
+}
+
+func (r csvRow) get(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.fields) {
+		return ""
+	}
+	return strings.TrimSpace(r.fields[i])
+}
+
+func (r csvRow) list(column string) []string {
+	value := r.get(column)
+	if value == "" {
+		return nil
+	}
+	var values []string
+	for _, v := range strings.Split(value, listSeparator) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func (r csvRow) number(column string) (int, error) {
+	value := r.get(column)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a whole number, got %q", column, value)
+	}
+	return n, nil
+}
+
+func (r csvRow) empty() bool {
+	for _, field := range r.fields {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (r csvRow) businessUnit() (model.BusinessUnit, error) {
+	priority, err := r.number("priority")
+	if err != nil {
+		return model.BusinessUnit{}, err
+	}
+	var maintainers map[string]string
+	for _, entry := range r.list("maintainers") {
+		phone, name, ok := strings.Cut(entry, maintainerSeparator)
+		if !ok {
+			return model.BusinessUnit{}, fmt.Errorf("maintainer %q must be written as phone%sname", entry, maintainerSeparator)
+		}
+		if maintainers == nil {
+			maintainers = map[string]string{}
+		}
+		maintainers[strings.TrimSpace(phone)] = strings.TrimSpace(name)
+	}
+	return model.BusinessUnit{
+		Name:           r.get("name"),
+		AdminPhone:     r.get("admin_phone"),
+		Cities:         r.list("cities"),
+		Labels:         r.list("labels"),
+		Maintainers:    maintainers,
+		Priority:       int64(priority),
+		TimeZone:       r.get("time_zone"),
+		WebsiteURLs:    r.list("website_urls"),
+		OrganizationID: r.get("organization_id"),
+		Status:         strings.ToLower(r.get("status")),
+	}, nil
+}
+
+// schedule returns the row's schedule, or nil when its schedule columns are
+// all blank.
+func (r csvRow) schedule() (*model.Schedule, error) {
+	blank := true
+	for _, column := range scheduleColumns {
+		if r.get(column) != "" {
+			blank = false
+			break
+		}
+	}
+	if blank {
+		return nil, nil
+	}
+
+	meeting, err := r.number("meeting_duration_min")
+	if err != nil {
+		return nil, err
+	}
+	breakMin, err := r.number("break_duration_min")
+	if err != nil {
+		return nil, err
+	}
+	participants, err := r.number("max_participants_per_slot")
+	if err != nil {
+		return nil, err
+	}
+	return &model.Schedule{
+		Name:                      r.get("schedule_name"),
+		City:                      r.get("schedule_city"),
+		Address:                   r.get("schedule_address"),
+		TimeZone:                  r.get("schedule_time_zone"),
+		StartOfDay:                r.get("start_of_day"),
+		EndOfDay:                  r.get("end_of_day"),
+		WorkingDays:               r.list("working_days"),
+		DefaultMeetingDurationMin: meeting,
+		DefaultBreakDurationMin:   breakMin,
+		MaxParticipantsPerSlot:    participants,
+	}, nil
+}
+
+// recordKey identifies a business within an import and against the units
+// already stored: the same admin phone running a business of the same name.
+func recordKey(bu *model.BusinessUnit) string {
+	return sanitizer.SanitizePhone(bu.AdminPhone) + "/" + strings.ToLower(sanitizer.SanitizeNameOrAddress(bu.Name))
+}
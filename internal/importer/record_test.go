@@ -0,0 +1,104 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCSV_GroupsSchedulesByBusiness(t *testing.T) {
+	input := "\ufeffName,Admin_Phone,cities,labels,maintainers,priority,schedule_name,schedule_city,working_days,meeting_duration_min\n" +
+		"Salon Dana,+972501234567,Tel Aviv|Haifa,Haircut| Styling ,+972541111111:Noa,3,Center,Tel Aviv,sunday|monday,30\n" +
+		",,,,,,,,,\n" +
+		"Salon Dana,+972501234567,,,,,North,Haifa,,\n" +
+		"Barber Avi,+972502222222,Jerusalem,Haircut,,,,,,\n"
+
+	records, rowErrors, err := ParseCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rowErrors) != 0 {
+		t.Fatalf("unexpected row errors: %v", rowErrors)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+
+	dana := records[0]
+	if dana.Line != 2 || dana.BusinessUnit.Name != "Salon Dana" || dana.BusinessUnit.Priority != 3 {
+		t.Errorf("unexpected first record: line %d, %+v", dana.Line, dana.BusinessUnit)
+	}
+	if got := strings.Join(dana.BusinessUnit.Cities, ","); got != "Tel Aviv,Haifa" {
+		t.Errorf("expected cities split on |, got %q", got)
+	}
+	if got := strings.Join(dana.BusinessUnit.Labels, ","); got != "Haircut,Styling" {
+		t.Errorf("expected trimmed labels, got %q", got)
+	}
+	if dana.BusinessUnit.Maintainers["+972541111111"] != "Noa" {
+		t.Errorf("expected maintainer Noa, got %v", dana.BusinessUnit.Maintainers)
+	}
+	if len(dana.Schedules) != 2 {
+		t.Fatalf("expected 2 schedules for the first business, got %d", len(dana.Schedules))
+	}
+	center, north := dana.Schedules[0], dana.Schedules[1]
+	if center.Name != "Center" || center.Line != 2 || center.DefaultMeetingDurationMin != 30 || len(center.WorkingDays) != 2 {
+		t.Errorf("unexpected first schedule: %+v", center)
+	}
+	if north.Name != "North" || north.City != "Haifa" || north.Line != 4 {
+		t.Errorf("unexpected second schedule: %+v", north)
+	}
+
+	if avi := records[1]; avi.Line != 5 || len(avi.Schedules) != 0 {
+		t.Errorf("expected a business without schedules on line 5, got line %d with %d schedules", avi.Line, len(avi.Schedules))
+	}
+}
+
+func TestParseCSV_RowErrors(t *testing.T) {
+	input := "name,admin_phone,priority,maintainers,schedule_name,max_participants_per_slot\n" +
+		"Bad Priority,+972501234567,high,,,\n" +
+		"Bad Maintainer,+972501234568,,+972541111111,,\n" +
+		"Bad Capacity,+972501234569,,,Center,many\n" +
+		"Good,+972501234570,1,,Center,4\n"
+
+	records, rowErrors, err := ParseCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 1 || records[0].BusinessUnit.Name != "Good" || records[0].Schedules[0].MaxParticipantsPerSlot != 4 {
+		t.Errorf("expected only the good record, got %+v", records)
+	}
+	expected := []struct {
+		line    int
+		message string
+	}{
+		{2, "priority must be a whole number"},
+		{3, "must be written as phone:name"},
+		{4, "max_participants_per_slot must be a whole number"},
+	}
+	if len(rowErrors) != len(expected) {
+		t.Fatalf("expected %d row errors, got %v", len(expected), rowErrors)
+	}
+	for i, want := range expected {
+		if rowErrors[i].Line != want.line || !strings.Contains(rowErrors[i].Message, want.message) {
+			t.Errorf("row error %d: expected line %d containing %q, got %v", i, want.line, want.message, rowErrors[i])
+		}
+	}
+}
+
+func TestParseCSV_Header(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"empty file", "", "import file is empty"},
+		{"unknown column", "name,admin_phone,color\n", `unknown CSV column "color"`},
+		{"duplicate column", "name,admin_phone,Name\n", `duplicate CSV column "name"`},
+		{"missing admin phone", "name,cities\n", `missing required CSV column "admin_phone"`},
+	}
+	for _, tc := range cases {
+		_, _, err := ParseCSV(strings.NewReader(tc.input))
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%s: expected error containing %q, got %v", tc.name, tc.err, err)
+		}
+	}
+}
@@ -2,14 +2,21 @@ package api
 
 import (
 	"net/http"
+	"skeji/internal/maestro/declarative"
 	"skeji/internal/maestro/handlers"
 	"skeji/internal/maestro/service"
 	"skeji/pkg/client"
 	"skeji/pkg/logger"
+	"skeji/pkg/ranking"
 )
 
-func SetupRouter(client *client.Client, log *logger.Logger) *http.ServeMux {
-	maestroService := service.NewMaestroService(client, log)
+// SetupRouter serves the Go flows and the given declarative flows; it fails
+// when a declarative flow takes the name of another flow.
+func SetupRouter(client *client.Client, ranker *ranking.Ranker, definitions []*declarative.Flow, log *logger.Logger) (*http.ServeMux, error) {
+	maestroService := service.NewMaestroService(client, ranker, log)
+	if err := maestroService.Register(definitions); err != nil {
+		return nil, err
+	}
 	flowHandler := handlers.NewFlowHandler(maestroService, log)
 	mux := http.NewServeMux()
 	mux.HandleFunc("/api/v1/maestro/execute", flowHandler.ExecuteFlow)
@@ -19,5 +26,5 @@ func SetupRouter(client *client.Client, log *logger.Logger) *http.ServeMux {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{"status":"healthy"}`))
 	})
-	return mux
+	return mux, nil
 }
@@ -1,7 +1,5 @@
 package core
 
-import "fmt"
-
 const (
 	MAX_CONCURRENT_API_CALLS = 40
 )
@@ -35,5 +33,5 @@ func IsMissing(str string) bool {
 }
 
 func MissingParamErr(paramName string) error {
-	return fmt.Errorf("required param [%v] is missing", paramName)
+	return &InputError{Errors: []FieldError{{Field: paramName, Message: "is required"}}}
 }
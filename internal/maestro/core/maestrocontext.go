@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"skeji/pkg/client"
 	"skeji/pkg/logger"
+	"skeji/pkg/ranking"
 	"time"
 )
 
@@ -14,16 +15,19 @@ type MaestroContext struct {
 	Process map[string]any
 	Output  map[string]any
 	Client  *client.Client
+	Ranker  *ranking.Ranker
 	Logger  *logger.Logger
 }
 
-func NewMaestroContext(ctx context.Context, input map[string]any, client *client.Client, logger *logger.Logger) *MaestroContext {
+func NewMaestroContext(ctx context.Context, input map[string]any, client *client.Client, ranker *ranking.Ranker, logger *logger.Logger) *MaestroContext {
 	return &MaestroContext{
-		Ctx:    ctx,
-		Input:  input,
-		Output: make(map[string]any),
-		Client: client,
-		Logger: logger,
+		Ctx:     ctx,
+		Input:   input,
+		Process: make(map[string]any),
+		Output:  make(map[string]any),
+		Client:  client,
+		Ranker:  ranker,
+		Logger:  logger,
 	}
 }
 
@@ -45,6 +49,21 @@ func (ctx *MaestroContext) ExtractString(key string) string {
 	return ""
 }
 
+// ExtractFloat returns a numeric input and whether it was present.
+func (ctx *MaestroContext) ExtractFloat(key string) (float64, bool) {
+	if val, exists := ctx.Input[key]; exists && val != nil {
+		switch v := val.(type) {
+		case float64:
+			return v, true
+		case int:
+			return float64(v), true
+		case int64:
+			return float64(v), true
+		}
+	}
+	return 0, false
+}
+
 func (ctx *MaestroContext) ExtractStringList(key string) []string {
 	if val, exists := ctx.Input[key]; exists && val != nil {
 		if interfaceList, ok := val.([]any); ok {
@@ -0,0 +1,377 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Field types, as in JSON Schema. A field without a type takes any value.
+const (
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeArray   = "array"
+	TypeObject  = "object"
+)
+
+// Formats of string fields.
+const (
+	// FormatDateTime is an RFC3339 time.
+	FormatDateTime = "date-time"
+	// FormatPhone is a phone number in E.164 format.
+	FormatPhone = "phone"
+)
+
+var (
+	fieldTypes   = []string{TypeString, TypeInteger, TypeNumber, TypeBoolean, TypeArray, TypeObject}
+	fieldFormats = []string{FormatDateTime, FormatPhone}
+
+	formatValidator = validator.New()
+)
+
+// Field describes one input or output value of a flow.
+type Field struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	// Format applies to strings and, through Items, to array items.
+	Format string `json:"format,omitempty"`
+	// Items is the type of the items of an array.
+	Items string   `json:"items,omitempty"`
+	Enum  []string `json:"enum,omitempty"`
+
+	Minimum   *float64 `json:"minimum,omitempty"`
+	Maximum   *float64 `json:"maximum,omitempty"`
+	MinLength int      `json:"min_length,omitempty"`
+	MaxLength int      `json:"max_length,omitempty"`
+	MinItems  int      `json:"min_items,omitempty"`
+	MaxItems  int      `json:"max_items,omitempty"`
+}
+
+// Schema describes the values a flow takes or returns.
+type Schema struct {
+	Fields []Field `json:"fields,omitempty"`
+	// AnyOf lists groups of fields of which at least one must be given, such
+	// as labels or query.
+	AnyOf [][]string `json:"any_of,omitempty"`
+	// Together lists groups of fields given all or none, such as lat and lng.
+	Together [][]string `json:"together,omitempty"`
+}
+
+// FlowSpec is what a flow tells about itself in the flow catalog.
+type FlowSpec struct {
+	Description string
+	Input       Schema
+	Output      Schema
+}
+
+// FieldError is one problem with one input field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// InputError reports every problem found in a flow input.
+type InputError struct {
+	Errors []FieldError
+}
+
+func (e *InputError) Error() string {
+	problems := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		problems[i] = fe.Field + ": " + fe.Message
+	}
+	return "invalid input: " + strings.Join(problems, "; ")
+}
+
+// NewInputError reports a single field at fault, for problems a flow finds
+// beyond its schema.
+func NewInputError(field, message string) *InputError {
+	return &InputError{Errors: []FieldError{{Field: field, Message: message}}}
+}
+
+// Bound returns a pointer to a bound, for Field.Minimum and Field.Maximum.
+func Bound(bound float64) *float64 {
+	return &bound
+}
+
+// Check reports a schema that cannot be validated against: unknown types
+// and formats, and any_of and together groups naming undeclared fields.
+func (s Schema) Check() error {
+	names := map[string]bool{}
+	for _, field := range s.Fields {
+		if field.Name == "" {
+			return fmt.Errorf("field without a name")
+		}
+		if names[field.Name] {
+			return fmt.Errorf("field %s is declared twice", field.Name)
+		}
+		names[field.Name] = true
+		if field.Type != "" && !slices.Contains(fieldTypes, field.Type) {
+			return fmt.Errorf("field %s: unknown type %q, must be one of %s", field.Name, field.Type, strings.Join(fieldTypes, ", "))
+		}
+		if field.Items != "" && (field.Type != TypeArray || !slices.Contains(fieldTypes, field.Items)) {
+			return fmt.Errorf("field %s: items must be a type and only apply to arrays", field.Name)
+		}
+		if field.Format != "" && !slices.Contains(fieldFormats, field.Format) {
+			return fmt.Errorf("field %s: unknown format %q, must be one of %s", field.Name, field.Format, strings.Join(fieldFormats, ", "))
+		}
+	}
+	for _, group := range s.AnyOf {
+		for _, name := range group {
+			if !names[name] {
+				return fmt.Errorf("any_of names undeclared field %s", name)
+			}
+		}
+	}
+	for _, group := range s.Together {
+		for _, name := range group {
+			if !names[name] {
+				return fmt.Errorf("together names undeclared field %s", name)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks the whole input against the schema and returns an
+// *InputError listing every problem, in field order, or nil. Fields the
+// schema does not declare are left alone.
+func (s Schema) Validate(input map[string]any) error {
+	problems := []FieldError{}
+	for _, field := range s.Fields {
+		value, given := input[field.Name]
+		if field.Required && !present(value) {
+			problems = append(problems, FieldError{Field: field.Name, Message: "is required"})
+			continue
+		}
+		if !given || value == nil || value == "" {
+			if field.Required {
+				problems = append(problems, FieldError{Field: field.Name, Message: "is required"})
+			}
+			continue
+		}
+		if message := field.check(value); message != "" {
+			problems = append(problems, FieldError{Field: field.Name, Message: message})
+		}
+	}
+	for _, group := range s.AnyOf {
+		if !slices.ContainsFunc(group, func(name string) bool { return present(input[name]) }) {
+			problems = append(problems, FieldError{
+				Field:   group[0],
+				Message: fmt.Sprintf("one of %s is required", strings.Join(group, ", ")),
+			})
+		}
+	}
+	for _, group := range s.Together {
+		missing := slices.DeleteFunc(slices.Clone(group), func(name string) bool { return present(input[name]) })
+		if len(missing) > 0 && len(missing) < len(group) {
+			problems = append(problems, FieldError{
+				Field:   missing[0],
+				Message: fmt.Sprintf("%s must be given together", strings.Join(group, " and ")),
+			})
+		}
+	}
+	if len(problems) > 0 {
+		return &InputError{Errors: problems}
+	}
+	return nil
+}
+
+// present reports whether a value counts as given: blank strings and lists
+// of nothing but blank strings do not.
+func present(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case string:
+		return strings.TrimSpace(v) != ""
+	case []any:
+		return slices.ContainsFunc(v, present)
+	}
+	return true
+}
+
+// check returns what is wrong with a given value, empty when nothing is.
+func (f Field) check(value any) string {
+	switch f.Type {
+	case TypeString:
+		s, ok := value.(string)
+		if !ok {
+			return "must be a string"
+		}
+		if f.MinLength > 0 && len([]rune(s)) < f.MinLength {
+			return fmt.Sprintf("must be at least %d characters", f.MinLength)
+		}
+		if f.MaxLength > 0 && len([]rune(s)) > f.MaxLength {
+			return fmt.Sprintf("must be at most %d characters", f.MaxLength)
+		}
+		return f.checkString(s)
+	case TypeInteger, TypeNumber:
+		n, ok := number(value)
+		if !ok {
+			return "must be a number"
+		}
+		if f.Type == TypeInteger && n != math.Trunc(n) {
+			return "must be a whole number"
+		}
+		if f.Minimum != nil && n < *f.Minimum {
+			return fmt.Sprintf("must be at least %v", *f.Minimum)
+		}
+		if f.Maximum != nil && n > *f.Maximum {
+			return fmt.Sprintf("must be at most %v", *f.Maximum)
+		}
+	case TypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return "must be true or false"
+		}
+	case TypeObject:
+		if _, ok := value.(map[string]any); !ok {
+			return "must be an object"
+		}
+	case TypeArray:
+		items, ok := value.([]any)
+		if !ok {
+			return "must be an array"
+		}
+		if f.MinItems > 0 && len(items) < f.MinItems {
+			return fmt.Sprintf("must have at least %d items", f.MinItems)
+		}
+		if f.MaxItems > 0 && len(items) > f.MaxItems {
+			return fmt.Sprintf("must have at most %d items", f.MaxItems)
+		}
+		if f.Items == "" {
+			return ""
+		}
+		item := Field{Type: f.Items, Format: f.Format, Enum: f.Enum}
+		for i, v := range items {
+			if message := item.check(v); message != "" {
+				return fmt.Sprintf("item %d %s", i, message)
+			}
+		}
+	}
+	return ""
+}
+
+// number reads a number as decoded from JSON or set in Go.
+func number(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func (f Field) checkString(s string) string {
+	if len(f.Enum) > 0 && !slices.Contains(f.Enum, s) {
+		return fmt.Sprintf("must be one of %s", strings.Join(f.Enum, ", "))
+	}
+	switch f.Format {
+	case FormatDateTime:
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			return "must be an RFC3339 time, e.g. 2025-11-19T14:00:00Z"
+		}
+	case FormatPhone:
+		if formatValidator.Var(s, "e164") != nil {
+			return "must be a phone number in E.164 format, e.g. +972501234567"
+		}
+	}
+	return ""
+}
+
+// JSONSchema returns the schema as a JSON Schema object.
+func (s Schema) JSONSchema() map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+	for _, field := range s.Fields {
+		properties[field.Name] = field.jsonSchema()
+		if field.Required {
+			required = append(required, field.Name)
+		}
+	}
+	schema := map[string]any{
+		"$schema":    "https://json-schema.org/draft/2020-12/schema",
+		"type":       TypeObject,
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	if len(s.AnyOf) > 0 {
+		anyOf := make([]any, 0, len(s.AnyOf))
+		for _, group := range s.AnyOf {
+			alternatives := make([]any, len(group))
+			for i, name := range group {
+				alternatives[i] = map[string]any{"required": []string{name}}
+			}
+			anyOf = append(anyOf, map[string]any{"anyOf": alternatives})
+		}
+		schema["allOf"] = anyOf
+	}
+	if len(s.Together) > 0 {
+		dependentRequired := map[string][]string{}
+		for _, group := range s.Together {
+			for _, name := range group {
+				others := slices.DeleteFunc(slices.Clone(group), func(other string) bool { return other == name })
+				dependentRequired[name] = append(dependentRequired[name], others...)
+			}
+		}
+		schema["dependentRequired"] = dependentRequired
+	}
+	return schema
+}
+
+func (f Field) jsonSchema() map[string]any {
+	property := map[string]any{}
+	if f.Type != "" {
+		property["type"] = f.Type
+	}
+	if f.Description != "" {
+		property["description"] = f.Description
+	}
+	if f.Type == TypeArray {
+		if f.Items != "" {
+			property["items"] = Field{Type: f.Items, Format: f.Format, Enum: f.Enum}.jsonSchema()
+		}
+		if f.MinItems > 0 {
+			property["minItems"] = f.MinItems
+		}
+		if f.MaxItems > 0 {
+			property["maxItems"] = f.MaxItems
+		}
+		return property
+	}
+	if len(f.Enum) > 0 {
+		property["enum"] = f.Enum
+	}
+	switch f.Format {
+	case FormatDateTime:
+		property["format"] = FormatDateTime
+	case FormatPhone:
+		property["pattern"] = `^\+[1-9]\d{1,14}$`
+	}
+	if f.Minimum != nil {
+		property["minimum"] = *f.Minimum
+	}
+	if f.Maximum != nil {
+		property["maximum"] = *f.Maximum
+	}
+	if f.MinLength > 0 {
+		property["minLength"] = f.MinLength
+	}
+	if f.MaxLength > 0 {
+		property["maxLength"] = f.MaxLength
+	}
+	return property
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+var testSchema = Schema{
+	Fields: []Field{
+		{Name: "phone", Type: TypeString, Format: FormatPhone, Required: true},
+		{Name: "cities", Type: TypeArray, Items: TypeString, MinItems: 1},
+		{Name: "labels", Type: TypeArray, Items: TypeString},
+		{Name: "query", Type: TypeString},
+		{Name: "start", Type: TypeString, Format: FormatDateTime},
+		{Name: "duration", Type: TypeInteger, Minimum: Bound(5)},
+		{Name: "lat", Type: TypeNumber},
+		{Name: "lng", Type: TypeNumber},
+	},
+	AnyOf:    [][]string{{"labels", "query"}},
+	Together: [][]string{{"lat", "lng"}},
+}
+
+func TestValidate_ReportsEveryField(t *testing.T) {
+	err := testSchema.Validate(map[string]any{
+		"cities":   []any{},
+		"start":    "tomorrow",
+		"duration": float64(2.5),
+	})
+	var inputErr *InputError
+	if !errors.As(err, &inputErr) {
+		t.Fatalf("expected an input error, got %v", err)
+	}
+	want := []string{"phone", "cities", "start", "duration", "labels"}
+	if len(inputErr.Errors) != len(want) {
+		t.Fatalf("expected errors for %v, got %v", want, inputErr.Errors)
+	}
+	for i, field := range want {
+		if inputErr.Errors[i].Field != field {
+			t.Errorf("expected error %d on %s, got %v", i, field, inputErr.Errors[i])
+		}
+	}
+}
+
+func TestValidate_AcceptsValidInput(t *testing.T) {
+	err := testSchema.Validate(map[string]any{
+		"phone":    "+972501234567",
+		"cities":   []any{"tel aviv"},
+		"query":    "salon",
+		"start":    "2025-11-27T15:30:00Z",
+		"duration": float64(30),
+		"extra":    true,
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	schema := testSchema.JSONSchema()
+	if required, _ := schema["required"].([]string); len(required) != 1 || required[0] != "phone" {
+		t.Errorf("expected phone to be required, got %v", schema["required"])
+	}
+	properties := schema["properties"].(map[string]any)
+	cities := properties["cities"].(map[string]any)
+	if cities["type"] != TypeArray || cities["minItems"] != 1 || cities["items"].(map[string]any)["type"] != TypeString {
+		t.Errorf("unexpected cities schema %v", cities)
+	}
+	if _, ok := schema["allOf"]; !ok {
+		t.Error("expected labels or query as an anyOf")
+	}
+	dependent, _ := schema["dependentRequired"].(map[string][]string)
+	if len(dependent["lat"]) != 1 || dependent["lat"][0] != "lng" || len(dependent["lng"]) != 1 || dependent["lng"][0] != "lat" {
+		t.Errorf("expected lat and lng to require each other, got %v", schema["dependentRequired"])
+	}
+}
+
+func TestValidate_CrossFieldRules(t *testing.T) {
+	cases := []struct {
+		name  string
+		input map[string]any
+		field string
+	}{
+		{"blank labels do not count", map[string]any{"phone": "+972501234567", "labels": []any{" "}}, "labels"},
+		{"lat without lng", map[string]any{"phone": "+972501234567", "query": "salon", "lat": float64(32)}, "lng"},
+		{"lng without lat", map[string]any{"phone": "+972501234567", "query": "salon", "lng": float64(34)}, "lat"},
+	}
+	for _, tc := range cases {
+		var inputErr *InputError
+		if err := testSchema.Validate(tc.input); !errors.As(err, &inputErr) {
+			t.Errorf("%s: expected an input error, got %v", tc.name, err)
+			continue
+		}
+		if len(inputErr.Errors) != 1 || inputErr.Errors[0].Field != tc.field {
+			t.Errorf("%s: expected a single error on %s, got %v", tc.name, tc.field, inputErr.Errors)
+		}
+	}
+
+	err := testSchema.Validate(map[string]any{"phone": "+972501234567", "query": "salon", "lat": float64(32), "lng": float64(34)})
+	if err != nil {
+		t.Errorf("unexpected error with lat and lng together: %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+package declarative
+
+import (
+	"encoding/json"
+	"fmt"
+	"skeji/pkg/client"
+	"sort"
+	"strconv"
+)
+
+// DefaultLimit is the page size of search actions called without a limit.
+const DefaultLimit = 50
+
+// CallerArg is the argument every action takes to act as a phone instead of
+// the maestro service.
+const CallerArg = "as"
+
+// action calls one client method. params are the arguments it takes,
+// required those it cannot do without. Actions that mutate records must act
+// as a phone: the maestro service itself passes every role check.
+type action struct {
+	params   []string
+	required []string
+	mutates  bool
+	call     func(c *client.Client, args arguments) (*client.Response, error)
+}
+
+// actions are what flow steps can call, by "<service>.<operation>".
+var actions = map[string]action{
+	"business_units.get": {
+		params: []string{"id"}, required: []string{"id"},
+		call: func(c *client.Client, args arguments) (*client.Response, error) {
+			return c.BusinessUnitClient.GetByID(args.str("id"))
+		},
+	},
+	"business_units.search": {
+		params: []string{"cities", "labels", "limit", "offset"}, required: []string{"cities", "labels"},
+		call: func(c *client.Client, args arguments) (*client.Response, error) {
+			return c.BusinessUnitClient.Search(args.strs("cities"), args.strs("labels"), args.limit(), args.offset())
+		},
+	},
+	"business_units.text_search": {
+		params: []string{"query", "cities", "limit", "offset"}, required: []string{"query"},
+		call: func(c *client.Client, args arguments) (*client.Response, error) {
+			return c.BusinessUnitClient.TextSearch(args.str("query"), args.strs("cities"), args.limit(), args.offset())
+		},
+	},
+	"business_units.by_phone": {
+		params: []string{"phone", "cities", "labels", "limit", "offset"}, required: []string{"phone"},
+		call: func(c *client.Client, args arguments) (*client.Response, error) {
+			return c.BusinessUnitClient.GetByPhone(args.str("phone"), args.strs("cities"), args.strs("labels"), args.limit(), args.offset())
+		},
+	},
+	"business_units.create": {
+		params: []string{"body"}, required: []string{"body"},
+		mutates: true,
+		call: func(c *client.Client, args arguments) (*client.Response, error) {
+			return c.BusinessUnitClient.Create(args["body"])
+		},
+	},
+	"business_units.update": {
+		params: []string{"id", "body"}, required: []string{"id", "body"},
+		mutates: true,
+		call: func(c *client.Client, args arguments) (*client.Response, error) {
+			return c.BusinessUnitClient.Update(args.str("id"), args["body"])
+		},
+	},
+	"schedules.get": {
+		params: []string{"id"}, required: []string{"id"},
+		call: func(c *client.Client, args arguments) (*client.Response, error) {
+			return c.ScheduleClient.GetByID(args.str("id"))
+		},
+	},
+	"schedules.search": {
+		params: []string{"business_id", "city", "limit", "offset"}, required: []string{"business_id"},
+		call: func(c *client.Client, args arguments) (*client.Response, error) {
+			return c.ScheduleClient.Search(args.str("business_id"), args.str("city"), args.limit(), args.offset())
+		},
+	},
+	"schedules.availability": {
+		params: []string{"id", "from", "to", "service", "staff"}, required: []string{"id"},
+		call: func(c *client.Client, args arguments) (*client.Response, error) {
+			return c.ScheduleClient.GetAvailability(args.str("id"), args.str("from"), args.str("to"), args.str("service"), args.str("staff"))
+		},
+	},
+	"schedules.create": {
+		params: []string{"body"}, required: []string{"body"},
+		mutates: true,
+		call: func(c *client.Client, args arguments) (*client.Response, error) {
+			return c.ScheduleClient.Create(args["body"])
+		},
+	},
+	"bookings.get": {
+		params: []string{"id"}, required: []string{"id"},
+		call: func(c *client.Client, args arguments) (*client.Response, error) {
+			return c.BookingClient.GetByID(args.str("id"))
+		},
+	},
+	"bookings.search": {
+		params: []string{"business_id", "schedule_id", "start", "end", "limit", "offset"}, required: []string{"business_id"},
+		call: func(c *client.Client, args arguments) (*client.Response, error) {
+			return c.BookingClient.Search(args.str("business_id"), args.str("schedule_id"), args.str("start"), args.str("end"), args.limit(), args.offset())
+		},
+	},
+	"bookings.create": {
+		params: []string{"body"}, required: []string{"body"},
+		mutates: true,
+		call: func(c *client.Client, args arguments) (*client.Response, error) {
+			return c.BookingClient.Create(args["body"])
+		},
+	},
+	"bookings.update": {
+		params: []string{"id", "body"}, required: []string{"id", "body"},
+		mutates: true,
+		call: func(c *client.Client, args arguments) (*client.Response, error) {
+			return c.BookingClient.Update(args.str("id"), args["body"])
+		},
+	},
+}
+
+func init() {
+	for name, a := range actions {
+		a.params = append(a.params, CallerArg)
+		if a.mutates {
+			a.required = append(a.required, CallerArg)
+		}
+		actions[name] = a
+	}
+}
+
+// Actions returns the names of the actions steps can call, sorted.
+func Actions() []string {
+	names := make([]string, 0, len(actions))
+	for name := range actions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// callAction runs the action and returns the data of its response, decoded
+// as plain JSON values. Responses outside 2xx are errors. A mutating action
+// whose caller resolves empty is refused rather than run as the service.
+func callAction(c *client.Client, name string, args arguments) (any, error) {
+	caller := args.str(CallerArg)
+	if caller != "" {
+		c = c.As(caller)
+	} else if actions[name].mutates {
+		return nil, fmt.Errorf("%s needs a phone to act as", name)
+	}
+	resp, err := actions[name].call(c, args)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s failed with status %d: %s", name, resp.StatusCode, client.GetErrorMessage(resp))
+	}
+	if len(resp.Body) == 0 {
+		return nil, nil
+	}
+	var body struct {
+		Data any `json:"data"`
+	}
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return nil, fmt.Errorf("failed to decode %s response: %w", name, err)
+	}
+	return body.Data, nil
+}
+
+// arguments are resolved step arguments.
+type arguments map[string]any
+
+func (a arguments) str(name string) string {
+	switch v := a[name].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// strs reads a list argument; a single string is a list of one.
+func (a arguments) strs(name string) []string {
+	switch v := a[name].(type) {
+	case string:
+		return []string{v}
+	case []any:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+func (a arguments) num(name string) (int64, bool) {
+	switch v := a[name].(type) {
+	case float64:
+		return int64(v), true
+	case int:
+		return int64(v), true
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func (a arguments) limit() int {
+	if n, ok := a.num("limit"); ok && n > 0 {
+		return int(n)
+	}
+	return DefaultLimit
+}
+
+func (a arguments) offset() int64 {
+	n, _ := a.num("offset")
+	return n
+}
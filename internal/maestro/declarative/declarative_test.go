@@ -0,0 +1,137 @@
+package declarative
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	maestro "skeji/internal/maestro/core"
+	"skeji/pkg/client"
+	"skeji/pkg/logger"
+)
+
+func TestLoadDir_ExampleDefinitions(t *testing.T) {
+	flows, err := LoadDir("../flows/definitions")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(flows) != 2 || flows[0].Name != "cancel_booking" || flows[1].Name != "get_business" {
+		t.Errorf("expected cancel_booking and get_business, got %d flows", len(flows))
+	}
+}
+
+func TestLoadDir_RejectsMutatingCallWithoutCaller(t *testing.T) {
+	dir := t.TempDir()
+	definition := "name: open_salon\nsteps:\n  - name: create\n    call: business_units.create\n    args: {body: {name: Salon}}\n"
+	if err := os.WriteFile(filepath.Join(dir, "open_salon.yaml"), []byte(definition), 0o600); err != nil {
+		t.Fatalf("failed to write flow: %v", err)
+	}
+	_, err := LoadDir(dir)
+	if err == nil || !strings.Contains(err.Error(), `needs argument "as"`) {
+		t.Errorf("expected the flow to be rejected for acting as the service, got %v", err)
+	}
+}
+
+func TestParse_RejectsInvalidFlows(t *testing.T) {
+	cases := map[string]string{
+		"unknown field":    "name: f\nsteps:\n  - name: a\n    fail: x\n    retries: 3\n",
+		"unknown action":   "name: f\nsteps:\n  - name: a\n    call: bookings.delete_all\n",
+		"unknown argument": "name: f\nsteps:\n  - name: a\n    call: bookings.get\n    args: {id: x, force: true}\n",
+		"missing argument": "name: f\nsteps:\n  - name: a\n    call: bookings.get\n",
+		"two kinds":        "name: f\nsteps:\n  - name: a\n    fail: x\n    set: {b: c}\n",
+		"unknown next":     "name: f\nsteps:\n  - name: a\n    fail: x\n    next: b\n",
+		"bad template":     "name: f\nsteps:\n  - name: a\n    fail: \"{{ env.HOME }}\"\n",
+		"duplicate step":   "name: f\nsteps:\n  - name: a\n    fail: x\n  - name: a\n    fail: y\n",
+		"no steps":         "name: f\n",
+		"unknown type":     "name: f\ninputs:\n  - name: a\n    type: text\nsteps:\n  - name: a\n    fail: x\n",
+		"outputs unset":    "name: f\nsteps:\n  - name: a\n    fail: x\noutputs:\n  - name: b\n",
+	}
+	for name, definition := range cases {
+		if _, err := Parse([]byte(definition)); err == nil {
+			t.Errorf("%s: expected the flow to be rejected", name)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	scope := map[string]any{
+		"input":   map[string]any{"phone": "+972501234567"},
+		"process": map[string]any{"units": []any{map[string]any{"name": "Salon", "priority": float64(3)}}},
+	}
+	got := resolve(map[string]any{
+		"unit":     "{{ process.units.0 }}",
+		"priority": "{{process.units.0.priority}}",
+		"text":     "{{ process.units.0.name }} for {{ input.phone }}{{ input.missing }}",
+	}, scope).(map[string]any)
+
+	if unit, ok := got["unit"].(map[string]any); !ok || unit["name"] != "Salon" {
+		t.Errorf("expected the unit object, got %v", got["unit"])
+	}
+	if got["priority"] != float64(3) {
+		t.Errorf("expected the number to keep its type, got %v", got["priority"])
+	}
+	if got["text"] != "Salon for +972501234567" {
+		t.Errorf("unexpected text %q", got["text"])
+	}
+}
+
+func TestRun_BranchesAndHandlesErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"booking not found"}`))
+			return
+		}
+		w.Write([]byte(`{"data":{"id":"b1","status":"confirmed"}}`))
+	}))
+	defer server.Close()
+
+	flow, err := Parse([]byte(`
+name: booking_status
+inputs:
+  - name: booking_id
+    required: true
+steps:
+  - name: fetch
+    call: bookings.get
+    args: {id: "{{ input.booking_id }}"}
+    save: booking
+    on_error: unknown
+  - name: confirmed
+    when: {value: "{{ process.booking.status }}", equals: confirmed}
+    set: {status: "booking {{ process.booking.id }} is confirmed"}
+    next: end
+  - name: unknown
+    set: {status: "unknown: {{ process.error }}"}
+output:
+  status: "{{ process.status }}"
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c := client.NewClient()
+	c.SetBookingClient(server.URL)
+	run := func(input map[string]any) (map[string]any, error) {
+		ctx := maestro.NewMaestroContext(context.Background(), input, c, nil, logger.New(logger.Config{Level: "error"}))
+		err := flow.Run(ctx)
+		return ctx.Output, err
+	}
+
+	output, err := run(map[string]any{"booking_id": "b1"})
+	if err != nil || output["status"] != "booking b1 is confirmed" {
+		t.Errorf("expected the confirmed branch, got %v (%v)", output, err)
+	}
+	output, err = run(map[string]any{"booking_id": "missing"})
+	if err != nil || !strings.Contains(output["status"].(string), "booking not found") {
+		t.Errorf("expected the error branch, got %v (%v)", output, err)
+	}
+	if err := flow.Spec().Input.Validate(map[string]any{}); err == nil {
+		t.Error("expected the spec to require booking_id")
+	}
+}
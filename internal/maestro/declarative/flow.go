@@ -0,0 +1,232 @@
+// Package declarative runs maestro flows described in YAML or JSON files
+// instead of Go code. A flow is a list of steps that call the service
+// clients, keep what they return in the flow's process state and branch on
+// conditions; its output maps that state to the flow result.
+//
+// Values are written as templates: "{{ input.phone }}" is the phone input,
+// "{{ process.unit.name }}" the name of the unit a step saved as "unit".
+// A value that is a single template keeps the type of what it refers to,
+// a template inside text is formatted into it.
+package declarative
+
+import (
+	"fmt"
+	"regexp"
+	maestro "skeji/internal/maestro/core"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// EndStep is the next step that finishes a flow.
+const EndStep = "end"
+
+// ContinueOnError makes a step ignore a failed call.
+const ContinueOnError = "continue"
+
+// MaxSteps bounds the steps one run executes, so a flow that loops through
+// next or on_error cannot run forever.
+const MaxSteps = 100
+
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type Flow struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Inputs are the input schema; requests are validated against it before
+	// any step runs.
+	Inputs []maestro.Field `json:"inputs,omitempty"`
+	Steps  []Step          `json:"steps"`
+	// Output becomes the flow output, each value a template.
+	Output map[string]any `json:"output,omitempty"`
+	// Outputs types the values of Output; those left out take any value.
+	Outputs []maestro.Field `json:"outputs,omitempty"`
+}
+
+// Step does one of: call a client action and save its result, set process
+// values, or fail the flow. Steps run in order unless next says otherwise.
+type Step struct {
+	Name string `json:"name"`
+	// When skips the step unless the condition holds.
+	When *Condition `json:"when,omitempty"`
+
+	Call string         `json:"call,omitempty"`
+	Args map[string]any `json:"args,omitempty"`
+	// Save names the process value the call result is kept in.
+	Save string `json:"save,omitempty"`
+	// OnError is the step to go to when the call fails, or "continue". The
+	// error message is kept in process.error. Without it the flow fails.
+	OnError string `json:"on_error,omitempty"`
+
+	Set map[string]any `json:"set,omitempty"`
+
+	Fail string `json:"fail,omitempty"`
+
+	// Next is the step to go to after this one, or "end".
+	Next string `json:"next,omitempty"`
+}
+
+// Condition compares Value, a template, with one of Equals, NotEquals or
+// Empty. Empty is true for a missing value, "", 0, false and an empty list
+// or object.
+type Condition struct {
+	Value     string `json:"value"`
+	Equals    any    `json:"equals,omitempty"`
+	NotEquals any    `json:"not_equals,omitempty"`
+	Empty     *bool  `json:"empty,omitempty"`
+}
+
+// Validate checks the flow is complete and consistent: steps are named
+// uniquely and do one thing, calls name known actions with known arguments,
+// jumps go to existing steps and templates refer to input or process.
+func (f *Flow) Validate() error {
+	if !namePattern.MatchString(f.Name) {
+		return fmt.Errorf("flow name %q must be lowercase letters, digits and underscores", f.Name)
+	}
+	if len(f.Steps) == 0 {
+		return fmt.Errorf("flow %s has no steps", f.Name)
+	}
+	for _, input := range f.Inputs {
+		if !namePattern.MatchString(input.Name) {
+			return fmt.Errorf("flow %s: invalid input name %q", f.Name, input.Name)
+		}
+	}
+	if err := (maestro.Schema{Fields: f.Inputs}).Check(); err != nil {
+		return fmt.Errorf("flow %s, inputs: %w", f.Name, err)
+	}
+	for _, output := range f.Outputs {
+		if _, ok := f.Output[output.Name]; !ok {
+			return fmt.Errorf("flow %s: outputs types %s, which output does not set", f.Name, output.Name)
+		}
+	}
+	if err := (maestro.Schema{Fields: f.Outputs}).Check(); err != nil {
+		return fmt.Errorf("flow %s, outputs: %w", f.Name, err)
+	}
+
+	steps := map[string]bool{}
+	for _, step := range f.Steps {
+		if !namePattern.MatchString(step.Name) || step.Name == EndStep {
+			return fmt.Errorf("flow %s: invalid step name %q", f.Name, step.Name)
+		}
+		if steps[step.Name] {
+			return fmt.Errorf("flow %s: step %s is declared twice", f.Name, step.Name)
+		}
+		steps[step.Name] = true
+	}
+	target := func(name string) bool {
+		return name == EndStep || steps[name]
+	}
+
+	for _, step := range f.Steps {
+		if err := step.validate(target); err != nil {
+			return fmt.Errorf("flow %s, step %s: %w", f.Name, step.Name, err)
+		}
+	}
+	if err := checkTemplates(f.Output); err != nil {
+		return fmt.Errorf("flow %s, output: %w", f.Name, err)
+	}
+	return nil
+}
+
+// Spec describes the flow for the flow catalog: its inputs, and its output
+// values typed as declared in outputs.
+func (f *Flow) Spec() maestro.FlowSpec {
+	names := make([]string, 0, len(f.Output))
+	for name := range f.Output {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	outputs := make([]maestro.Field, 0, len(names))
+	for _, name := range names {
+		field := maestro.Field{Name: name}
+		if i := slices.IndexFunc(f.Outputs, func(o maestro.Field) bool { return o.Name == name }); i >= 0 {
+			field = f.Outputs[i]
+		}
+		outputs = append(outputs, field)
+	}
+	return maestro.FlowSpec{
+		Description: f.Description,
+		Input:       maestro.Schema{Fields: f.Inputs},
+		Output:      maestro.Schema{Fields: outputs},
+	}
+}
+
+func (s *Step) validate(target func(name string) bool) error {
+	kinds := 0
+	for _, set := range []bool{s.Call != "", len(s.Set) > 0, s.Fail != ""} {
+		if set {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return fmt.Errorf("must have exactly one of call, set or fail")
+	}
+
+	if s.Call != "" {
+		action, ok := actions[s.Call]
+		if !ok {
+			return fmt.Errorf("unknown action %q, must be one of %s", s.Call, strings.Join(Actions(), ", "))
+		}
+		for name := range s.Args {
+			if !slices.Contains(action.params, name) {
+				return fmt.Errorf("action %s has no argument %q", s.Call, name)
+			}
+		}
+		for _, name := range action.required {
+			if _, ok := s.Args[name]; !ok {
+				return fmt.Errorf("action %s needs argument %q", s.Call, name)
+			}
+		}
+		if err := checkTemplates(s.Args); err != nil {
+			return err
+		}
+		if s.Save != "" && !namePattern.MatchString(s.Save) {
+			return fmt.Errorf("invalid save name %q", s.Save)
+		}
+		if s.OnError != "" && s.OnError != ContinueOnError && !target(s.OnError) {
+			return fmt.Errorf("on_error goes to unknown step %q", s.OnError)
+		}
+	} else if s.Save != "" || s.OnError != "" || len(s.Args) > 0 {
+		return fmt.Errorf("args, save and on_error only apply to calls")
+	}
+
+	for name := range s.Set {
+		if !namePattern.MatchString(name) {
+			return fmt.Errorf("invalid set name %q", name)
+		}
+	}
+	if err := checkTemplates(s.Set); err != nil {
+		return err
+	}
+	if err := checkTemplates(s.Fail); err != nil {
+		return err
+	}
+
+	if s.Next != "" && !target(s.Next) {
+		return fmt.Errorf("next goes to unknown step %q", s.Next)
+	}
+	if s.When != nil {
+		return s.When.validate()
+	}
+	return nil
+}
+
+func (c *Condition) validate() error {
+	if _, ok := parseTemplate(c.Value); !ok {
+		return fmt.Errorf("condition value %q must be a single template", c.Value)
+	}
+	if err := checkTemplates(c.Value); err != nil {
+		return err
+	}
+	tests := 0
+	for _, set := range []bool{c.Equals != nil, c.NotEquals != nil, c.Empty != nil} {
+		if set {
+			tests++
+		}
+	}
+	if tests != 1 {
+		return fmt.Errorf("condition must have exactly one of equals, not_equals or empty")
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package declarative
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Parse reads a flow from YAML or JSON and validates it. Unknown fields are
+// rejected so a misspelled key does not silently change what a flow does.
+func Parse(data []byte) (*Flow, error) {
+	var flow Flow
+	if err := yaml.UnmarshalStrict(data, &flow); err != nil {
+		return nil, fmt.Errorf("invalid flow definition: %w", err)
+	}
+	if err := flow.Validate(); err != nil {
+		return nil, err
+	}
+	return &flow, nil
+}
+
+// LoadDir reads and validates the .yaml, .yml and .json flow files in dir,
+// ordered by file name. An empty dir has no flows. A flow that does not
+// validate, or shares its name with another, fails the whole load.
+func LoadDir(dir string) ([]*Flow, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read flows directory: %w", err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	flows := []*Flow{}
+	files := map[string]string{}
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		flow, err := Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if other, ok := files[flow.Name]; ok {
+			return nil, fmt.Errorf("%s: flow %s is already defined in %s", path, flow.Name, other)
+		}
+		files[flow.Name] = path
+		flows = append(flows, flow)
+	}
+	return flows, nil
+}
@@ -0,0 +1,93 @@
+package declarative
+
+import (
+	"errors"
+	"fmt"
+	maestro "skeji/internal/maestro/core"
+)
+
+// Run executes the flow as a maestro flow: steps keep their results in
+// ctx.Process and the resolved output is set on ctx.Output. The input is
+// expected to be validated against the flow's spec.
+func (f *Flow) Run(ctx *maestro.MaestroContext) error {
+	if ctx.Process == nil {
+		ctx.Process = make(map[string]any)
+	}
+	scope := map[string]any{"input": ctx.Input, "process": ctx.Process}
+
+	positions := make(map[string]int, len(f.Steps))
+	for i, step := range f.Steps {
+		positions[step.Name] = i
+	}
+	for i, executed := 0, 0; i < len(f.Steps); executed++ {
+		if executed == MaxSteps {
+			return fmt.Errorf("flow %s ran more than %d steps", f.Name, MaxSteps)
+		}
+		if err := ctx.Ctx.Err(); err != nil {
+			return err
+		}
+		next, err := f.runStep(ctx, &f.Steps[i], scope)
+		if err != nil {
+			return err
+		}
+		switch next {
+		case "":
+			i++
+		case EndStep:
+			i = len(f.Steps)
+		default:
+			i = positions[next]
+		}
+	}
+
+	for key, value := range f.Output {
+		ctx.Output[key] = resolve(value, scope)
+	}
+	return nil
+}
+
+// runStep runs one step and returns the step to go to, empty for the
+// following one.
+func (f *Flow) runStep(ctx *maestro.MaestroContext, step *Step, scope map[string]any) (string, error) {
+	if step.When != nil && !step.When.holds(scope) {
+		return "", nil
+	}
+	switch {
+	case step.Fail != "":
+		return "", errors.New(fmt.Sprint(resolve(step.Fail, scope)))
+	case len(step.Set) > 0:
+		for name, value := range step.Set {
+			ctx.Process[name] = resolve(value, scope)
+		}
+	default:
+		args := resolve(step.Args, scope).(map[string]any)
+		result, err := callAction(ctx.Client, step.Call, args)
+		if err != nil {
+			if step.OnError == "" {
+				return "", fmt.Errorf("step %s: %w", step.Name, err)
+			}
+			ctx.Logger.Warn("flow step failed", "flow", f.Name, "step", step.Name, "error", err)
+			ctx.Process["error"] = err.Error()
+			if step.OnError != ContinueOnError {
+				return step.OnError, nil
+			}
+			return step.Next, nil
+		}
+		if step.Save != "" {
+			ctx.Process[step.Save] = result
+		}
+	}
+	return step.Next, nil
+}
+
+func (c *Condition) holds(scope map[string]any) bool {
+	value := resolve(c.Value, scope)
+	switch {
+	case c.Empty != nil:
+		return isEmpty(value) == *c.Empty
+	case c.Equals != nil:
+		return equal(value, c.Equals)
+	default:
+		return !equal(value, c.NotEquals)
+	}
+}
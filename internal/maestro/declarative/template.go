@@ -0,0 +1,142 @@
+package declarative
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var templatePattern = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+
+// parseTemplate returns the path of a string that is a single template.
+func parseTemplate(s string) (string, bool) {
+	match := templatePattern.FindStringSubmatch(s)
+	if match == nil || match[0] != strings.TrimSpace(s) {
+		return "", false
+	}
+	return match[1], true
+}
+
+// checkTemplates reports templates in the value that do not refer to input
+// or process, and braces that do not form a template.
+func checkTemplates(value any) error {
+	switch v := value.(type) {
+	case string:
+		for _, match := range templatePattern.FindAllStringSubmatch(v, -1) {
+			root, _, _ := strings.Cut(match[1], ".")
+			if root != "input" && root != "process" {
+				return fmt.Errorf("template %q must start with input or process", match[0])
+			}
+		}
+		if strings.Contains(templatePattern.ReplaceAllString(v, ""), "{{") {
+			return fmt.Errorf("unclosed template in %q", v)
+		}
+	case map[string]any:
+		for _, item := range v {
+			if err := checkTemplates(item); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if err := checkTemplates(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolve replaces the templates in the value, which may be nested in
+// objects and lists, with what they refer to in scope.
+func resolve(value any, scope map[string]any) any {
+	switch v := value.(type) {
+	case string:
+		if path, ok := parseTemplate(v); ok {
+			return lookup(scope, path)
+		}
+		return templatePattern.ReplaceAllStringFunc(v, func(match string) string {
+			found := lookup(scope, templatePattern.FindStringSubmatch(match)[1])
+			if found == nil {
+				return ""
+			}
+			return fmt.Sprint(found)
+		})
+	case map[string]any:
+		resolved := make(map[string]any, len(v))
+		for key, item := range v {
+			resolved[key] = resolve(item, scope)
+		}
+		return resolved
+	case []any:
+		resolved := make([]any, len(v))
+		for i, item := range v {
+			resolved[i] = resolve(item, scope)
+		}
+		return resolved
+	}
+	return value
+}
+
+// lookup follows a dotted path through objects and, by index, lists. A path
+// that leads nowhere is nil.
+func lookup(scope map[string]any, path string) any {
+	var current any = scope
+	for _, part := range strings.Split(path, ".") {
+		switch v := current.(type) {
+		case map[string]any:
+			current = v[part]
+		case []any:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil
+			}
+			current = v[i]
+		default:
+			return nil
+		}
+	}
+	return current
+}
+
+// isEmpty reports whether a value counts as empty in a condition.
+func isEmpty(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+	return false
+}
+
+// equal compares values as JSON leaves them: numbers by value, the rest as
+// formatted.
+func equal(a, b any) bool {
+	if x, ok := number(a); ok {
+		y, ok := number(b)
+		return ok && x == y
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func number(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
@@ -4,12 +4,31 @@ import (
 	"fmt"
 	"net/http"
 	maestro "skeji/internal/maestro/core"
+	"skeji/pkg/availability"
 	"skeji/pkg/config"
 	"skeji/pkg/model"
 	"skeji/pkg/sealer"
 	"time"
 )
 
+var CreateBookingSpec = maestro.FlowSpec{
+	Description: "Books a slot found by search_business. Owners and maintainers book confirmed slots, customers pending ones.",
+	Input: maestro.Schema{
+		Fields: []maestro.Field{
+			{Name: "requester_phone", Type: maestro.TypeString, Format: maestro.FormatPhone, Required: true, Description: "Phone of the person booking"},
+			{Name: "requester_name", Type: maestro.TypeString, Description: "Name of the person booking, required for customers"},
+			{Name: "slot_id", Type: maestro.TypeString, Required: true, Description: "Slot ID from search_business"},
+			{Name: "start_time", Type: maestro.TypeString, Format: maestro.FormatDateTime, Required: true, Description: "Start of the booking"},
+			{Name: "end_time", Type: maestro.TypeString, Format: maestro.FormatDateTime, Description: "End of the booking, owners and maintainers only; default one meeting"},
+		},
+	},
+	Output: maestro.Schema{
+		Fields: []maestro.Field{
+			{Name: "booking", Type: maestro.TypeObject, Description: "The created booking"},
+		},
+	},
+}
+
 func CreateBooking(ctx *maestro.MaestroContext) error {
 	requesterPhone := ctx.ExtractString("requester_phone")
 	if maestro.IsMissing(requesterPhone) {
@@ -28,7 +47,7 @@ func CreateBooking(ctx *maestro.MaestroContext) error {
 	var endTime time.Time
 	buid, schid, err := sealer.ParseOpaqueToken(slotId)
 	if err != nil {
-		return err
+		return maestro.NewInputError("slot_id", "must be a slot ID from search_business")
 	}
 	resp, err := ctx.Client.BusinessUnitClient.GetByID(buid)
 	if err != nil {
@@ -83,9 +102,12 @@ func CreateBooking(ctx *maestro.MaestroContext) error {
 	if endTimeProvided {
 		booking.EndTime = endTime
 	} else {
-		booking.EndTime = booking.StartTime.Add(time.Duration(schedule.DefaultMeetingDurationMin) * time.Minute)
+		effective := schedule.EffectiveOn(booking.StartTime.In(availability.Location(schedule)))
+		booking.EndTime = booking.StartTime.Add(time.Duration(effective.DefaultMeetingDurationMin) * time.Minute)
 	}
-	resp, err = ctx.Client.BookingClient.Create(booking)
+	// Booked on behalf of the requester, so the bookings service checks that
+	// they may book it this way.
+	resp, err = ctx.Client.As(requesterPhone).BookingClient.Create(booking)
 	if err != nil {
 		return err
 	}
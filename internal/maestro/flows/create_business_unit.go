@@ -8,10 +8,41 @@ import (
 	"skeji/pkg/model"
 )
 
+var CreateBusinessUnitSpec = maestro.FlowSpec{
+	Description: "Registers a business acting as admin_phone, with a schedule in its first city or one per city.",
+	Input: maestro.Schema{
+		Fields: []maestro.Field{
+			{Name: "name", Type: maestro.TypeString, Required: true, MinLength: 2, MaxLength: 100},
+			{Name: "admin_phone", Type: maestro.TypeString, Format: maestro.FormatPhone, Required: true, Description: "Phone of the owner"},
+			{Name: "cities", Type: maestro.TypeArray, Items: maestro.TypeString, Required: true, MinItems: 1, MaxItems: 50},
+			{Name: "labels", Type: maestro.TypeArray, Items: maestro.TypeString, MaxItems: 10, Description: "Service labels, optional in an organization"},
+			{Name: "time_zone", Type: maestro.TypeString, Description: "IANA time zone"},
+			{Name: "website_urls", Type: maestro.TypeArray, Items: maestro.TypeString, MaxItems: 5},
+			{Name: "maintainers", Type: maestro.TypeObject, Description: "Maintainer names to phones"},
+			{Name: "organization_id", Type: maestro.TypeString, Description: "Opens the unit as a branch of this organization"},
+			{Name: "start_of_day", Type: maestro.TypeString, Description: "HH:MM"},
+			{Name: "end_of_day", Type: maestro.TypeString, Description: "HH:MM"},
+			{Name: "working_days", Type: maestro.TypeArray, Items: maestro.TypeString, MinItems: 1, MaxItems: 7},
+			{Name: "schedule_time_zone", Type: maestro.TypeString, Description: "IANA time zone of the schedules"},
+			{Name: "default_meeting_duration_min", Type: maestro.TypeInteger, Minimum: maestro.Bound(5), Maximum: maestro.Bound(480)},
+			{Name: "default_break_duration_min", Type: maestro.TypeInteger, Minimum: maestro.Bound(0), Maximum: maestro.Bound(480)},
+			{Name: "max_participants_per_slot", Type: maestro.TypeInteger, Minimum: maestro.Bound(1), Maximum: maestro.Bound(200)},
+			{Name: "schedule_per_city", Type: maestro.TypeBoolean, Description: "Open a schedule in every city, not only the first"},
+		},
+		AnyOf: [][]string{{"labels", "organization_id"}},
+	},
+	Output: maestro.Schema{
+		Fields: []maestro.Field{
+			{Name: "business_unit_id", Type: maestro.TypeString},
+			{Name: "schedule_ids", Type: maestro.TypeArray, Items: maestro.TypeString},
+		},
+	},
+}
+
 func CreateBusinessUnit(ctx *maestro.MaestroContext) error {
 	input, err := types.FromMapCreateBusinessUnit(ctx.Input)
 	if err != nil {
-		return fmt.Errorf("invalid input: %w", err)
+		return maestro.NewInputError("input", err.Error())
 	}
 
 	businessUnit := &model.BusinessUnit{
@@ -30,66 +61,123 @@ func CreateBusinessUnit(ctx *maestro.MaestroContext) error {
 	if len(input.Maintainers) > 0 {
 		businessUnit.Maintainers = input.Maintainers
 	}
+	businessUnit.OrganizationID = input.OrganizationID
+
+	// The business unit and its schedules are created on behalf of its admin.
+	admin := ctx.Client.As(input.AdminPhone)
 
-	resp, err := ctx.Client.BusinessUnitClient.Create(businessUnit)
+	var hours *model.OrganizationHours
+	if input.OrganizationID != "" {
+		orgResp, err := admin.BusinessUnitClient.GetOrganization(input.OrganizationID)
+		if err != nil {
+			return err
+		}
+		if orgResp.StatusCode != http.StatusOK {
+			return fmt.Errorf("failed to get organization: %+v", orgResp.ToString())
+		}
+		org, err := admin.BusinessUnitClient.DecodeOrganization(orgResp)
+		if err != nil {
+			return err
+		}
+		hours = org.Hours
+	}
+	resp, err := admin.BusinessUnitClient.Create(businessUnit)
 	if err != nil {
 		return err
 	}
 	if resp.StatusCode != http.StatusCreated {
 		return fmt.Errorf("failed to create business unit: %+v", resp.ToString())
 	}
-	createdBU, err := ctx.Client.BusinessUnitClient.DecodeBusinessUnit(resp)
+	createdBU, err := admin.BusinessUnitClient.DecodeBusinessUnit(resp)
 	if err != nil {
 		return err
 	}
+	scheduleIDs := []string{}
+	ctx.Output["business_unit_id"] = createdBU.ID
+	ctx.Output["schedule_ids"] = scheduleIDs
 
-	for _, city := range createdBU.Cities {
-		schedule := &model.Schedule{
-			BusinessID: createdBU.ID,
-			Name:       createdBU.Name + "_" + city,
-			City:       city,
-			Address:    city,
-			TimeZone:   createdBU.TimeZone,
-		}
+	if len(createdBU.Cities) == 0 {
+		return nil
+	}
 
-		if input.StartOfDay != nil {
-			schedule.StartOfDay = *input.StartOfDay
-		}
-		if input.EndOfDay != nil {
-			schedule.EndOfDay = *input.EndOfDay
-		}
-		if len(input.WorkingDays) > 0 {
-			schedule.WorkingDays = input.WorkingDays
-		}
-		if input.ScheduleTimeZone != nil {
-			schedule.TimeZone = *input.ScheduleTimeZone
-		}
-		if input.DefaultMeetingDurationMin != nil {
-			schedule.DefaultMeetingDurationMin = *input.DefaultMeetingDurationMin
-		}
-		if input.DefaultBreakDurationMin != nil {
-			schedule.DefaultBreakDurationMin = *input.DefaultBreakDurationMin
-		}
-		if input.MaxParticipantsPerSlot != nil {
-			schedule.MaxParticipantsPerSlot = *input.MaxParticipantsPerSlot
-		}
+	city := createdBU.Cities[0]
+	schedule := &model.Schedule{
+		BusinessID: createdBU.ID,
+		Name:       createdBU.Name + "_" + city,
+		City:       city,
+		Address:    city,
+		TimeZone:   createdBU.TimeZone,
+	}
+
+	// organization hours are the template; explicit input overrides them
+	if hours != nil {
+		schedule.StartOfDay = hours.StartOfDay
+		schedule.EndOfDay = hours.EndOfDay
+		schedule.WorkingDays = hours.WorkingDays
+		schedule.DefaultMeetingDurationMin = hours.DefaultMeetingDurationMin
+	}
 
-		schedResp, err := ctx.Client.ScheduleClient.Create(schedule)
+	if input.StartOfDay != nil {
+		schedule.StartOfDay = *input.StartOfDay
+	}
+	if input.EndOfDay != nil {
+		schedule.EndOfDay = *input.EndOfDay
+	}
+	if len(input.WorkingDays) > 0 {
+		schedule.WorkingDays = input.WorkingDays
+	}
+	if input.ScheduleTimeZone != nil {
+		schedule.TimeZone = *input.ScheduleTimeZone
+	}
+	if input.DefaultMeetingDurationMin != nil {
+		schedule.DefaultMeetingDurationMin = *input.DefaultMeetingDurationMin
+	}
+	if input.DefaultBreakDurationMin != nil {
+		schedule.DefaultBreakDurationMin = *input.DefaultBreakDurationMin
+	}
+	if input.MaxParticipantsPerSlot != nil {
+		schedule.MaxParticipantsPerSlot = *input.MaxParticipantsPerSlot
+	}
+
+	schedResp, err := admin.ScheduleClient.Create(schedule)
+	if err != nil {
+		return fmt.Errorf("failed to create schedule for city %s: %v", city, err)
+	}
+	if schedResp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to create schedule for city %s: %+v", city, schedResp.ToString())
+	}
+
+	first, err := admin.ScheduleClient.DecodeSchedule(schedResp)
+	if err != nil {
+		return fmt.Errorf("failed to decode schedule for city %s: %v", city, err)
+	}
+	scheduleIDs = append(scheduleIDs, first.ID)
+	ctx.Output["schedule_ids"] = scheduleIDs
+
+	if !input.SchedulePerCity {
+		return nil
+	}
+
+	// The remaining branches share the first one's configuration.
+	for _, city := range createdBU.Cities[1:] {
+		target := model.ScheduleClone{
+			Name:    createdBU.Name + "_" + city,
+			City:    city,
+			Address: city,
+		}
+		cloneResp, err := admin.ScheduleClient.Clone(first.ID, target)
 		if err != nil {
 			return fmt.Errorf("failed to create schedule for city %s: %v", city, err)
 		}
-		if schedResp.StatusCode != http.StatusCreated {
-			return fmt.Errorf("failed to create schedule for city %s: %+v", city, schedResp.ToString())
+		if cloneResp.StatusCode != http.StatusCreated {
+			return fmt.Errorf("failed to create schedule for city %s: %+v", city, cloneResp.ToString())
 		}
-
-		_, err = ctx.Client.ScheduleClient.DecodeSchedule(schedResp)
+		clone, err := admin.ScheduleClient.DecodeSchedule(cloneResp)
 		if err != nil {
 			return fmt.Errorf("failed to decode schedule for city %s: %v", city, err)
 		}
-
-		if !input.SchedulePerCity {
-			break
-		}
+		scheduleIDs = append(scheduleIDs, clone.ID)
+		ctx.Output["schedule_ids"] = scheduleIDs
 	}
 	return nil
 }
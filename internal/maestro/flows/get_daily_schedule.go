@@ -10,10 +10,28 @@ import (
 	"time"
 )
 
+var GetDailyScheduleSpec = core.FlowSpec{
+	Description: "Lists the bookings of the businesses a phone owns or maintains.",
+	Input: core.Schema{
+		Fields: []core.Field{
+			{Name: "phone", Type: core.TypeString, Format: core.FormatPhone, Required: true, Description: "Phone of the owner or maintainer"},
+			{Name: "cities", Type: core.TypeArray, Items: core.TypeString, MaxItems: 50, Description: "Only schedules in these cities"},
+			{Name: "labels", Type: core.TypeArray, Items: core.TypeString, MaxItems: 10, Description: "Only businesses with these labels"},
+			{Name: "start", Type: core.TypeString, Format: core.FormatDateTime, Description: "Start of the time frame, default now"},
+			{Name: "end", Type: core.TypeString, Format: core.FormatDateTime, Description: "End of the time frame"},
+		},
+	},
+	Output: core.Schema{
+		Fields: []core.Field{
+			{Name: "result", Type: core.TypeObject, Description: "Units with their schedules and bookings"},
+		},
+	},
+}
+
 func GetDailySchedule(ctx *core.MaestroContext) error {
 	input, err := types.FromMapGetDailySchedule(ctx.Input)
 	if err != nil {
-		return fmt.Errorf("invalid input: %w", err)
+		return core.NewInputError("input", err.Error())
 	}
 
 	start, end := extractTimeFrame(input)
@@ -2,19 +2,28 @@ package flows
 
 import (
 	"fmt"
+	"net/http"
 	maestro "skeji/internal/maestro/core"
+	"skeji/pkg/attributes"
+	"skeji/pkg/availability"
+	"skeji/pkg/client"
 	"skeji/pkg/config"
 	"skeji/pkg/model"
+	"skeji/pkg/ranking"
 	"skeji/pkg/sealer"
+	"skeji/pkg/taxonomy"
+	"skeji/pkg/textsearch"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 )
 
 const (
-	MAX_RESULTS_FOR_SEARCH    = 5
-	MAX_BRANCHES_PER_UNIT     = 3
-	MAX_OPEN_SLOTS_PER_BRANCH = 3
+	MAX_RESULTS_FOR_SEARCH     = 5
+	MAX_CANDIDATES_FOR_RANKING = 20
+	MAX_BRANCHES_PER_UNIT      = 3
+	MAX_OPEN_SLOTS_PER_BRANCH  = 3
 
 	MAX_RESULTS_PER_PAGE = 200
 )
@@ -31,92 +40,295 @@ type BusinessBranch struct {
 	WorkingDays []string
 	StartOfDay  string
 	EndOfDay    string
+	WeeklyHours []model.DayHours
 	OpenSlots   []*OpenSlot
+	// DistanceMeters is set when the search has an origin and the branch a location.
+	DistanceMeters *float64
+	// Attributes are the branch's own attributes over the business unit's.
+	Attributes model.Attributes
 }
 
 type Business struct {
 	Name     string
 	Phones   []string
 	Branches []*BusinessBranch
+	// Brand is set when the search collapses branches per organization.
+	Brand string
+}
+
+// unitSearch fetches one page of business units.
+type unitSearch func(limit int, offset int64) (*client.Response, error)
+
+// candidate is a business with open branches and the unit it was built from.
+type candidate struct {
+	unit     *model.BusinessUnit
+	business *Business
+}
+
+var SearchBusinessSpec = maestro.FlowSpec{
+	Description: "Finds businesses with open slots in the given cities by labels or free text, best match first.",
+	Input: maestro.Schema{
+		Fields: []maestro.Field{
+			{Name: "cities", Type: maestro.TypeArray, Items: maestro.TypeString, Required: true, MinItems: 1, Description: "Cities to search in, any spelling or alias"},
+			{Name: "labels", Type: maestro.TypeArray, Items: maestro.TypeString, Description: "Service labels, e.g. hair or barber"},
+			{Name: "query", Type: maestro.TypeString, Description: "Free text such as a business name; typos are tolerated"},
+			{Name: "start", Type: maestro.TypeString, Format: maestro.FormatDateTime, Description: "Start of the time frame, default now"},
+			{Name: "end", Type: maestro.TypeString, Format: maestro.FormatDateTime, Description: "End of the time frame, default 36 hours after start"},
+			{Name: "lat", Type: maestro.TypeNumber, Minimum: maestro.Bound(-90), Maximum: maestro.Bound(90), Description: "Latitude of the customer, with lng"},
+			{Name: "lng", Type: maestro.TypeNumber, Minimum: maestro.Bound(-180), Maximum: maestro.Bound(180), Description: "Longitude of the customer, with lat"},
+			{Name: "requester_phone", Type: maestro.TypeString, Format: maestro.FormatPhone, Description: "Customer phone; seeds the rotation of equally ranked businesses"},
+			{Name: "one_per_brand", Type: maestro.TypeBoolean, Description: "Collapse branches of an organization into the nearest one"},
+			{Name: "attributes", Type: maestro.TypeArray, Items: maestro.TypeString, Description: "Attribute filters as key:value, e.g. languages:english"},
+		},
+		AnyOf:    [][]string{{"labels", "query"}},
+		Together: [][]string{{"lat", "lng"}},
+	},
+	Output: maestro.Schema{
+		Fields: []maestro.Field{
+			{Name: "result", Type: maestro.TypeArray, Items: maestro.TypeObject, Description: "Businesses with their open branches and slots, best match first"},
+			{Name: "facets", Type: maestro.TypeArray, Items: maestro.TypeObject, Description: "Per attribute, how many of the businesses found have each value"},
+		},
+	},
 }
 
 func SearchBusiness(ctx *maestro.MaestroContext) error {
 	cities := ctx.ExtractStringList("cities")
 	labels := ctx.ExtractStringList("labels")
-	if len(cities) == 0 || len(labels) == 0 {
-		return fmt.Errorf("at least one label and one city must be specified")
+	query := strings.TrimSpace(ctx.ExtractString("query"))
+	origin := extractOrigin(ctx)
+	filter, err := attributes.ParseFilter(ctx.ExtractStringList("attributes"))
+	if err != nil {
+		return maestro.NewInputError("attributes", err.Error())
 	}
+	// attributes a schedule can override are matched per branch, the rest by
+	// the business units search
+	unitFilter, branchFilter := attributes.Split(filter)
 	start, end := fetchAndApplyTimeFrameForSearch(ctx)
-	businesses := []*Business{}
-	var offset int64 = 0
-
-	resp, err := ctx.Client.BusinessUnitClient.Search(cities, labels, MAX_RESULTS_PER_PAGE, offset)
-	if err != nil {
-		return err
+	fairness := model.SearchFairness{
+		Mode:      ctx.Ranker.Fairness(),
+		Requester: ctx.ExtractString("requester_phone"),
 	}
-	units, metadata, err := ctx.Client.BusinessUnitClient.DecodeBusinessUnits(resp)
-	if err != nil {
-		return err
+	candidates := []candidate{}
+	var match func(unit *model.BusinessUnit) float64
+
+	if len(labels) > 0 {
+		candidates, err = collectCandidates(ctx, func(limit int, offset int64) (*client.Response, error) {
+			return ctx.Client.BusinessUnitClient.FilteredSearch(cities, labels, unitFilter, fairness, limit, offset)
+		}, cities, branchFilter, origin, start, end)
+		if err != nil {
+			return err
+		}
+		requested := taxonomy.Canonicalize(labels)
+		match = func(unit *model.BusinessUnit) float64 {
+			// a barber fully matches a request for hair
+			return ranking.LabelOverlap(requested, taxonomy.Expand(unit.Labels))
+		}
 	}
 
-	for len(businesses) < MAX_RESULTS_FOR_SEARCH && offset < metadata.TotalCount {
-		select {
-		case <-ctx.Ctx.Done():
-			ctx.Logger.Warn("search cancelled or timed out", "businesses_found", len(businesses), "error", ctx.Ctx.Err())
-			return fmt.Errorf("search cancelled: %w", ctx.Ctx.Err())
-		default:
+	// Exact label matching found nothing: fall back to a fuzzy search over
+	// business names and labels, which tolerates typos and partial names.
+	if len(candidates) == 0 {
+		if query == "" {
+			query = strings.Join(labels, " ")
 		}
+		ctx.Logger.Info("no exact matches, falling back to text search", "query", query, "cities", cities)
+		candidates, err = collectCandidates(ctx, func(limit int, offset int64) (*client.Response, error) {
+			return ctx.Client.BusinessUnitClient.FilteredTextSearch(query, cities, unitFilter, fairness, limit, offset)
+		}, cities, branchFilter, origin, start, end)
+		if err != nil {
+			return err
+		}
+		tokens := textsearch.Tokens(query)
+		match = func(unit *model.BusinessUnit) float64 {
+			return textsearch.Relevance(tokens, unit.Name, unit.Labels)
+		}
+	}
+
+	if ctx.ExtractBool("one_per_brand") {
+		candidates = collapseBrands(ctx, candidates)
+	}
 
-		var wg sync.WaitGroup
-		var mu sync.Mutex
+	shown := rankCandidates(ctx, candidates, match, start, end)
+	businesses := make([]*Business, len(shown))
+	ids := make([]string, len(shown))
+	for i, c := range shown {
+		businesses[i] = c.business
+		ids[i] = c.unit.ID
+	}
+	recordImpressions(ctx, ids)
 
-		for _, unit := range units {
-			unit := unit
+	ctx.Output["result"] = businesses
+	ctx.Output["facets"] = countFacets(candidates)
+	return nil
+}
 
-			mu.Lock()
-			if len(businesses) >= MAX_RESULTS_FOR_SEARCH {
-				mu.Unlock()
-				break
+// countFacets counts the candidates per attribute value any of their open
+// branches has.
+func countFacets(candidates []candidate) []model.AttributeFacet {
+	counts := map[string]int64{}
+	for _, c := range candidates {
+		seen := map[string]bool{}
+		for _, branch := range c.business.Branches {
+			for _, term := range attributes.Terms(branch.Attributes) {
+				if !seen[term] {
+					seen[term] = true
+					counts[term]++
+				}
 			}
-			mu.Unlock()
+		}
+	}
+	return attributes.Facets(counts)
+}
 
-			wg.Add(1)
-			maestro.RunWithRateLimitedConcurrency(func() {
-				defer wg.Done()
+// collapseBrands keeps one candidate per organization: the one with the
+// nearest branch, or the first in search order when distances are unknown.
+// Candidates outside organizations are kept as they are.
+func collapseBrands(ctx *maestro.MaestroContext, candidates []candidate) []candidate {
+	collapsed := []candidate{}
+	byBrand := map[string]int{}
+	for _, c := range candidates {
+		orgID := c.unit.OrganizationID
+		if orgID == "" {
+			collapsed = append(collapsed, c)
+			continue
+		}
+		i, ok := byBrand[orgID]
+		if !ok {
+			c.business.Brand = brandName(ctx, orgID)
+			byBrand[orgID] = len(collapsed)
+			collapsed = append(collapsed, c)
+			continue
+		}
+		if closerThan(nearestBranch(c.business), nearestBranch(collapsed[i].business)) {
+			c.business.Brand = collapsed[i].business.Brand
+			collapsed[i] = c
+		}
+	}
+	return collapsed
+}
 
-				business := &Business{
-					Name:     unit.Name,
-					Phones:   []string{unit.AdminPhone},
-					Branches: []*BusinessBranch{},
-				}
-				for phone := range unit.Maintainers {
-					business.Phones = append(business.Phones, phone)
-				}
+// brandName returns the organization's name, empty when it cannot be read.
+func brandName(ctx *maestro.MaestroContext, organizationID string) string {
+	resp, err := ctx.Client.BusinessUnitClient.GetOrganization(organizationID)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		ctx.Logger.Warn("failed to get organization", "organization_id", organizationID, "error", err)
+		return ""
+	}
+	org, err := ctx.Client.BusinessUnitClient.DecodeOrganization(resp)
+	if err != nil {
+		ctx.Logger.Warn("failed to decode organization", "organization_id", organizationID, "error", err)
+		return ""
+	}
+	return org.Name
+}
 
-				branches := fetchBranches(ctx, unit.ID, cities, start, end)
-				if len(branches) > 0 {
-					if len(branches) > MAX_BRANCHES_PER_UNIT {
-						branches = branches[:MAX_BRANCHES_PER_UNIT]
-					}
-					business.Branches = branches
+// recordImpressions reports the businesses shown so search fairness can
+// balance exposure. Failing to record does not fail the search.
+func recordImpressions(ctx *maestro.MaestroContext, ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+	resp, err := ctx.Client.BusinessUnitClient.RecordImpressions(ids)
+	if err != nil {
+		ctx.Logger.Warn("failed to record impressions", "business_ids", ids, "error", err)
+		return
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		ctx.Logger.Warn("failed to record impressions", "business_ids", ids, "status", resp.StatusCode, "error", client.GetErrorMessage(resp))
+	}
+}
 
-					mu.Lock()
-					if len(businesses) < MAX_RESULTS_FOR_SEARCH {
-						businesses = append(businesses, business)
-					}
-					mu.Unlock()
+// rankCandidates orders the candidates with the configured ranker and keeps
+// the best MAX_RESULTS_FOR_SEARCH. Candidates that score the same keep the
+// order of the search, which already rotates ties fairly.
+func rankCandidates(ctx *maestro.MaestroContext, candidates []candidate, match func(unit *model.BusinessUnit) float64, start, end time.Time) []candidate {
+	signals := make([]ranking.Candidate, len(candidates))
+	for i, c := range candidates {
+		signals[i] = ranking.Candidate{
+			Key:            c.unit.ID,
+			Priority:       c.unit.Priority,
+			LabelMatch:     match(c.unit),
+			DistanceMeters: nearestBranch(c.business),
+			Position:       i,
+		}
+		for _, branch := range c.business.Branches {
+			signals[i].OpenSlots += len(branch.OpenSlots)
+			for _, slot := range branch.OpenSlots {
+				if signals[i].EarliestSlot == nil || slot.Start.Before(*signals[i].EarliestSlot) {
+					earliest := slot.Start
+					signals[i].EarliestSlot = &earliest
 				}
-			})
+			}
+		}
+	}
+
+	shown := []candidate{}
+	for _, ranked := range ctx.Ranker.Rank(signals, start, end) {
+		if len(shown) >= MAX_RESULTS_FOR_SEARCH {
+			break
 		}
+		shown = append(shown, candidates[ranked.Index])
+	}
+	return shown
+}
+
+// collectCandidates pages through the units returned by search and keeps up
+// to MAX_CANDIDATES_FOR_RANKING that have open branches in the given cities.
+// Units are checked concurrently in chunks, but kept in the order the search
+// returned them, so the pool does not depend on which request finishes first.
+func collectCandidates(ctx *maestro.MaestroContext, search unitSearch, cities []string, filter model.AttributeFilter, origin *model.GeoPoint, start, end time.Time) ([]candidate, error) {
+	candidates := []candidate{}
+	var offset int64 = 0
 
-		wg.Wait()
+	resp, err := search(MAX_RESULTS_PER_PAGE, offset)
+	if err != nil {
+		return nil, err
+	}
+	units, metadata, err := ctx.Client.BusinessUnitClient.DecodeBusinessUnits(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	for len(candidates) < MAX_CANDIDATES_FOR_RANKING && offset < metadata.TotalCount {
+		for chunkStart := 0; chunkStart < len(units) && len(candidates) < MAX_CANDIDATES_FOR_RANKING; chunkStart += MAX_CANDIDATES_FOR_RANKING {
+			select {
+			case <-ctx.Ctx.Done():
+				ctx.Logger.Warn("search cancelled or timed out", "candidates_found", len(candidates), "error", ctx.Ctx.Err())
+				return nil, fmt.Errorf("search cancelled: %w", ctx.Ctx.Err())
+			default:
+			}
+
+			chunk := units[chunkStart:min(chunkStart+MAX_CANDIDATES_FOR_RANKING, len(units))]
+			found := make([]*Business, len(chunk))
+			var wg sync.WaitGroup
+			for i, unit := range chunk {
+				wg.Add(1)
+				go maestro.RunWithRateLimitedConcurrency(func() {
+					defer wg.Done()
+					defer func() {
+						if r := recover(); r != nil {
+							ctx.Logger.Error(fmt.Sprintf("panic while building business %s: %v", unit.ID, r))
+							// Leave the business as nil - the unit is skipped as a candidate
+						}
+					}()
+					found[i] = buildBusiness(ctx, unit, cities, filter, origin, start, end)
+				})
+			}
+			wg.Wait()
+
+			for i, business := range found {
+				if business != nil && len(candidates) < MAX_CANDIDATES_FOR_RANKING {
+					candidates = append(candidates, candidate{unit: chunk[i], business: business})
+				}
+			}
+		}
 
-		if len(businesses) >= MAX_RESULTS_FOR_SEARCH {
+		if len(candidates) >= MAX_CANDIDATES_FOR_RANKING {
 			break
 		}
 
 		offset += MAX_RESULTS_PER_PAGE
-		resp, err = ctx.Client.BusinessUnitClient.Search(cities, labels, MAX_RESULTS_PER_PAGE, offset)
+		resp, err = search(MAX_RESULTS_PER_PAGE, offset)
 		if err != nil {
 			ctx.Logger.Warn(fmt.Sprintf("business units search failed, err: %+v", err))
 			continue
@@ -128,11 +340,85 @@ func SearchBusiness(ctx *maestro.MaestroContext) error {
 		}
 	}
 
-	ctx.Output["result"] = businesses
-	return nil
+	return candidates, nil
 }
 
-func fetchBranches(ctx *maestro.MaestroContext, buid string, cities []string, start, end time.Time) []*BusinessBranch {
+// buildBusiness returns the unit with its open branches matching the filter,
+// or nil when no such branch has an open slot.
+func buildBusiness(ctx *maestro.MaestroContext, unit *model.BusinessUnit, cities []string, filter model.AttributeFilter, origin *model.GeoPoint, start, end time.Time) *Business {
+	// search already hides units that are not active, this guards against a
+	// status change between the search and the lookup
+	if !config.IsLiveBusinessStatus(unit.Status) {
+		return nil
+	}
+	branches := fetchBranches(ctx, unit, cities, filter, origin, start, end)
+	if len(branches) == 0 {
+		return nil
+	}
+	if len(branches) > MAX_BRANCHES_PER_UNIT {
+		branches = branches[:MAX_BRANCHES_PER_UNIT]
+	}
+
+	business := &Business{
+		Name:     unit.Name,
+		Phones:   []string{unit.AdminPhone},
+		Branches: branches,
+	}
+	for phone := range unit.Maintainers {
+		business.Phones = append(business.Phones, phone)
+	}
+	return business
+}
+
+// extractOrigin reads the optional lat/lng the user is searching from. The
+// input schema has already checked that both or neither are given, in range.
+func extractOrigin(ctx *maestro.MaestroContext) *model.GeoPoint {
+	lat, hasLat := ctx.ExtractFloat("lat")
+	lng, hasLng := ctx.ExtractFloat("lng")
+	if !hasLat || !hasLng {
+		return nil
+	}
+	return model.NewGeoPoint(lat, lng)
+}
+
+// sortByDistance orders schedules nearest to origin first; schedules without a
+// location keep their relative order after the located ones.
+func sortByDistance(schedules []*model.Schedule, origin *model.GeoPoint) {
+	distance := func(sc *model.Schedule) *float64 {
+		if sc.Location == nil {
+			return nil
+		}
+		d := origin.DistanceMeters(sc.Location)
+		return &d
+	}
+	sort.SliceStable(schedules, func(i, j int) bool {
+		return closerThan(distance(schedules[i]), distance(schedules[j]))
+	})
+}
+
+func nearestBranch(business *Business) *float64 {
+	var nearest *float64
+	for _, branch := range business.Branches {
+		if closerThan(branch.DistanceMeters, nearest) {
+			nearest = branch.DistanceMeters
+		}
+	}
+	return nearest
+}
+
+// closerThan reports whether distance a ranks before b; unknown distances rank last.
+func closerThan(a, b *float64) bool {
+	if a == nil {
+		return false
+	}
+	if b == nil {
+		return true
+	}
+	return *a < *b
+}
+
+func fetchBranches(ctx *maestro.MaestroContext, unit *model.BusinessUnit, cities []string, filter model.AttributeFilter, origin *model.GeoPoint, start, end time.Time) []*BusinessBranch {
+	buid := unit.ID
 	branches := []*BusinessBranch{}
 	var offset int64 = 0
 
@@ -148,11 +434,12 @@ func fetchBranches(ctx *maestro.MaestroContext, buid string, cities []string, st
 	}
 
 	for len(branches) < MAX_BRANCHES_PER_UNIT && offset < metadata.TotalCount {
+		if origin != nil {
+			sortByDistance(schedules, origin)
+		}
 		scheduleIDs := make([]string, 0, len(schedules))
-		scheduleMap := make(map[string]*model.Schedule)
 		for _, schedule := range schedules {
 			scheduleIDs = append(scheduleIDs, schedule.ID)
-			scheduleMap[schedule.ID] = schedule
 		}
 
 		bookingResp, err := ctx.Client.BookingClient.BatchSearch(
@@ -174,21 +461,33 @@ func fetchBranches(ctx *maestro.MaestroContext, buid string, cities []string, st
 			return branches
 		}
 
-		for scheduleID, schedule := range scheduleMap {
+		for _, schedule := range schedules {
 			if len(branches) >= MAX_BRANCHES_PER_UNIT {
 				break
 			}
+			effective := attributes.Inherit(unit.Attributes, schedule.Attributes)
+			if !attributes.Matches(effective, filter) {
+				continue
+			}
 
+			// hours are reported as in effect at the start of the search window
+			current := schedule.EffectiveOn(start.In(availability.Location(schedule)))
 			branch := &BusinessBranch{
 				City:        schedule.City,
 				Address:     schedule.Address,
-				WorkingDays: schedule.WorkingDays,
-				StartOfDay:  schedule.StartOfDay,
-				EndOfDay:    schedule.EndOfDay,
+				WorkingDays: current.WorkingDays,
+				StartOfDay:  current.StartOfDay,
+				EndOfDay:    current.EndOfDay,
+				WeeklyHours: current.WeeklyHours,
 				OpenSlots:   []*OpenSlot{},
+				Attributes:  effective,
+			}
+			if origin != nil && schedule.Location != nil {
+				distance := origin.DistanceMeters(schedule.Location)
+				branch.DistanceMeters = &distance
 			}
 
-			bookings := bookingsBySchedule[scheduleID]
+			bookings := bookingsBySchedule[schedule.ID]
 			openSlots := calculateOpenSlots(ctx, buid, schedule, bookings, start, end)
 
 			if len(openSlots) > 0 {
@@ -221,6 +520,9 @@ func fetchBranches(ctx *maestro.MaestroContext, buid string, cities []string, st
 	return branches
 }
 
+// calculateOpenSlots returns the bookable slots of the schedule within
+// [start, end), computed like the schedules service's availability so both
+// show the same slots. Staff members offering the same time make one slot.
 func calculateOpenSlots(ctx *maestro.MaestroContext, buid string, sc *model.Schedule, bookings []*model.Booking, start, end time.Time) []*OpenSlot {
 	openSlots := []*OpenSlot{}
 
@@ -230,162 +532,15 @@ func calculateOpenSlots(ctx *maestro.MaestroContext, buid string, sc *model.Sche
 		return openSlots
 	}
 
-	if len(bookings) == 0 {
-		openSlots = append(openSlots, &OpenSlot{Start: start, End: end})
-	} else {
-		slots := filterSlots(bookings, start, end)
-		if len(slots) > MAX_OPEN_SLOTS_PER_BRANCH {
-			slots = slots[:MAX_OPEN_SLOTS_PER_BRANCH]
-		}
-		openSlots = append(openSlots, slots...)
-	}
-
-	if len(openSlots) == 0 {
-		return openSlots
-	}
-
-	return normalizeSlots(ctx, batchId, openSlots, sc, start, end)
-}
-
-func filterSlots(bookings []*model.Booking, start, end time.Time) []*OpenSlot {
-	openSlots := []*OpenSlot{}
-	pStart := start
-	for _, booking := range bookings {
-		if booking.StartTime.After(pStart) {
-			openSlots = append(openSlots, &OpenSlot{Start: pStart, End: booking.StartTime})
-		}
-		pStart = booking.EndTime
-	}
-	if end.After(pStart) {
-		openSlots = append(openSlots, &OpenSlot{Start: pStart, End: end})
-	}
-	return openSlots
-}
-
-func normalizeSlots(ctx *maestro.MaestroContext, batchId string, slots []*OpenSlot, sc *model.Schedule, viewStart, viewEnd time.Time) []*OpenSlot {
-	workWeek := buildWorkingDaysSet(sc.WorkingDays)
-	openSlots := []*OpenSlot{}
-	startToday, endToday, startTomorrow, endTomorrow, err := extractDailyFrames(sc.StartOfDay, sc.EndOfDay)
-	if err != nil {
-		ctx.Logger.Warn(fmt.Sprintf("extract daily frames failed: %v", err))
-		return openSlots
-	}
-	for _, s := range slots {
-		start1 := maxTime(s.Start, viewStart)
-		end1 := minTime(s.End, viewEnd)
-		if end1.Before(start1) {
+	for _, slot := range availability.Slots(sc, bookings, start, end) {
+		if n := len(openSlots); n > 0 && openSlots[n-1].Start.Equal(slot.Start) && openSlots[n-1].End.Equal(slot.End) {
 			continue
 		}
-		part1Start := maxTime(start1, startToday)
-		part1End := minTime(end1, endToday)
-
-		if part1End.After(part1Start) {
-			openSlot := &OpenSlot{
-				ID:    batchId,
-				Start: part1Start,
-				End:   part1End,
-			}
-			if isLegitSlot(openSlot, sc, workWeek) {
-				openSlots = append(openSlots, openSlot)
-			}
-		}
-
-		if end1.After(startTomorrow) {
-			part2Start := maxTime(start1, startTomorrow)
-			part2End := minTime(end1, endTomorrow)
-
-			if part2End.After(part2Start) {
-				openSlot := &OpenSlot{
-					ID:    batchId,
-					Start: part2Start,
-					End:   part2End,
-				}
-				if isLegitSlot(openSlot, sc, workWeek) {
-					openSlots = append(openSlots, openSlot)
-				}
-			}
-		}
+		openSlots = append(openSlots, &OpenSlot{ID: batchId, Start: slot.Start, End: slot.End})
 	}
 	return openSlots
 }
 
-func isLegitSlot(
-	slot *OpenSlot,
-	sc *model.Schedule,
-	workWeek map[time.Weekday]bool,
-) bool {
-	if !workWeek[slot.Start.Weekday()] {
-		return false
-	}
-
-	required := time.Duration(sc.DefaultMeetingDurationMin) * time.Minute
-	return slot.End.Sub(slot.Start) >= required
-}
-
-func buildWorkingDaysSet(days []string) map[time.Weekday]bool {
-	set := make(map[time.Weekday]bool)
-	for _, d := range days {
-		switch strings.ToLower(d) {
-		case config.Sunday:
-			set[time.Sunday] = true
-		case config.Monday:
-			set[time.Monday] = true
-		case config.Tuesday:
-			set[time.Tuesday] = true
-		case config.Wednesday:
-			set[time.Wednesday] = true
-		case config.Thursday:
-			set[time.Thursday] = true
-		case config.Friday:
-			set[time.Friday] = true
-		case config.Saturday:
-			set[time.Saturday] = true
-		}
-	}
-	return set
-}
-
-func minTime(a, b time.Time) time.Time {
-	if a.Before(b) {
-		return a
-	}
-	return b
-}
-
-func maxTime(a, b time.Time) time.Time {
-	if a.After(b) {
-		return a
-	}
-	return b
-}
-
-func extractDailyFrames(startStr, endStr string) (time.Time, time.Time, time.Time, time.Time, error) {
-	startParsed, err := time.Parse("15:04", startStr)
-	if err != nil {
-		return time.Time{}, time.Time{}, time.Time{}, time.Time{}, err
-	}
-	endParsed, err := time.Parse("15:04", endStr)
-	if err != nil {
-		return time.Time{}, time.Time{}, time.Time{}, time.Time{}, err
-	}
-
-	now := time.Now()
-	year, month, day := now.Date()
-	loc := now.Location()
-
-	todayStart := time.Date(year, month, day, startParsed.Hour(), startParsed.Minute(), 0, 0, loc)
-	todayEnd := time.Date(year, month, day, endParsed.Hour(), endParsed.Minute(), 0, 0, loc)
-
-	if todayEnd.Before(todayStart) {
-		todayEnd = todayStart.Add(24 * time.Hour)
-	}
-
-	tomorrowStart := todayStart.Add(24 * time.Hour)
-	tomorrowEnd := todayEnd.Add(24 * time.Hour)
-
-	return todayStart, todayEnd, tomorrowStart, tomorrowEnd, nil
-}
-
 func fetchAndApplyTimeFrameForSearch(ctx *maestro.MaestroContext) (time.Time, time.Time) {
 	now := time.Now()
 
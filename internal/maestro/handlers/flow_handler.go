@@ -3,7 +3,9 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	maestro "skeji/internal/maestro/core"
 	"skeji/internal/maestro/service"
 	"skeji/pkg/logger"
 	"time"
@@ -30,10 +32,12 @@ type ExecuteFlowResponse struct {
 	Success bool           `json:"success"`
 	Output  map[string]any `json:"output,omitempty"`
 	Error   string         `json:"error,omitempty"`
+	// Errors lists every invalid input field.
+	Errors []maestro.FieldError `json:"errors,omitempty"`
 }
 
 type ListFlowsResponse struct {
-	Flows []string `json:"flows"`
+	Flows []service.FlowInfo `json:"flows"`
 }
 
 func (h *FlowHandler) ExecuteFlow(w http.ResponseWriter, r *http.Request) {
@@ -65,6 +69,16 @@ func (h *FlowHandler) ExecuteFlow(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	output, err := h.service.ExecuteFlow(ctx, req.Flow, req.Input)
+	var inputErr *maestro.InputError
+	if errors.As(err, &inputErr) {
+		h.log.Info("invalid flow input", "flow", req.Flow, "error", err)
+		h.writeJSON(w, http.StatusBadRequest, ExecuteFlowResponse{
+			Success: false,
+			Error:   "invalid input",
+			Errors:  inputErr.Errors,
+		})
+		return
+	}
 	if err != nil {
 		h.log.Error("flow execution failed", "flow", req.Flow, "error", err)
 		h.writeError(w, http.StatusInternalServerError, err.Error())
@@ -4,49 +4,101 @@ import (
 	"context"
 	"fmt"
 	maestro "skeji/internal/maestro/core"
+	"skeji/internal/maestro/declarative"
 	"skeji/internal/maestro/flows"
 	"skeji/pkg/client"
 	"skeji/pkg/logger"
+	"skeji/pkg/ranking"
+	"sort"
 )
 
 type MaestroService struct {
 	client *client.Client
+	ranker *ranking.Ranker
+	flows  map[string]Flow
 	Logger *logger.Logger
 }
 
-func NewMaestroService(client *client.Client, logger *logger.Logger) *MaestroService {
+func NewMaestroService(client *client.Client, ranker *ranking.Ranker, logger *logger.Logger) *MaestroService {
+	flows := make(map[string]Flow, len(flowRegistry))
+	for name, flow := range flowRegistry {
+		flows[name] = flow
+	}
 	return &MaestroService{
 		client: client,
+		ranker: ranker,
+		flows:  flows,
 		Logger: logger,
 	}
 }
 
 type FlowHandler func(ctx *maestro.MaestroContext) error
 
-var flowRegistry = map[string]FlowHandler{
-	"create_business_unit": flows.CreateBusinessUnit,
-	"create_booking":       flows.CreateBooking,
-	"get_daily_schedule":   flows.GetDailySchedule,
-	"search_business":      flows.SearchBusiness,
+// Flow is a registered flow: its spec and what runs it.
+type Flow struct {
+	Spec    maestro.FlowSpec
+	Handler FlowHandler
+}
+
+// FlowInfo describes a flow in the flow catalog, with its input and output
+// as JSON Schema.
+type FlowInfo struct {
+	Name         string         `json:"name"`
+	Description  string         `json:"description,omitempty"`
+	InputSchema  map[string]any `json:"input_schema"`
+	OutputSchema map[string]any `json:"output_schema"`
+}
+
+var flowRegistry = map[string]Flow{
+	"create_business_unit": {Spec: flows.CreateBusinessUnitSpec, Handler: flows.CreateBusinessUnit},
+	"create_booking":       {Spec: flows.CreateBookingSpec, Handler: flows.CreateBooking},
+	"get_daily_schedule":   {Spec: flows.GetDailyScheduleSpec, Handler: flows.GetDailySchedule},
+	"search_business":      {Spec: flows.SearchBusinessSpec, Handler: flows.SearchBusiness},
 }
 
+// Register adds declarative flows alongside the Go flows. A flow cannot
+// replace one that is already registered.
+func (s *MaestroService) Register(definitions []*declarative.Flow) error {
+	for _, flow := range definitions {
+		if _, exists := s.flows[flow.Name]; exists {
+			return fmt.Errorf("flow %s is already registered", flow.Name)
+		}
+		s.flows[flow.Name] = Flow{Spec: flow.Spec(), Handler: flow.Run}
+		s.Logger.Info("registered declarative flow", "flow", flow.Name, "steps", len(flow.Steps))
+	}
+	return nil
+}
+
+// ExecuteFlow validates the whole input against the flow's input schema
+// before running it; invalid input is a *maestro.InputError listing every
+// field at fault, and so is an unknown flow.
 func (s *MaestroService) ExecuteFlow(ctx context.Context, flowName string, input map[string]any) (map[string]any, error) {
-	handler, exists := flowRegistry[flowName]
+	flow, exists := s.flows[flowName]
 	if !exists {
-		return nil, fmt.Errorf("unknown flow: %s", flowName)
+		return nil, maestro.NewInputError("flow", fmt.Sprintf("unknown flow %s", flowName))
+	}
+	if err := flow.Spec.Input.Validate(input); err != nil {
+		return nil, err
 	}
-	maestroCtx := maestro.NewMaestroContext(ctx, input, s.client, s.Logger)
-	err := handler(maestroCtx)
+	maestroCtx := maestro.NewMaestroContext(ctx, input, s.client, s.ranker, s.Logger)
+	err := flow.Handler(maestroCtx)
 	if err != nil {
-		return nil, fmt.Errorf("flow execution failed: %v", err)
+		return nil, fmt.Errorf("flow execution failed: %w", err)
 	}
 	return maestroCtx.Output, nil
 }
 
-func (s *MaestroService) GetAvailableFlows() []string {
-	flows := make([]string, 0, len(flowRegistry))
-	for flowName := range flowRegistry {
-		flows = append(flows, flowName)
+// GetAvailableFlows returns the flow catalog, ordered by name.
+func (s *MaestroService) GetAvailableFlows() []FlowInfo {
+	flows := make([]FlowInfo, 0, len(s.flows))
+	for flowName, flow := range s.flows {
+		flows = append(flows, FlowInfo{
+			Name:         flowName,
+			Description:  flow.Spec.Description,
+			InputSchema:  flow.Spec.Input.JSONSchema(),
+			OutputSchema: flow.Spec.Output.JSONSchema(),
+		})
 	}
+	sort.Slice(flows, func(i, j int) bool { return flows[i].Name < flows[j].Name })
 	return flows
 }
@@ -11,12 +11,16 @@ type CreateBusinessUnitInput struct {
 	Name       string   `json:"name" validate:"required,min=2,max=100"`
 	AdminPhone string   `json:"admin_phone" validate:"required,e164"`
 	Cities     []string `json:"cities" validate:"required,min=1,max=50"`
-	Labels     []string `json:"labels" validate:"required,min=1,max=10"`
+	Labels     []string `json:"labels" validate:"required_without=OrganizationID,max=10"`
 
 	// Optional Business Unit fields
 	TimeZone    string            `json:"time_zone,omitempty" validate:"omitempty,timezone"`
 	WebsiteURLs []string          `json:"website_urls,omitempty" validate:"omitempty,max=5,dive,url"`
 	Maintainers map[string]string `json:"maintainers,omitempty"`
+	// OrganizationID opens the unit as a branch of an organization, whose
+	// labels and hours fill in what is left out. The admin phone must be
+	// the organization's owner or one of its admins.
+	OrganizationID string `json:"organization_id,omitempty" validate:"omitempty"`
 
 	// Optional Schedule fields (applied to all city schedules)
 	StartOfDay                *string  `json:"start_of_day,omitempty" validate:"omitempty,valid_time_range"`
@@ -52,8 +56,8 @@ func (i *CreateBusinessUnitInput) Validate() error {
 		errors = append(errors, "cities cannot have more than 50 items")
 	}
 
-	if len(i.Labels) == 0 {
-		errors = append(errors, "labels is required (at least one label)")
+	if len(i.Labels) == 0 && i.OrganizationID == "" {
+		errors = append(errors, "labels is required (at least one label) outside an organization")
 	} else if len(i.Labels) > 10 {
 		errors = append(errors, "labels cannot have more than 10 items")
 	}
@@ -122,6 +126,9 @@ func (i *CreateBusinessUnitInput) ToMap() map[string]any {
 	if len(i.Maintainers) > 0 {
 		m["maintainers"] = i.Maintainers
 	}
+	if i.OrganizationID != "" {
+		m["organization_id"] = i.OrganizationID
+	}
 
 	// Add optional schedule fields
 	if i.StartOfDay != nil {
@@ -213,6 +220,10 @@ func FromMapCreateBusinessUnit(input map[string]any) (*CreateBusinessUnitInput,
 		}
 	}
 
+	if organizationID, ok := input["organization_id"].(string); ok {
+		i.OrganizationID = organizationID
+	}
+
 	// Extract optional schedule fields
 	if startOfDay, ok := input["start_of_day"].(string); ok {
 		i.StartOfDay = &startOfDay
@@ -149,14 +149,9 @@ func FromMapGetDailySchedule(input map[string]any) (*GetDailyScheduleInput, erro
 		}
 	}
 
-	// Extract optional time fields
-	if start, ok := input["start"].(time.Time); ok {
-		i.Start = &start
-	}
-
-	if end, ok := input["end"].(time.Time); ok {
-		i.End = &end
-	}
+	// Extract optional time fields, as times or RFC3339 strings
+	i.Start = extractTime(input["start"])
+	i.End = extractTime(input["end"])
 
 	// Validate the parsed input
 	if err := i.Validate(); err != nil {
@@ -165,3 +160,15 @@ func FromMapGetDailySchedule(input map[string]any) (*GetDailyScheduleInput, erro
 
 	return i, nil
 }
+
+func extractTime(val any) *time.Time {
+	switch v := val.(type) {
+	case time.Time:
+		return &v
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,318 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"skeji/pkg/locale"
+	"skeji/pkg/model"
+	"skeji/pkg/taxonomy"
+	"skeji/pkg/textsearch"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DataMigration is a one-off document rewrite that runs after validators and
+// indexes are in place. Each migration is recorded in `_migrations` by name and
+// is skipped on subsequent runs.
+type DataMigration struct {
+	Name string
+	Run  func(ctx context.Context, db *mongo.Database) (int64, error)
+}
+
+var DataMigrations = []DataMigration{
+	{Name: "schedules_typed_exceptions", Run: migrateScheduleExceptions},
+	{Name: "schedules_weekly_hours", Run: migrateScheduleWeeklyHours},
+	{Name: "business_units_search_keys", Run: migrateBusinessUnitSearchKeys},
+	{Name: "business_units_status", Run: migrateBusinessUnitStatus},
+	{Name: "business_units_label_taxonomy", Run: migrateBusinessUnitLabels},
+	{Name: "business_units_canonical_cities", Run: migrateBusinessUnitCities},
+	{Name: "schedules_canonical_cities", Run: migrateScheduleCities},
+}
+
+func runDataMigrations(ctx context.Context, db *mongo.Database) error {
+	meta := db.Collection("_migrations")
+	for _, m := range DataMigrations {
+		count, err := meta.CountDocuments(ctx, bson.M{"data_migration": m.Name})
+		if err != nil {
+			return fmt.Errorf("failed to check data migration %s: %w", m.Name, err)
+		}
+		if count > 0 {
+			fmt.Printf("ℹ️  Data migration %s already applied\n", m.Name)
+			continue
+		}
+
+		fmt.Printf("\n🔸 Running data migration: %s\n", m.Name)
+		modified, err := m.Run(ctx, db)
+		if err != nil {
+			return fmt.Errorf("❌ data migration %s failed: %w", m.Name, err)
+		}
+		fmt.Printf("✅ Data migration %s rewrote %d documents\n", m.Name, modified)
+
+		_, err = meta.UpdateOne(
+			ctx,
+			bson.M{"data_migration": m.Name},
+			bson.M{"$set": bson.M{"applied_at": time.Now(), "modified": modified}},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to log data migration %s: %w", m.Name, err)
+		}
+	}
+	return nil
+}
+
+// migrateScheduleExceptions converts legacy free-text exceptions into typed
+// full-day closures. Legacy values were sanitized into snake_case, so
+// "2025-12-25" was stored as "2025_12_25"; anything that does not parse as a
+// date is dropped.
+func migrateScheduleExceptions(ctx context.Context, db *mongo.Database) (int64, error) {
+	coll := db.Collection("Schedules")
+	cursor, err := coll.Find(ctx, bson.M{"exceptions": bson.M{"$type": "string"}})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var modified int64
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID         any   `bson:"_id"`
+			Exceptions []any `bson:"exceptions"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return modified, err
+		}
+
+		converted := []bson.M{}
+		seen := map[string]bool{}
+		for _, raw := range doc.Exceptions {
+			switch v := raw.(type) {
+			case string:
+				date := strings.ReplaceAll(strings.TrimSpace(v), "_", "-")
+				if _, err := time.Parse(time.DateOnly, date); err != nil || seen[date] {
+					continue
+				}
+				seen[date] = true
+				converted = append(converted, bson.M{"type": "closed", "date": date})
+			case bson.D:
+				converted = append(converted, v.Map())
+			case bson.M:
+				converted = append(converted, v)
+			}
+		}
+
+		res, err := coll.UpdateOne(ctx, bson.M{"_id": doc.ID}, bson.M{"$set": bson.M{"exceptions": converted}})
+		if err != nil {
+			return modified, err
+		}
+		modified += res.ModifiedCount
+	}
+	return modified, cursor.Err()
+}
+
+// migrateScheduleWeeklyHours backfills weekly_hours from the flat
+// working_days/start_of_day/end_of_day fields, one interval per working day.
+func migrateScheduleWeeklyHours(ctx context.Context, db *mongo.Database) (int64, error) {
+	coll := db.Collection("Schedules")
+	cursor, err := coll.Find(ctx, bson.M{"weekly_hours": bson.M{"$exists": false}})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var modified int64
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID          any      `bson:"_id"`
+			WorkingDays []string `bson:"working_days"`
+			StartOfDay  string   `bson:"start_of_day"`
+			EndOfDay    string   `bson:"end_of_day"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return modified, err
+		}
+
+		days := make([]string, 0, len(doc.WorkingDays))
+		for _, day := range doc.WorkingDays {
+			days = append(days, strings.ToLower(strings.TrimSpace(day)))
+		}
+		hours := model.WeeklyHoursFromFlat(days, doc.StartOfDay, doc.EndOfDay)
+
+		res, err := coll.UpdateOne(ctx, bson.M{"_id": doc.ID}, bson.M{"$set": bson.M{"weekly_hours": hours}})
+		if err != nil {
+			return modified, err
+		}
+		modified += res.ModifiedCount
+	}
+	return modified, cursor.Err()
+}
+
+// migrateBusinessUnitSearchKeys backfills the text search keys of business
+// units created before name search existed.
+func migrateBusinessUnitSearchKeys(ctx context.Context, db *mongo.Database) (int64, error) {
+	coll := db.Collection("Business_units")
+	cursor, err := coll.Find(ctx, bson.M{"search_keys": bson.M{"$exists": false}})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var modified int64
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID     any      `bson:"_id"`
+			Name   string   `bson:"name"`
+			Labels []string `bson:"labels"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return modified, err
+		}
+
+		keys := textsearch.Keys(append([]string{doc.Name}, doc.Labels...)...)
+		res, err := coll.UpdateOne(ctx, bson.M{"_id": doc.ID}, bson.M{"$set": bson.M{"search_keys": keys}})
+		if err != nil {
+			return modified, err
+		}
+		modified += res.ModifiedCount
+	}
+	return modified, cursor.Err()
+}
+
+// migrateBusinessUnitStatus marks business units created before lifecycle
+// statuses existed as active, which is how they behaved.
+func migrateBusinessUnitStatus(ctx context.Context, db *mongo.Database) (int64, error) {
+	res, err := db.Collection("Business_units").UpdateMany(
+		ctx,
+		bson.M{"status": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"status": "active"}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// migrateBusinessUnitLabels resolves the labels of existing business units to
+// canonical taxonomy labels and indexes them under their parent categories.
+func migrateBusinessUnitLabels(ctx context.Context, db *mongo.Database) (int64, error) {
+	coll := db.Collection("Business_units")
+	cursor, err := coll.Find(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var modified int64
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID     any      `bson:"_id"`
+			Name   string   `bson:"name"`
+			Cities []string `bson:"cities"`
+			Labels []string `bson:"labels"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return modified, err
+		}
+
+		labels := taxonomy.Canonicalize(doc.Labels)
+		res, err := coll.UpdateOne(ctx, bson.M{"_id": doc.ID}, bson.M{"$set": bson.M{
+			"labels":           labels,
+			"city_label_pairs": cityLabelPairs(doc.Cities, labels),
+			"search_keys":      textsearch.Keys(append([]string{doc.Name}, labels...)...),
+		}})
+		if err != nil {
+			return modified, err
+		}
+		modified += res.ModifiedCount
+	}
+	return modified, cursor.Err()
+}
+
+// migrateBusinessUnitCities resolves the cities of existing business units to
+// gazetteer IDs, so aliases stored before the gazetteer existed are searchable.
+func migrateBusinessUnitCities(ctx context.Context, db *mongo.Database) (int64, error) {
+	coll := db.Collection("Business_units")
+	cursor, err := coll.Find(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var modified int64
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID     any      `bson:"_id"`
+			Cities []string `bson:"cities"`
+			Labels []string `bson:"labels"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return modified, err
+		}
+
+		cities := locale.CanonicalCities(doc.Cities)
+		res, err := coll.UpdateOne(ctx, bson.M{"_id": doc.ID}, bson.M{"$set": bson.M{
+			"cities":           cities,
+			"city_label_pairs": cityLabelPairs(cities, doc.Labels),
+		}})
+		if err != nil {
+			return modified, err
+		}
+		modified += res.ModifiedCount
+	}
+	return modified, cursor.Err()
+}
+
+// migrateScheduleCities resolves schedule cities to gazetteer IDs. A schedule
+// that would then collide with another branch of the same business at the same
+// address is left as it is and reported.
+func migrateScheduleCities(ctx context.Context, db *mongo.Database) (int64, error) {
+	coll := db.Collection("Schedules")
+	cursor, err := coll.Find(ctx, bson.M{})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var modified int64
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID   any    `bson:"_id"`
+			City string `bson:"city"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return modified, err
+		}
+
+		city := locale.CanonicalCity(doc.City)
+		if city == doc.City {
+			continue
+		}
+		res, err := coll.UpdateOne(ctx, bson.M{"_id": doc.ID}, bson.M{"$set": bson.M{"city": city}})
+		if mongo.IsDuplicateKeyError(err) {
+			fmt.Printf("⚠️  Schedule %v kept city %s, %s already has a branch at its address\n", doc.ID, doc.City, city)
+			continue
+		}
+		if err != nil {
+			return modified, err
+		}
+		modified += res.ModifiedCount
+	}
+	return modified, cursor.Err()
+}
+
+// cityLabelPairs indexes a business unit under every city and every label
+// with its parent categories, the same way the business units service does.
+func cityLabelPairs(cities, labels []string) []string {
+	pairs := []string{}
+	for _, city := range cities {
+		for _, label := range taxonomy.Expand(labels) {
+			pairs = append(pairs, fmt.Sprintf("%s|%s", city, label))
+		}
+	}
+	return pairs
+}
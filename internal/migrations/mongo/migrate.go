@@ -22,6 +22,9 @@ var (
 			{Key: "city_label_pairs", Value: 1},
 			{Key: "priority", Value: -1},
 		}},
+		{Keys: bson.D{{Key: "search_keys", Value: 1}}},
+		{Keys: bson.D{{Key: "organization_id", Value: 1}}},
+		{Keys: bson.D{{Key: "attribute_terms", Value: 1}}},
 		{
 			Keys: bson.D{
 				{Key: "admin_phone", Value: 1},
@@ -45,6 +48,18 @@ var (
 			},
 			Options: options.Index().SetUnique(true),
 		},
+		// sparse by nature: schedules without a location are left out
+		{Keys: bson.D{{Key: "location", Value: "2dsphere"}}},
+	}
+
+	ScheduleTemplatesIndexes = []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "business_id", Value: 1},
+				{Key: "name", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
 	}
 
 	BookingsIndexes = []mongo.IndexModel{
@@ -60,6 +75,81 @@ var (
 		}},
 	}
 
+	BookingsArchiveIndexes = []mongo.IndexModel{
+		{Keys: bson.D{
+			{Key: "business_id", Value: 1},
+			{Key: "schedule_id", Value: 1},
+			{Key: "start_time", Value: 1},
+		}},
+	}
+
+	BusinessUnitDeletionsIndexes = []mongo.IndexModel{
+		{Keys: bson.D{{Key: "business_id", Value: 1}}},
+		{Keys: bson.D{
+			{Key: "status", Value: 1},
+			{Key: "next_attempt_at", Value: 1},
+		}},
+	}
+
+	BusinessUnitImpressionsIndexes = []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "business_id", Value: 1},
+				{Key: "day", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+	}
+
+	BusinessUnitTransfersIndexes = []mongo.IndexModel{
+		{
+			// one pending transfer per business unit
+			Keys: bson.D{{Key: "business_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": "pending"}),
+		},
+		{Keys: bson.D{{Key: "to_phone", Value: 1}, {Key: "status", Value: 1}}},
+	}
+
+	BusinessUnitAuditLogIndexes = []mongo.IndexModel{
+		{Keys: bson.D{
+			{Key: "business_id", Value: 1},
+			{Key: "created_at", Value: -1},
+		}},
+	}
+
+	BusinessUnitMergesIndexes = []mongo.IndexModel{
+		{
+			// a unit is merged away at most once; the merge is its tombstone
+			Keys:    bson.D{{Key: "source_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "target_id", Value: 1}}},
+	}
+
+	OrganizationsIndexes = []mongo.IndexModel{
+		{Keys: bson.D{{Key: "admin_phone", Value: 1}}},
+		{Keys: bson.D{{Key: "admins", Value: 1}}},
+	}
+
+	PlanAssignmentsIndexes = []mongo.IndexModel{
+		{
+			// one plan per admin phone
+			Keys: bson.D{{Key: "phone", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"phone": bson.M{"$exists": true}}),
+		},
+		{
+			// one plan per organization
+			Keys: bson.D{{Key: "organization_id", Value: 1}},
+			Options: options.Index().
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"organization_id": bson.M{"$exists": true}}),
+		},
+	}
+
 	BookingLocksIndexes = []mongo.IndexModel{
 		{
 			Keys:    bson.D{{Key: "expires_at", Value: 1}},
@@ -80,10 +170,18 @@ func RunMigration(ctx context.Context, client *mongo.Client) error {
 			Indexes:   BusinessUnitsIndexes,
 			Validator: validators.BusinessUnitValidator,
 		},
+		"Organizations": {
+			Indexes:   OrganizationsIndexes,
+			Validator: validators.OrganizationValidator,
+		},
 		"Schedules": {
 			Indexes:   SchedulesIndexes,
 			Validator: validators.ScheduleValidator,
 		},
+		"Schedule_templates": {
+			Indexes:   ScheduleTemplatesIndexes,
+			Validator: validators.ScheduleTemplateValidator,
+		},
 		"Bookings": {
 			Indexes:   BookingsIndexes,
 			Validator: validators.BookingValidator,
@@ -92,6 +190,34 @@ func RunMigration(ctx context.Context, client *mongo.Client) error {
 			Indexes:   BookingLocksIndexes,
 			Validator: nil, // No validator needed for simple lock collection
 		},
+		"Bookings_archive": {
+			Indexes:   BookingsArchiveIndexes,
+			Validator: nil, // Documents are validated bookings moved out of Bookings
+		},
+		"Business_unit_deletions": {
+			Indexes:   BusinessUnitDeletionsIndexes,
+			Validator: nil, // Written only by the business units service
+		},
+		"Business_unit_impressions": {
+			Indexes:   BusinessUnitImpressionsIndexes,
+			Validator: nil, // Counters written only by the business units service
+		},
+		"Business_unit_transfers": {
+			Indexes:   BusinessUnitTransfersIndexes,
+			Validator: nil, // Written only by the business units service
+		},
+		"Business_unit_audit_log": {
+			Indexes:   BusinessUnitAuditLogIndexes,
+			Validator: nil, // Append-only, written by the business units service
+		},
+		"Business_unit_merges": {
+			Indexes:   BusinessUnitMergesIndexes,
+			Validator: nil, // Written only by the business units service
+		},
+		"Plan_assignments": {
+			Indexes:   PlanAssignmentsIndexes,
+			Validator: nil, // Written only by the business units service
+		},
 	}
 
 	for name, def := range collections {
@@ -108,6 +234,10 @@ func RunMigration(ctx context.Context, client *mongo.Client) error {
 		}
 	}
 
+	if err := runDataMigrations(ctx, db); err != nil {
+		return err
+	}
+
 	fmt.Println("\n✅ All migrations applied successfully.")
 	return nil
 }
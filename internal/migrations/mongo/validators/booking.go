@@ -39,6 +39,12 @@ var BookingValidator = bson.M{
 				"maxLength": 100,
 			},
 
+			"staff_id": bson.M{
+				"bsonType":  "string",
+				"minLength": 24,
+				"maxLength": 24,
+			},
+
 			"start_time": bson.M{
 				"bsonType": "date",
 			},
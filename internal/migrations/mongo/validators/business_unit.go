@@ -51,6 +51,13 @@ var BusinessUnitValidator = bson.M{
 				},
 			},
 
+			"viewers": bson.M{
+				"bsonType": "object",
+				"additionalProperties": bson.M{
+					"bsonType": "string",
+				},
+			},
+
 			"priority": bson.M{
 				"bsonType": "long",
 				"minimum":  0,
@@ -68,6 +75,19 @@ var BusinessUnitValidator = bson.M{
 				},
 			},
 
+			"organization_id": bson.M{
+				"bsonType": "string",
+			},
+
+			"status": bson.M{
+				"enum": []string{"draft", "active", "suspended", "archived"},
+			},
+
+			"status_reason": bson.M{
+				"bsonType":  "string",
+				"maxLength": 500,
+			},
+
 			"created_at": bson.M{
 				"bsonType": "date",
 			},
@@ -78,6 +98,13 @@ var BusinessUnitValidator = bson.M{
 					"bsonType": "string",
 				},
 			},
+
+			"search_keys": bson.M{
+				"bsonType": "array",
+				"items": bson.M{
+					"bsonType": "string",
+				},
+			},
 		},
 	},
 }
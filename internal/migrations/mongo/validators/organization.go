@@ -0,0 +1,90 @@
+package validators
+
+import "go.mongodb.org/mongo-driver/bson"
+
+var OrganizationValidator = bson.M{
+	"$jsonSchema": bson.M{
+		"bsonType":             "object",
+		"required":             []string{"name", "admin_phone", "created_at"},
+		"additionalProperties": true,
+		"properties": bson.M{
+			"_id": bson.M{
+				"bsonType": "objectId",
+			},
+
+			"name": bson.M{
+				"bsonType":  "string",
+				"minLength": 2,
+				"maxLength": 100,
+			},
+
+			"admin_phone": bson.M{
+				"bsonType":  "string",
+				"minLength": 8,
+				"maxLength": 16,
+			},
+
+			"admins": bson.M{
+				"bsonType": "object",
+				"additionalProperties": bson.M{
+					"bsonType": "string",
+				},
+			},
+
+			"labels": bson.M{
+				"bsonType": "array",
+				"maxItems": 10,
+				"items": bson.M{
+					"bsonType":  "string",
+					"minLength": 1,
+				},
+			},
+
+			"services": bson.M{
+				"bsonType": "array",
+				"maxItems": 50,
+				"items": bson.M{
+					"bsonType":  "string",
+					"minLength": 2,
+				},
+			},
+
+			"hours": bson.M{
+				"bsonType": "object",
+				"required": []string{"start_of_day", "end_of_day", "working_days"},
+				"properties": bson.M{
+					"start_of_day": bson.M{
+						"bsonType": "string",
+					},
+					"end_of_day": bson.M{
+						"bsonType": "string",
+					},
+					"working_days": bson.M{
+						"bsonType": "array",
+						"minItems": 1,
+						"maxItems": 7,
+						"items": bson.M{
+							"enum": []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"},
+						},
+					},
+				},
+			},
+
+			"time_zone": bson.M{
+				"bsonType": "string",
+			},
+
+			"website_urls": bson.M{
+				"bsonType": "array",
+				"maxItems": 5,
+				"items": bson.M{
+					"bsonType": "string",
+				},
+			},
+
+			"created_at": bson.M{
+				"bsonType": "date",
+			},
+		},
+	},
+}
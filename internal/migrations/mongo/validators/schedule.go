@@ -58,6 +58,24 @@ var ScheduleValidator = bson.M{
 				"bsonType": "string",
 			},
 
+			"location": bson.M{
+				"bsonType": "object",
+				"required": []string{"type", "coordinates"},
+				"properties": bson.M{
+					"type": bson.M{
+						"enum": []string{"Point"},
+					},
+					"coordinates": bson.M{
+						"bsonType": "array",
+						"minItems": 2,
+						"maxItems": 2,
+						"items": bson.M{
+							"bsonType": "double",
+						},
+					},
+				},
+			},
+
 			"working_days": bson.M{
 				"bsonType": "array",
 				"minItems": 1,
@@ -68,6 +86,41 @@ var ScheduleValidator = bson.M{
 				},
 			},
 
+			"weekly_hours": bson.M{
+				"bsonType": "array",
+				"maxItems": 7,
+				"items": bson.M{
+					"bsonType": "object",
+					"required": []string{"day", "intervals"},
+					"properties": bson.M{
+						"day": bson.M{
+							"bsonType": "string",
+							"enum": []string{
+								"sunday", "monday", "tuesday", "wednesday",
+								"thursday", "friday", "saturday",
+							},
+						},
+						"intervals": bson.M{
+							"bsonType": "array",
+							"minItems": 1,
+							"maxItems": 4,
+							"items": bson.M{
+								"bsonType": "object",
+								"required": []string{"start", "end"},
+								"properties": bson.M{
+									"start": bson.M{
+										"bsonType": "string",
+									},
+									"end": bson.M{
+										"bsonType": "string",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
 			"default_meeting_duration_min": bson.M{
 				"bsonType": "int",
 				"minimum":  5,
@@ -88,9 +141,134 @@ var ScheduleValidator = bson.M{
 
 			"exceptions": bson.M{
 				"bsonType": "array",
-				"maxItems": 10,
+				"maxItems": 50,
+				"items": bson.M{
+					"bsonType": "object",
+					"required": []string{"type", "date"},
+					"properties": bson.M{
+						"type": bson.M{
+							"bsonType": "string",
+							"enum":     []string{"closed", "special_hours"},
+						},
+						"date": bson.M{
+							"bsonType":  "string",
+							"minLength": 10,
+							"maxLength": 10,
+						},
+						"end_date": bson.M{
+							"bsonType":  "string",
+							"minLength": 10,
+							"maxLength": 10,
+						},
+						"start_of_day": bson.M{
+							"bsonType": "string",
+						},
+						"end_of_day": bson.M{
+							"bsonType": "string",
+						},
+						"reason": bson.M{
+							"bsonType":  "string",
+							"maxLength": 200,
+						},
+					},
+				},
+			},
+
+			"staff": bson.M{
+				"bsonType": "array",
+				"maxItems": 50,
+				"items": bson.M{
+					"bsonType": "object",
+					"required": []string{"id", "name"},
+					"properties": bson.M{
+						"id": bson.M{
+							"bsonType":  "string",
+							"minLength": 24,
+							"maxLength": 24,
+						},
+						"name": bson.M{
+							"bsonType":  "string",
+							"minLength": 2,
+							"maxLength": 100,
+						},
+						"services": bson.M{
+							"bsonType": "array",
+							"maxItems": 20,
+							"items": bson.M{
+								"bsonType": "string",
+							},
+						},
+						"weekly_hours": bson.M{
+							"bsonType": "array",
+							"maxItems": 7,
+						},
+						"time_off": bson.M{
+							"bsonType": "array",
+							"maxItems": 50,
+							"items": bson.M{
+								"bsonType": "object",
+								"required": []string{"start", "end"},
+								"properties": bson.M{
+									"start": bson.M{
+										"bsonType": "date",
+									},
+									"end": bson.M{
+										"bsonType": "date",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"seasons": bson.M{
+				"bsonType": "array",
+				"maxItems": 20,
 				"items": bson.M{
-					"bsonType": "string",
+					"bsonType": "object",
+					"required": []string{"effective_from", "start_of_day", "end_of_day", "working_days"},
+					"properties": bson.M{
+						"name": bson.M{
+							"bsonType":  "string",
+							"maxLength": 100,
+						},
+						"effective_from": bson.M{
+							"bsonType":  "string",
+							"minLength": 10,
+							"maxLength": 10,
+						},
+						"effective_until": bson.M{
+							"bsonType":  "string",
+							"minLength": 10,
+							"maxLength": 10,
+						},
+						"start_of_day": bson.M{
+							"bsonType": "string",
+						},
+						"end_of_day": bson.M{
+							"bsonType": "string",
+						},
+						"working_days": bson.M{
+							"bsonType": "array",
+							"minItems": 1,
+							"maxItems": 7,
+						},
+						"weekly_hours": bson.M{
+							"bsonType": "array",
+							"maxItems": 7,
+						},
+						"default_meeting_duration_min": bson.M{
+							"bsonType": "int",
+							"minimum":  5,
+							"maximum":  480,
+						},
+						"default_break_duration_min": bson.M{
+							"bsonType": "int",
+							"minimum":  0,
+							"maximum":  480,
+						},
+					},
 				},
 			},
 
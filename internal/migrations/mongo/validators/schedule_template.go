@@ -0,0 +1,90 @@
+package validators
+
+import "go.mongodb.org/mongo-driver/bson"
+
+var ScheduleTemplateValidator = bson.M{
+	"$jsonSchema": bson.M{
+		"bsonType": "object",
+		"required": []string{
+			"business_id",
+			"name",
+			"start_of_day",
+			"end_of_day",
+			"working_days",
+			"default_meeting_duration_min",
+			"default_break_duration_min",
+			"max_participants_per_slot",
+			"time_zone",
+			"created_at",
+		},
+		"additionalProperties": true,
+
+		"properties": bson.M{
+			"_id": bson.M{
+				"bsonType": "objectId",
+			},
+
+			"business_id": bson.M{
+				"bsonType":  "string",
+				"minLength": 24,
+				"maxLength": 24,
+			},
+
+			"name": bson.M{
+				"bsonType":  "string",
+				"minLength": 2,
+				"maxLength": 100,
+			},
+
+			"start_of_day": bson.M{
+				"bsonType": "string",
+			},
+
+			"end_of_day": bson.M{
+				"bsonType": "string",
+			},
+
+			"working_days": bson.M{
+				"bsonType": "array",
+				"minItems": 1,
+				"maxItems": 7,
+				"items": bson.M{
+					"bsonType":  "string",
+					"minLength": 1,
+				},
+			},
+
+			"weekly_hours": bson.M{
+				"bsonType": "array",
+				"maxItems": 7,
+			},
+
+			"default_meeting_duration_min": bson.M{
+				"bsonType": "int",
+				"minimum":  5,
+				"maximum":  480,
+			},
+
+			"default_break_duration_min": bson.M{
+				"bsonType": "int",
+				"minimum":  0,
+				"maximum":  480,
+			},
+
+			"max_participants_per_slot": bson.M{
+				"bsonType": "int",
+				"minimum":  1,
+				"maximum":  200,
+			},
+
+			"time_zone": bson.M{
+				"bsonType":  "string",
+				"minLength": 1,
+			},
+
+			"created_at": bson.M{
+				"bsonType": "date",
+			},
+		},
+	},
+}
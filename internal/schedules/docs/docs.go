@@ -73,6 +73,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.Schedule"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -88,6 +101,77 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedules/batch-search": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Batch search schedules across multiple cities",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "business_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of cities, by name or any alias",
+                        "name": "cities",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -137,6 +221,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
+                "description": "Fails with 409 while the schedule has active upcoming or ongoing bookings, unless mode is \"cancel\" (cancel and notify) or \"move\" (reassign to target_schedule_id in the same business).",
                 "produces": [
                     "application/json"
                 ],
@@ -151,23 +236,88 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "cancel",
+                            "move"
+                        ],
+                        "type": "string",
+                        "description": "How to handle upcoming and ongoing bookings",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Schedule receiving the bookings when mode is move",
+                        "name": "target_schedule_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
                     }
                 ],
                 "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ScheduleDeletion"
+                        }
+                    },
                     "204": {
                         "description": "No Content"
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
                     }
                 }
             },
@@ -198,6 +348,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/model.ScheduleUpdate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -210,6 +373,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -225,39 +400,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/schedules/search": {
+        "/api/v1/schedules/id/{id}/availability": {
             "get": {
+                "description": "Returns bookable slots for the schedule between from and to, respecting working hours, exceptions, breaks, meeting duration, capacity and existing bookings. Defaults to the next 24 hours. On schedules with staff, slots are computed per staff member.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Schedules"
                 ],
-                "summary": "Search schedules by business and city",
+                "summary": "Get schedule availability",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Business ID",
-                        "name": "business_id",
-                        "in": "query",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "City",
-                        "name": "city",
+                        "description": "Range start (RFC3339), defaults to now",
+                        "name": "from",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "Range end (RFC3339), defaults to from + 24h",
+                        "name": "to",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Offset",
-                        "name": "offset",
+                        "type": "string",
+                        "description": "Service label, limits staff to members offering it",
+                        "name": "service",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Staff member ID",
+                        "name": "staff",
                         "in": "query"
                     }
                 ],
@@ -265,7 +447,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/http.PaginatedResponse"
+                            "$ref": "#/definitions/model.Availability"
                         }
                     },
                     "400": {
@@ -274,49 +456,805 @@ const docTemplate = `{
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/http.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "http.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "details": {
-                    "type": "object",
-                    "additionalProperties": {}
-                },
-                "error": {
-                    "type": "string"
-                }
-            }
         },
-        "http.PaginatedResponse": {
-            "type": "object",
-            "properties": {
-                "data": {},
-                "limit": {
-                    "type": "integer"
-                },
-                "offset": {
-                    "type": "integer"
-                },
-                "total_count": {
-                    "type": "integer"
+        "/api/v1/schedules/id/{id}/clone": {
+            "post": {
+                "description": "Creates a schedule at a new city and address with the hours, durations, capacity, time zone and exceptions of an existing one. Staff members are not copied.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Clone schedule to a new branch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New location",
+                        "name": "target",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ScheduleClone"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Schedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
                 }
             }
         },
-        "model.Schedule": {
-            "type": "object",
-            "required": [
-                "address",
-                "business_id",
+        "/api/v1/schedules/id/{id}/reassign": {
+            "post": {
+                "description": "Hands the schedule over to another business unit, e.g. when duplicate units are merged, within the target's plan. Only the services may call it; the bookings are moved separately through the bookings service. A dry run only checks that the schedule may move.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Reassign schedule to another business unit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target business unit",
+                        "name": "target",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ScheduleReassign"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Schedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedules/near": {
+            "get": {
+                "description": "Returns schedules with a location within radius meters of lat/lng, nearest first, each with its distance_meters. Schedules without a location are not returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Search schedules near a location",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Latitude",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude",
+                        "name": "lng",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Radius in meters (default 5000, max 100000)",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "business_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedules/search": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Search schedules by business and city",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "business_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "City, by name or any alias",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedules/templates": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule Templates"
+                ],
+                "summary": "Create a schedule template",
+                "parameters": [
+                    {
+                        "description": "Schedule template data",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ScheduleTemplate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.ScheduleTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedules/templates/id/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule Templates"
+                ],
+                "summary": "Get schedule template by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ScheduleTemplate"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule Templates"
+                ],
+                "summary": "Delete schedule template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedules/templates/id/{id}/apply": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule Templates"
+                ],
+                "summary": "Create a schedule from a template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schedule template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Location of the new schedule",
+                        "name": "target",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ScheduleClone"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caller: a phone number in E.164 format, or system for other services",
+                        "name": "X-Caller",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 of X-Caller, required when the services share a secret",
+                        "name": "X-Caller-Signature",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Schedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedules/templates/search": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule Templates"
+                ],
+                "summary": "Search schedule templates by business",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Business ID",
+                        "name": "business_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/http.PaginatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "http.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "http.PaginatedResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "facets": {
+                    "description": "Facets summarizes the whole result set, on searches that ask for it."
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AffectedBooking": {
+            "type": "object",
+            "properties": {
+                "end_time": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "type": "string"
+                },
+                "participants": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Attributes": {
+            "type": "object",
+            "additionalProperties": {}
+        },
+        "model.Availability": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "schedule_id": {
+                    "type": "string"
+                },
+                "service": {
+                    "type": "string"
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AvailabilitySlot"
+                    }
+                },
+                "staff_id": {
+                    "type": "string"
+                },
+                "time_zone": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AvailabilitySlot": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "end": {
+                    "type": "string"
+                },
+                "remaining": {
+                    "type": "integer"
+                },
+                "staff_id": {
+                    "type": "string"
+                },
+                "staff_name": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DayHours": {
+            "type": "object",
+            "required": [
+                "day",
+                "intervals"
+            ],
+            "properties": {
+                "day": {
+                    "type": "string"
+                },
+                "intervals": {
+                    "type": "array",
+                    "maxItems": 4,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.TimeRange"
+                    }
+                }
+            }
+        },
+        "model.GeoPoint": {
+            "type": "object",
+            "required": [
+                "coordinates",
+                "type"
+            ],
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Schedule": {
+            "type": "object",
+            "required": [
+                "address",
+                "business_id",
                 "city",
                 "default_break_duration_min",
                 "default_meeting_duration_min",
@@ -333,6 +1271,9 @@ const docTemplate = `{
                     "maxLength": 200,
                     "minLength": 2
                 },
+                "attributes": {
+                    "$ref": "#/definitions/model.Attributes"
+                },
                 "business_id": {
                     "type": "string"
                 },
@@ -359,10 +1300,220 @@ const docTemplate = `{
                 },
                 "exceptions": {
                     "type": "array",
-                    "maxItems": 10,
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/model.ScheduleException"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location": {
+                    "$ref": "#/definitions/model.GeoPoint"
+                },
+                "max_participants_per_slot": {
+                    "type": "integer",
+                    "maximum": 200,
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "seasons": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "$ref": "#/definitions/model.ScheduleSeason"
+                    }
+                },
+                "staff": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/model.StaffMember"
+                    }
+                },
+                "start_of_day": {
+                    "type": "string"
+                },
+                "time_zone": {
+                    "type": "string"
+                },
+                "weekly_hours": {
+                    "type": "array",
+                    "maxItems": 7,
+                    "items": {
+                        "$ref": "#/definitions/model.DayHours"
+                    }
+                },
+                "working_days": {
+                    "type": "array",
+                    "maxItems": 7,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ScheduleClone": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ScheduleDeletion": {
+            "type": "object",
+            "properties": {
+                "affected_bookings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AffectedBooking"
+                    }
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "schedule_id": {
+                    "type": "string"
+                },
+                "target_schedule_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ScheduleException": {
+            "type": "object",
+            "required": [
+                "date",
+                "type"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "end_of_day": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "start_of_day": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "closed",
+                        "special_hours"
+                    ]
+                }
+            }
+        },
+        "model.ScheduleReassign": {
+            "type": "object",
+            "properties": {
+                "business_id": {
+                    "type": "string"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.ScheduleSeason": {
+            "type": "object",
+            "required": [
+                "effective_from"
+            ],
+            "properties": {
+                "default_break_duration_min": {
+                    "type": "integer",
+                    "maximum": 480,
+                    "minimum": 0
+                },
+                "default_meeting_duration_min": {
+                    "type": "integer",
+                    "maximum": 480,
+                    "minimum": 5
+                },
+                "effective_from": {
+                    "type": "string"
+                },
+                "effective_until": {
+                    "type": "string"
+                },
+                "end_of_day": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "start_of_day": {
+                    "type": "string"
+                },
+                "weekly_hours": {
+                    "type": "array",
+                    "maxItems": 7,
+                    "items": {
+                        "$ref": "#/definitions/model.DayHours"
+                    }
+                },
+                "working_days": {
+                    "type": "array",
+                    "maxItems": 7,
                     "items": {
                         "type": "string"
                     }
+                }
+            }
+        },
+        "model.ScheduleTemplate": {
+            "type": "object",
+            "required": [
+                "business_id",
+                "default_meeting_duration_min",
+                "end_of_day",
+                "max_participants_per_slot",
+                "name",
+                "start_of_day",
+                "time_zone",
+                "working_days"
+            ],
+            "properties": {
+                "business_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "default_break_duration_min": {
+                    "type": "integer",
+                    "maximum": 480,
+                    "minimum": 0
+                },
+                "default_meeting_duration_min": {
+                    "type": "integer",
+                    "maximum": 480,
+                    "minimum": 5
+                },
+                "end_of_day": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "string"
@@ -383,6 +1534,13 @@ const docTemplate = `{
                 "time_zone": {
                     "type": "string"
                 },
+                "weekly_hours": {
+                    "type": "array",
+                    "maxItems": 7,
+                    "items": {
+                        "$ref": "#/definitions/model.DayHours"
+                    }
+                },
                 "working_days": {
                     "type": "array",
                     "maxItems": 7,
@@ -401,6 +1559,9 @@ const docTemplate = `{
                     "maxLength": 200,
                     "minLength": 2
                 },
+                "attributes": {
+                    "$ref": "#/definitions/model.Attributes"
+                },
                 "city": {
                     "type": "string",
                     "maxLength": 100,
@@ -421,11 +1582,14 @@ const docTemplate = `{
                 },
                 "exceptions": {
                     "type": "array",
-                    "maxItems": 10,
+                    "maxItems": 50,
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/model.ScheduleException"
                     }
                 },
+                "location": {
+                    "$ref": "#/definitions/model.GeoPoint"
+                },
                 "max_participants_per_slot": {
                     "type": "integer",
                     "maximum": 200,
@@ -436,12 +1600,34 @@ const docTemplate = `{
                     "maxLength": 100,
                     "minLength": 2
                 },
+                "seasons": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "$ref": "#/definitions/model.ScheduleSeason"
+                    }
+                },
+                "staff": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/model.StaffMember"
+                    }
+                },
                 "start_of_day": {
                     "type": "string"
                 },
                 "time_zone": {
                     "type": "string"
                 },
+                "weekly_hours": {
+                    "type": "array",
+                    "maxItems": 7,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.DayHours"
+                    }
+                },
                 "working_days": {
                     "type": "array",
                     "maxItems": 7,
@@ -451,6 +1637,77 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "model.StaffMember": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "services": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "time_off": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/model.StaffTimeOff"
+                    }
+                },
+                "weekly_hours": {
+                    "type": "array",
+                    "maxItems": 7,
+                    "items": {
+                        "$ref": "#/definitions/model.DayHours"
+                    }
+                }
+            }
+        },
+        "model.StaffTimeOff": {
+            "type": "object",
+            "required": [
+                "end",
+                "start"
+            ],
+            "properties": {
+                "end": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.TimeRange": {
+            "type": "object",
+            "required": [
+                "end",
+                "start"
+            ],
+            "properties": {
+                "end": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
@@ -6,4 +6,6 @@ var (
 	ErrNotFound = errors.New("schedule not found")
 
 	ErrInvalidID = errors.New("invalid schedule ID format")
+
+	ErrTemplateNotFound = errors.New("schedule template not found")
 )
@@ -2,14 +2,18 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 	httpSwagger "github.com/swaggo/http-swagger"
 
 	_ "skeji/internal/schedules/docs" // Import generated swagger docs
 	"skeji/internal/schedules/service"
+	apperrors "skeji/pkg/errors"
 	httputil "skeji/pkg/http"
 	"skeji/pkg/logger"
 	"skeji/pkg/model"
@@ -32,8 +36,12 @@ func NewScheduleHandler(service service.ScheduleService, log *logger.Logger) *Sc
 // @Accept json
 // @Produce json
 // @Param schedule body model.Schedule true "Schedule data"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
 // @Success 201 {object} model.Schedule
 // @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
 // @Failure 500 {object} httputil.ErrorResponse
 // @Router /api/v1/schedules [post]
 func (h *ScheduleHandler) Create(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
@@ -127,9 +135,13 @@ func (h *ScheduleHandler) GetAll(w http.ResponseWriter, r *http.Request, _ httpr
 // @Produce json
 // @Param id path string true "Schedule ID"
 // @Param schedule body model.ScheduleUpdate true "Schedule update"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
 // @Success 204 "No Content"
 // @Failure 400 {object} httputil.ErrorResponse
 // @Failure 404 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
 // @Failure 500 {object} httputil.ErrorResponse
 // @Router /api/v1/schedules/id/{id} [patch]
 func (h *ScheduleHandler) Update(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
@@ -164,11 +176,22 @@ func (h *ScheduleHandler) Update(w http.ResponseWriter, r *http.Request, ps http
 }
 
 // @Summary Delete schedule
+// @Description Fails with 409 while the schedule has active upcoming or ongoing bookings, unless mode is "cancel" (cancel and notify) or "move" (reassign to target_schedule_id in the same business).
 // @Tags Schedules
 // @Produce json
 // @Param id path string true "Schedule ID"
+// @Param mode query string false "How to handle upcoming and ongoing bookings" Enums(cancel, move)
+// @Param target_schedule_id query string false "Schedule receiving the bookings when mode is move"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 200 {object} model.ScheduleDeletion
 // @Success 204 "No Content"
+// @Failure 400 {object} httputil.ErrorResponse
 // @Failure 404 {object} httputil.ErrorResponse
+// @Failure 409 {object} httputil.ErrorResponse
+// @Failure 503 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
 // @Failure 500 {object} httputil.ErrorResponse
 // @Router /api/v1/schedules/id/{id} [delete]
 func (h *ScheduleHandler) Delete(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
@@ -182,21 +205,34 @@ func (h *ScheduleHandler) Delete(w http.ResponseWriter, r *http.Request, ps http
 		return
 	}
 
-	if err := h.service.Delete(r.Context(), id); err != nil {
+	query := r.URL.Query()
+	opts := model.ScheduleDeleteOptions{
+		Mode:             strings.TrimSpace(query.Get("mode")),
+		TargetScheduleID: strings.TrimSpace(query.Get("target_schedule_id")),
+	}
+
+	result, err := h.service.Delete(r.Context(), id, opts)
+	if err != nil {
 		if writeErr := httputil.WriteError(w, err); writeErr != nil {
 			h.log.Error("failed to write error response", "handler", "Delete", "operation", "WriteError", "error", writeErr)
 		}
 		return
 	}
 
-	httputil.WriteNoContent(w)
+	if opts.Mode == "" && len(result.AffectedBookings) == 0 {
+		httputil.WriteNoContent(w)
+		return
+	}
+	if err := httputil.WriteSuccess(w, result); err != nil {
+		h.log.Error("failed to write success response", "handler", "Delete", "operation", "WriteSuccess", "error", err)
+	}
 }
 
 // @Summary Search schedules by business and city
 // @Tags Schedules
 // @Produce json
 // @Param business_id query string true "Business ID"
-// @Param city query string false "City"
+// @Param city query string false "City, by name or any alias"
 // @Param limit query int false "Limit"
 // @Param offset query int false "Offset"
 // @Success 200 {object} httputil.PaginatedResponse
@@ -242,7 +278,7 @@ func (h *ScheduleHandler) Search(w http.ResponseWriter, r *http.Request, _ httpr
 // @Tags Schedules
 // @Produce json
 // @Param business_id query string true "Business ID"
-// @Param cities query string true "Comma-separated list of cities"
+// @Param cities query string true "Comma-separated list of cities, by name or any alias"
 // @Param limit query int false "Limit"
 // @Param offset query int false "Offset"
 // @Success 200 {object} httputil.PaginatedResponse
@@ -298,6 +334,419 @@ func (h *ScheduleHandler) BatchSearch(w http.ResponseWriter, r *http.Request, _
 	}
 }
 
+// @Summary Search schedules near a location
+// @Description Returns schedules with a location within radius meters of lat/lng, nearest first, each with its distance_meters. Schedules without a location are not returned.
+// @Tags Schedules
+// @Produce json
+// @Param lat query number true "Latitude"
+// @Param lng query number true "Longitude"
+// @Param radius query number false "Radius in meters (default 5000, max 100000)"
+// @Param business_id query string false "Business ID"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} httputil.PaginatedResponse
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/schedules/near [get]
+func (h *ScheduleHandler) Near(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	query := r.URL.Query()
+	businessID := strings.TrimSpace(query.Get("business_id"))
+
+	coords := map[string]float64{"lat": 0, "lng": 0, "radius": 0}
+	for _, key := range []string{"lat", "lng", "radius"} {
+		raw := strings.TrimSpace(query.Get(key))
+		if raw == "" {
+			if key == "radius" {
+				continue
+			}
+			if writeErr := httputil.WriteError(w, apperrors.InvalidInput(fmt.Sprintf("'%s' query parameter is required", key))); writeErr != nil {
+				h.log.Error("failed to write error response", "handler", "Near", "operation", "WriteError", "error", writeErr)
+			}
+			return
+		}
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			if writeErr := httputil.WriteError(w, apperrors.InvalidInput(fmt.Sprintf("invalid %s, must be a number", key))); writeErr != nil {
+				h.log.Error("failed to write error response", "handler", "Near", "operation", "WriteError", "error", writeErr)
+			}
+			return
+		}
+		coords[key] = parsed
+	}
+
+	limit, offset, err := httputil.ExtractLimitOffset(r)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "Near", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	results, totalCount, err := h.service.Near(r.Context(), businessID, coords["lat"], coords["lng"], coords["radius"], limit, offset)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "Near", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WritePaginated(w, results, totalCount, limit, offset); err != nil {
+		h.log.Error("failed to write paginated response", "handler", "Near", "operation", "WritePaginated", "error", err)
+	}
+}
+
+// @Summary Get schedule availability
+// @Description Returns bookable slots for the schedule between from and to, respecting working hours, exceptions, breaks, meeting duration, capacity and existing bookings. Defaults to the next 24 hours. On schedules with staff, slots are computed per staff member.
+// @Tags Schedules
+// @Produce json
+// @Param id path string true "Schedule ID"
+// @Param from query string false "Range start (RFC3339), defaults to now"
+// @Param to query string false "Range end (RFC3339), defaults to from + 24h"
+// @Param service query string false "Service label, limits staff to members offering it"
+// @Param staff query string false "Staff member ID"
+// @Success 200 {object} model.Availability
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 503 {object} httputil.ErrorResponse
+// @Router /api/v1/schedules/id/{id}/availability [get]
+func (h *ScheduleHandler) GetAvailability(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+	if id == "" {
+		if err := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "ID parameter is required",
+		}); err != nil {
+			h.log.Error("failed to write bad request response", "handler", "GetAvailability", "operation", "WriteJSON", "error", err)
+		}
+		return
+	}
+
+	query := r.URL.Query()
+	from := time.Now()
+	if fromStr := strings.TrimSpace(query.Get("from")); fromStr != "" {
+		parsed, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			if writeErr := httputil.WriteError(w, apperrors.InvalidInput("invalid from format, must be RFC3339")); writeErr != nil {
+				h.log.Error("failed to write error response", "handler", "GetAvailability", "operation", "WriteError", "error", writeErr)
+			}
+			return
+		}
+		from = parsed
+	}
+	to := from.Add(24 * time.Hour)
+	if toStr := strings.TrimSpace(query.Get("to")); toStr != "" {
+		parsed, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			if writeErr := httputil.WriteError(w, apperrors.InvalidInput("invalid to format, must be RFC3339")); writeErr != nil {
+				h.log.Error("failed to write error response", "handler", "GetAvailability", "operation", "WriteError", "error", writeErr)
+			}
+			return
+		}
+		to = parsed
+	}
+	service := strings.TrimSpace(query.Get("service"))
+	staffID := strings.TrimSpace(query.Get("staff"))
+
+	result, err := h.service.GetAvailability(r.Context(), id, from, to, service, staffID)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "GetAvailability", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteSuccess(w, result); err != nil {
+		h.log.Error("failed to write success response", "handler", "GetAvailability", "operation", "WriteSuccess", "error", err)
+	}
+}
+
+// @Summary Clone schedule to a new branch
+// @Description Creates a schedule at a new city and address with the hours, durations, capacity, time zone and exceptions of an existing one. Staff members are not copied.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Param id path string true "Source schedule ID"
+// @Param target body model.ScheduleClone true "New location"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 201 {object} model.Schedule
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 409 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/schedules/id/{id}/clone [post]
+func (h *ScheduleHandler) Clone(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+	if id == "" {
+		if err := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "ID parameter is required",
+		}); err != nil {
+			h.log.Error("failed to write bad request response", "handler", "Clone", "operation", "WriteJSON", "error", err)
+		}
+		return
+	}
+
+	var target model.ScheduleClone
+	if err := json.NewDecoder(r.Body).Decode(&target); err != nil {
+		if writeErr := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "Invalid request body",
+		}); writeErr != nil {
+			h.log.Error("failed to write JSON response", "handler", "Clone", "operation", "WriteJSON", "error", writeErr)
+		}
+		return
+	}
+
+	sc, err := h.service.Clone(r.Context(), id, target)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "Clone", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteCreated(w, sc); err != nil {
+		h.log.Error("failed to write created response", "handler", "Clone", "operation", "WriteCreated", "error", err)
+	}
+}
+
+// @Summary Reassign schedule to another business unit
+// @Description Hands the schedule over to another business unit, e.g. when duplicate units are merged. Only the services may call it; the bookings are moved separately through the bookings service.
+// @Tags Schedules
+// @Accept json
+// @Produce json
+// @Param id path string true "Schedule ID"
+// @Param target body model.ScheduleReassign true "Target business unit"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 200 {object} model.Schedule
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 409 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/schedules/id/{id}/reassign [post]
+func (h *ScheduleHandler) Reassign(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+
+	var target model.ScheduleReassign
+	if err := json.NewDecoder(r.Body).Decode(&target); err != nil {
+		if writeErr := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "Invalid request body",
+		}); writeErr != nil {
+			h.log.Error("failed to write JSON response", "handler", "Reassign", "operation", "WriteJSON", "error", writeErr)
+		}
+		return
+	}
+
+	sc, err := h.service.Reassign(r.Context(), id, target)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "Reassign", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteSuccess(w, sc); err != nil {
+		h.log.Error("failed to write success response", "handler", "Reassign", "operation", "WriteSuccess", "error", err)
+	}
+}
+
+// @Summary Create a schedule template
+// @Tags Schedule Templates
+// @Accept json
+// @Produce json
+// @Param template body model.ScheduleTemplate true "Schedule template data"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 201 {object} model.ScheduleTemplate
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 409 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/schedules/templates [post]
+func (h *ScheduleHandler) CreateTemplate(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var t model.ScheduleTemplate
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		if writeErr := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "Invalid request body",
+		}); writeErr != nil {
+			h.log.Error("failed to write JSON response", "handler", "CreateTemplate", "operation", "WriteJSON", "error", writeErr)
+		}
+		return
+	}
+
+	if err := h.service.CreateTemplate(r.Context(), &t); err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "CreateTemplate", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteCreated(w, t); err != nil {
+		h.log.Error("failed to write created response", "handler", "CreateTemplate", "operation", "WriteCreated", "error", err)
+	}
+}
+
+// @Summary Get schedule template by ID
+// @Tags Schedule Templates
+// @Produce json
+// @Param id path string true "Schedule template ID"
+// @Success 200 {object} model.ScheduleTemplate
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/schedules/templates/id/{id} [get]
+func (h *ScheduleHandler) GetTemplateByID(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+	if id == "" {
+		if err := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "ID parameter is required",
+		}); err != nil {
+			h.log.Error("failed to write bad request response", "handler", "GetTemplateByID", "operation", "WriteJSON", "error", err)
+		}
+		return
+	}
+
+	t, err := h.service.GetTemplateByID(r.Context(), id)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "GetTemplateByID", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteSuccess(w, t); err != nil {
+		h.log.Error("failed to write success response", "handler", "GetTemplateByID", "operation", "WriteSuccess", "error", err)
+	}
+}
+
+// @Summary Search schedule templates by business
+// @Tags Schedule Templates
+// @Produce json
+// @Param business_id query string true "Business ID"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} httputil.PaginatedResponse
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/schedules/templates/search [get]
+func (h *ScheduleHandler) SearchTemplates(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	businessID := strings.TrimSpace(r.URL.Query().Get("business_id"))
+	if businessID == "" {
+		if writeErr := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "'business_id' query parameter is required",
+		}); writeErr != nil {
+			h.log.Error("failed to write JSON response", "handler", "SearchTemplates", "operation", "WriteJSON", "error", writeErr)
+		}
+		return
+	}
+
+	limit, offset, err := httputil.ExtractLimitOffset(r)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "SearchTemplates", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	results, totalCount, err := h.service.SearchTemplates(r.Context(), businessID, limit, offset)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "SearchTemplates", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WritePaginated(w, results, totalCount, limit, offset); err != nil {
+		h.log.Error("failed to write paginated response", "handler", "SearchTemplates", "operation", "WritePaginated", "error", err)
+	}
+}
+
+// @Summary Delete schedule template
+// @Tags Schedule Templates
+// @Produce json
+// @Param id path string true "Schedule template ID"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 204 "No Content"
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/schedules/templates/id/{id} [delete]
+func (h *ScheduleHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+	if id == "" {
+		if err := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "ID parameter is required",
+		}); err != nil {
+			h.log.Error("failed to write bad request response", "handler", "DeleteTemplate", "operation", "WriteJSON", "error", err)
+		}
+		return
+	}
+
+	if err := h.service.DeleteTemplate(r.Context(), id); err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "DeleteTemplate", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	httputil.WriteNoContent(w)
+}
+
+// @Summary Create a schedule from a template
+// @Tags Schedule Templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Schedule template ID"
+// @Param target body model.ScheduleClone true "Location of the new schedule"
+// @Param X-Caller header string true "Caller: a phone number in E.164 format, or system for other services"
+// @Param X-Caller-Signature header string false "HMAC-SHA256 of X-Caller, required when the services share a secret"
+// @Success 201 {object} model.Schedule
+// @Failure 400 {object} httputil.ErrorResponse
+// @Failure 404 {object} httputil.ErrorResponse
+// @Failure 409 {object} httputil.ErrorResponse
+// @Failure 401 {object} httputil.ErrorResponse
+// @Failure 403 {object} httputil.ErrorResponse
+// @Failure 500 {object} httputil.ErrorResponse
+// @Router /api/v1/schedules/templates/id/{id}/apply [post]
+func (h *ScheduleHandler) CreateFromTemplate(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id := ps.ByName("id")
+	if id == "" {
+		if err := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "ID parameter is required",
+		}); err != nil {
+			h.log.Error("failed to write bad request response", "handler", "CreateFromTemplate", "operation", "WriteJSON", "error", err)
+		}
+		return
+	}
+
+	var target model.ScheduleClone
+	if err := json.NewDecoder(r.Body).Decode(&target); err != nil {
+		if writeErr := httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{
+			Error: "Invalid request body",
+		}); writeErr != nil {
+			h.log.Error("failed to write JSON response", "handler", "CreateFromTemplate", "operation", "WriteJSON", "error", writeErr)
+		}
+		return
+	}
+
+	sc, err := h.service.CreateFromTemplate(r.Context(), id, target)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, err); writeErr != nil {
+			h.log.Error("failed to write error response", "handler", "CreateFromTemplate", "operation", "WriteError", "error", writeErr)
+		}
+		return
+	}
+
+	if err := httputil.WriteCreated(w, sc); err != nil {
+		h.log.Error("failed to write created response", "handler", "CreateFromTemplate", "operation", "WriteCreated", "error", err)
+	}
+}
+
 func (h *ScheduleHandler) RegisterRoutes(router *httprouter.Router) {
 	// Swagger UI routes
 	router.Handler("GET", "/swagger/*any", httpSwagger.WrapHandler)
@@ -307,7 +756,17 @@ func (h *ScheduleHandler) RegisterRoutes(router *httprouter.Router) {
 	router.GET("/api/v1/schedules", h.GetAll)
 	router.GET("/api/v1/schedules/search", h.Search)
 	router.GET("/api/v1/schedules/batch-search", h.BatchSearch)
+	router.GET("/api/v1/schedules/near", h.Near)
 	router.GET("/api/v1/schedules/id/:id", h.GetByID)
+	router.GET("/api/v1/schedules/id/:id/availability", h.GetAvailability)
 	router.PATCH("/api/v1/schedules/id/:id", h.Update)
 	router.DELETE("/api/v1/schedules/id/:id", h.Delete)
+	router.POST("/api/v1/schedules/id/:id/clone", h.Clone)
+	router.POST("/api/v1/schedules/id/:id/reassign", h.Reassign)
+
+	router.POST("/api/v1/schedules/templates", h.CreateTemplate)
+	router.GET("/api/v1/schedules/templates/search", h.SearchTemplates)
+	router.GET("/api/v1/schedules/templates/id/:id", h.GetTemplateByID)
+	router.DELETE("/api/v1/schedules/templates/id/:id", h.DeleteTemplate)
+	router.POST("/api/v1/schedules/templates/id/:id/apply", h.CreateFromTemplate)
 }
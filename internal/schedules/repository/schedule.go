@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"regexp"
 	scheduleserrors "skeji/internal/schedules/errors"
 	"skeji/pkg/config"
 	mongotx "skeji/pkg/db/mongo"
@@ -38,6 +37,8 @@ type ScheduleRepository interface {
 	BatchSearch(ctx context.Context, businessId string, cities []string, limit int, offset int64) ([]*model.Schedule, error)
 	CountBySearch(ctx context.Context, businessId string, city string) (int64, error)
 	CountByBatchSearch(ctx context.Context, businessId string, cities []string) (int64, error)
+	Near(ctx context.Context, businessId string, point *model.GeoPoint, radiusMeters float64, limit int, offset int64) ([]*model.NearbySchedule, error)
+	CountNear(ctx context.Context, businessId string, point *model.GeoPoint, radiusMeters float64) (int64, error)
 	Count(ctx context.Context) (int64, error)
 	ExecuteTransaction(ctx context.Context, fn mongotx.TransactionFunc) error
 }
@@ -156,13 +157,24 @@ func (r *mongoScheduleRepository) Update(ctx context.Context, id string, sc *mod
 			"start_of_day":                 sc.StartOfDay,
 			"end_of_day":                   sc.EndOfDay,
 			"working_days":                 sc.WorkingDays,
+			"weekly_hours":                 sc.WeeklyHours,
 			"default_meeting_duration_min": sc.DefaultMeetingDurationMin,
 			"default_break_duration_min":   sc.DefaultBreakDurationMin,
 			"max_participants_per_slot":    sc.MaxParticipantsPerSlot,
 			"exceptions":                   sc.Exceptions,
+			"staff":                        sc.Staff,
+			"seasons":                      sc.Seasons,
 			"time_zone":                    sc.TimeZone,
 		},
 	}
+	if sc.Location != nil {
+		update["$set"].(bson.M)["location"] = sc.Location
+	}
+	if len(sc.Attributes) > 0 {
+		update["$set"].(bson.M)["attributes"] = sc.Attributes
+	} else {
+		update["$unset"] = bson.M{"attributes": ""}
+	}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
@@ -196,15 +208,6 @@ func (r *mongoScheduleRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// escapeRegexSpecialChars escapes special regex characters to prevent ReDoS attacks
-func escapeRegexSpecialChars(s string) string {
-	// Escape special regex characters: . * + ? ^ $ ( ) [ ] { } | \
-	specialChars := regexp.MustCompile(`[.*+?^$()[\]{}|\\]`)
-	return specialChars.ReplaceAllStringFunc(s, func(match string) string {
-		return "\\" + match
-	})
-}
-
 func (r *mongoScheduleRepository) Search(ctx context.Context, businessId string, city string, limit int, offset int64) ([]*model.Schedule, error) {
 	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
 	defer cancel()
@@ -214,9 +217,7 @@ func (r *mongoScheduleRepository) Search(ctx context.Context, businessId string,
 		filter["business_id"] = businessId
 	}
 	if city != "" {
-		// Escape special regex characters to prevent ReDoS attacks
-		escapedCity := escapeRegexSpecialChars(city)
-		filter["city"] = bson.M{"$regex": escapedCity, "$options": "i"}
+		filter["city"] = city
 	}
 
 	opts := options.Find().
@@ -247,9 +248,7 @@ func (r *mongoScheduleRepository) CountBySearch(ctx context.Context, businessId
 		filter["business_id"] = businessId
 	}
 	if city != "" {
-		// Escape special regex characters to prevent ReDoS attacks
-		escapedCity := escapeRegexSpecialChars(city)
-		filter["city"] = bson.M{"$regex": escapedCity, "$options": "i"}
+		filter["city"] = city
 	}
 
 	count, err := r.collection.CountDocuments(ctx, filter)
@@ -279,18 +278,9 @@ func (r *mongoScheduleRepository) BatchSearch(ctx context.Context, businessId st
 		filter["business_id"] = businessId
 	}
 
-	// If cities provided, match any of them (case-insensitive)
+	// cities are canonical, so any of them is matched exactly
 	if len(cities) > 0 {
-		cityFilters := make([]bson.M, 0, len(cities))
-		for _, city := range cities {
-			if city != "" {
-				escapedCity := escapeRegexSpecialChars(city)
-				cityFilters = append(cityFilters, bson.M{"city": bson.M{"$regex": escapedCity, "$options": "i"}})
-			}
-		}
-		if len(cityFilters) > 0 {
-			filter["$or"] = cityFilters
-		}
+		filter["city"] = bson.M{"$in": cities}
 	}
 
 	opts := options.Find().
@@ -321,18 +311,9 @@ func (r *mongoScheduleRepository) CountByBatchSearch(ctx context.Context, busine
 		filter["business_id"] = businessId
 	}
 
-	// If cities provided, match any of them (case-insensitive)
+	// cities are canonical, so any of them is matched exactly
 	if len(cities) > 0 {
-		cityFilters := make([]bson.M, 0, len(cities))
-		for _, city := range cities {
-			if city != "" {
-				escapedCity := escapeRegexSpecialChars(city)
-				cityFilters = append(cityFilters, bson.M{"city": bson.M{"$regex": escapedCity, "$options": "i"}})
-			}
-		}
-		if len(cityFilters) > 0 {
-			filter["$or"] = cityFilters
-		}
+		filter["city"] = bson.M{"$in": cities}
 	}
 
 	count, err := r.collection.CountDocuments(ctx, filter)
@@ -342,6 +323,65 @@ func (r *mongoScheduleRepository) CountByBatchSearch(ctx context.Context, busine
 	return count, nil
 }
 
+// Near returns the schedules within radiusMeters of point, nearest first.
+// Schedules without a location are never matched.
+func (r *mongoScheduleRepository) Near(ctx context.Context, businessId string, point *model.GeoPoint, radiusMeters float64, limit int, offset int64) ([]*model.NearbySchedule, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	geoNear := bson.M{
+		"near":          point,
+		"key":           "location",
+		"distanceField": "distance_meters",
+		"maxDistance":   radiusMeters,
+		"spherical":     true,
+	}
+	if businessId != "" {
+		geoNear["query"] = bson.M{"business_id": businessId}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$geoNear", Value: geoNear}},
+		{{Key: "$skip", Value: offset}},
+		{{Key: "$limit", Value: int64(limit)}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search schedules near point: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var schedules []*model.NearbySchedule
+	if err = cursor.All(ctx, &schedules); err != nil {
+		return nil, fmt.Errorf("failed to decode near search results: %w", err)
+	}
+
+	return schedules, nil
+}
+
+func (r *mongoScheduleRepository) CountNear(ctx context.Context, businessId string, point *model.GeoPoint, radiusMeters float64) (int64, error) {
+	ctx, cancel := r.withTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	// $geoNear is not allowed in counts; $centerSphere takes the radius in radians
+	filter := bson.M{
+		"location": bson.M{
+			"$geoWithin": bson.M{
+				"$centerSphere": bson.A{point.Coordinates, radiusMeters / model.EarthRadiusMeters},
+			},
+		},
+	}
+	if businessId != "" {
+		filter["business_id"] = businessId
+	}
+
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count schedules near point: %w", err)
+	}
+	return count, nil
+}
+
 func (r *mongoScheduleRepository) ExecuteTransaction(ctx context.Context, fn mongotx.TransactionFunc) error {
 	return r.txManager.ExecuteTransaction(ctx, fn)
 }
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	scheduleserrors "skeji/internal/schedules/errors"
+	"skeji/pkg/config"
+	"skeji/pkg/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	TemplatesCollectionName = "Schedule_templates"
+)
+
+// ScheduleTemplateRepository stores reusable schedule configurations per business unit
+type ScheduleTemplateRepository interface {
+	Create(ctx context.Context, t *model.ScheduleTemplate) error
+	FindByID(ctx context.Context, id string) (*model.ScheduleTemplate, error)
+	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, businessId string, limit int, offset int64) ([]*model.ScheduleTemplate, error)
+	CountBySearch(ctx context.Context, businessId string) (int64, error)
+}
+
+type mongoScheduleTemplateRepository struct {
+	cfg        *config.Config
+	collection *mongo.Collection
+}
+
+func NewMongoScheduleTemplateRepository(cfg *config.Config) ScheduleTemplateRepository {
+	db := cfg.Client.Mongo.Client.Database(cfg.MongoDatabaseName)
+	return &mongoScheduleTemplateRepository{
+		cfg:        cfg,
+		collection: db.Collection(TemplatesCollectionName),
+	}
+}
+
+func (r *mongoScheduleTemplateRepository) Create(ctx context.Context, t *model.ScheduleTemplate) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	t.CreatedAt = time.Now().UTC().Truncate(time.Millisecond)
+	result, err := r.collection.InsertOne(ctx, t)
+	if err != nil {
+		return fmt.Errorf("failed to create schedule template: %w", err)
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		t.ID = oid.Hex()
+	}
+	return nil
+}
+
+func (r *mongoScheduleTemplateRepository) FindByID(ctx context.Context, id string) (*model.ScheduleTemplate, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", scheduleserrors.ErrInvalidID, id)
+	}
+
+	var t model.ScheduleTemplate
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&t)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("%w: %s", scheduleserrors.ErrTemplateNotFound, id)
+		}
+		return nil, fmt.Errorf("failed to find schedule template: %w", err)
+	}
+
+	return &t, nil
+}
+
+func (r *mongoScheduleTemplateRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.WriteTimeout)
+	defer cancel()
+
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("%w: %s", scheduleserrors.ErrInvalidID, id)
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return fmt.Errorf("failed to delete schedule template: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("%w: %s", scheduleserrors.ErrTemplateNotFound, id)
+	}
+	return nil
+}
+
+func (r *mongoScheduleTemplateRepository) Search(ctx context.Context, businessId string, limit int, offset int64) ([]*model.ScheduleTemplate, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"business_id": businessId}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search schedule templates: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var templates []*model.ScheduleTemplate
+	if err = cursor.All(ctx, &templates); err != nil {
+		return nil, fmt.Errorf("failed to decode schedule templates: %w", err)
+	}
+	return templates, nil
+}
+
+func (r *mongoScheduleTemplateRepository) CountBySearch(ctx context.Context, businessId string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.ReadTimeout)
+	defer cancel()
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"business_id": businessId})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count schedule templates: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"skeji/pkg/logger"
+	"skeji/pkg/model"
+)
+
+// BookingNotifier tells participants about changes the business made to their
+// bookings, such as a branch closing down.
+type BookingNotifier interface {
+	BookingCancelled(ctx context.Context, sc *model.Schedule, booking *model.Booking) error
+	BookingMoved(ctx context.Context, from *model.Schedule, to *model.Schedule, booking *model.Booking) error
+}
+
+type logNotifier struct {
+	log *logger.Logger
+}
+
+// NewLogNotifier returns a notifier that records notifications in the service
+// log until a delivery channel is wired in.
+func NewLogNotifier(log *logger.Logger) BookingNotifier {
+	return &logNotifier{log: log}
+}
+
+func (n *logNotifier) BookingCancelled(_ context.Context, sc *model.Schedule, booking *model.Booking) error {
+	n.log.Info("Notify participants: booking cancelled",
+		"booking_id", booking.ID,
+		"schedule_id", sc.ID,
+		"schedule_name", sc.Name,
+		"start_time", booking.StartTime,
+		"recipients", recipients(booking),
+	)
+	return nil
+}
+
+func (n *logNotifier) BookingMoved(_ context.Context, from *model.Schedule, to *model.Schedule, booking *model.Booking) error {
+	n.log.Info("Notify participants: booking moved",
+		"booking_id", booking.ID,
+		"from_schedule_id", from.ID,
+		"to_schedule_id", to.ID,
+		"to_address", to.Address,
+		"start_time", booking.StartTime,
+		"recipients", recipients(booking),
+	)
+	return nil
+}
+
+func recipients(booking *model.Booking) []string {
+	phones := make([]string, 0, len(booking.Participants))
+	for _, phone := range booking.Participants {
+		phones = append(phones, phone)
+	}
+	return phones
+}
@@ -2,19 +2,29 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	scheduleerrors "skeji/internal/schedules/errors"
 	"skeji/internal/schedules/repository"
 	"skeji/internal/schedules/validator"
+	"skeji/pkg/attributes"
+	"skeji/pkg/auth"
+	"skeji/pkg/availability"
+	"skeji/pkg/client"
 	"skeji/pkg/config"
 	apperrors "skeji/pkg/errors"
 	"skeji/pkg/locale"
 	"skeji/pkg/model"
+	"skeji/pkg/plans"
 	"skeji/pkg/sanitizer"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -23,32 +33,54 @@ type ScheduleService interface {
 	GetByID(ctx context.Context, id string) (*model.Schedule, error)
 	GetAll(ctx context.Context, limit int, offset int64) ([]*model.Schedule, int64, error)
 	Update(ctx context.Context, id string, updates *model.ScheduleUpdate) error
-	Delete(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string, opts model.ScheduleDeleteOptions) (*model.ScheduleDeletion, error)
 	Search(ctx context.Context, businessID string, city string, limit int, offset int64) ([]*model.Schedule, int64, error)
 	BatchSearch(ctx context.Context, businessID string, cities []string, limit int, offset int64) ([]*model.Schedule, int64, error)
+	Near(ctx context.Context, businessID string, lat, lng, radiusMeters float64, limit int, offset int64) ([]*model.NearbySchedule, int64, error)
+	GetAvailability(ctx context.Context, id string, from, to time.Time, service string, staffID string) (*model.Availability, error)
+	Clone(ctx context.Context, id string, target model.ScheduleClone) (*model.Schedule, error)
+	Reassign(ctx context.Context, id string, target model.ScheduleReassign) (*model.Schedule, error)
+
+	CreateTemplate(ctx context.Context, t *model.ScheduleTemplate) error
+	GetTemplateByID(ctx context.Context, id string) (*model.ScheduleTemplate, error)
+	SearchTemplates(ctx context.Context, businessID string, limit int, offset int64) ([]*model.ScheduleTemplate, int64, error)
+	DeleteTemplate(ctx context.Context, id string) error
+	CreateFromTemplate(ctx context.Context, templateID string, target model.ScheduleClone) (*model.Schedule, error)
 }
 
 type scheduleService struct {
-	repo      repository.ScheduleRepository
-	validator *validator.ScheduleValidator
-	cfg       *config.Config
+	repo         repository.ScheduleRepository
+	templateRepo repository.ScheduleTemplateRepository
+	notifier     BookingNotifier
+	validator    *validator.ScheduleValidator
+	cfg          *config.Config
 }
 
 func NewScheduleService(
 	repo repository.ScheduleRepository,
+	templateRepo repository.ScheduleTemplateRepository,
+	notifier BookingNotifier,
 	validator *validator.ScheduleValidator,
 	cfg *config.Config,
 ) ScheduleService {
 	return &scheduleService{
-		repo:      repo,
-		validator: validator,
-		cfg:       cfg,
+		repo:         repo,
+		templateRepo: templateRepo,
+		notifier:     notifier,
+		validator:    validator,
+		cfg:          cfg,
 	}
 }
 
 func (s *scheduleService) Create(ctx context.Context, sc *model.Schedule) error {
-	s.applyDefaults(sc)
-	s.sanitize(sc)
+	if err := s.authorize(ctx, sc.BusinessID, "create schedules"); err != nil {
+		return err
+	}
+	if err := s.verifyBusinessWritable(sc.BusinessID); err != nil {
+		return err
+	}
+	ApplyDefaults(s.cfg, sc)
+	Sanitize(sc)
 	err := s.verifyLimitPerBusinessUnit(ctx, sc)
 	if err != nil {
 		return err
@@ -161,8 +193,14 @@ func (s *scheduleService) Update(ctx context.Context, id string, updates *model.
 		}
 		return apperrors.Internal("Failed to check schedule existence", err)
 	}
+	if err := s.authorize(ctx, existing.BusinessID, "update schedules"); err != nil {
+		return err
+	}
+	if err := s.verifyBusinessWritable(existing.BusinessID); err != nil {
+		return err
+	}
 	merged := s.mergeScheduleUpdates(existing, updates)
-	s.sanitize(merged)
+	Sanitize(merged)
 	err = s.validate(merged)
 	if err != nil {
 		return err
@@ -193,12 +231,89 @@ func (s *scheduleService) Update(ctx context.Context, id string, updates *model.
 	return nil
 }
 
-func (s *scheduleService) Delete(ctx context.Context, id string) error {
+// Delete removes a schedule. Active bookings that have not ended, including
+// the ones in progress, block the deletion unless opts.Mode says to cancel
+// them or move them to another schedule of the same business; every booking
+// handled is listed in the result. Handling is all or
+// nothing: if one booking cannot be cancelled or moved, those already handled
+// are restored and the schedule is kept. A bookings service that cannot list
+// those bookings blocks the deletion in every mode.
+func (s *scheduleService) Delete(ctx context.Context, id string, opts model.ScheduleDeleteOptions) (*model.ScheduleDeletion, error) {
 	if id == "" {
-		return apperrors.InvalidInput("Schedule ID cannot be empty")
+		return nil, apperrors.InvalidInput("Schedule ID cannot be empty")
+	}
+	switch opts.Mode {
+	case "", config.DeleteModeCancel:
+	case config.DeleteModeMove:
+		if opts.TargetScheduleID == "" {
+			return nil, apperrors.InvalidInput("target_schedule_id is required when mode is 'move'")
+		}
+		if opts.TargetScheduleID == id {
+			return nil, apperrors.InvalidInput("target_schedule_id must differ from the deleted schedule")
+		}
+	default:
+		return nil, apperrors.InvalidInput(fmt.Sprintf("mode must be one of: %s, %s", config.DeleteModeCancel, config.DeleteModeMove))
+	}
+
+	sc, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorize(ctx, sc.BusinessID, "delete schedules"); err != nil {
+		return nil, err
+	}
+	if err := s.verifyBusinessWritable(sc.BusinessID); err != nil {
+		return nil, err
+	}
+	var target *model.Schedule
+	if opts.Mode == config.DeleteModeMove {
+		target, err = s.GetByID(ctx, opts.TargetScheduleID)
+		if err != nil {
+			return nil, err
+		}
+		if target.BusinessID != sc.BusinessID {
+			return nil, apperrors.InvalidInput("target schedule must belong to the same business")
+		}
 	}
 
-	err := s.repo.ExecuteTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+	result := &model.ScheduleDeletion{
+		ScheduleID:       id,
+		Mode:             opts.Mode,
+		TargetScheduleID: opts.TargetScheduleID,
+		AffectedBookings: []model.AffectedBooking{},
+	}
+
+	bookings, err := s.fetchUnfinishedBookings(sc)
+	if err != nil {
+		s.cfg.Log.Error("Failed to fetch unfinished bookings for schedule deletion", "id", id, "error", err)
+		return nil, apperrors.Unavailable("Bookings service")
+	}
+
+	if len(bookings) > 0 {
+		switch opts.Mode {
+		case "":
+			for _, b := range bookings {
+				result.AffectedBookings = append(result.AffectedBookings, model.NewAffectedBooking(b))
+			}
+			return nil, apperrors.Conflict(fmt.Sprintf(
+				"Schedule has %d upcoming or ongoing booking(s); delete with mode '%s' or '%s' to handle them",
+				len(bookings), config.DeleteModeCancel, config.DeleteModeMove,
+			)).WithDetails(map[string]any{"affected_bookings": result.AffectedBookings})
+		case config.DeleteModeCancel:
+			result.AffectedBookings = s.cancelBookings(bookings)
+		case config.DeleteModeMove:
+			result.AffectedBookings = s.moveBookings(target, bookings)
+		}
+		for _, affected := range result.AffectedBookings {
+			if affected.Outcome == config.OutcomeFailed {
+				s.restoreBookings(sc, bookings, result.AffectedBookings)
+				return nil, apperrors.Conflict("Some bookings could not be handled; the bookings were restored and the schedule was not deleted").
+					WithDetails(map[string]any{"affected_bookings": result.AffectedBookings})
+			}
+		}
+	}
+
+	err = s.repo.ExecuteTransaction(ctx, func(sessCtx mongo.SessionContext) error {
 		if err := s.repo.Delete(sessCtx, id); err != nil {
 			if errors.Is(err, scheduleerrors.ErrNotFound) {
 				return apperrors.NotFoundWithID("Schedule", id)
@@ -214,10 +329,124 @@ func (s *scheduleService) Delete(ctx context.Context, id string) error {
 		}
 		return nil
 	})
+	if err != nil {
+		s.restoreBookings(sc, bookings, result.AffectedBookings)
+		return nil, err
+	}
+	s.notifyAffected(ctx, sc, target, bookings, result.AffectedBookings)
+	s.cfg.Log.Info("Schedule deleted successfully",
+		"id", id,
+		"mode", opts.Mode,
+		"affected_bookings", len(result.AffectedBookings),
+	)
+	return result, nil
+}
+
+// cancelBookings cancels the bookings through the bookings service, stopping
+// at the first one that fails; the bookings after it are listed untouched.
+func (s *scheduleService) cancelBookings(bookings []*model.Booking) []model.AffectedBooking {
+	return s.handleBookings(bookings, config.OutcomeCancelled, func(*model.Booking) map[string]any {
+		return map[string]any{"status": config.Cancelled}
+	})
+}
+
+// moveBookings reassigns the bookings to the target schedule, stopping at the
+// first one that fails. The bookings service re-checks the target's hours,
+// staff and overlaps, so a booking that does not fit fails the move.
+func (s *scheduleService) moveBookings(target *model.Schedule, bookings []*model.Booking) []model.AffectedBooking {
+	return s.handleBookings(bookings, config.OutcomeMoved, func(*model.Booking) map[string]any {
+		return map[string]any{
+			"schedule_id": target.ID,
+			"staff_id":    config.AnyStaff,
+		}
+	})
+}
+
+func (s *scheduleService) handleBookings(bookings []*model.Booking, outcome string, update func(*model.Booking) map[string]any) []model.AffectedBooking {
+	affected := make([]model.AffectedBooking, 0, len(bookings))
+	failed := false
+	for _, b := range bookings {
+		entry := model.NewAffectedBooking(b)
+		if !failed {
+			if err := s.updateBooking(b.ID, update(b)); err != nil {
+				entry.Outcome = config.OutcomeFailed
+				entry.Error = err.Error()
+				failed = true
+			} else {
+				entry.Outcome = outcome
+			}
+		}
+		affected = append(affected, entry)
+	}
+	return affected
+}
+
+// restoreBookings undoes the cancellations and moves of an abandoned deletion,
+// putting each handled booking back on the schedule with its original status
+// and staff member.
+func (s *scheduleService) restoreBookings(sc *model.Schedule, bookings []*model.Booking, affected []model.AffectedBooking) {
+	for i := range affected {
+		entry := &affected[i]
+		if entry.Outcome != config.OutcomeCancelled && entry.Outcome != config.OutcomeMoved {
+			continue
+		}
+		b := bookings[i]
+		staffID := b.StaffID
+		if staffID == "" {
+			staffID = config.AnyStaff
+		}
+		restore := map[string]any{
+			"schedule_id": sc.ID,
+			"staff_id":    staffID,
+			"status":      b.Status,
+		}
+		if err := s.updateBooking(b.ID, restore); err != nil {
+			s.cfg.Log.Error("Failed to restore booking of abandoned schedule deletion",
+				"schedule_id", sc.ID,
+				"booking_id", b.ID,
+				"error", err,
+			)
+			entry.Error = fmt.Sprintf("could not be restored: %v", err)
+			continue
+		}
+		entry.Outcome = config.OutcomeRolledBack
+	}
+}
+
+// notifyAffected tells the participants of every cancelled or moved booking,
+// once the deletion has gone through.
+func (s *scheduleService) notifyAffected(ctx context.Context, sc *model.Schedule, target *model.Schedule, bookings []*model.Booking, affected []model.AffectedBooking) {
+	for i, entry := range affected {
+		b := bookings[i]
+		var err error
+		switch entry.Outcome {
+		case config.OutcomeCancelled:
+			err = s.notifier.BookingCancelled(ctx, sc, b)
+		case config.OutcomeMoved:
+			err = s.notifier.BookingMoved(ctx, sc, target, b)
+		default:
+			continue
+		}
+		if err != nil {
+			s.cfg.Log.Warn("Failed to notify about affected booking", "booking_id", b.ID, "outcome", entry.Outcome, "error", err)
+		}
+	}
+}
+
+func (s *scheduleService) updateBooking(id string, update map[string]any) error {
+	resp, err := s.cfg.Client.BookingClient.Update(id, update)
 	if err != nil {
 		return err
 	}
-	s.cfg.Log.Info("Schedule deleted successfully", "id", id)
+	if resp.StatusCode != http.StatusNoContent {
+		var body struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(resp.Body, &body) == nil && body.Error != "" {
+			return fmt.Errorf("%s", body.Error)
+		}
+		return fmt.Errorf("bookings service returned status %d", resp.StatusCode)
+	}
 	return nil
 }
 
@@ -226,7 +455,7 @@ func (s *scheduleService) Search(ctx context.Context, businessID string, city st
 		return nil, 0, apperrors.InvalidInput("Business_id must be provided, city is optional")
 	}
 
-	city = sanitizer.SanitizeCityOrLabel(city)
+	city = locale.CanonicalCity(city)
 
 	var count int64
 	var schedules []*model.Schedule
@@ -288,13 +517,7 @@ func (s *scheduleService) BatchSearch(ctx context.Context, businessID string, ci
 		return nil, 0, apperrors.InvalidInput("Business_id must be provided")
 	}
 
-	// Sanitize all cities
-	sanitizedCities := make([]string, 0, len(cities))
-	for _, city := range cities {
-		if sanitized := sanitizer.SanitizeCityOrLabel(city); sanitized != "" {
-			sanitizedCities = append(sanitizedCities, sanitized)
-		}
-	}
+	sanitizedCities := locale.CanonicalCities(cities)
 
 	var count int64
 	var schedules []*model.Schedule
@@ -351,42 +574,550 @@ func (s *scheduleService) BatchSearch(ctx context.Context, businessID string, ci
 	return schedules, count, nil
 }
 
-func (s *scheduleService) sanitize(sc *model.Schedule) {
+// Near finds the schedules within radiusMeters of the given point, nearest
+// first, optionally limited to one business. A zero radius uses the default.
+func (s *scheduleService) Near(ctx context.Context, businessID string, lat, lng, radiusMeters float64, limit int, offset int64) ([]*model.NearbySchedule, int64, error) {
+	if !model.ValidLatLng(lat, lng) {
+		return nil, 0, apperrors.InvalidInput("lat must be in [-90, 90] and lng in [-180, 180]")
+	}
+	if radiusMeters == 0 {
+		radiusMeters = config.DefaultNearRadiusMeters
+	}
+	if radiusMeters < 0 || radiusMeters > config.MaxNearRadiusMeters {
+		return nil, 0, apperrors.InvalidInput(fmt.Sprintf("radius must be between 1 and %d meters", config.MaxNearRadiusMeters))
+	}
+	point := model.NewGeoPoint(lat, lng)
+
+	var count int64
+	var schedules []*model.NearbySchedule
+	var errCount, errFind error
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		var err error
+		count, err = s.repo.CountNear(ctx, businessID, point, radiusMeters)
+		if err != nil {
+			s.cfg.Log.Error("Failed to count schedules near point",
+				"business_id", businessID,
+				"lat", lat,
+				"lng", lng,
+				"radius", radiusMeters,
+				"error", err,
+			)
+			errCount = apperrors.Internal("Failed to count schedules", err)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		var err error
+		schedules, err = s.repo.Near(ctx, businessID, point, radiusMeters, limit, offset)
+		if err != nil {
+			s.cfg.Log.Error("Failed to search schedules near point",
+				"business_id", businessID,
+				"lat", lat,
+				"lng", lng,
+				"radius", radiusMeters,
+				"error", err,
+			)
+			errFind = apperrors.Internal("Failed to search schedules", err)
+		}
+	}()
+
+	wg.Wait()
+
+	if errCount != nil {
+		return nil, 0, errCount
+	}
+	if errFind != nil {
+		return nil, 0, errFind
+	}
+	if schedules == nil {
+		schedules = []*model.NearbySchedule{}
+	}
+
+	s.cfg.Log.Debug("Schedules near search completed",
+		"business_id", businessID,
+		"lat", lat,
+		"lng", lng,
+		"radius", radiusMeters,
+		"results_count", len(schedules),
+		"total_count", count,
+	)
+
+	return schedules, count, nil
+}
+
+func (s *scheduleService) GetAvailability(ctx context.Context, id string, from, to time.Time, service string, staffID string) (*model.Availability, error) {
+	if !to.After(from) {
+		return nil, apperrors.InvalidInput("'to' must be after 'from'")
+	}
+	maxRange := time.Duration(s.cfg.MaxAvailabilityRangeDays) * 24 * time.Hour
+	if to.Sub(from) > maxRange {
+		return nil, apperrors.InvalidInput(fmt.Sprintf("availability range cannot exceed %d days", s.cfg.MaxAvailabilityRangeDays))
+	}
+	if now := time.Now(); from.Before(now) {
+		from = now
+	}
+
+	sc, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if staffID != "" && sc.StaffByID(staffID) == nil {
+		return nil, apperrors.NotFoundWithID("Staff member", staffID)
+	}
+
+	filter := availability.Filter{
+		Service: sanitizer.SanitizeCityOrLabel(service),
+		StaffID: staffID,
+	}
+	loc := availability.Location(sc)
+	result := &model.Availability{
+		ScheduleID: sc.ID,
+		TimeZone:   sc.TimeZone,
+		Service:    filter.Service,
+		StaffID:    filter.StaffID,
+		From:       from.In(loc),
+		To:         to.In(loc),
+		Slots:      []model.AvailabilitySlot{},
+	}
+	if !to.After(from) {
+		return result, nil
+	}
+
+	bookings, err := s.fetchBookings(sc, from, to)
+	if err != nil {
+		s.cfg.Log.Error("Failed to fetch bookings for availability",
+			"schedule_id", sc.ID,
+			"error", err,
+		)
+		return nil, apperrors.Unavailable("Bookings service")
+	}
+
+	result.Slots = availability.SlotsFor(sc, bookings, from, to, filter)
+	s.cfg.Log.Debug("Schedule availability computed",
+		"schedule_id", sc.ID,
+		"from", from,
+		"to", to,
+		"slots_count", len(result.Slots),
+	)
+	return result, nil
+}
+
+// Clone creates a schedule at a new city and address with the configuration of
+// an existing one. The copy goes through the same limit and duplication checks
+// as any new schedule.
+func (s *scheduleService) Clone(ctx context.Context, id string, target model.ScheduleClone) (*model.Schedule, error) {
+	source, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := source.CloneTo(target)
+	if err := s.Create(ctx, clone); err != nil {
+		return nil, err
+	}
+	s.cfg.Log.Info("Schedule cloned successfully",
+		"source_id", source.ID,
+		"id", clone.ID,
+		"city", clone.City,
+	)
+	return clone, nil
+}
+
+func (s *scheduleService) CreateTemplate(ctx context.Context, t *model.ScheduleTemplate) error {
+	if err := s.authorize(ctx, t.BusinessID, "create schedule templates"); err != nil {
+		return err
+	}
+	// Templates are normalized the same way schedules are, so a schedule
+	// created from one is stored exactly as configured.
+	sc := t.NewSchedule(model.ScheduleClone{})
+	ApplyDefaults(s.cfg, sc)
+	Sanitize(sc)
+	*t = *model.TemplateFromSchedule(sc, sc.Name)
+
+	if err := s.validator.ValidateTemplate(t); err != nil {
+		s.cfg.Log.Warn("Schedule template validation failed",
+			"name", t.Name,
+			"business_id", t.BusinessID,
+			"error", err,
+		)
+		return apperrors.Validation("Schedule template validation failed", map[string]any{
+			"error": err.Error(),
+		})
+	}
+
+	existing, err := s.templateRepo.Search(ctx, t.BusinessID, config.DefaultMaxTemplatesPerBusinessUnit, 0)
+	if err != nil {
+		return apperrors.Internal("Failed to check existing schedule templates", err)
+	}
+	if len(existing) >= config.DefaultMaxTemplatesPerBusinessUnit {
+		return apperrors.Conflict("Business unit exceeded num of allowed schedule templates")
+	}
+	for _, e := range existing {
+		if strings.EqualFold(e.Name, t.Name) {
+			return apperrors.Conflict("Another schedule template with the same name already exists for this business")
+		}
+	}
+
+	if err := s.templateRepo.Create(ctx, t); err != nil {
+		s.cfg.Log.Error("Failed to create schedule template",
+			"name", t.Name,
+			"business_id", t.BusinessID,
+			"error", err,
+		)
+		return apperrors.Internal("Failed to create schedule template", err)
+	}
+
+	s.cfg.Log.Info("Schedule template created successfully",
+		"id", t.ID,
+		"name", t.Name,
+		"business_id", t.BusinessID,
+	)
+	return nil
+}
+
+func (s *scheduleService) GetTemplateByID(ctx context.Context, id string) (*model.ScheduleTemplate, error) {
+	if id == "" {
+		return nil, apperrors.InvalidInput("Schedule template ID cannot be empty")
+	}
+
+	t, err := s.templateRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, scheduleerrors.ErrTemplateNotFound) {
+			return nil, apperrors.NotFoundWithID("Schedule template", id)
+		}
+		if errors.Is(err, scheduleerrors.ErrInvalidID) {
+			return nil, apperrors.InvalidInput("Invalid schedule template ID format")
+		}
+		s.cfg.Log.Error("Failed to get schedule template by ID",
+			"id", id,
+			"error", err,
+		)
+		return nil, apperrors.Internal("Failed to retrieve schedule template", err)
+	}
+
+	return t, nil
+}
+
+func (s *scheduleService) SearchTemplates(ctx context.Context, businessID string, limit int, offset int64) ([]*model.ScheduleTemplate, int64, error) {
+	if businessID == "" {
+		return nil, 0, apperrors.InvalidInput("Business_id must be provided")
+	}
+
+	count, err := s.templateRepo.CountBySearch(ctx, businessID)
+	if err != nil {
+		s.cfg.Log.Error("Failed to count schedule templates", "business_id", businessID, "error", err)
+		return nil, 0, apperrors.Internal("Failed to count schedule templates", err)
+	}
+	templates, err := s.templateRepo.Search(ctx, businessID, limit, offset)
+	if err != nil {
+		s.cfg.Log.Error("Failed to search schedule templates", "business_id", businessID, "error", err)
+		return nil, 0, apperrors.Internal("Failed to search schedule templates", err)
+	}
+
+	return templates, count, nil
+}
+
+func (s *scheduleService) DeleteTemplate(ctx context.Context, id string) error {
+	if id == "" {
+		return apperrors.InvalidInput("Schedule template ID cannot be empty")
+	}
+	t, err := s.GetTemplateByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.authorize(ctx, t.BusinessID, "delete schedule templates"); err != nil {
+		return err
+	}
+
+	if err := s.templateRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, scheduleerrors.ErrTemplateNotFound) {
+			return apperrors.NotFoundWithID("Schedule template", id)
+		}
+		if errors.Is(err, scheduleerrors.ErrInvalidID) {
+			return apperrors.InvalidInput("Invalid schedule template ID format")
+		}
+		s.cfg.Log.Error("Failed to delete schedule template",
+			"id", id,
+			"error", err,
+		)
+		return apperrors.Internal("Failed to delete schedule template", err)
+	}
+
+	s.cfg.Log.Info("Schedule template deleted successfully", "id", id)
+	return nil
+}
+
+// CreateFromTemplate opens a schedule at the given city and address using a
+// template's configuration.
+func (s *scheduleService) CreateFromTemplate(ctx context.Context, templateID string, target model.ScheduleClone) (*model.Schedule, error) {
+	t, err := s.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	sc := t.NewSchedule(target)
+	if err := s.Create(ctx, sc); err != nil {
+		return nil, err
+	}
+	s.cfg.Log.Info("Schedule created from template",
+		"template_id", t.ID,
+		"id", sc.ID,
+		"city", sc.City,
+	)
+	return sc, nil
+}
+
+// fetchUnfinishedBookings returns the schedule's active bookings that have
+// not ended yet, including the ones in progress.
+func (s *scheduleService) fetchUnfinishedBookings(sc *model.Schedule) ([]*model.Booking, error) {
+	now := time.Now()
+	bookings, err := s.fetchBookings(sc, now, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	unfinished := make([]*model.Booking, 0, len(bookings))
+	for _, b := range bookings {
+		if b.Status != config.Cancelled && b.EndTime.After(now) {
+			unfinished = append(unfinished, b)
+		}
+	}
+	return unfinished, nil
+}
+
+// fetchBookings pages through the schedule's bookings in [from, to). A zero to
+// leaves the range open-ended.
+func (s *scheduleService) fetchBookings(sc *model.Schedule, from, to time.Time) ([]*model.Booking, error) {
+	if s.cfg.Client == nil || s.cfg.Client.BookingClient == nil {
+		return nil, fmt.Errorf("booking client is not configured")
+	}
+
+	bookings := []*model.Booking{}
+	var offset int64 = 0
+	for {
+		end := ""
+		if !to.IsZero() {
+			end = to.Format(time.RFC3339)
+		}
+		resp, err := s.cfg.Client.BookingClient.Search(
+			sc.BusinessID,
+			sc.ID,
+			from.Format(time.RFC3339),
+			end,
+			config.DefaultPaginationLimit,
+			offset,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("bookings search returned status %d", resp.StatusCode)
+		}
+		page, metadata, err := s.cfg.Client.BookingClient.DecodeBookings(resp)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, page...)
+
+		offset += int64(len(page))
+		if len(page) == 0 || offset >= metadata.TotalCount {
+			return bookings, nil
+		}
+	}
+}
+
+// Sanitize normalizes a schedule the way it is stored: canonical city, HH:MM
+// times, weekly hours kept in step with the flat fields, seasons and
+// exceptions in date order, and IDs for new staff members.
+func Sanitize(sc *model.Schedule) {
 	sc.Name = sanitizer.SanitizeNameOrAddress(sc.Name)
-	sc.City = sanitizer.SanitizeCityOrLabel(sc.City)
+	sc.City = locale.CanonicalCity(sc.City)
 	sc.Address = sanitizer.SanitizeNameOrAddress(sc.Address)
+	if sc.Location != nil && sc.Location.Type == "" {
+		sc.Location.Type = model.GeoPointType
+	}
 	sc.WorkingDays = sanitizer.SanitizeSlice(sc.WorkingDays, sanitizer.SanitizeCityOrLabel)
-	sc.Exceptions = sanitizer.SanitizeSlice(sc.Exceptions, sanitizer.SanitizeNameOrAddress)
+	sc.WeeklyHours = sanitizeWeeklyHours(sc.WeeklyHours)
+	syncWeeklyHours(sc)
+	sc.Exceptions = sanitizeExceptions(sc.Exceptions)
+	sc.Staff = sanitizeStaff(sc.Staff)
+	sc.Seasons = sanitizeSeasons(sc.Seasons)
+	sc.Attributes = attributes.Normalize(sc.Attributes)
+}
+
+// sanitizeSeasons normalizes each season's hours the way the schedule's own
+// are kept and orders seasons by their start date.
+func sanitizeSeasons(seasons []model.ScheduleSeason) []model.ScheduleSeason {
+	if seasons == nil {
+		return nil
+	}
+	out := make([]model.ScheduleSeason, 0, len(seasons))
+	for _, season := range seasons {
+		season.Name = sanitizer.SanitizeNameOrAddress(season.Name)
+		season.EffectiveFrom = strings.TrimSpace(season.EffectiveFrom)
+		season.EffectiveUntil = strings.TrimSpace(season.EffectiveUntil)
+		season.WorkingDays = sanitizer.SanitizeSlice(season.WorkingDays, sanitizer.SanitizeCityOrLabel)
+		season.StartOfDay = normalizeClock(season.StartOfDay)
+		season.EndOfDay = normalizeClock(season.EndOfDay)
+		season.WorkingDays, season.StartOfDay, season.EndOfDay, season.WeeklyHours = syncHours(
+			season.WorkingDays, season.StartOfDay, season.EndOfDay, sanitizeWeeklyHours(season.WeeklyHours),
+		)
+		out = append(out, season)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].EffectiveFrom < out[j].EffectiveFrom
+	})
+	return out
 }
 
-func (s *scheduleService) applyDefaults(sc *model.Schedule) {
+// sanitizeStaff normalizes staff members and assigns IDs to new ones so
+// bookings can reference them.
+func sanitizeStaff(staff []model.StaffMember) []model.StaffMember {
+	out := make([]model.StaffMember, 0, len(staff))
+	for _, m := range staff {
+		m.ID = strings.TrimSpace(m.ID)
+		if m.ID == "" {
+			m.ID = primitive.NewObjectID().Hex()
+		}
+		m.Name = sanitizer.SanitizeNameOrAddress(m.Name)
+		m.Services = sanitizer.SanitizeSlice(m.Services, sanitizer.SanitizeCityOrLabel)
+		m.WeeklyHours = sanitizeWeeklyHours(m.WeeklyHours)
+		sort.SliceStable(m.TimeOff, func(i, j int) bool {
+			return m.TimeOff[i].Start.Before(m.TimeOff[j].Start)
+		})
+		out = append(out, m)
+	}
+	return out
+}
+
+var weekdayOrder = map[string]int{
+	config.Sunday: 0, config.Monday: 1, config.Tuesday: 2, config.Wednesday: 3,
+	config.Thursday: 4, config.Friday: 5, config.Saturday: 6,
+}
+
+// sanitizeWeeklyHours lowercases day names, normalizes times to HH:MM and
+// orders days by weekday and intervals by start time.
+func sanitizeWeeklyHours(hours []model.DayHours) []model.DayHours {
+	out := make([]model.DayHours, 0, len(hours))
+	for _, d := range hours {
+		d.Day = strings.ToLower(strings.TrimSpace(d.Day))
+		intervals := make([]model.TimeRange, 0, len(d.Intervals))
+		for _, iv := range d.Intervals {
+			intervals = append(intervals, model.TimeRange{
+				Start: normalizeClock(iv.Start),
+				End:   normalizeClock(iv.End),
+			})
+		}
+		sort.SliceStable(intervals, func(i, j int) bool {
+			return intervals[i].Start < intervals[j].Start
+		})
+		d.Intervals = intervals
+		out = append(out, d)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return weekdayOrder[out[i].Day] < weekdayOrder[out[j].Day]
+	})
+	return out
+}
+
+func normalizeClock(val string) string {
+	val = strings.TrimSpace(val)
+	t, err := time.Parse("15:04", val)
+	if err != nil {
+		return val
+	}
+	return t.Format("15:04")
+}
+
+// syncWeeklyHours keeps weekly hours and the flat working_days/start_of_day/
+// end_of_day fields consistent. Weekly hours win when present; otherwise they
+// are derived from the flat fields, so every stored schedule carries both.
+func syncWeeklyHours(sc *model.Schedule) {
+	sc.WorkingDays, sc.StartOfDay, sc.EndOfDay, sc.WeeklyHours = syncHours(sc.WorkingDays, sc.StartOfDay, sc.EndOfDay, sc.WeeklyHours)
+}
+
+func syncHours(workingDays []string, startOfDay, endOfDay string, weeklyHours []model.DayHours) ([]string, string, string, []model.DayHours) {
+	if len(weeklyHours) == 0 {
+		return workingDays, startOfDay, endOfDay, model.WeeklyHoursFromFlat(workingDays, startOfDay, endOfDay)
+	}
+	days := make([]string, 0, len(weeklyHours))
+	start, end := "", ""
+	for _, d := range weeklyHours {
+		days = append(days, d.Day)
+		for _, iv := range d.Intervals {
+			if start == "" || iv.Start < start {
+				start = iv.Start
+			}
+			if iv.End > end {
+				end = iv.End
+			}
+		}
+	}
+	return days, start, end, weeklyHours
+}
+
+// sanitizeExceptions normalizes exception fields, drops exact duplicates and
+// orders the result by date so overlap checks and lookups are deterministic.
+func sanitizeExceptions(exceptions []model.ScheduleException) []model.ScheduleException {
+	seen := make(map[model.ScheduleException]struct{}, len(exceptions))
+	out := make([]model.ScheduleException, 0, len(exceptions))
+	for _, e := range exceptions {
+		e.Type = strings.ToLower(strings.TrimSpace(e.Type))
+		e.Date = strings.TrimSpace(e.Date)
+		e.EndDate = strings.TrimSpace(e.EndDate)
+		if e.EndDate == e.Date {
+			e.EndDate = ""
+		}
+		e.StartOfDay = strings.TrimSpace(e.StartOfDay)
+		e.EndOfDay = strings.TrimSpace(e.EndOfDay)
+		e.Reason = strings.TrimSpace(e.Reason)
+		if _, ok := seen[e]; ok {
+			continue
+		}
+		seen[e] = struct{}{}
+		out = append(out, e)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Date < out[j].Date
+	})
+	return out
+}
+
+// ApplyDefaults fills in the durations, capacity and hours a new schedule
+// leaves unset from cfg. Schedules given weekly hours keep them; the flat
+// fields are then derived from the weekly hours by Sanitize.
+func ApplyDefaults(cfg *config.Config, sc *model.Schedule) {
 	if sc.DefaultMeetingDurationMin == 0 {
-		sc.DefaultMeetingDurationMin = s.cfg.DefaultMeetingDurationMin
+		sc.DefaultMeetingDurationMin = cfg.DefaultMeetingDurationMin
 	}
 	if sc.DefaultBreakDurationMin == 0 {
-		sc.DefaultBreakDurationMin = s.cfg.DefaultBreakDurationMin
+		sc.DefaultBreakDurationMin = cfg.DefaultBreakDurationMin
 	}
 	if sc.MaxParticipantsPerSlot == 0 {
-		sc.MaxParticipantsPerSlot = s.cfg.DefaultMaxParticipantsPerSlot
+		sc.MaxParticipantsPerSlot = cfg.DefaultMaxParticipantsPerSlot
 	}
 	if sc.StartOfDay == "" {
-		sc.StartOfDay = s.cfg.DefaultStartOfDay
+		sc.StartOfDay = cfg.DefaultStartOfDay
 	}
 	if sc.EndOfDay == "" {
-		sc.EndOfDay = s.cfg.DefaultEndOfDay
+		sc.EndOfDay = cfg.DefaultEndOfDay
 	}
 	if len(sc.WorkingDays) == 0 {
 		switch locale.DetectRegion(sc.TimeZone) {
 		case "IL":
-			sc.WorkingDays = s.cfg.DefaultWorkingDaysIsrael
+			sc.WorkingDays = cfg.DefaultWorkingDaysIsrael
 		case "US":
-			sc.WorkingDays = s.cfg.DefaultWorkingDaysUs
+			sc.WorkingDays = cfg.DefaultWorkingDaysUs
 		default:
-			sc.WorkingDays = s.cfg.DefaultWorkingDaysIsrael
+			sc.WorkingDays = cfg.DefaultWorkingDaysIsrael
 		}
 	}
 	if sc.Exceptions == nil {
-		sc.Exceptions = []string{}
+		sc.Exceptions = []model.ScheduleException{}
 	}
 }
 
@@ -402,6 +1133,9 @@ func (s *scheduleService) mergeScheduleUpdates(existing *model.Schedule, updates
 	if updates.Address != "" {
 		merged.Address = updates.Address
 	}
+	if updates.Location != nil {
+		merged.Location = updates.Location
+	}
 	if updates.StartOfDay != "" {
 		merged.StartOfDay = updates.StartOfDay
 	}
@@ -411,6 +1145,12 @@ func (s *scheduleService) mergeScheduleUpdates(existing *model.Schedule, updates
 	if updates.WorkingDays != nil {
 		merged.WorkingDays = updates.WorkingDays
 	}
+	if updates.WeeklyHours != nil {
+		merged.WeeklyHours = append([]model.DayHours{}, *updates.WeeklyHours...)
+	} else if updates.StartOfDay != "" || updates.EndOfDay != "" || updates.WorkingDays != nil {
+		// flat fields describe uniform hours, so weekly hours are rebuilt from them
+		merged.WeeklyHours = nil
+	}
 	if updates.DefaultMeetingDurationMin != nil {
 		merged.DefaultMeetingDurationMin = *updates.DefaultMeetingDurationMin
 	}
@@ -421,7 +1161,16 @@ func (s *scheduleService) mergeScheduleUpdates(existing *model.Schedule, updates
 		merged.MaxParticipantsPerSlot = *updates.MaxParticipantsPerSlot
 	}
 	if updates.Exceptions != nil {
-		merged.Exceptions = append([]string{}, *updates.Exceptions...)
+		merged.Exceptions = append([]model.ScheduleException{}, *updates.Exceptions...)
+	}
+	if updates.Staff != nil {
+		merged.Staff = append([]model.StaffMember{}, *updates.Staff...)
+	}
+	if updates.Seasons != nil {
+		merged.Seasons = append([]model.ScheduleSeason{}, *updates.Seasons...)
+	}
+	if updates.Attributes != nil {
+		merged.Attributes = *updates.Attributes
 	}
 	if updates.TimeZone != "" {
 		merged.TimeZone = updates.TimeZone
@@ -443,6 +1192,15 @@ func (s *scheduleService) validate(sc *model.Schedule) error {
 			"error": err.Error(),
 		})
 	}
+	if s.cfg.CityValidation == config.CityValidationStrict {
+		if _, ok := locale.ResolveCity(sc.City); !ok {
+			s.cfg.Log.Warn("Schedule has an unknown city", "name", sc.Name, "city", sc.City)
+			return apperrors.Validation("Schedule validation failed", map[string]any{
+				"error": "unknown city",
+				"city":  sc.City,
+			})
+		}
+	}
 	return nil
 }
 
@@ -473,9 +1231,155 @@ func (s *scheduleService) verifyLimitPerBusinessUnit(ctx context.Context, sc *mo
 	if err != nil {
 		return err
 	}
-	if totalCount >= int64(config.DefaultMaxSchedulesPerBusinessUnits) {
-		return apperrors.Conflict("Business unit exceeded num of allowed schedules")
+	plan, limit := s.scheduleLimit(sc.BusinessID)
+	if totalCount >= int64(limit) {
+		return apperrors.Conflict("Business unit exceeded num of allowed schedules").WithDetails(map[string]any{
+			"plan":  plan,
+			"limit": limit,
+		})
+	}
+
+	return nil
+}
+
+// scheduleLimit returns the plan of the business and how many schedules it
+// allows. Like fetching the business elsewhere it is best effort: when the
+// business units service cannot tell, the default plan applies.
+func (s *scheduleService) scheduleLimit(businessID string) (string, int) {
+	fallback := plans.Resolve("", config.DefaultPlan)
+	if s.cfg.Client == nil || s.cfg.Client.BusinessUnitClient == nil {
+		return fallback.Name, fallback.Limits.SchedulesPerBusinessUnit
+	}
+	resp, err := s.cfg.Client.BusinessUnitClient.GetQuota(businessID)
+	if err != nil {
+		s.cfg.Log.Warn("Failed to fetch business unit quota", "business_id", businessID, "error", err)
+		return fallback.Name, fallback.Limits.SchedulesPerBusinessUnit
+	}
+	if resp.StatusCode != http.StatusOK {
+		s.cfg.Log.Warn("Failed to fetch business unit quota",
+			"business_id", businessID,
+			"status", resp.StatusCode,
+			"error", client.GetErrorMessage(resp),
+		)
+		return fallback.Name, fallback.Limits.SchedulesPerBusinessUnit
+	}
+	quota, err := s.cfg.Client.BusinessUnitClient.DecodeQuota(resp)
+	if err != nil {
+		s.cfg.Log.Warn("Failed to decode business unit quota", "business_id", businessID, "error", err)
+		return fallback.Name, fallback.Limits.SchedulesPerBusinessUnit
+	}
+	return quota.Plan, quota.Limits.SchedulesPerBusinessUnit
+}
+
+// Reassign moves the schedule to another business unit, within the target's
+// plan. Only the services may do it; its bookings are moved by the bookings
+// service. A schedule that already belongs to the target is returned as is,
+// and a dry run returns the schedule as it would be without storing it.
+func (s *scheduleService) Reassign(ctx context.Context, id string, target model.ScheduleReassign) (*model.Schedule, error) {
+	caller, err := auth.RequireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !caller.System {
+		return nil, apperrors.Forbidden("Only the services can reassign schedules")
+	}
+	target.BusinessID = strings.TrimSpace(target.BusinessID)
+	if _, err := primitive.ObjectIDFromHex(target.BusinessID); err != nil {
+		return nil, apperrors.InvalidInput("A valid business_id is required")
+	}
+
+	sc, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sc.BusinessID == target.BusinessID {
+		return sc, nil
+	}
+	from := sc.BusinessID
+	sc.BusinessID = target.BusinessID
+
+	if err := s.verifyLimitPerBusinessUnit(ctx, sc); err != nil {
+		return nil, err
+	}
+	err = s.repo.ExecuteTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		if err := s.verifyDuplication(sessCtx, sc); err != nil {
+			return err
+		}
+		if target.DryRun {
+			return nil
+		}
+		if _, err := s.repo.Update(sessCtx, id, sc); err != nil {
+			return apperrors.Internal("Failed to reassign schedule", err)
+		}
+		return nil
+	})
+	if err != nil {
+		s.cfg.Log.Error("Failed to reassign schedule",
+			"id", id,
+			"from_business_id", from,
+			"to_business_id", target.BusinessID,
+			"error", err,
+		)
+		return nil, err
+	}
+	if target.DryRun {
+		return sc, nil
 	}
 
+	s.cfg.Log.Info("Schedule reassigned",
+		"id", id,
+		"from_business_id", from,
+		"to_business_id", target.BusinessID,
+	)
+	return sc, nil
+}
+
+// verifyBusinessWritable rejects changes to the schedules of an archived
+// business unit, which are read-only like the unit itself. A unit that no
+// longer exists is being deleted, and its schedules with it.
+func (s *scheduleService) verifyBusinessWritable(businessID string) error {
+	if s.cfg.Client == nil || s.cfg.Client.BusinessUnitClient == nil {
+		return nil
+	}
+	resp, err := s.cfg.Client.BusinessUnitClient.GetByID(businessID)
+	if err != nil {
+		s.cfg.Log.Error("Failed to fetch business unit for schedule change", "business_id", businessID, "error", err)
+		return apperrors.Unavailable("Business units service")
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil
+	default:
+		s.cfg.Log.Error("Business unit unavailable for schedule change", "business_id", businessID, "status", resp.StatusCode)
+		return apperrors.Unavailable("Business units service")
+	}
+	bu, err := s.cfg.Client.BusinessUnitClient.DecodeBusinessUnit(resp)
+	if err != nil {
+		return apperrors.Internal("Failed to decode business unit", err)
+	}
+	if bu.Status == config.BusinessStatusArchived {
+		s.cfg.Log.Warn("Schedule change rejected, business unit is archived", "business_id", businessID)
+		return apperrors.Conflict("Business unit is archived and its schedules are read-only")
+	}
 	return nil
 }
+
+// authorize checks that the caller is the owner or a maintainer of the
+// business. Other services calling as the system are always allowed. Without
+// a business units client no role can be checked, so the action is refused.
+func (s *scheduleService) authorize(ctx context.Context, businessID string, action string) error {
+	if s.cfg.Client == nil || s.cfg.Client.BusinessUnitClient == nil {
+		s.cfg.Log.Error("Business units client is not configured, cannot check schedule permissions", "business_id", businessID)
+		return apperrors.Unavailable("Business units service")
+	}
+	err := auth.RequireStaff(ctx, s.cfg.Client.BusinessUnitClient, businessID, action)
+	if err != nil {
+		s.cfg.Log.Warn("Schedule action refused",
+			"business_id", businessID,
+			"action", action,
+			"error", err,
+		)
+	}
+	return err
+}
@@ -3,9 +3,12 @@ package validator
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"skeji/pkg/attributes"
 	"skeji/pkg/config"
 	"skeji/pkg/logger"
 	"skeji/pkg/model"
+	"slices"
 	"strings"
 	"time"
 
@@ -54,6 +57,9 @@ func NewScheduleValidator(log *logger.Logger) *ScheduleValidator {
 	if err := v.RegisterValidation("valid_week_days", validWeekDays); err != nil {
 		log.Fatal("Failed to register 'valid_week_days' validator", "error", err)
 	}
+	if err := v.RegisterValidation("valid_date", validateDate); err != nil {
+		log.Fatal("Failed to register 'valid_date' validator", "error", err)
+	}
 	log.Info("Schedule validator initialized successfully")
 	return &ScheduleValidator{validate: v, logger: log}
 }
@@ -84,6 +90,15 @@ func validWeekDays(fl validator.FieldLevel) bool {
 	return true
 }
 
+func validateDate(fl validator.FieldLevel) bool {
+	val := strings.TrimSpace(fl.Field().String())
+	if val == "" {
+		return true
+	}
+	_, err := time.Parse(time.DateOnly, val)
+	return err == nil
+}
+
 func (v *ScheduleValidator) Validate(sc *model.Schedule) error {
 	if err := v.validate.Struct(sc); err != nil {
 		var validationErrs validator.ValidationErrors
@@ -93,11 +108,72 @@ func (v *ScheduleValidator) Validate(sc *model.Schedule) error {
 		return err
 	}
 
-	startOfDay, err := time.Parse("15:04", sc.StartOfDay)
+	if err := validateHours(sc.StartOfDay, sc.EndOfDay, sc.WorkingDays, sc.WeeklyHours); err != nil {
+		return err
+	}
+	if len(sc.Exceptions) > config.DefaultMaxExceptionsPerSchedule {
+		return ValidationErrors{{
+			Field:   "exceptions",
+			Message: fmt.Sprintf("exceptions length must be no more than %d distinct items", config.DefaultMaxExceptionsPerSchedule),
+		}}
+	}
+	if errs := validateExceptions(sc.Exceptions); len(errs) > 0 {
+		return errs
+	}
+	if len(sc.Staff) > config.DefaultMaxStaffPerSchedule {
+		return ValidationErrors{{
+			Field:   "staff",
+			Message: fmt.Sprintf("staff length must be no more than %d members", config.DefaultMaxStaffPerSchedule),
+		}}
+	}
+	if errs := validateStaff(sc.Staff); len(errs) > 0 {
+		return errs
+	}
+	if errs := validateSeasons(sc.Seasons); len(errs) > 0 {
+		return errs
+	}
+	if sc.Location != nil && !model.ValidLatLng(sc.Location.Lat(), sc.Location.Lng()) {
+		return ValidationErrors{{
+			Field:   "location",
+			Message: "coordinates must be [longitude, latitude] with longitude in [-180, 180] and latitude in [-90, 90]",
+		}}
+	}
+	if errs := validateAttributes(sc.Attributes); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateAttributes checks the branch attributes; only attributes a schedule
+// may override are accepted.
+func validateAttributes(attrs model.Attributes) ValidationErrors {
+	problems := attributes.Validate(attrs, true)
+	var out ValidationErrors
+	for _, key := range slices.Sorted(maps.Keys(problems)) {
+		out = append(out, ValidationError{Field: "attributes." + key, Message: problems[key]})
+	}
+	return out
+}
+
+// ValidateTemplate checks a schedule template with the same rules a schedule's
+// hours, durations and capacity are held to.
+func (v *ScheduleValidator) ValidateTemplate(t *model.ScheduleTemplate) error {
+	if err := v.validate.Struct(t); err != nil {
+		var validationErrs validator.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			return v.translateValidationErrors(validationErrs)
+		}
+		return err
+	}
+	return validateHours(t.StartOfDay, t.EndOfDay, t.WorkingDays, t.WeeklyHours)
+}
+
+func validateHours(start, end string, workingDays []string, weeklyHours []model.DayHours) error {
+	startOfDay, err := time.Parse("15:04", start)
 	if err != nil {
 		return err
 	}
-	endOfDay, err := time.Parse("15:04", sc.EndOfDay)
+	endOfDay, err := time.Parse("15:04", end)
 	if err != nil {
 		return err
 	}
@@ -107,21 +183,171 @@ func (v *ScheduleValidator) Validate(sc *model.Schedule) error {
 			Message: "end_of_day must be after start_of_day",
 		}}
 	}
-	if len(sc.WorkingDays) == 0 || len(sc.WorkingDays) > 7 {
+	if len(workingDays) == 0 || len(workingDays) > 7 {
 		return ValidationErrors{{
 			Field:   "working_days",
 			Message: "working_days lenght must be between 1 to 7",
 		}}
 	}
-	if len(sc.Exceptions) > 10 {
-		return ValidationErrors{{
-			Field:   "exceptions",
-			Message: "excpetions lenght must be no more than 10 distinct items",
-		}}
+	if errs := validateWeeklyHours(weeklyHours); len(errs) > 0 {
+		return errs
 	}
 	return nil
 }
 
+// validateSeasons checks each season's hours and date range; seasons must not
+// overlap so exactly one configuration is in effect on any date.
+func validateSeasons(seasons []model.ScheduleSeason) ValidationErrors {
+	var out ValidationErrors
+	for i, season := range seasons {
+		field := fmt.Sprintf("seasons[%d]", i)
+		if season.EffectiveUntil != "" && season.EffectiveUntil < season.EffectiveFrom {
+			out = append(out, ValidationError{Field: field, Message: "effective_until must not be before effective_from"})
+		}
+		if err := validateHours(season.StartOfDay, season.EndOfDay, season.WorkingDays, season.WeeklyHours); err != nil {
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				errs = ValidationErrors{{Field: "start_of_day", Message: "start_of_day and end_of_day are required"}}
+			}
+			for _, e := range errs {
+				e.Field = field + "." + e.Field
+				out = append(out, e)
+			}
+		}
+		for j := 0; j < i; j++ {
+			if seasonsOverlap(seasons[j], season) {
+				out = append(out, ValidationError{
+					Field:   field,
+					Message: fmt.Sprintf("overlaps with seasons[%d]", j),
+				})
+				break
+			}
+		}
+	}
+	return out
+}
+
+func seasonsOverlap(a, b model.ScheduleSeason) bool {
+	return (b.EffectiveUntil == "" || a.EffectiveFrom <= b.EffectiveUntil) &&
+		(a.EffectiveUntil == "" || b.EffectiveFrom <= a.EffectiveUntil)
+}
+
+func validateStaff(staff []model.StaffMember) ValidationErrors {
+	var out ValidationErrors
+	ids := make(map[string]int, len(staff))
+	names := make(map[string]int, len(staff))
+	for i, m := range staff {
+		field := fmt.Sprintf("staff[%d]", i)
+		if j, ok := ids[m.ID]; ok && m.ID != "" {
+			out = append(out, ValidationError{Field: field, Message: fmt.Sprintf("id is already used by staff[%d]", j)})
+		}
+		ids[m.ID] = i
+		name := strings.ToLower(m.Name)
+		if j, ok := names[name]; ok {
+			out = append(out, ValidationError{Field: field, Message: fmt.Sprintf("name is already used by staff[%d]", j)})
+		}
+		names[name] = i
+
+		for _, e := range validateWeeklyHours(m.WeeklyHours) {
+			e.Field = field + "." + e.Field
+			out = append(out, e)
+		}
+		for k, off := range m.TimeOff {
+			if !off.End.After(off.Start) {
+				out = append(out, ValidationError{
+					Field:   fmt.Sprintf("%s.time_off[%d]", field, k),
+					Message: "end must be after start",
+				})
+			}
+		}
+	}
+	return out
+}
+
+func validateWeeklyHours(hours []model.DayHours) ValidationErrors {
+	var out ValidationErrors
+	seen := make(map[string]int, len(hours))
+	for i, d := range hours {
+		field := fmt.Sprintf("weekly_hours[%d]", i)
+		day := strings.ToLower(d.Day)
+		if j, ok := seen[day]; ok {
+			out = append(out, ValidationError{Field: field, Message: fmt.Sprintf("%s is already defined in weekly_hours[%d]", day, j)})
+			continue
+		}
+		seen[day] = i
+
+		for k, iv := range d.Intervals {
+			intervalField := fmt.Sprintf("%s.intervals[%d]", field, k)
+			start, errStart := time.Parse("15:04", iv.Start)
+			end, errEnd := time.Parse("15:04", iv.End)
+			if errStart != nil || errEnd != nil {
+				continue
+			}
+			if !end.After(start) {
+				out = append(out, ValidationError{Field: intervalField, Message: "end must be after start"})
+				continue
+			}
+			for m := 0; m < k; m++ {
+				if d.Intervals[m].Start < iv.End && iv.Start < d.Intervals[m].End {
+					out = append(out, ValidationError{
+						Field:   intervalField,
+						Message: fmt.Sprintf("overlaps with intervals[%d]", m),
+					})
+					break
+				}
+			}
+		}
+	}
+	return out
+}
+
+func validateExceptions(exceptions []model.ScheduleException) ValidationErrors {
+	var out ValidationErrors
+	for i, e := range exceptions {
+		field := fmt.Sprintf("exceptions[%d]", i)
+		if e.EndDate != "" && e.EndDate < e.Date {
+			out = append(out, ValidationError{Field: field, Message: "end_date must not be before date"})
+		}
+		switch e.Type {
+		case config.ExceptionClosed:
+			if e.StartOfDay != "" || e.EndOfDay != "" {
+				out = append(out, ValidationError{Field: field, Message: "closed exception must not define start_of_day or end_of_day"})
+			}
+		case config.ExceptionSpecialHours:
+			if e.StartOfDay == "" || e.EndOfDay == "" {
+				out = append(out, ValidationError{Field: field, Message: "special_hours exception requires start_of_day and end_of_day"})
+				break
+			}
+			start, errStart := time.Parse("15:04", e.StartOfDay)
+			end, errEnd := time.Parse("15:04", e.EndOfDay)
+			if errStart == nil && errEnd == nil && !end.After(start) {
+				out = append(out, ValidationError{Field: field, Message: "end_of_day must be after start_of_day"})
+			}
+		}
+		for j := 0; j < i; j++ {
+			if exceptionsOverlap(exceptions[j], e) {
+				out = append(out, ValidationError{
+					Field:   field,
+					Message: fmt.Sprintf("overlaps with exceptions[%d]", j),
+				})
+				break
+			}
+		}
+	}
+	return out
+}
+
+func exceptionsOverlap(a, b model.ScheduleException) bool {
+	aEnd, bEnd := a.EndDate, b.EndDate
+	if aEnd == "" {
+		aEnd = a.Date
+	}
+	if bEnd == "" {
+		bEnd = b.Date
+	}
+	return a.Date <= bEnd && b.Date <= aEnd
+}
+
 func (v *ScheduleValidator) translateValidationErrors(errs validator.ValidationErrors) ValidationErrors {
 	var out ValidationErrors
 
@@ -129,9 +355,27 @@ func (v *ScheduleValidator) translateValidationErrors(errs validator.ValidationE
 		"Name": "name", "City": "city", "Address": "address",
 		"StartOfDay": "start_of_day", "EndOfDay": "end_of_day",
 		"WorkingDays":               "working_days",
+		"WeeklyHours":               "weekly_hours",
+		"Day":                       "day",
+		"Intervals":                 "intervals",
+		"Start":                     "start",
+		"End":                       "end",
 		"DefaultMeetingDurationMin": "default_meeting_duration_min",
 		"DefaultBreakDurationMin":   "default_break_duration_min",
 		"MaxParticipantsPerSlot":    "max_participants_per_slot",
+		"Exceptions":                "exceptions",
+		"Type":                      "type",
+		"Date":                      "date",
+		"EndDate":                   "end_date",
+		"Reason":                    "reason",
+		"Staff":                     "staff",
+		"Services":                  "services",
+		"TimeOff":                   "time_off",
+		"Seasons":                   "seasons",
+		"Location":                  "location",
+		"Coordinates":               "coordinates",
+		"EffectiveFrom":             "effective_from",
+		"EffectiveUntil":            "effective_until",
 	}
 
 	for _, err := range errs {
@@ -141,28 +385,48 @@ func (v *ScheduleValidator) translateValidationErrors(errs validator.ValidationE
 			message = fmt.Sprintf("%s is required", err.Field())
 		case "min":
 			switch err.Field() {
-			case "Name", "City", "Address":
+			case "Name", "City", "Address", "Services":
 				message = fmt.Sprintf("%s must be at least %s chars", err.Field(), err.Param())
 			case "StartOfDay", "EndOfDay", "DefaultMeetingDurationMin", "DefaultBreakDurationMin":
 				message = fmt.Sprintf("%s must be at least %s minutes", err.Field(), err.Param())
 			case "WorkingDays":
 				message = fmt.Sprintf("%s must be at least %s weekdays", err.Field(), err.Param())
+			case "Intervals":
+				message = fmt.Sprintf("%s must have at least %s interval", err.Field(), err.Param())
 			case "MaxParticipantsPerSlot":
 				message = fmt.Sprintf("%s must be at least %s participants", err.Field(), err.Param())
 			}
 		case "max":
 			switch err.Field() {
-			case "Name", "City", "Address":
+			case "Name", "City", "Address", "Reason":
 				message = fmt.Sprintf("%s must be at most %s chars", err.Field(), err.Param())
+			case "Staff", "Services", "TimeOff", "Exceptions", "Seasons":
+				message = fmt.Sprintf("%s must have at most %s items", err.Field(), err.Param())
 			case "StartOfDay", "EndOfDay", "DefaultMeetingDurationMin", "DefaultBreakDurationMin":
 				message = fmt.Sprintf("%s must be at most %s minutes", err.Field(), err.Param())
-			case "WorkingDays":
+			case "WorkingDays", "WeeklyHours":
 				message = fmt.Sprintf("%s must be at most %s weekdays", err.Field(), err.Param())
+			case "Intervals":
+				message = fmt.Sprintf("%s must have at most %s intervals", err.Field(), err.Param())
 			case "MaxParticipantsPerSlot":
 				message = fmt.Sprintf("%s must be at most %s participants", err.Field(), err.Param())
 			}
 		case "valid_time_range":
 			message = fmt.Sprintf("%s must be in valid 24-hour HH:MM format", err.Field())
+		case "valid_week_days":
+			message = fmt.Sprintf("%s must be a valid weekday name", err.Field())
+		case "valid_date":
+			message = fmt.Sprintf("%s must be a valid date in YYYY-MM-DD format", err.Field())
+		case "mongodb":
+			message = fmt.Sprintf("%s must be a valid ID", err.Field())
+		case "gtfield":
+			message = fmt.Sprintf("%s must be after %s", err.Field(), err.Param())
+		case "oneof":
+			message = fmt.Sprintf("%s must be one of: %s", err.Field(), err.Param())
+		case "eq":
+			message = fmt.Sprintf("%s must be %s", err.Field(), err.Param())
+		case "len":
+			message = fmt.Sprintf("%s must have exactly %s items", err.Field(), err.Param())
 		default:
 			message = err.Error()
 		}
@@ -20,6 +20,7 @@ type Application struct {
 	rateLimiter      *middleware.PhoneRateLimiter
 	healthHandler    *http.Handler
 	appHttpHandler   *http.Handler
+	workers          []contracts.Worker
 }
 
 func NewApplication(cfg *config.Config) *Application {
@@ -34,6 +35,12 @@ func (a *Application) SetApp(appHandler contracts.Handler) {
 	a.setAppServer()
 }
 
+// AddWorker registers a background worker, started by Run and stopped on
+// shutdown.
+func (a *Application) AddWorker(worker contracts.Worker) {
+	a.workers = append(a.workers, worker)
+}
+
 func (a *Application) setHealthHandler() {
 	healthRouter := httprouter.New()
 	healthHandler := NewHealthHandler(a.cfg.Client.Mongo.Client, a.cfg.Log)
@@ -60,6 +67,7 @@ func (a *Application) setAppHandler(appHandler contracts.Handler) {
 	var appHttpHandler http.Handler = appRouter
 	appHttpHandler = middleware.Idempotency(a.idempotencyStore, "Idempotency-Key")(appHttpHandler)
 	appHttpHandler = middleware.RequestTimeout(a.cfg.RequestTimeout)(appHttpHandler)
+	appHttpHandler = middleware.CallerIdentity(a.cfg.CallerSecret, a.cfg.Log)(appHttpHandler)
 	appHttpHandler = middleware.PhoneRateLimit(a.rateLimiter)(appHttpHandler)
 	appHttpHandler = middleware.ContentTypeValidation(a.cfg.Log)(appHttpHandler)
 	appHttpHandler = middleware.MaxRequestSize(int64(a.cfg.MaxRequestSize))(appHttpHandler)
@@ -89,6 +97,10 @@ func (a *Application) setAppServer() {
 func (a *Application) Run() {
 	serverErrors := make(chan error, 1)
 
+	for _, worker := range a.workers {
+		worker.Start()
+	}
+
 	go func() {
 		a.cfg.Log.Info("Starting HTTP server", "address", a.server.Addr)
 		serverErrors <- a.server.ListenAndServe()
@@ -113,6 +125,9 @@ func (a *Application) gracefulShutdown() {
 	a.cfg.Log.Info("Stopping background workers...")
 	a.idempotencyStore.Stop()
 	a.rateLimiter.Stop()
+	for _, worker := range a.workers {
+		worker.Stop()
+	}
 	a.cfg.Log.Info("Background workers stopped")
 
 	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.ShutdownTimeout)
@@ -0,0 +1,348 @@
+// Package attributes defines the structured attributes business units and
+// schedules can carry, such as "wheelchair_accessible" or "languages", and
+// how they are validated, indexed and filtered on.
+//
+// Values are indexed as terms of the form "key=value", one per value of a
+// multi-select attribute, so a filter is a match on terms and facets are
+// counts of them.
+package attributes
+
+import (
+	"fmt"
+	"reflect"
+	"skeji/pkg/model"
+	"skeji/pkg/sanitizer"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const (
+	TypeBoolean     = "boolean"
+	TypeEnum        = "enum"
+	TypeMultiSelect = "multi_select"
+)
+
+var Definitions = map[string]model.AttributeDefinition{
+	"wheelchair_accessible": {
+		Key:         "wheelchair_accessible",
+		Type:        TypeBoolean,
+		Schedules:   true,
+		Description: "The premises are accessible by wheelchair",
+	},
+	"kids_friendly": {
+		Key:         "kids_friendly",
+		Type:        TypeBoolean,
+		Description: "Children are welcome as customers",
+	},
+	"parking": {
+		Key:         "parking",
+		Type:        TypeEnum,
+		Options:     []string{"none", "street", "free", "paid"},
+		Schedules:   true,
+		Description: "Parking near the premises",
+	},
+	"clientele": {
+		Key:         "clientele",
+		Type:        TypeEnum,
+		Options:     []string{"women", "men", "everyone"},
+		Description: "Who the business serves",
+	},
+	"languages": {
+		Key:         "languages",
+		Type:        TypeMultiSelect,
+		Options:     []string{"hebrew", "english", "arabic", "russian", "french", "spanish", "amharic"},
+		Description: "Languages spoken with customers",
+	},
+	"payment_methods": {
+		Key:         "payment_methods",
+		Type:        TypeMultiSelect,
+		Options:     []string{"cash", "credit_card", "bit", "paybox", "bank_transfer"},
+		Description: "Accepted ways to pay",
+	},
+}
+
+// Get returns the definition of the attribute.
+func Get(key string) (model.AttributeDefinition, bool) {
+	def, ok := Definitions[key]
+	return def, ok
+}
+
+// All returns the attribute definitions ordered by key.
+func All() []model.AttributeDefinition {
+	all := make([]model.AttributeDefinition, 0, len(Definitions))
+	for _, def := range Definitions {
+		all = append(all, def)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Key < all[j].Key })
+	return all
+}
+
+// options returns the values an attribute takes, in display order.
+func options(def model.AttributeDefinition) []string {
+	if def.Type == TypeBoolean {
+		return []string{"true", "false"}
+	}
+	return def.Options
+}
+
+// Normalize brings values into their stored form: keys and options are
+// sanitized, "true"/"false" strings become booleans, a single multi-select
+// value becomes a list, and lists are deduplicated into option order. Empty
+// values are dropped. Values that do not fit their attribute are kept as
+// they are for Validate to report.
+func Normalize(attrs model.Attributes) model.Attributes {
+	if attrs == nil {
+		return nil
+	}
+	out := model.Attributes{}
+	for rawKey, value := range attrs {
+		key := sanitizer.SanitizeCityOrLabel(rawKey)
+		def, ok := Definitions[key]
+		if !ok {
+			out[key] = value
+			continue
+		}
+		switch def.Type {
+		case TypeBoolean:
+			if s, ok := value.(string); ok {
+				switch sanitizer.SanitizeCityOrLabel(s) {
+				case "true", "yes":
+					value = true
+				case "false", "no":
+					value = false
+				}
+			}
+		case TypeEnum:
+			if s, ok := value.(string); ok {
+				value = sanitizer.SanitizeCityOrLabel(s)
+				if value == "" {
+					continue
+				}
+			}
+		case TypeMultiSelect:
+			if s, ok := value.(string); ok {
+				value = []string{s}
+			}
+			if values, ok := stringList(value); ok {
+				values = sanitizer.SanitizeSlice(values, sanitizer.SanitizeCityOrLabel)
+				if len(values) == 0 {
+					continue
+				}
+				sortByOptions(values, def.Options)
+				value = values
+			}
+		}
+		if value == nil {
+			continue
+		}
+		out[key] = value
+	}
+	return out
+}
+
+// Validate checks the values against their definitions and returns a message
+// per invalid attribute. On schedules only attributes marked Schedules are
+// allowed.
+func Validate(attrs model.Attributes, onSchedule bool) map[string]string {
+	problems := map[string]string{}
+	for key, value := range attrs {
+		def, ok := Definitions[key]
+		if !ok {
+			problems[key] = "unknown attribute"
+			continue
+		}
+		if onSchedule && !def.Schedules {
+			problems[key] = "can only be set on the business unit"
+			continue
+		}
+		switch def.Type {
+		case TypeBoolean:
+			if _, ok := value.(bool); !ok {
+				problems[key] = "must be true or false"
+			}
+		case TypeEnum:
+			s, ok := value.(string)
+			if !ok || !slices.Contains(def.Options, s) {
+				problems[key] = fmt.Sprintf("must be one of %s", strings.Join(def.Options, ", "))
+			}
+		case TypeMultiSelect:
+			values, ok := stringList(value)
+			if !ok {
+				problems[key] = fmt.Sprintf("must be a list of %s", strings.Join(def.Options, ", "))
+				continue
+			}
+			for _, v := range values {
+				if !slices.Contains(def.Options, v) {
+					problems[key] = fmt.Sprintf("%q is not one of %s", v, strings.Join(def.Options, ", "))
+					break
+				}
+			}
+		}
+	}
+	return problems
+}
+
+// Terms returns the index terms of the values, "key=value" each, sorted.
+func Terms(attrs model.Attributes) []string {
+	terms := []string{}
+	for key, value := range attrs {
+		for _, v := range values(value) {
+			terms = append(terms, term(key, v))
+		}
+	}
+	sort.Strings(terms)
+	return terms
+}
+
+func term(key, value string) string {
+	return key + "=" + value
+}
+
+// values returns the value of an attribute as the strings it is indexed by.
+func values(value any) []string {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return []string{"true"}
+		}
+		return []string{"false"}
+	case string:
+		return []string{v}
+	}
+	list, _ := stringList(value)
+	return list
+}
+
+// ParseFilter reads "key:value" filters, as given in search query
+// parameters; a boolean attribute given without a value means true. Values
+// of the same attribute are alternatives.
+func ParseFilter(raw []string) (model.AttributeFilter, error) {
+	filter := model.AttributeFilter{}
+	for _, item := range raw {
+		rawKey, rawValue, hasValue := strings.Cut(item, ":")
+		key := sanitizer.SanitizeCityOrLabel(rawKey)
+		def, ok := Definitions[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown attribute %q", strings.TrimSpace(rawKey))
+		}
+		value := sanitizer.SanitizeCityOrLabel(rawValue)
+		if !hasValue && def.Type == TypeBoolean {
+			value = "true"
+		}
+		if !slices.Contains(options(def), value) {
+			return nil, fmt.Errorf("invalid value %q for attribute %s, must be one of %s", strings.TrimSpace(rawValue), key, strings.Join(options(def), ", "))
+		}
+		if !slices.Contains(filter[key], value) {
+			filter[key] = append(filter[key], value)
+		}
+	}
+	return filter, nil
+}
+
+// FilterTerms returns the filter as groups of terms, one group per
+// attribute: a match needs a term of every group.
+func FilterTerms(filter model.AttributeFilter) [][]string {
+	keys := make([]string, 0, len(filter))
+	for key := range filter {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	groups := make([][]string, 0, len(keys))
+	for _, key := range keys {
+		group := make([]string, 0, len(filter[key]))
+		for _, value := range filter[key] {
+			group = append(group, term(key, value))
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// Matches reports whether the values satisfy the filter.
+func Matches(attrs model.Attributes, filter model.AttributeFilter) bool {
+	for key, wanted := range filter {
+		if !slices.ContainsFunc(values(attrs[key]), func(v string) bool {
+			return slices.Contains(wanted, v)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// Split separates the filter into attributes only business units carry and
+// those schedules may override, which have to be matched per branch.
+func Split(filter model.AttributeFilter) (unit, branch model.AttributeFilter) {
+	unit, branch = model.AttributeFilter{}, model.AttributeFilter{}
+	for key, wanted := range filter {
+		if Definitions[key].Schedules {
+			branch[key] = wanted
+		} else {
+			unit[key] = wanted
+		}
+	}
+	return unit, branch
+}
+
+// Inherit returns the values in effect for a branch: the schedule's own,
+// falling back to the business unit's.
+func Inherit(unit, schedule model.Attributes) model.Attributes {
+	effective := model.Attributes{}
+	for key, value := range unit {
+		effective[key] = value
+	}
+	for key, value := range schedule {
+		effective[key] = value
+	}
+	return effective
+}
+
+// Facets turns term counts into facets, attributes ordered by key and values
+// in option order. Values no result has are left out.
+func Facets(counts map[string]int64) []model.AttributeFacet {
+	facets := []model.AttributeFacet{}
+	for _, def := range All() {
+		facet := model.AttributeFacet{Key: def.Key, Values: []model.FacetValue{}}
+		for _, option := range options(def) {
+			if count := counts[term(def.Key, option)]; count > 0 {
+				facet.Values = append(facet.Values, model.FacetValue{Value: option, Count: count})
+			}
+		}
+		if len(facet.Values) > 0 {
+			facets = append(facets, facet)
+		}
+	}
+	return facets
+}
+
+// stringList reads a list of strings, as decoded from JSON or BSON.
+func stringList(value any) ([]string, bool) {
+	if list, ok := value.([]string); ok {
+		return list, true
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice {
+		return nil, false
+	}
+	list := make([]string, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		s, ok := rv.Index(i).Interface().(string)
+		if !ok {
+			return nil, false
+		}
+		list = append(list, s)
+	}
+	return list, true
+}
+
+func sortByOptions(values []string, options []string) {
+	rank := func(v string) int {
+		if i := slices.Index(options, v); i >= 0 {
+			return i
+		}
+		return len(options)
+	}
+	sort.SliceStable(values, func(i, j int) bool { return rank(values[i]) < rank(values[j]) })
+}
@@ -0,0 +1,120 @@
+package attributes
+
+import (
+	"reflect"
+	"skeji/pkg/model"
+	"testing"
+)
+
+func TestDefinitions_AreConsistent(t *testing.T) {
+	for key, def := range Definitions {
+		if def.Key != key {
+			t.Errorf("attribute %q is registered as %q", def.Key, key)
+		}
+		switch def.Type {
+		case TypeBoolean:
+			if len(def.Options) > 0 {
+				t.Errorf("boolean attribute %q lists options", key)
+			}
+		case TypeEnum, TypeMultiSelect:
+			if len(def.Options) == 0 {
+				t.Errorf("attribute %q has no options", key)
+			}
+		default:
+			t.Errorf("attribute %q has unknown type %q", key, def.Type)
+		}
+	}
+}
+
+func TestNormalize_CoercesValues(t *testing.T) {
+	got := Normalize(model.Attributes{
+		"Wheelchair Accessible": "yes",
+		"parking":               " Free ",
+		"languages":             []any{"English", "hebrew", "english"},
+		"payment_methods":       "cash",
+		"clientele":             "",
+	})
+	want := model.Attributes{
+		"wheelchair_accessible": true,
+		"parking":               "free",
+		"languages":             []string{"hebrew", "english"},
+		"payment_methods":       []string{"cash"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestValidate_ReportsEachInvalidAttribute(t *testing.T) {
+	problems := Validate(model.Attributes{
+		"parking":       "valet",
+		"languages":     []string{"klingon"},
+		"kids_friendly": "maybe",
+		"wifi":          true,
+	}, false)
+	for _, key := range []string{"parking", "languages", "kids_friendly", "wifi"} {
+		if problems[key] == "" {
+			t.Errorf("expected a problem with %q, got %v", key, problems)
+		}
+	}
+
+	problems = Validate(model.Attributes{"parking": "free", "kids_friendly": true}, true)
+	if len(problems) != 1 || problems["kids_friendly"] == "" {
+		t.Errorf("expected only the business-level attribute rejected on a schedule, got %v", problems)
+	}
+}
+
+func TestParseFilter_AndMatches(t *testing.T) {
+	filter, err := ParseFilter([]string{"wheelchair_accessible", "languages:english", "languages:Russian"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	attrs := model.Attributes{"wheelchair_accessible": true, "languages": []any{"hebrew", "russian"}}
+	if !Matches(attrs, filter) {
+		t.Errorf("expected %v to match %v", attrs, filter)
+	}
+	attrs["wheelchair_accessible"] = false
+	if Matches(attrs, filter) {
+		t.Errorf("expected %v not to match %v", attrs, filter)
+	}
+
+	if _, err := ParseFilter([]string{"parking:valet"}); err == nil {
+		t.Error("expected an invalid option to be rejected")
+	}
+	if _, err := ParseFilter([]string{"wifi"}); err == nil {
+		t.Error("expected an unknown attribute to be rejected")
+	}
+}
+
+func TestTermsAndFacets(t *testing.T) {
+	counts := map[string]int64{}
+	for _, attrs := range []model.Attributes{
+		{"parking": "free", "languages": []string{"hebrew", "english"}},
+		{"parking": "paid", "languages": []string{"english"}},
+	} {
+		for _, term := range Terms(attrs) {
+			counts[term]++
+		}
+	}
+
+	facets := Facets(counts)
+	want := []model.AttributeFacet{
+		{Key: "languages", Values: []model.FacetValue{{Value: "hebrew", Count: 1}, {Value: "english", Count: 2}}},
+		{Key: "parking", Values: []model.FacetValue{{Value: "free", Count: 1}, {Value: "paid", Count: 1}}},
+	}
+	if !reflect.DeepEqual(facets, want) {
+		t.Errorf("expected %v, got %v", want, facets)
+	}
+}
+
+func TestSplitAndInherit(t *testing.T) {
+	unit, branch := Split(model.AttributeFilter{"parking": {"free"}, "kids_friendly": {"true"}})
+	if len(unit) != 1 || unit["kids_friendly"] == nil || len(branch) != 1 || branch["parking"] == nil {
+		t.Fatalf("unexpected split %v / %v", unit, branch)
+	}
+
+	effective := Inherit(model.Attributes{"parking": "street", "kids_friendly": true}, model.Attributes{"parking": "free"})
+	if !Matches(effective, branch) || effective["kids_friendly"] != true {
+		t.Errorf("expected the branch to override parking and inherit the rest, got %v", effective)
+	}
+}
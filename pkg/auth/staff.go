@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	apperrors "skeji/pkg/errors"
+)
+
+// RoleResolver looks up the role a phone holds in a business unit, e.g. the
+// business units client.
+type RoleResolver interface {
+	Role(businessID string, phone string) (string, error)
+}
+
+// ResolveRole returns the caller and, unless it is the system, its role in
+// the business unit.
+func ResolveRole(ctx context.Context, roles RoleResolver, businessID string) (Caller, string, error) {
+	caller, err := RequireCaller(ctx)
+	if err != nil {
+		return Caller{}, RoleNone, err
+	}
+	if caller.System {
+		return caller, RoleNone, nil
+	}
+
+	role, err := roles.Role(businessID, caller.Phone)
+	if err != nil {
+		return caller, RoleNone, apperrors.Unavailable("business units service").WithDetails(map[string]any{
+			"error": err.Error(),
+		})
+	}
+	return caller, role, nil
+}
+
+// RequireStaff checks that the caller is the owner or a maintainer of the
+// business unit. The system caller is always allowed.
+func RequireStaff(ctx context.Context, roles RoleResolver, businessID string, action string) error {
+	caller, role, err := ResolveRole(ctx, roles, businessID)
+	if err != nil {
+		return err
+	}
+	if caller.System || IsStaff(role) {
+		return nil
+	}
+	return apperrors.Forbidden(fmt.Sprintf("Only the owner or a maintainer can %s", action))
+}
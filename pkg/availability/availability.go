@@ -0,0 +1,320 @@
+// Package availability turns a schedule and its bookings into working frames
+// and bookable slots. It is shared by the schedules service and maestro so
+// both compute open time the same way.
+package availability
+
+import (
+	"sort"
+	"strings"
+	"time"
+	// Embed the IANA database so schedule zones resolve on images without zoneinfo.
+	_ "time/tzdata"
+
+	"skeji/pkg/config"
+	"skeji/pkg/model"
+)
+
+// Frame is a contiguous period during which a schedule is open.
+type Frame struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Filter narrows availability to staff members offering a service and/or to a
+// single staff member. Zero values match everything.
+type Filter struct {
+	Service string
+	StaffID string
+}
+
+// Location returns the schedule's time zone, falling back to UTC when the zone
+// is empty or unknown. "Local" is rejected so results never depend on the
+// zone of the server computing them.
+func Location(sc *model.Schedule) *time.Location {
+	if strings.EqualFold(sc.TimeZone, "local") {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(sc.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// DayIntervals resolves the working intervals of the calendar day of date, which
+// must already be expressed in the schedule's location.
+// A closed exception yields no intervals, special hours replace the weekly
+// hours (even on a day off), otherwise the weekday's hours of the season in
+// effect apply.
+func DayIntervals(sc *model.Schedule, date time.Time) []model.TimeRange {
+	if exception := sc.ExceptionOn(date); exception != nil {
+		if exception.Type == config.ExceptionClosed {
+			return nil
+		}
+		return []model.TimeRange{{Start: exception.StartOfDay, End: exception.EndOfDay}}
+	}
+	return sc.EffectiveOn(date).HoursOn(date.Weekday())
+}
+
+// StaffDayIntervals resolves a staff member's working intervals for the
+// calendar day of date. Schedule exceptions apply to every member; otherwise
+// the member's own hours are used, falling back to the schedule's hours in
+// effect that day.
+func StaffDayIntervals(sc *model.Schedule, member *model.StaffMember, date time.Time) []model.TimeRange {
+	if exception := sc.ExceptionOn(date); exception != nil {
+		return DayIntervals(sc, date)
+	}
+	return member.HoursOn(sc.EffectiveOn(date), date.Weekday())
+}
+
+// WorkingFrames returns the schedule's working frames that overlap [from, to).
+// Days are walked in the schedule's time zone and frames are not clipped, so
+// slot grids stay anchored to the start of each interval.
+func WorkingFrames(sc *model.Schedule, from, to time.Time) []Frame {
+	return buildFrames(sc, from, to, func(date time.Time) []model.TimeRange {
+		return DayIntervals(sc, date)
+	})
+}
+
+// StaffFrames returns a staff member's working frames that overlap [from, to),
+// with their time off cut out.
+func StaffFrames(sc *model.Schedule, member *model.StaffMember, from, to time.Time) []Frame {
+	frames := buildFrames(sc, from, to, func(date time.Time) []model.TimeRange {
+		return StaffDayIntervals(sc, member, date)
+	})
+	blocked := make([]Frame, 0, len(member.TimeOff))
+	for _, off := range member.TimeOff {
+		blocked = append(blocked, Frame{Start: off.Start, End: off.End})
+	}
+	return subtract(frames, blocked)
+}
+
+func buildFrames(sc *model.Schedule, from, to time.Time, intervalsOn func(date time.Time) []model.TimeRange) []Frame {
+	loc := Location(sc)
+	year, month, day := from.In(loc).Date()
+
+	frames := []Frame{}
+	for offset := 0; ; offset++ {
+		date := time.Date(year, month, day+offset, 0, 0, 0, 0, loc)
+		if !date.Before(to) {
+			break
+		}
+		for _, interval := range intervalsOn(date) {
+			frame, ok := buildFrame(date, interval)
+			if !ok || !frame.End.After(from) || !frame.Start.Before(to) {
+				continue
+			}
+			frames = append(frames, frame)
+		}
+	}
+	return frames
+}
+
+// Covers reports whether the frames, joined where they touch, span [start, end)
+// without a gap. A frame ending at 23:59, the latest end an HH:MM interval can
+// express, runs until midnight, so hours open around the clock join across days.
+func Covers(frames []Frame, start, end time.Time) bool {
+	joined := make([]Frame, 0, len(frames))
+	for _, f := range frames {
+		if f.End.Hour() == 23 && f.End.Minute() == 59 {
+			f.End = f.End.Add(time.Minute)
+		}
+		joined = append(joined, f)
+	}
+	for _, f := range merge(joined) {
+		if !f.Start.After(start) && !f.End.Before(end) {
+			return true
+		}
+	}
+	return false
+}
+
+// Slots returns the bookable slots within [from, to) without filtering by
+// service or staff member. See SlotsFor.
+func Slots(sc *model.Schedule, bookings []*model.Booking, from, to time.Time) []model.AvailabilitySlot {
+	return SlotsFor(sc, bookings, from, to, Filter{})
+}
+
+// SlotsFor returns the bookable slots within [from, to), expressed in the
+// schedule's location. Slots are laid out from the start of every working
+// frame, one meeting duration long and separated by the break, both taken
+// from the season in effect on the frame's date;
+// durations are elapsed time, so a frame spanning a DST switch holds one slot
+// more or less than its wall-clock length suggests. Active bookings
+// overlapping a slot consume its capacity and full slots are omitted.
+//
+// Schedules with staff are computed per member: each member contributes their
+// own slots, tagged with their ID, and only their bookings (plus unassigned
+// ones) consume their capacity.
+func SlotsFor(sc *model.Schedule, bookings []*model.Booking, from, to time.Time, filter Filter) []model.AvailabilitySlot {
+	if len(sc.Staff) == 0 {
+		if filter.StaffID != "" {
+			return []model.AvailabilitySlot{}
+		}
+		return laneSlots(sc, nil, WorkingFrames(sc, from, to), bookings, from, to)
+	}
+
+	slots := []model.AvailabilitySlot{}
+	for i := range sc.Staff {
+		member := &sc.Staff[i]
+		if filter.StaffID != "" && member.ID != filter.StaffID {
+			continue
+		}
+		if !member.Offers(filter.Service) {
+			continue
+		}
+		frames := StaffFrames(sc, member, from, to)
+		slots = append(slots, laneSlots(sc, member, frames, StaffBookings(bookings, member.ID), from, to)...)
+	}
+	sort.SliceStable(slots, func(i, j int) bool {
+		if !slots[i].Start.Equal(slots[j].Start) {
+			return slots[i].Start.Before(slots[j].Start)
+		}
+		return slots[i].StaffID < slots[j].StaffID
+	})
+	return slots
+}
+
+func laneSlots(sc *model.Schedule, member *model.StaffMember, frames []Frame, bookings []*model.Booking, from, to time.Time) []model.AvailabilitySlot {
+	capacity := max(sc.MaxParticipantsPerSlot, 1)
+
+	slots := []model.AvailabilitySlot{}
+	for _, frame := range frames {
+		effective := sc.EffectiveOn(frame.Start)
+		duration := time.Duration(effective.DefaultMeetingDurationMin) * time.Minute
+		step := duration + time.Duration(effective.DefaultBreakDurationMin)*time.Minute
+		if duration <= 0 {
+			continue
+		}
+		for start := frame.Start; !start.Add(duration).After(frame.End); start = start.Add(step) {
+			end := start.Add(duration)
+			if start.Before(from) || end.After(to) {
+				continue
+			}
+			remaining := capacity - usedCapacity(bookings, start, end)
+			if remaining <= 0 {
+				continue
+			}
+			slot := model.AvailabilitySlot{
+				Start:     start,
+				End:       end,
+				Capacity:  capacity,
+				Remaining: remaining,
+			}
+			if member != nil {
+				slot.StaffID = member.ID
+				slot.StaffName = member.Name
+			}
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
+
+// StaffBookings returns the bookings that occupy a staff member: the ones
+// assigned to them and unassigned ones, which hold the whole schedule.
+func StaffBookings(bookings []*model.Booking, staffID string) []*model.Booking {
+	out := make([]*model.Booking, 0, len(bookings))
+	for _, b := range bookings {
+		if b.StaffID == "" || b.StaffID == staffID {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// StaffAvailable reports whether the member works throughout [start, end)
+// and has room for participants more. Like a slot, a member serves up to
+// MaxParticipantsPerSlot participants at a time.
+func StaffAvailable(sc *model.Schedule, member *model.StaffMember, bookings []*model.Booking, start, end time.Time, participants int) bool {
+	covered := false
+	for _, frame := range StaffFrames(sc, member, start, end) {
+		if !frame.Start.After(start) && !frame.End.Before(end) {
+			covered = true
+			break
+		}
+	}
+	if !covered {
+		return false
+	}
+	capacity := max(sc.MaxParticipantsPerSlot, 1)
+	return usedCapacity(StaffBookings(bookings, member.ID), start, end)+max(participants, 1) <= capacity
+}
+
+// subtract removes the blocked periods from frames, splitting frames that
+// are blocked in the middle.
+func subtract(frames []Frame, blocked []Frame) []Frame {
+	out := frames
+	for _, b := range blocked {
+		next := make([]Frame, 0, len(out))
+		for _, f := range out {
+			if !b.Start.Before(f.End) || !b.End.After(f.Start) {
+				next = append(next, f)
+				continue
+			}
+			if b.Start.After(f.Start) {
+				next = append(next, Frame{Start: f.Start, End: b.Start})
+			}
+			if b.End.Before(f.End) {
+				next = append(next, Frame{Start: b.End, End: f.End})
+			}
+		}
+		out = next
+	}
+	return out
+}
+
+func merge(frames []Frame) []Frame {
+	if len(frames) == 0 {
+		return frames
+	}
+	sort.Slice(frames, func(i, j int) bool {
+		return frames[i].Start.Before(frames[j].Start)
+	})
+	merged := []Frame{frames[0]}
+	for _, f := range frames[1:] {
+		last := &merged[len(merged)-1]
+		if !f.Start.After(last.End) {
+			if f.End.After(last.End) {
+				last.End = f.End
+			}
+			continue
+		}
+		merged = append(merged, f)
+	}
+	return merged
+}
+
+func usedCapacity(bookings []*model.Booking, start, end time.Time) int {
+	used := 0
+	for _, b := range bookings {
+		if b.Status == config.Cancelled {
+			continue
+		}
+		if b.StartTime.Before(end) && b.EndTime.After(start) {
+			used += max(b.Capacity, 1)
+		}
+	}
+	return used
+}
+
+func buildFrame(date time.Time, interval model.TimeRange) (Frame, bool) {
+	start, err := time.Parse("15:04", interval.Start)
+	if err != nil {
+		return Frame{}, false
+	}
+	end, err := time.Parse("15:04", interval.End)
+	if err != nil {
+		return Frame{}, false
+	}
+
+	year, month, day := date.Date()
+	frame := Frame{
+		Start: time.Date(year, month, day, start.Hour(), start.Minute(), 0, 0, date.Location()),
+		End:   time.Date(year, month, day, end.Hour(), end.Minute(), 0, 0, date.Location()),
+	}
+	if !frame.End.After(frame.Start) {
+		return Frame{}, false
+	}
+	return frame, true
+}
@@ -0,0 +1,330 @@
+package availability
+
+import (
+	"testing"
+	"time"
+
+	"skeji/pkg/model"
+)
+
+func newSchedule(tz string, day string, start, end string, durationMin int) *model.Schedule {
+	return &model.Schedule{
+		ID:                        "507f1f77bcf86cd799439011",
+		TimeZone:                  tz,
+		WorkingDays:               []string{day},
+		StartOfDay:                start,
+		EndOfDay:                  end,
+		WeeklyHours:               model.WeeklyHoursFromFlat([]string{day}, start, end),
+		DefaultMeetingDurationMin: durationMin,
+		DefaultBreakDurationMin:   0,
+		MaxParticipantsPerSlot:    1,
+	}
+}
+
+func mustParse(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatalf("failed to parse %s: %v", value, err)
+	}
+	return parsed
+}
+
+func slotStarts(slots []model.AvailabilitySlot) []string {
+	starts := make([]string, 0, len(slots))
+	for _, slot := range slots {
+		starts = append(starts, slot.Start.Format(time.RFC3339))
+	}
+	return starts
+}
+
+func assertStarts(t *testing.T, got []model.AvailabilitySlot, want []string) {
+	t.Helper()
+	starts := slotStarts(got)
+	if len(starts) != len(want) {
+		t.Fatalf("expected %d slots %v, got %d %v", len(want), want, len(starts), starts)
+	}
+	for i := range want {
+		if starts[i] != want[i] {
+			t.Errorf("slot %d: expected %s, got %s", i, want[i], starts[i])
+		}
+	}
+}
+
+func TestSlots_DSTTransitions(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule *model.Schedule
+		from     string
+		to       string
+		want     []string
+	}{
+		{
+			// 2025-03-28 02:00 IST jumps to 03:00 IDT: 01:00-05:00 is three elapsed hours
+			name:     "Asia/Jerusalem spring forward",
+			schedule: newSchedule("Asia/Jerusalem", "friday", "01:00", "05:00", 60),
+			from:     "2025-03-27T00:00:00Z",
+			to:       "2025-03-29T00:00:00Z",
+			want:     []string{"2025-03-28T01:00:00+02:00", "2025-03-28T03:00:00+03:00", "2025-03-28T04:00:00+03:00"},
+		},
+		{
+			// 2025-10-26 02:00 IDT falls back to 01:00 IST: 09:00 local is 07:00 UTC
+			name:     "Asia/Jerusalem fall back",
+			schedule: newSchedule("Asia/Jerusalem", "sunday", "09:00", "10:00", 60),
+			from:     "2025-10-18T00:00:00Z",
+			to:       "2025-10-27T00:00:00Z",
+			want:     []string{"2025-10-19T09:00:00+03:00", "2025-10-26T09:00:00+02:00"},
+		},
+		{
+			// 2025-03-09 02:00 EST jumps to 03:00 EDT: 09:00 local moves from 14:00 to 13:00 UTC
+			name:     "America/New_York spring forward",
+			schedule: newSchedule("America/New_York", "sunday", "09:00", "10:00", 60),
+			from:     "2025-03-01T00:00:00Z",
+			to:       "2025-03-10T00:00:00Z",
+			want:     []string{"2025-03-02T09:00:00-05:00", "2025-03-09T09:00:00-04:00"},
+		},
+		{
+			// 2025-11-02 02:00 EDT falls back to 01:00 EST: 00:00-03:00 is four elapsed hours
+			name:     "America/New_York fall back",
+			schedule: newSchedule("America/New_York", "sunday", "00:00", "03:00", 60),
+			from:     "2025-11-02T00:00:00Z",
+			to:       "2025-11-03T00:00:00Z",
+			want: []string{
+				"2025-11-02T00:00:00-04:00",
+				"2025-11-02T01:00:00-04:00",
+				"2025-11-02T01:00:00-05:00",
+				"2025-11-02T02:00:00-05:00",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Slots(tt.schedule, nil, mustParse(t, tt.from), mustParse(t, tt.to))
+			assertStarts(t, got, tt.want)
+		})
+	}
+}
+
+func TestWorkingFrames_UsesScheduleZoneNotServerZone(t *testing.T) {
+	original := time.Local
+	defer func() { time.Local = original }()
+
+	sc := newSchedule("America/Los_Angeles", "monday", "09:00", "17:00", 60)
+	// Monday 00:00 UTC is still Sunday in Los Angeles.
+	from := mustParse(t, "2025-06-09T00:00:00Z")
+	to := mustParse(t, "2025-06-10T00:00:00Z")
+
+	for _, local := range []string{"UTC", "Asia/Jerusalem", "Pacific/Auckland"} {
+		loc, err := time.LoadLocation(local)
+		if err != nil {
+			t.Fatalf("failed to load %s: %v", local, err)
+		}
+		time.Local = loc
+
+		frames := WorkingFrames(sc, from, to)
+		if len(frames) != 1 {
+			t.Fatalf("server zone %s: expected 1 frame, got %d", local, len(frames))
+		}
+		if got := frames[0].Start.UTC().Format(time.RFC3339); got != "2025-06-09T16:00:00Z" {
+			t.Errorf("server zone %s: expected frame to start at 2025-06-09T16:00:00Z, got %s", local, got)
+		}
+	}
+}
+
+func TestSlots_ExceptionsUseScheduleDate(t *testing.T) {
+	sc := newSchedule("Asia/Jerusalem", "monday", "00:00", "02:00", 60)
+	// 2025-06-09 00:30 in Jerusalem is still 2025-06-08 in UTC.
+	sc.Exceptions = []model.ScheduleException{{Type: "closed", Date: "2025-06-09"}}
+
+	got := Slots(sc, nil, mustParse(t, "2025-06-08T00:00:00Z"), mustParse(t, "2025-06-10T00:00:00Z"))
+	if len(got) != 0 {
+		t.Fatalf("expected closed date to have no slots, got %v", slotStarts(got))
+	}
+
+	sc.Exceptions = []model.ScheduleException{{Type: "special_hours", Date: "2025-06-09", StartOfDay: "01:00", EndOfDay: "02:00"}}
+	got = Slots(sc, nil, mustParse(t, "2025-06-08T00:00:00Z"), mustParse(t, "2025-06-10T00:00:00Z"))
+	assertStarts(t, got, []string{"2025-06-09T01:00:00+03:00"})
+}
+
+func TestSlots_BookingsConsumeCapacity(t *testing.T) {
+	sc := newSchedule("America/New_York", "monday", "09:00", "12:00", 60)
+	sc.MaxParticipantsPerSlot = 2
+	bookings := []*model.Booking{
+		{StartTime: mustParse(t, "2025-06-09T09:00:00-04:00"), EndTime: mustParse(t, "2025-06-09T10:00:00-04:00"), Capacity: 2, Status: "confirmed"},
+		{StartTime: mustParse(t, "2025-06-09T10:00:00-04:00"), EndTime: mustParse(t, "2025-06-09T11:00:00-04:00"), Capacity: 1, Status: "pending"},
+		{StartTime: mustParse(t, "2025-06-09T11:00:00-04:00"), EndTime: mustParse(t, "2025-06-09T12:00:00-04:00"), Capacity: 2, Status: "cancelled"},
+	}
+
+	got := Slots(sc, bookings, mustParse(t, "2025-06-09T00:00:00Z"), mustParse(t, "2025-06-10T00:00:00Z"))
+	assertStarts(t, got, []string{"2025-06-09T10:00:00-04:00", "2025-06-09T11:00:00-04:00"})
+	if got[0].Remaining != 1 || got[1].Remaining != 2 {
+		t.Errorf("unexpected remaining capacity: %d, %d", got[0].Remaining, got[1].Remaining)
+	}
+}
+
+func TestSlots_SplitShiftWithBreak(t *testing.T) {
+	sc := newSchedule("Asia/Jerusalem", "sunday", "09:00", "10:00", 30)
+	sc.DefaultBreakDurationMin = 15
+	sc.WeeklyHours = []model.DayHours{{
+		Day: "sunday",
+		Intervals: []model.TimeRange{
+			{Start: "09:00", End: "10:00"},
+			{Start: "16:00", End: "17:00"},
+		},
+	}}
+
+	got := Slots(sc, nil, mustParse(t, "2025-06-08T00:00:00+03:00"), mustParse(t, "2025-06-09T00:00:00+03:00"))
+	assertStarts(t, got, []string{
+		"2025-06-08T09:00:00+03:00",
+		"2025-06-08T16:00:00+03:00",
+	})
+}
+
+func TestSlotsFor_StaffLanes(t *testing.T) {
+	sc := newSchedule("Asia/Jerusalem", "sunday", "09:00", "11:00", 60)
+	sc.Staff = []model.StaffMember{
+		{ID: "aaaaaaaaaaaaaaaaaaaaaaaa", Name: "Dana", Services: []string{"haircut"}},
+		{
+			ID:          "bbbbbbbbbbbbbbbbbbbbbbbb",
+			Name:        "Noa",
+			Services:    []string{"coloring"},
+			WeeklyHours: []model.DayHours{{Day: "sunday", Intervals: []model.TimeRange{{Start: "10:00", End: "12:00"}}}},
+		},
+	}
+	from := mustParse(t, "2025-06-08T00:00:00+03:00")
+	to := mustParse(t, "2025-06-09T00:00:00+03:00")
+
+	got := SlotsFor(sc, nil, from, to, Filter{})
+	assertStarts(t, got, []string{
+		"2025-06-08T09:00:00+03:00",
+		"2025-06-08T10:00:00+03:00",
+		"2025-06-08T10:00:00+03:00",
+		"2025-06-08T11:00:00+03:00",
+	})
+	if got[1].StaffID != "aaaaaaaaaaaaaaaaaaaaaaaa" || got[2].StaffID != "bbbbbbbbbbbbbbbbbbbbbbbb" {
+		t.Errorf("expected slots ordered by staff ID, got %s, %s", got[1].StaffID, got[2].StaffID)
+	}
+
+	got = SlotsFor(sc, nil, from, to, Filter{Service: "coloring"})
+	assertStarts(t, got, []string{"2025-06-08T10:00:00+03:00", "2025-06-08T11:00:00+03:00"})
+
+	got = SlotsFor(sc, nil, from, to, Filter{StaffID: "aaaaaaaaaaaaaaaaaaaaaaaa"})
+	assertStarts(t, got, []string{"2025-06-08T09:00:00+03:00", "2025-06-08T10:00:00+03:00"})
+}
+
+func TestSlotsFor_StaffTimeOffAndBookings(t *testing.T) {
+	sc := newSchedule("Asia/Jerusalem", "sunday", "09:00", "12:00", 60)
+	sc.Staff = []model.StaffMember{
+		{
+			ID:      "aaaaaaaaaaaaaaaaaaaaaaaa",
+			Name:    "Dana",
+			TimeOff: []model.StaffTimeOff{{Start: mustParse(t, "2025-06-08T09:00:00+03:00"), End: mustParse(t, "2025-06-08T10:00:00+03:00")}},
+		},
+		{ID: "bbbbbbbbbbbbbbbbbbbbbbbb", Name: "Noa"},
+	}
+	bookings := []*model.Booking{
+		// Assigned to Noa only.
+		{StaffID: "bbbbbbbbbbbbbbbbbbbbbbbb", StartTime: mustParse(t, "2025-06-08T09:00:00+03:00"), EndTime: mustParse(t, "2025-06-08T10:00:00+03:00"), Capacity: 1},
+		// Unassigned bookings hold every member.
+		{StartTime: mustParse(t, "2025-06-08T11:00:00+03:00"), EndTime: mustParse(t, "2025-06-08T12:00:00+03:00"), Capacity: 1},
+	}
+	from := mustParse(t, "2025-06-08T00:00:00+03:00")
+	to := mustParse(t, "2025-06-09T00:00:00+03:00")
+
+	got := SlotsFor(sc, bookings, from, to, Filter{})
+	assertStarts(t, got, []string{"2025-06-08T10:00:00+03:00", "2025-06-08T10:00:00+03:00"})
+
+	dana := &sc.Staff[0]
+	if StaffAvailable(sc, dana, bookings, mustParse(t, "2025-06-08T09:00:00+03:00"), mustParse(t, "2025-06-08T10:00:00+03:00"), 1) {
+		t.Error("expected staff member on time off to be unavailable")
+	}
+	if !StaffAvailable(sc, dana, bookings, mustParse(t, "2025-06-08T10:00:00+03:00"), mustParse(t, "2025-06-08T11:00:00+03:00"), 1) {
+		t.Error("expected staff member to be available")
+	}
+
+}
+
+func TestStaffAvailable_Capacity(t *testing.T) {
+	sc := newSchedule("Asia/Jerusalem", "sunday", "09:00", "12:00", 60)
+	sc.MaxParticipantsPerSlot = 3
+	sc.Staff = []model.StaffMember{{ID: "aaaaaaaaaaaaaaaaaaaaaaaa", Name: "Dana"}}
+	start := mustParse(t, "2025-06-08T09:00:00+03:00")
+	end := mustParse(t, "2025-06-08T10:00:00+03:00")
+	bookings := []*model.Booking{
+		{StaffID: "aaaaaaaaaaaaaaaaaaaaaaaa", StartTime: start, EndTime: end, Capacity: 2},
+	}
+
+	dana := &sc.Staff[0]
+	if !StaffAvailable(sc, dana, bookings, start, end, 1) {
+		t.Error("expected staff member with a free seat to be available")
+	}
+	if StaffAvailable(sc, dana, bookings, start, end, 2) {
+		t.Error("expected staff member without enough seats to be unavailable")
+	}
+	if got := SlotsFor(sc, bookings, start, end, Filter{}); len(got) != 1 || got[0].Remaining != 1 {
+		t.Errorf("expected the slot to report the same free seat, got %v", got)
+	}
+}
+
+func TestCovers(t *testing.T) {
+	sc := newSchedule("Asia/Jerusalem", "sunday", "09:00", "17:00", 60)
+	sc.WeeklyHours = []model.DayHours{
+		{Day: "sunday", Intervals: []model.TimeRange{{Start: "09:00", End: "12:00"}, {Start: "12:00", End: "17:00"}}},
+		{Day: "monday", Intervals: []model.TimeRange{{Start: "09:00", End: "12:00"}, {Start: "13:00", End: "17:00"}}},
+	}
+	cases := []struct {
+		name       string
+		start, end string
+		want       bool
+	}{
+		{"within one interval", "2025-06-08T09:00:00+03:00", "2025-06-08T10:00:00+03:00", true},
+		{"across touching intervals", "2025-06-08T11:00:00+03:00", "2025-06-08T13:00:00+03:00", true},
+		{"before opening", "2025-06-08T08:30:00+03:00", "2025-06-08T09:30:00+03:00", false},
+		{"across a break", "2025-06-09T11:30:00+03:00", "2025-06-09T13:30:00+03:00", false},
+		{"on a closed day", "2025-06-10T10:00:00+03:00", "2025-06-10T11:00:00+03:00", false},
+	}
+	for _, tc := range cases {
+		start, end := mustParse(t, tc.start), mustParse(t, tc.end)
+		if got := Covers(WorkingFrames(sc, start, end), start, end); got != tc.want {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestCovers_AroundTheClock(t *testing.T) {
+	sc := newSchedule("UTC", "sunday", "00:00", "23:59", 5)
+	sc.WeeklyHours = model.WeeklyHoursFromFlat([]string{"sunday", "monday"}, "00:00", "23:59")
+	start := mustParse(t, "2025-06-08T22:00:00Z")
+	end := mustParse(t, "2025-06-09T02:00:00Z")
+	if !Covers(WorkingFrames(sc, start, end), start, end) {
+		t.Error("expected hours ending at 23:59 to join the next day")
+	}
+	end = mustParse(t, "2025-06-10T02:00:00Z")
+	if Covers(WorkingFrames(sc, start, end), start, end) {
+		t.Error("expected a closed day to break the span")
+	}
+}
+
+func TestSlots_SeasonInEffect(t *testing.T) {
+	sc := newSchedule("UTC", "monday", "09:00", "11:00", 60)
+	duration := 30
+	sc.Seasons = []model.ScheduleSeason{{
+		Name:                      "summer",
+		EffectiveFrom:             "2025-06-16",
+		EffectiveUntil:            "2025-06-23",
+		WorkingDays:               []string{"monday"},
+		StartOfDay:                "08:00",
+		EndOfDay:                  "09:30",
+		WeeklyHours:               model.WeeklyHoursFromFlat([]string{"monday"}, "08:00", "09:30"),
+		DefaultMeetingDurationMin: &duration,
+	}}
+
+	got := Slots(sc, nil, mustParse(t, "2025-06-09T00:00:00Z"), mustParse(t, "2025-07-01T00:00:00Z"))
+	assertStarts(t, got, []string{
+		"2025-06-09T09:00:00Z", "2025-06-09T10:00:00Z",
+		"2025-06-16T08:00:00Z", "2025-06-16T08:30:00Z", "2025-06-16T09:00:00Z",
+		"2025-06-23T08:00:00Z", "2025-06-23T08:30:00Z", "2025-06-23T09:00:00Z",
+		"2025-06-30T09:00:00Z", "2025-06-30T10:00:00Z",
+	})
+}
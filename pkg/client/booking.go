@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"skeji/pkg/model"
+	"time"
 )
 
 type BookingClient struct {
@@ -17,6 +18,13 @@ func NewBookingClient(baseUrl string) *BookingClient {
 	}
 }
 
+// WithCaller returns a copy of the client acting as caller
+func (c *BookingClient) WithCaller(caller, secret string) *BookingClient {
+	return &BookingClient{
+		httpClient: c.httpClient.WithCaller(caller, secret),
+	}
+}
+
 func (c *BookingClient) Create(body any) (*Response, error) {
 	return c.httpClient.POST("/api/v1/bookings", body)
 }
@@ -90,6 +98,24 @@ func (c *BookingClient) Delete(id string) (*Response, error) {
 	return c.httpClient.DELETE(path)
 }
 
+func (c *BookingClient) Archive(businessID string, scheduleID string, before time.Time) (*Response, error) {
+	body := model.BookingArchiveRequest{
+		BusinessID: businessID,
+		ScheduleID: scheduleID,
+		Before:     before,
+	}
+	return c.httpClient.POST("/api/v1/bookings/archive", body)
+}
+
+func (c *BookingClient) Reassign(fromBusinessID string, scheduleID string, toBusinessID string) (*Response, error) {
+	body := model.BookingReassignRequest{
+		FromBusinessID: fromBusinessID,
+		ScheduleID:     scheduleID,
+		ToBusinessID:   toBusinessID,
+	}
+	return c.httpClient.POST("/api/v1/bookings/reassign", body)
+}
+
 func (c *BookingClient) CreateRaw(rawBody []byte) (*Response, error) {
 	return c.httpClient.POSTRaw("/api/v1/bookings", rawBody)
 }
@@ -142,6 +168,40 @@ func (c *BookingClient) DecodeBookings(resp *Response) ([]*model.Booking, *Metad
 	return bookings, metadata, nil
 }
 
+func (c *BookingClient) DecodeArchiveResult(resp *Response) (*model.BookingArchiveResult, error) {
+	var wrapper struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &wrapper); err != nil {
+		return nil, fmt.Errorf("could not decode archive result wrapper:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	var result model.BookingArchiveResult
+	if err := json.Unmarshal(wrapper.Data, &result); err != nil {
+		return nil, fmt.Errorf("could not decode archive result json:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	return &result, nil
+}
+
+func (c *BookingClient) DecodeReassignResult(resp *Response) (*model.BookingReassignResult, error) {
+	var wrapper struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &wrapper); err != nil {
+		return nil, fmt.Errorf("could not decode reassign result wrapper:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	var result model.BookingReassignResult
+	if err := json.Unmarshal(wrapper.Data, &result); err != nil {
+		return nil, fmt.Errorf("could not decode reassign result json:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	return &result, nil
+}
+
 func (c *BookingClient) DecodeBatchBookings(resp *Response) (map[string][]*model.Booking, error) {
 	var wrapper struct {
 		Data map[string][]*model.Booking `json:"data"`
@@ -3,8 +3,12 @@ package client
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
+	"skeji/pkg/auth"
 	"skeji/pkg/model"
+	"sort"
+	"strconv"
 )
 
 type BusinessUnitClient struct {
@@ -17,6 +21,13 @@ func NewBusinessUnitClient(baseUrl string) *BusinessUnitClient {
 	}
 }
 
+// WithCaller returns a copy of the client acting as caller
+func (c *BusinessUnitClient) WithCaller(caller, secret string) *BusinessUnitClient {
+	return &BusinessUnitClient{
+		httpClient: c.httpClient.WithCaller(caller, secret),
+	}
+}
+
 func (c *BusinessUnitClient) Create(body any) (*Response, error) {
 	return c.httpClient.POST("/api/v1/business-units", body)
 }
@@ -28,6 +39,16 @@ func (c *BusinessUnitClient) GetAll(limit int, offset int64) (*Response, error)
 
 // Search supports repeated list params (cities=x&cities=y)
 func (c *BusinessUnitClient) Search(cities []string, labels []string, limit int, offset int64) (*Response, error) {
+	return c.FairSearch(cities, labels, model.SearchFairness{}, limit, offset)
+}
+
+// FairSearch is Search with equally ranked businesses reordered by fairness
+func (c *BusinessUnitClient) FairSearch(cities []string, labels []string, fairness model.SearchFairness, limit int, offset int64) (*Response, error) {
+	return c.FilteredSearch(cities, labels, nil, fairness, limit, offset)
+}
+
+// FilteredSearch is FairSearch narrowed to units matching the attribute filter
+func (c *BusinessUnitClient) FilteredSearch(cities []string, labels []string, attrs model.AttributeFilter, fairness model.SearchFairness, limit int, offset int64) (*Response, error) {
 	q := url.Values{}
 
 	for _, cty := range cities {
@@ -36,6 +57,8 @@ func (c *BusinessUnitClient) Search(cities []string, labels []string, limit int,
 	for _, lbl := range labels {
 		q.Add("labels", lbl)
 	}
+	setAttributes(q, attrs)
+	setFairness(q, fairness)
 
 	q.Set("limit", fmt.Sprintf("%d", limit))
 	q.Set("offset", fmt.Sprintf("%d", offset))
@@ -44,6 +67,91 @@ func (c *BusinessUnitClient) Search(cities []string, labels []string, limit int,
 	return c.httpClient.GET(path)
 }
 
+// FacetedSearch is FilteredSearch with the attribute facets of all results;
+// read them with DecodeFacets
+func (c *BusinessUnitClient) FacetedSearch(cities []string, labels []string, attrs model.AttributeFilter, limit int, offset int64) (*Response, error) {
+	q := url.Values{}
+
+	for _, cty := range cities {
+		q.Add("cities", cty)
+	}
+	for _, lbl := range labels {
+		q.Add("labels", lbl)
+	}
+	setAttributes(q, attrs)
+	q.Set("facets", "true")
+
+	q.Set("limit", fmt.Sprintf("%d", limit))
+	q.Set("offset", fmt.Sprintf("%d", offset))
+
+	path := "/api/v1/business-units/search?" + q.Encode()
+	return c.httpClient.GET(path)
+}
+
+// TextSearch matches query against business names and labels; cities are optional
+func (c *BusinessUnitClient) TextSearch(query string, cities []string, limit int, offset int64) (*Response, error) {
+	return c.FairTextSearch(query, cities, model.SearchFairness{}, limit, offset)
+}
+
+// FairTextSearch is TextSearch with equally ranked businesses reordered by fairness
+func (c *BusinessUnitClient) FairTextSearch(query string, cities []string, fairness model.SearchFairness, limit int, offset int64) (*Response, error) {
+	return c.FilteredTextSearch(query, cities, nil, fairness, limit, offset)
+}
+
+// FilteredTextSearch is FairTextSearch narrowed to units matching the attribute filter
+func (c *BusinessUnitClient) FilteredTextSearch(query string, cities []string, attrs model.AttributeFilter, fairness model.SearchFairness, limit int, offset int64) (*Response, error) {
+	q := url.Values{}
+	q.Set("q", query)
+	for _, cty := range cities {
+		q.Add("cities", cty)
+	}
+	setAttributes(q, attrs)
+	setFairness(q, fairness)
+	q.Set("limit", fmt.Sprintf("%d", limit))
+	q.Set("offset", fmt.Sprintf("%d", offset))
+
+	path := "/api/v1/business-units/search?" + q.Encode()
+	return c.httpClient.GET(path)
+}
+
+func setFairness(q url.Values, fairness model.SearchFairness) {
+	if fairness.Mode != "" {
+		q.Set("fairness", fairness.Mode)
+	}
+	if fairness.Requester != "" {
+		q.Set("requester", fairness.Requester)
+	}
+}
+
+// setAttributes writes the filter as key:value items, keys in order
+func setAttributes(q url.Values, attrs model.AttributeFilter) {
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, value := range attrs[key] {
+			q.Add("attributes", key+":"+value)
+		}
+	}
+}
+
+// ListAttributes returns the attribute definitions
+func (c *BusinessUnitClient) ListAttributes() (*Response, error) {
+	return c.httpClient.GET("/api/v1/attributes")
+}
+
+// RecordImpressions reports businesses that were shown to a customer
+func (c *BusinessUnitClient) RecordImpressions(businessIDs []string) (*Response, error) {
+	return c.httpClient.POST("/api/v1/business-units/impressions", model.ImpressionRecord{BusinessIDs: businessIDs})
+}
+
+func (c *BusinessUnitClient) GetImpressions(id string, days int) (*Response, error) {
+	path := fmt.Sprintf("/api/v1/business-units/id/%s/impressions?days=%d", url.PathEscape(id), days)
+	return c.httpClient.GET(path)
+}
+
 // UPDATED: Now supports cities + labels
 func (c *BusinessUnitClient) GetByPhone(phone string, cities []string, labels []string, limit int, offset int64) (*Response, error) {
 	q := url.Values{}
@@ -72,6 +180,48 @@ func (c *BusinessUnitClient) GetByID(id string) (*Response, error) {
 	return c.httpClient.GET(path)
 }
 
+// Role returns the role phone holds in the business unit, RoleNone when the
+// unit does not exist
+func (c *BusinessUnitClient) Role(businessID string, phone string) (string, error) {
+	resp, err := c.GetByID(businessID)
+	if err != nil {
+		return "", err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusBadRequest:
+		return auth.RoleNone, nil
+	default:
+		return "", fmt.Errorf("business units service returned status %d: %s", resp.StatusCode, GetErrorMessage(resp))
+	}
+
+	bu, err := c.DecodeBusinessUnit(resp)
+	if err != nil {
+		return "", err
+	}
+	role := auth.RoleOf(bu, phone)
+	if bu.OrganizationID == "" || role == auth.RoleOwner {
+		return role, nil
+	}
+
+	resp, err = c.GetOrganization(bu.OrganizationID)
+	if err != nil {
+		return "", err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return role, nil
+	default:
+		return "", fmt.Errorf("business units service returned status %d: %s", resp.StatusCode, GetErrorMessage(resp))
+	}
+	org, err := c.DecodeOrganization(resp)
+	if err != nil {
+		return "", err
+	}
+	return auth.StrongerRole(role, auth.OrgRoleOf(org, phone)), nil
+}
+
 func (c *BusinessUnitClient) Update(id string, body any) (*Response, error) {
 	path := "/api/v1/business-units/id/" + url.PathEscape(id)
 	return c.httpClient.PATCH(path, body)
@@ -82,6 +232,134 @@ func (c *BusinessUnitClient) Delete(id string) (*Response, error) {
 	return c.httpClient.DELETE(path)
 }
 
+func (c *BusinessUnitClient) GetDeletion(operationID string) (*Response, error) {
+	path := "/api/v1/business-units/operations/id/" + url.PathEscape(operationID)
+	return c.httpClient.GET(path)
+}
+
+func (c *BusinessUnitClient) ResumeDeletion(operationID string) (*Response, error) {
+	path := "/api/v1/business-units/operations/id/" + url.PathEscape(operationID) + "/resume"
+	return c.httpClient.POST(path, map[string]any{})
+}
+
+func (c *BusinessUnitClient) ChangeStatus(businessID string, change model.BusinessUnitStatusChange) (*Response, error) {
+	path := "/api/v1/business-units/id/" + url.PathEscape(businessID) + "/status"
+	return c.httpClient.POST(path, change)
+}
+
+func (c *BusinessUnitClient) StartTransfer(businessID string, req model.OwnershipTransferRequest) (*Response, error) {
+	path := "/api/v1/business-units/id/" + url.PathEscape(businessID) + "/transfers"
+	return c.httpClient.POST(path, req)
+}
+
+func (c *BusinessUnitClient) GetTransfer(transferID string) (*Response, error) {
+	path := "/api/v1/business-units/transfers/id/" + url.PathEscape(transferID)
+	return c.httpClient.GET(path)
+}
+
+func (c *BusinessUnitClient) AcceptTransfer(transferID string) (*Response, error) {
+	path := "/api/v1/business-units/transfers/id/" + url.PathEscape(transferID) + "/accept"
+	return c.httpClient.POST(path, map[string]any{})
+}
+
+func (c *BusinessUnitClient) DeclineTransfer(transferID string) (*Response, error) {
+	path := "/api/v1/business-units/transfers/id/" + url.PathEscape(transferID) + "/decline"
+	return c.httpClient.POST(path, map[string]any{})
+}
+
+func (c *BusinessUnitClient) CancelTransfer(transferID string) (*Response, error) {
+	path := "/api/v1/business-units/transfers/id/" + url.PathEscape(transferID) + "/cancel"
+	return c.httpClient.POST(path, map[string]any{})
+}
+
+func (c *BusinessUnitClient) GetAuditLog(businessID string, limit int, offset int64) (*Response, error) {
+	path := fmt.Sprintf("/api/v1/business-units/id/%s/audit?limit=%d&offset=%d", url.PathEscape(businessID), limit, offset)
+	return c.httpClient.GET(path)
+}
+
+func (c *BusinessUnitClient) CreateOrganization(body any) (*Response, error) {
+	return c.httpClient.POST("/api/v1/organizations", body)
+}
+
+func (c *BusinessUnitClient) GetOrganization(id string) (*Response, error) {
+	path := "/api/v1/organizations/id/" + url.PathEscape(id)
+	return c.httpClient.GET(path)
+}
+
+func (c *BusinessUnitClient) UpdateOrganization(id string, body any) (*Response, error) {
+	path := "/api/v1/organizations/id/" + url.PathEscape(id)
+	return c.httpClient.PATCH(path, body)
+}
+
+func (c *BusinessUnitClient) DeleteOrganization(id string) (*Response, error) {
+	path := "/api/v1/organizations/id/" + url.PathEscape(id)
+	return c.httpClient.DELETE(path)
+}
+
+func (c *BusinessUnitClient) GetOrganizationUnits(id string, limit int, offset int64) (*Response, error) {
+	path := fmt.Sprintf("/api/v1/organizations/id/%s/business-units?limit=%d&offset=%d", url.PathEscape(id), limit, offset)
+	return c.httpClient.GET(path)
+}
+
+func (c *BusinessUnitClient) ListPlans() (*Response, error) {
+	return c.httpClient.GET("/api/v1/plans")
+}
+
+func (c *BusinessUnitClient) AssignPlan(assignment model.PlanAssignment) (*Response, error) {
+	return c.httpClient.POST("/api/v1/plans/assignments", assignment)
+}
+
+func (c *BusinessUnitClient) GetQuota(businessID string) (*Response, error) {
+	path := "/api/v1/business-units/id/" + url.PathEscape(businessID) + "/quota"
+	return c.httpClient.GET(path)
+}
+
+// FindDuplicates reports likely duplicate pairs among the units of phone
+// (the caller's by default), optionally only in the given cities; a zero
+// minScore uses the service default
+func (c *BusinessUnitClient) FindDuplicates(phone string, cities []string, minScore float64, limit int, offset int64) (*Response, error) {
+	q := url.Values{}
+
+	if phone != "" {
+		q.Set("phone", phone)
+	}
+	for _, cty := range cities {
+		q.Add("cities", cty)
+	}
+	if minScore > 0 {
+		q.Set("min_score", strconv.FormatFloat(minScore, 'f', -1, 64))
+	}
+
+	q.Set("limit", fmt.Sprintf("%d", limit))
+	q.Set("offset", fmt.Sprintf("%d", offset))
+
+	path := "/api/v1/business-units/duplicates?" + q.Encode()
+	return c.httpClient.GET(path)
+}
+
+// Merge merges the duplicate businessID into intoID
+func (c *BusinessUnitClient) Merge(businessID string, intoID string) (*Response, error) {
+	path := "/api/v1/business-units/id/" + url.PathEscape(businessID) + "/merge"
+	return c.httpClient.POST(path, model.BusinessUnitMergeRequest{IntoID: intoID})
+}
+
+// SearchBrands returns one result per brand with its nearest branch; cities
+// are listed nearest first
+func (c *BusinessUnitClient) SearchBrands(cities []string, labels []string, limit int, offset int64) (*Response, error) {
+	q := url.Values{}
+	for _, cty := range cities {
+		q.Add("cities", cty)
+	}
+	for _, lbl := range labels {
+		q.Add("labels", lbl)
+	}
+	q.Set("limit", fmt.Sprintf("%d", limit))
+	q.Set("offset", fmt.Sprintf("%d", offset))
+
+	path := "/api/v1/organizations/search?" + q.Encode()
+	return c.httpClient.GET(path)
+}
+
 func (c *BusinessUnitClient) CreateRaw(rawBody []byte) (*Response, error) {
 	return c.httpClient.POSTRaw("/api/v1/business-units", rawBody)
 }
@@ -108,6 +386,199 @@ func (c *BusinessUnitClient) DecodeBusinessUnit(resp *Response) (*model.Business
 	return &bu, nil
 }
 
+func (c *BusinessUnitClient) DecodeOrganization(resp *Response) (*model.Organization, error) {
+	var wrapper struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &wrapper); err != nil {
+		return nil, fmt.Errorf("could not decode organization wrapper:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	var org model.Organization
+	if err := json.Unmarshal(wrapper.Data, &org); err != nil {
+		return nil, fmt.Errorf("could not decode organization json:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	return &org, nil
+}
+
+func (c *BusinessUnitClient) DecodeQuota(resp *Response) (*model.Quota, error) {
+	var wrapper struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &wrapper); err != nil {
+		return nil, fmt.Errorf("could not decode quota wrapper:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	var quota model.Quota
+	if err := json.Unmarshal(wrapper.Data, &quota); err != nil {
+		return nil, fmt.Errorf("could not decode quota json:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	return &quota, nil
+}
+
+func (c *BusinessUnitClient) DecodeMerge(resp *Response) (*model.BusinessUnitMerge, error) {
+	var wrapper struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &wrapper); err != nil {
+		return nil, fmt.Errorf("could not decode merge wrapper:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	var merge model.BusinessUnitMerge
+	if err := json.Unmarshal(wrapper.Data, &merge); err != nil {
+		return nil, fmt.Errorf("could not decode merge json:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	return &merge, nil
+}
+
+func (c *BusinessUnitClient) DecodeDeletion(resp *Response) (*model.BusinessUnitDeletion, error) {
+	var wrapper struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &wrapper); err != nil {
+		return nil, fmt.Errorf("could not decode deletion wrapper:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	var op model.BusinessUnitDeletion
+	if err := json.Unmarshal(wrapper.Data, &op); err != nil {
+		return nil, fmt.Errorf("could not decode deletion json:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	return &op, nil
+}
+
+func (c *BusinessUnitClient) DecodeImpressions(resp *Response) (*model.ImpressionSummary, error) {
+	var wrapper struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &wrapper); err != nil {
+		return nil, fmt.Errorf("could not decode impressions wrapper:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	var summary model.ImpressionSummary
+	if err := json.Unmarshal(wrapper.Data, &summary); err != nil {
+		return nil, fmt.Errorf("could not decode impressions json:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	return &summary, nil
+}
+
+func (c *BusinessUnitClient) DecodeTransfer(resp *Response) (*model.OwnershipTransfer, error) {
+	var wrapper struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &wrapper); err != nil {
+		return nil, fmt.Errorf("could not decode transfer wrapper:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	var transfer model.OwnershipTransfer
+	if err := json.Unmarshal(wrapper.Data, &transfer); err != nil {
+		return nil, fmt.Errorf("could not decode transfer json:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	return &transfer, nil
+}
+
+func (c *BusinessUnitClient) DecodeAuditEntries(resp *Response) ([]*model.AuditEntry, *Metadata, error) {
+	var wrapper struct {
+		Data       json.RawMessage `json:"data"`
+		TotalCount int64           `json:"total_count"`
+		Limit      int             `json:"limit"`
+		Offset     int64           `json:"offset"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &wrapper); err != nil {
+		return nil, nil, fmt.Errorf("could not decode paginated resp:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	var entries []*model.AuditEntry
+	if err := json.Unmarshal(wrapper.Data, &entries); err != nil {
+		return nil, nil, fmt.Errorf("could not decode audit entries:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	metadata := &Metadata{
+		TotalCount: wrapper.TotalCount,
+		Limit:      wrapper.Limit,
+		Offset:     wrapper.Offset,
+	}
+
+	return entries, metadata, nil
+}
+
+func (c *BusinessUnitClient) DecodeBrandMatches(resp *Response) ([]*model.BrandMatch, *Metadata, error) {
+	var wrapper struct {
+		Data       json.RawMessage `json:"data"`
+		TotalCount int64           `json:"total_count"`
+		Limit      int             `json:"limit"`
+		Offset     int64           `json:"offset"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &wrapper); err != nil {
+		return nil, nil, fmt.Errorf("could not decode paginated resp:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	var matches []*model.BrandMatch
+	if err := json.Unmarshal(wrapper.Data, &matches); err != nil {
+		return nil, nil, fmt.Errorf("could not decode brand matches:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	metadata := &Metadata{
+		TotalCount: wrapper.TotalCount,
+		Limit:      wrapper.Limit,
+		Offset:     wrapper.Offset,
+	}
+
+	return matches, metadata, nil
+}
+
+func (c *BusinessUnitClient) DecodeDuplicates(resp *Response) ([]model.DuplicateCandidate, *Metadata, error) {
+	var wrapper struct {
+		Data       json.RawMessage `json:"data"`
+		TotalCount int64           `json:"total_count"`
+		Limit      int             `json:"limit"`
+		Offset     int64           `json:"offset"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &wrapper); err != nil {
+		return nil, nil, fmt.Errorf("could not decode paginated resp:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	var candidates []model.DuplicateCandidate
+	if err := json.Unmarshal(wrapper.Data, &candidates); err != nil {
+		return nil, nil, fmt.Errorf("could not decode duplicate candidates:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	metadata := &Metadata{
+		TotalCount: wrapper.TotalCount,
+		Limit:      wrapper.Limit,
+		Offset:     wrapper.Offset,
+	}
+
+	return candidates, metadata, nil
+}
+
+// DecodeFacets reads the attribute facets of a FacetedSearch response
+func (c *BusinessUnitClient) DecodeFacets(resp *Response) ([]model.AttributeFacet, error) {
+	var wrapper struct {
+		Facets []model.AttributeFacet `json:"facets"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &wrapper); err != nil {
+		return nil, fmt.Errorf("could not decode facets:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	return wrapper.Facets, nil
+}
+
 func (c *BusinessUnitClient) DecodeBusinessUnits(resp *Response) ([]*model.BusinessUnit, *Metadata, error) {
 	var wrapper struct {
 		Data       json.RawMessage `json:"data"`
@@ -11,6 +11,9 @@ type Client struct {
 	BusinessUnitClient *BusinessUnitClient
 	ScheduleClient     *ScheduleClient
 	BookingClient      *BookingClient
+
+	caller string
+	secret string
 }
 
 func NewClient() *Client {
@@ -23,14 +26,54 @@ func (c *Client) SetMongo(log *logger.Logger, mongoURI string, mongoConnTimeout
 
 func (c *Client) SetBusinessUnitClient(baseUrl string) {
 	c.BusinessUnitClient = NewBusinessUnitClient(baseUrl)
+	if c.caller != "" {
+		c.BusinessUnitClient = c.BusinessUnitClient.WithCaller(c.caller, c.secret)
+	}
 }
 
 func (c *Client) SetScheduleClient(baseUrl string) {
 	c.ScheduleClient = NewScheduleClient(baseUrl)
+	if c.caller != "" {
+		c.ScheduleClient = c.ScheduleClient.WithCaller(c.caller, c.secret)
+	}
 }
 
 func (c *Client) SetBookingClient(baseUrl string) {
 	c.BookingClient = NewBookingClient(baseUrl)
+	if c.caller != "" {
+		c.BookingClient = c.BookingClient.WithCaller(c.caller, c.secret)
+	}
+}
+
+// SetCaller makes the service clients, set now or later, act as caller.
+func (c *Client) SetCaller(caller, secret string) {
+	c.caller, c.secret = caller, secret
+	if c.BusinessUnitClient != nil {
+		c.BusinessUnitClient = c.BusinessUnitClient.WithCaller(caller, secret)
+	}
+	if c.ScheduleClient != nil {
+		c.ScheduleClient = c.ScheduleClient.WithCaller(caller, secret)
+	}
+	if c.BookingClient != nil {
+		c.BookingClient = c.BookingClient.WithCaller(caller, secret)
+	}
+}
+
+// As returns a copy of the client whose service clients act as caller,
+// signed with the same secret. The Mongo client is shared.
+func (c *Client) As(caller string) *Client {
+	as := &Client{Mongo: c.Mongo, secret: c.secret}
+	as.SetCaller(caller, c.secret)
+	if c.BusinessUnitClient != nil {
+		as.BusinessUnitClient = c.BusinessUnitClient.WithCaller(caller, c.secret)
+	}
+	if c.ScheduleClient != nil {
+		as.ScheduleClient = c.ScheduleClient.WithCaller(caller, c.secret)
+	}
+	if c.BookingClient != nil {
+		as.BookingClient = c.BookingClient.WithCaller(caller, c.secret)
+	}
+	return as
 }
 
 func (c *Client) GracefulShutdown() {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"skeji/pkg/auth"
 	"strings"
 	"time"
 )
@@ -14,6 +15,7 @@ import (
 type HttpClient struct {
 	BaseURL    string
 	HTTPClient *http.Client
+	headers    map[string]string
 }
 
 func NewHttpClient(baseURL string) *HttpClient {
@@ -25,6 +27,19 @@ func NewHttpClient(baseURL string) *HttpClient {
 	}
 }
 
+// WithCaller returns a copy of the client that sends every request as
+// caller, signed with secret.
+func (c *HttpClient) WithCaller(caller, secret string) *HttpClient {
+	return &HttpClient{
+		BaseURL:    c.BaseURL,
+		HTTPClient: c.HTTPClient,
+		headers: map[string]string{
+			auth.HeaderCaller:          caller,
+			auth.HeaderCallerSignature: auth.Sign(secret, caller),
+		},
+	}
+}
+
 type Response struct {
 	*http.Response
 	Body []byte
@@ -121,6 +136,9 @@ func (c *HttpClient) do(method, path string, reqBody io.Reader, hasBody bool, he
 		req.Header.Set("Content-Type", "application/json")
 	}
 
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
 	for key, value := range headers {
 		req.Header.Set(key, value)
 	}
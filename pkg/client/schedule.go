@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"skeji/pkg/model"
+	"strconv"
 )
 
 type ScheduleClient struct {
@@ -17,6 +18,13 @@ func NewScheduleClient(baseUrl string) *ScheduleClient {
 	}
 }
 
+// WithCaller returns a copy of the client acting as caller
+func (c *ScheduleClient) WithCaller(caller, secret string) *ScheduleClient {
+	return &ScheduleClient{
+		httpClient: c.httpClient.WithCaller(caller, secret),
+	}
+}
+
 func (c *ScheduleClient) Create(body any) (*Response, error) {
 	return c.httpClient.POST("/api/v1/schedules", body)
 }
@@ -64,11 +72,50 @@ func (c *ScheduleClient) BatchSearch(businessID string, cities []string, limit i
 	return c.httpClient.GET(path)
 }
 
+func (c *ScheduleClient) Near(businessID string, lat float64, lng float64, radiusMeters float64, limit int, offset int64) (*Response, error) {
+	q := url.Values{}
+	if businessID != "" {
+		q.Set("business_id", businessID)
+	}
+	q.Set("lat", strconv.FormatFloat(lat, 'f', -1, 64))
+	q.Set("lng", strconv.FormatFloat(lng, 'f', -1, 64))
+	if radiusMeters > 0 {
+		q.Set("radius", strconv.FormatFloat(radiusMeters, 'f', -1, 64))
+	}
+	q.Set("limit", fmt.Sprintf("%d", limit))
+	q.Set("offset", fmt.Sprintf("%d", offset))
+
+	path := "/api/v1/schedules/near?" + q.Encode()
+	return c.httpClient.GET(path)
+}
+
 func (c *ScheduleClient) GetByID(id string) (*Response, error) {
 	path := "/api/v1/schedules/id/" + url.PathEscape(id)
 	return c.httpClient.GET(path)
 }
 
+func (c *ScheduleClient) GetAvailability(id string, from string, to string, service string, staffID string) (*Response, error) {
+	q := url.Values{}
+	if from != "" {
+		q.Set("from", from)
+	}
+	if to != "" {
+		q.Set("to", to)
+	}
+	if service != "" {
+		q.Set("service", service)
+	}
+	if staffID != "" {
+		q.Set("staff", staffID)
+	}
+
+	path := "/api/v1/schedules/id/" + url.PathEscape(id) + "/availability"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	return c.httpClient.GET(path)
+}
+
 func (c *ScheduleClient) Update(id string, body any) (*Response, error) {
 	path := "/api/v1/schedules/id/" + url.PathEscape(id)
 	return c.httpClient.PATCH(path, body)
@@ -79,6 +126,68 @@ func (c *ScheduleClient) Delete(id string) (*Response, error) {
 	return c.httpClient.DELETE(path)
 }
 
+func (c *ScheduleClient) DeleteWithMode(id string, mode string, targetScheduleID string) (*Response, error) {
+	q := url.Values{}
+	if mode != "" {
+		q.Set("mode", mode)
+	}
+	if targetScheduleID != "" {
+		q.Set("target_schedule_id", targetScheduleID)
+	}
+
+	path := "/api/v1/schedules/id/" + url.PathEscape(id)
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	return c.httpClient.DELETE(path)
+}
+
+func (c *ScheduleClient) Clone(id string, body any) (*Response, error) {
+	path := "/api/v1/schedules/id/" + url.PathEscape(id) + "/clone"
+	return c.httpClient.POST(path, body)
+}
+
+func (c *ScheduleClient) Reassign(id string, businessID string) (*Response, error) {
+	path := "/api/v1/schedules/id/" + url.PathEscape(id) + "/reassign"
+	return c.httpClient.POST(path, model.ScheduleReassign{BusinessID: businessID})
+}
+
+// CheckReassign asks whether the schedule may move to businessID without
+// moving it.
+func (c *ScheduleClient) CheckReassign(id string, businessID string) (*Response, error) {
+	path := "/api/v1/schedules/id/" + url.PathEscape(id) + "/reassign"
+	return c.httpClient.POST(path, model.ScheduleReassign{BusinessID: businessID, DryRun: true})
+}
+
+func (c *ScheduleClient) CreateTemplate(body any) (*Response, error) {
+	return c.httpClient.POST("/api/v1/schedules/templates", body)
+}
+
+func (c *ScheduleClient) GetTemplateByID(id string) (*Response, error) {
+	path := "/api/v1/schedules/templates/id/" + url.PathEscape(id)
+	return c.httpClient.GET(path)
+}
+
+func (c *ScheduleClient) SearchTemplates(businessID string, limit int, offset int64) (*Response, error) {
+	q := url.Values{}
+	q.Set("business_id", businessID)
+	q.Set("limit", fmt.Sprintf("%d", limit))
+	q.Set("offset", fmt.Sprintf("%d", offset))
+
+	path := "/api/v1/schedules/templates/search?" + q.Encode()
+	return c.httpClient.GET(path)
+}
+
+func (c *ScheduleClient) DeleteTemplate(id string) (*Response, error) {
+	path := "/api/v1/schedules/templates/id/" + url.PathEscape(id)
+	return c.httpClient.DELETE(path)
+}
+
+func (c *ScheduleClient) CreateFromTemplate(id string, body any) (*Response, error) {
+	path := "/api/v1/schedules/templates/id/" + url.PathEscape(id) + "/apply"
+	return c.httpClient.POST(path, body)
+}
+
 func (c *ScheduleClient) CreateRaw(rawBody []byte) (*Response, error) {
 	return c.httpClient.POSTRaw("/api/v1/schedules", rawBody)
 }
@@ -105,6 +214,23 @@ func (c *ScheduleClient) DecodeSchedule(resp *Response) (*model.Schedule, error)
 	return &schedule, nil
 }
 
+func (c *ScheduleClient) DecodeScheduleDeletion(resp *Response) (*model.ScheduleDeletion, error) {
+	var wrapper struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &wrapper); err != nil {
+		return nil, fmt.Errorf("could not decode schedule deletion wrapper:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	var deletion model.ScheduleDeletion
+	if err := json.Unmarshal(wrapper.Data, &deletion); err != nil {
+		return nil, fmt.Errorf("could not decode schedule deletion json:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	return &deletion, nil
+}
+
 func (c *ScheduleClient) DecodeSchedules(resp *Response) ([]*model.Schedule, *Metadata, error) {
 	var wrapper struct {
 		Data       json.RawMessage `json:"data"`
@@ -130,3 +256,63 @@ func (c *ScheduleClient) DecodeSchedules(resp *Response) ([]*model.Schedule, *Me
 
 	return schedules, metadata, nil
 }
+
+func (c *ScheduleClient) DecodeNearbySchedules(resp *Response) ([]*model.NearbySchedule, *Metadata, error) {
+	var wrapper struct {
+		Data       json.RawMessage `json:"data"`
+		TotalCount int64           `json:"total_count"`
+		Limit      int             `json:"limit"`
+		Offset     int64           `json:"offset"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &wrapper); err != nil {
+		return nil, nil, fmt.Errorf("could not decode paginated resp:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	var schedules []*model.NearbySchedule
+	if err := json.Unmarshal(wrapper.Data, &schedules); err != nil {
+		return nil, nil, fmt.Errorf("could not decode nearby schedule list:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	metadata := &Metadata{
+		TotalCount: wrapper.TotalCount,
+		Limit:      wrapper.Limit,
+		Offset:     wrapper.Offset,
+	}
+
+	return schedules, metadata, nil
+}
+
+func (c *ScheduleClient) DecodeAvailability(resp *Response) (*model.Availability, error) {
+	var wrapper struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &wrapper); err != nil {
+		return nil, fmt.Errorf("could not decode availability wrapper:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	var availability model.Availability
+	if err := json.Unmarshal(wrapper.Data, &availability); err != nil {
+		return nil, fmt.Errorf("could not decode availability json:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	return &availability, nil
+}
+
+func (c *ScheduleClient) DecodeScheduleTemplate(resp *Response) (*model.ScheduleTemplate, error) {
+	var wrapper struct {
+		Data json.RawMessage `json:"data"`
+	}
+
+	if err := json.Unmarshal(resp.Body, &wrapper); err != nil {
+		return nil, fmt.Errorf("could not decode schedule template wrapper:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	var template model.ScheduleTemplate
+	if err := json.Unmarshal(wrapper.Data, &template); err != nil {
+		return nil, fmt.Errorf("could not decode schedule template json:\n%+v\n%s", resp.ToString(), err)
+	}
+
+	return &template, nil
+}
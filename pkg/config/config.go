@@ -4,13 +4,17 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"skeji/pkg/auth"
 	"skeji/pkg/client"
 	"skeji/pkg/logger"
+	"skeji/pkg/ranking"
 	"strconv"
 	"time"
 )
 
 type Config struct {
+	ServiceName string
+
 	MongoURI          string
 	MongoDatabaseName string
 	MongoConnTimeout  time.Duration
@@ -18,6 +22,7 @@ type Config struct {
 	Port string
 
 	WhatsAppAppSecret string
+	CallerSecret      string
 
 	RateLimitRequests int
 	RateLimitWindow   time.Duration
@@ -43,16 +48,30 @@ type Config struct {
 	DefaultWorkingDaysIsrael      []string
 	DefaultWorkingDaysUs          []string
 
+	MaxAvailabilityRangeDays int
+
+	RankingWeights ranking.Weights
+
+	SearchFairness         string
+	FairnessRotationBucket time.Duration
+
+	CityValidation string
+
 	BusinessUnitBaseUrl string
 	ScheduleBaseUrl     string
 	BookingBaseUrl      string
 
+	// MaestroFlowsDir holds declarative maestro flow files; empty for none.
+	MaestroFlowsDir string
+
 	Log    *logger.Logger
 	Client *client.Client
 }
 
 func Load(serviceName string) *Config {
 	cfg := &Config{
+		ServiceName: serviceName,
+
 		MongoURI:          getEnvStr(EnvMongoURI, DefaultMongoURI),
 		MongoDatabaseName: getEnvStr(EnvMongoDatabaseName, DefaultMongoDatabaseName),
 		MongoConnTimeout:  getEnvDuration(EnvMongoConnTimeout, DefaultMongoConnTimeout),
@@ -60,6 +79,7 @@ func Load(serviceName string) *Config {
 		Port: getEnvStr(EnvPort, DefaultPort),
 
 		WhatsAppAppSecret: getEnvStr(EnvWhatsAppAppSecret, ""),
+		CallerSecret:      getEnvStr(EnvCallerSecret, ""),
 
 		RateLimitRequests: getEnvNum(EnvRateLimitRequests, DefaultRateLimitRequests),
 		RateLimitWindow:   getEnvDuration(EnvRateLimitWindow, DefaultRateLimitWindow),
@@ -85,10 +105,27 @@ func Load(serviceName string) *Config {
 		DefaultWorkingDaysIsrael:      DefaultWorkingDaysIsrael,
 		DefaultWorkingDaysUs:          DefaultWorkingDaysUs,
 
+		MaxAvailabilityRangeDays: getEnvNum(EnvMaxAvailabilityRangeDays, DefaultMaxAvailabilityRangeDays),
+
+		RankingWeights: ranking.Weights{
+			Priority:     getEnvFloat(EnvRankingWeightPriority, DefaultRankingWeightPriority),
+			Availability: getEnvFloat(EnvRankingWeightAvailability, DefaultRankingWeightAvailability),
+			OpenSlots:    getEnvFloat(EnvRankingWeightOpenSlots, DefaultRankingWeightOpenSlots),
+			LabelMatch:   getEnvFloat(EnvRankingWeightLabelMatch, DefaultRankingWeightLabelMatch),
+			Distance:     getEnvFloat(EnvRankingWeightDistance, DefaultRankingWeightDistance),
+		},
+
+		SearchFairness:         getEnvStr(EnvSearchFairness, DefaultSearchFairness),
+		FairnessRotationBucket: getEnvDuration(EnvFairnessRotationBucket, DefaultFairnessRotationBucket),
+
+		CityValidation: getEnvStr(EnvCityValidation, DefaultCityValidation),
+
 		BusinessUnitBaseUrl: getEnvStr(EnvBusinessUnitBaseUrl, DefaultBusinessUnitBaseUrl),
 		ScheduleBaseUrl:     getEnvStr(EnvScheduleBaseUrl, DefaultScheduleBaseUrl),
 		BookingBaseUrl:      getEnvStr(EnvBookingBaseUrl, DefaultBookingBaseUrl),
 
+		MaestroFlowsDir: getEnvStr(EnvMaestroFlowsDir, DefaultMaestroFlowsDir),
+
 		Log: logger.New(logger.Config{
 			Level:     getEnvStr(EnvLogLevel, DefaultLogLevel),
 			Format:    logger.JSON,
@@ -97,6 +134,8 @@ func Load(serviceName string) *Config {
 		}),
 		Client: client.NewClient(),
 	}
+	// calls between services act as the system, not as a person
+	cfg.Client.SetCaller(auth.SystemCaller, cfg.CallerSecret)
 
 	err := cfg.Validate()
 	if err != nil {
@@ -186,6 +225,25 @@ func (cfg *Config) Validate() error {
 	if cfg.DefaultMaxParticipantsPerSlot <= 0 {
 		errors = append(errors, fmt.Sprintf("DefaultMaxParticipantsPerSlot must be positive, got: %d", cfg.DefaultMaxParticipantsPerSlot))
 	}
+	if cfg.MaxAvailabilityRangeDays <= 0 {
+		errors = append(errors, fmt.Sprintf("MaxAvailabilityRangeDays must be positive, got: %d", cfg.MaxAvailabilityRangeDays))
+	}
+
+	if err := cfg.RankingWeights.Validate(); err != nil {
+		errors = append(errors, err.Error())
+	}
+	if !IsFairnessMode(cfg.SearchFairness) {
+		errors = append(errors, fmt.Sprintf("SearchFairness must be one of %s, %s or %s, got: %s", FairnessNone, FairnessRotate, FairnessBalance, cfg.SearchFairness))
+	}
+	if cfg.FairnessRotationBucket <= 0 {
+		errors = append(errors, fmt.Sprintf("FairnessRotationBucket must be positive, got: %v", cfg.FairnessRotationBucket))
+	}
+	if cfg.CityValidation != CityValidationLenient && cfg.CityValidation != CityValidationStrict {
+		errors = append(errors, fmt.Sprintf("CityValidation must be %s or %s, got: %s", CityValidationLenient, CityValidationStrict, cfg.CityValidation))
+	}
+	if cfg.CallerSecret == "" && ServicesVerifyingCallers[cfg.ServiceName] {
+		errors = append(errors, fmt.Sprintf("CallerSecret (%s) must be set, %s trusts X-Caller headers only when they are signed", EnvCallerSecret, cfg.ServiceName))
+	}
 
 	if len(errors) > 0 {
 		errMsg := "Configuration validation failed:\n"
@@ -205,6 +263,7 @@ func (cfg *Config) LogConfiguration() {
 		"mongo_conn_timeout", cfg.MongoConnTimeout,
 		"port", cfg.Port,
 		"whatsapp_secret_set", cfg.WhatsAppAppSecret != "",
+		"caller_secret_set", cfg.CallerSecret != "",
 		"rate_limit_requests", cfg.RateLimitRequests,
 		"rate_limit_window", cfg.RateLimitWindow,
 		"request_timeout", cfg.RequestTimeout,
@@ -222,10 +281,19 @@ func (cfg *Config) LogConfiguration() {
 		"default_max_participants_per_slot", cfg.DefaultMaxParticipantsPerSlot,
 		"default_start_of_day", cfg.DefaultStartOfDay,
 		"default_end_of_day", cfg.DefaultEndOfDay,
+		"max_availability_range_days", cfg.MaxAvailabilityRangeDays,
+		"ranking_weights", cfg.RankingWeights,
+		"search_fairness", cfg.SearchFairness,
+		"fairness_rotation_bucket", cfg.FairnessRotationBucket,
+		"city_validation", cfg.CityValidation,
 		"business_unit_base_url", cfg.BusinessUnitBaseUrl,
 		"schedule_base_url", cfg.ScheduleBaseUrl,
 		"booking_base_url", cfg.BookingBaseUrl,
+		"maestro_flows_dir", cfg.MaestroFlowsDir,
 	)
+	if cfg.CallerSecret == "" {
+		cfg.Log.Warn("Caller identity secret is not set, calls to other services are not signed")
+	}
 }
 
 func redactMongoURI(uri string) string {
@@ -249,6 +317,15 @@ func getEnvNum(key string, fallback int) int {
 	return fallback
 }
 
+func getEnvFloat(key string, fallback float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return fallback
+}
+
 func getEnvDuration(key string, fallback time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if d, err := time.ParseDuration(value); err == nil {
@@ -274,3 +351,15 @@ func NormalizePaginationLimit(limit int) int {
 func NormalizeOffset(offset int64) int64 {
 	return max(0, offset)
 }
+
+// IsLiveBusinessStatus reports whether a business unit with status is
+// searchable and takes bookings. Units stored before statuses existed have
+// none and are live.
+func IsLiveBusinessStatus(status string) bool {
+	return status == "" || status == BusinessStatusActive
+}
+
+// IsFairnessMode reports whether mode is a known search fairness mode.
+func IsFairnessMode(mode string) bool {
+	return mode == FairnessNone || mode == FairnessRotate || mode == FairnessBalance
+}
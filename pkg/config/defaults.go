@@ -14,6 +14,56 @@ const (
 	Pending   string = "pending"
 	Confirmed string = "confirmed"
 	Cancelled string = "cancelled"
+
+	ExceptionClosed       string = "closed"
+	ExceptionSpecialHours string = "special_hours"
+
+	AnyStaff string = "any"
+
+	DeleteModeCancel string = "cancel"
+	DeleteModeMove   string = "move"
+
+	OutcomeCancelled  string = "cancelled"
+	OutcomeMoved      string = "moved"
+	OutcomeFailed     string = "failed"
+	OutcomeRolledBack string = "rolled_back"
+
+	OperationPending   string = "pending"
+	OperationRunning   string = "running"
+	OperationRetrying  string = "retrying"
+	OperationCompleted string = "completed"
+	OperationFailed    string = "failed"
+
+	FairnessNone    string = "none"
+	FairnessRotate  string = "rotate"
+	FairnessBalance string = "balance"
+
+	CityValidationLenient string = "lenient"
+	CityValidationStrict  string = "strict"
+
+	BusinessStatusDraft     string = "draft"
+	BusinessStatusActive    string = "active"
+	BusinessStatusSuspended string = "suspended"
+	BusinessStatusArchived  string = "archived"
+
+	TransferPending   string = "pending"
+	TransferAccepted  string = "accepted"
+	TransferDeclined  string = "declined"
+	TransferCancelled string = "cancelled"
+	TransferExpired   string = "expired"
+
+	AuditTransferStarted   string = "ownership_transfer_started"
+	AuditTransferAccepted  string = "ownership_transfer_accepted"
+	AuditTransferDeclined  string = "ownership_transfer_declined"
+	AuditTransferCancelled string = "ownership_transfer_cancelled"
+	AuditTransferExpired   string = "ownership_transfer_expired"
+	AuditStatusChanged     string = "status_changed"
+	AuditMergedInto        string = "merged_into"
+	AuditMergedFrom        string = "merged_from"
+
+	PlanFree       string = "free"
+	PlanPro        string = "pro"
+	PlanEnterprise string = "enterprise"
 )
 
 const (
@@ -43,11 +93,22 @@ const (
 	DefaultMaxBusinessPriority     = 1000
 
 	DefaultMaxMaintainersPerBusiness     = 10
+	DefaultMaxViewersPerBusiness         = 20
 	MaxCitiesForBusiness                 = 50
 	MaxLabelsForBusiness                 = 10
+	MaxWebsiteURLsForBusiness            = 5
 	DefaultMaxBusinessUnitsPerAdminPhone = 10
 	DefaultMaxSchedulesPerBusinessUnits  = 10
 	DefaultMaxBookingsPerView            = 10
+	DefaultMaxExceptionsPerSchedule      = 50
+	DefaultMaxStaffPerSchedule           = 50
+	DefaultMaxTemplatesPerBusinessUnit   = 20
+	DefaultMaxAdminsPerOrganization      = 20
+	DefaultMaxBusinessUnitsPerOrg        = 500
+
+	// Admin phones and organizations without an assigned plan are on these
+	DefaultPlan             = PlanFree
+	DefaultOrganizationPlan = PlanEnterprise
 
 	DefaultDefaultMeetingDurationMin     = 45
 	DefaultDefaultBreakDurationMin       = 15
@@ -56,12 +117,58 @@ const (
 	DefaultDefaultStartOfDay = "09:00"
 	DefaultDefaultEndOfDay   = "18:00"
 
+	DefaultMaxAvailabilityRangeDays = 31
+
+	DefaultDeletionMaxAttempts    = 10
+	DefaultDeletionRetryBaseDelay = 30 * time.Second
+	DefaultDeletionRetryMaxDelay  = 30 * time.Minute
+	DefaultDeletionWorkerInterval = 15 * time.Second
+	DefaultDeletionLease          = 5 * time.Minute
+
+	MaxTextSearchCandidates = 500
+
+	// The duplicate report compares at most this many business units
+	MaxDuplicateScanUnits = 5000
+
+	DefaultRankingWeightPriority     = 0.3
+	DefaultRankingWeightAvailability = 0.25
+	DefaultRankingWeightOpenSlots    = 0.1
+	DefaultRankingWeightLabelMatch   = 0.25
+	DefaultRankingWeightDistance     = 0.1
+
+	DefaultSearchFairness         = FairnessRotate
+	DefaultFairnessRotationBucket = 1 * time.Hour
+	MaxFairSearchCandidates       = 500
+
+	DefaultCityValidation = CityValidationLenient
+
+	DefaultImpressionWindowDays = 7
+	MaxImpressionWindowDays     = 90
+	MaxImpressionsPerRecord     = 50
+
+	DefaultOwnershipTransferTTL = 72 * time.Hour
+	DefaultFormerOwnerName      = "Former owner"
+	MaxStatusReasonLength       = 500
+
+	DefaultNearRadiusMeters = 5000
+	MaxNearRadiusMeters     = 100000
+
 	DefaultBusinessUnitBaseUrl = "http://business-units.apps.svc.cluster.local"
 	DefaultScheduleBaseUrl     = "http://schedules.apps.svc.cluster.local"
 	DefaultBookingBaseUrl      = "http://bookings.apps.svc.cluster.local"
+
+	DefaultMaestroFlowsDir = ""
 )
 
 var (
 	DefaultWorkingDaysIsrael = []string{Sunday, Monday, Tuesday, Wednesday, Thursday}
 	DefaultWorkingDaysUs     = []string{Monday, Tuesday, Wednesday, Thursday, Friday}
+
+	// ServicesVerifyingCallers expose mutating endpoints behind the caller
+	// identity middleware, so they refuse to start without a caller secret.
+	ServicesVerifyingCallers = map[string]bool{
+		"business-units": true,
+		"schedules":      true,
+		"bookings":       true,
+	}
 )
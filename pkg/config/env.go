@@ -9,6 +9,7 @@ const (
 	EnvLogLevel = "LOG_LEVEL"
 
 	EnvWhatsAppAppSecret = "WHATSAPP_APP_SECRET"
+	EnvCallerSecret      = "CALLER_IDENTITY_SECRET"
 
 	EnvRateLimitRequests = "RATE_LIMIT_REQUESTS"
 	EnvRateLimitWindow   = "RATE_LIMIT_WINDOW"
@@ -32,7 +33,22 @@ const (
 	EnvDefaultStartOfDay            = "DEFAULT_START_OF_DAY"
 	EnvDefaultEndOfDay              = "DEFAULT_END_OF_DAY"
 
+	EnvMaxAvailabilityRangeDays = "MAX_AVAILABILITY_RANGE_DAYS"
+
+	EnvRankingWeightPriority     = "RANKING_WEIGHT_PRIORITY"
+	EnvRankingWeightAvailability = "RANKING_WEIGHT_AVAILABILITY"
+	EnvRankingWeightOpenSlots    = "RANKING_WEIGHT_OPEN_SLOTS"
+	EnvRankingWeightLabelMatch   = "RANKING_WEIGHT_LABEL_MATCH"
+	EnvRankingWeightDistance     = "RANKING_WEIGHT_DISTANCE"
+
+	EnvSearchFairness         = "SEARCH_FAIRNESS"
+	EnvFairnessRotationBucket = "FAIRNESS_ROTATION_BUCKET"
+
+	EnvCityValidation = "CITY_VALIDATION"
+
 	EnvBusinessUnitBaseUrl = "BUSINESS_UNIT_BASE_URL"
 	EnvScheduleBaseUrl     = "SCHEDULE_BASE_URL"
 	EnvBookingBaseUrl      = "BOOKING_BASE_URL"
+
+	EnvMaestroFlowsDir = "MAESTRO_FLOWS_DIR"
 )
@@ -0,0 +1,7 @@
+package contracts
+
+// Worker is a background job that runs for the lifetime of the application.
+type Worker interface {
+	Start()
+	Stop()
+}
@@ -0,0 +1,157 @@
+// Package dedup finds business units that are likely the same business
+// registered more than once: similar names, shared phones and overlapping
+// cities.
+//
+// Units are only compared when they share a phone, or share a city and a
+// name search key, so a report over many units stays far from comparing
+// every pair.
+package dedup
+
+import (
+	"skeji/pkg/model"
+	"skeji/pkg/textsearch"
+	"sort"
+)
+
+const (
+	// DefaultMinScore is the lowest score at which a pair is reported.
+	DefaultMinScore = 0.6
+
+	nameWeight  = 0.5
+	phoneWeight = 0.3
+	cityWeight  = 0.2
+
+	// maintainerPhoneScore is the phone signal for units that share a
+	// maintainer rather than their admin phone.
+	maintainerPhoneScore = 0.6
+)
+
+// NameSimilarity compares two business names in [0, 1]: the mean, taken
+// both ways, of each word's best match in the other name. It tolerates typos,
+// reordered words and the same word in Hebrew and Latin letters.
+func NameSimilarity(a, b string) float64 {
+	ta, tb := textsearch.Tokens(a), textsearch.Tokens(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	return (coverage(ta, tb) + coverage(tb, ta)) / 2
+}
+
+func coverage(query, doc []string) float64 {
+	total := 0.0
+	for _, q := range query {
+		best := 0.0
+		for _, d := range doc {
+			best = max(best, textsearch.Similarity(q, d))
+		}
+		total += best
+	}
+	return total / float64(len(query))
+}
+
+// Compare scores how likely a and b are the same business, in [0, 1].
+func Compare(a, b *model.BusinessUnit) model.DuplicateCandidate {
+	candidate := model.DuplicateCandidate{
+		Units:          []*model.BusinessUnit{a, b},
+		NameSimilarity: NameSimilarity(a.Name, b.Name),
+		SharedPhones:   intersect(phones(a), phones(b)),
+		SharedCities:   intersect(a.Cities, b.Cities),
+	}
+
+	phoneScore := 0.0
+	switch {
+	case a.AdminPhone == b.AdminPhone:
+		phoneScore = 1
+	case len(candidate.SharedPhones) > 0:
+		phoneScore = maintainerPhoneScore
+	}
+	cityScore := 0.0
+	if union := len(a.Cities) + len(b.Cities) - len(candidate.SharedCities); union > 0 {
+		cityScore = float64(len(candidate.SharedCities)) / float64(union)
+	}
+
+	candidate.Score = nameWeight*candidate.NameSimilarity + phoneWeight*phoneScore + cityWeight*cityScore
+	return candidate
+}
+
+// Find returns the pairs of units scoring at least minScore, best first.
+// Units already merged away should not be passed in.
+func Find(units []*model.BusinessUnit, minScore float64) []model.DuplicateCandidate {
+	pairs := map[[2]int]struct{}{}
+	block := func(members map[string][]int) {
+		for _, idx := range members {
+			for i := 0; i < len(idx); i++ {
+				for j := i + 1; j < len(idx); j++ {
+					pairs[[2]int{idx[i], idx[j]}] = struct{}{}
+				}
+			}
+		}
+	}
+
+	byPhone := map[string][]int{}
+	byCityKey := map[string][]int{}
+	for i, bu := range units {
+		for _, phone := range phones(bu) {
+			byPhone[phone] = append(byPhone[phone], i)
+		}
+		keys := textsearch.Keys(bu.Name)
+		for _, city := range bu.Cities {
+			for _, key := range keys {
+				byCityKey[city+"\x00"+key] = append(byCityKey[city+"\x00"+key], i)
+			}
+		}
+	}
+	block(byPhone)
+	block(byCityKey)
+
+	candidates := []model.DuplicateCandidate{}
+	for pair := range pairs {
+		candidate := Compare(units[pair[0]], units[pair[1]])
+		if candidate.Score >= minScore {
+			candidates = append(candidates, candidate)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		if candidates[i].Units[0].ID != candidates[j].Units[0].ID {
+			return candidates[i].Units[0].ID < candidates[j].Units[0].ID
+		}
+		return candidates[i].Units[1].ID < candidates[j].Units[1].ID
+	})
+	return candidates
+}
+
+// phones returns the admin and maintainer phones of the unit.
+func phones(bu *model.BusinessUnit) []string {
+	out := make([]string, 0, len(bu.Maintainers)+1)
+	out = append(out, bu.AdminPhone)
+	for phone := range bu.Maintainers {
+		if phone != bu.AdminPhone {
+			out = append(out, phone)
+		}
+	}
+	sort.Strings(out[1:])
+	return out
+}
+
+func intersect(a, b []string) []string {
+	in := make(map[string]struct{}, len(b))
+	for _, v := range b {
+		in[v] = struct{}{}
+	}
+	var out []string
+	seen := map[string]struct{}{}
+	for _, v := range a {
+		if _, ok := in[v]; !ok {
+			continue
+		}
+		if _, dup := seen[v]; dup {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
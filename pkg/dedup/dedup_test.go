@@ -0,0 +1,87 @@
+package dedup
+
+import (
+	"skeji/pkg/model"
+	"skeji/pkg/textsearch"
+	"testing"
+)
+
+func unit(id, name, phone string, cities ...string) *model.BusinessUnit {
+	return &model.BusinessUnit{ID: id, Name: name, AdminPhone: phone, Cities: cities}
+}
+
+func TestNameSimilarity_ToleratesTyposAndWordOrder(t *testing.T) {
+	if score := NameSimilarity("blue_ocean_spa", "blue_ocen_spa"); score < 0.9 {
+		t.Errorf("expected a one-letter typo to score high, got %.2f", score)
+	}
+	if score := NameSimilarity("blue_ocean_spa", "spa_blue_ocean"); score < 0.99 {
+		t.Errorf("expected reordered words to match, got %.2f", score)
+	}
+	if score := NameSimilarity("blue_ocean_spa", "green_valley_salon"); score >= textsearch.MinRelevance {
+		t.Errorf("expected unrelated names to score low, got %.2f", score)
+	}
+}
+
+func TestCompare_SharedAdminPhone(t *testing.T) {
+	a := unit("a", "blue_ocean_spa", "+972501111111", "tel_aviv", "haifa")
+	b := unit("b", "blue_ocean", "+972501111111", "tel_aviv")
+
+	candidate := Compare(a, b)
+	if candidate.Score < DefaultMinScore {
+		t.Errorf("expected a likely duplicate, got %.2f", candidate.Score)
+	}
+	if len(candidate.SharedPhones) != 1 || len(candidate.SharedCities) != 1 {
+		t.Errorf("expected one shared phone and city, got %v and %v", candidate.SharedPhones, candidate.SharedCities)
+	}
+}
+
+func TestCompare_MaintainerOverlapCountsLessThanAdmin(t *testing.T) {
+	a := unit("a", "blue_ocean_spa", "+972501111111", "tel_aviv")
+	b := unit("b", "blue_ocean_spa", "+972502222222", "tel_aviv")
+	b.Maintainers = map[string]string{"+972501111111": "dana"}
+	c := unit("c", "blue_ocean_spa", "+972501111111", "tel_aviv")
+
+	viaMaintainer := Compare(a, b).Score
+	viaAdmin := Compare(a, c).Score
+	if viaMaintainer >= viaAdmin {
+		t.Errorf("expected a shared admin (%.2f) to outscore a shared maintainer (%.2f)", viaAdmin, viaMaintainer)
+	}
+}
+
+func TestFind_ReportsLikelyPairsBestFirst(t *testing.T) {
+	units := []*model.BusinessUnit{
+		unit("a", "blue_ocean_spa", "+972501111111", "tel_aviv"),
+		unit("b", "blue_ocen_spa", "+972501111111", "tel_aviv"),
+		unit("c", "blue_ocean_spa", "+972503333333", "tel_aviv"),
+		unit("d", "green_valley_salon", "+972504444444", "haifa"),
+	}
+
+	candidates := Find(units, DefaultMinScore)
+	if len(candidates) == 0 {
+		t.Fatal("expected duplicates to be found")
+	}
+	top := candidates[0]
+	if top.Units[0].ID != "a" || top.Units[1].ID != "b" {
+		t.Errorf("expected the pair sharing name, phone and city first, got %s/%s", top.Units[0].ID, top.Units[1].ID)
+	}
+	for _, candidate := range candidates {
+		for _, bu := range candidate.Units {
+			if bu.ID == "d" {
+				t.Errorf("unrelated unit reported as a duplicate: %+v", candidate)
+			}
+		}
+		if candidate.Score < DefaultMinScore {
+			t.Errorf("candidate below the threshold reported: %.2f", candidate.Score)
+		}
+	}
+}
+
+func TestFind_SkipsUnitsWithNothingInCommon(t *testing.T) {
+	units := []*model.BusinessUnit{
+		unit("a", "blue_ocean_spa", "+972501111111", "tel_aviv"),
+		unit("b", "blue_ocean_spa", "+972502222222", "eilat"),
+	}
+	if candidates := Find(units, 0); len(candidates) != 0 {
+		t.Errorf("expected units without a shared phone or city not to be compared, got %d", len(candidates))
+	}
+}
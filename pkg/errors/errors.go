@@ -17,6 +17,7 @@ const (
 	CodeTimeout      = "TIMEOUT"
 	CodeUnavailable  = "SERVICE_UNAVAILABLE"
 	CodeInvalidInput = "INVALID_INPUT"
+	CodeMoved        = "MOVED"
 )
 
 type AppError struct {
@@ -141,6 +142,17 @@ func Conflict(message string) *AppError {
 	}
 }
 
+// Moved reports a resource that is gone because it now lives under another
+// ID, e.g. a business unit merged into another. The new ID is in the details.
+func Moved(resource, id, movedTo string) *AppError {
+	return &AppError{
+		Code:       CodeMoved,
+		Message:    fmt.Sprintf("%s with ID '%s' has moved to '%s'", resource, id, movedTo),
+		HTTPStatus: http.StatusGone,
+		Details:    map[string]any{"moved_to": movedTo},
+	}
+}
+
 func Internal(message string, err error) *AppError {
 	return &AppError{
 		Code:       CodeInternal,
@@ -203,6 +203,20 @@ func TestInternal(t *testing.T) {
 	}
 }
 
+func TestMoved(t *testing.T) {
+	err := Moved("Business unit", "old", "new")
+
+	if err.Code != CodeMoved {
+		t.Errorf("expected code %s, got %s", CodeMoved, err.Code)
+	}
+	if err.HTTPStatus != http.StatusGone {
+		t.Errorf("expected status %d, got %d", http.StatusGone, err.HTTPStatus)
+	}
+	if err.Details["moved_to"] != "new" {
+		t.Errorf("expected the new ID in the details, got %v", err.Details)
+	}
+}
+
 func TestTimeout(t *testing.T) {
 	err := Timeout("request timed out")
 
@@ -20,6 +20,8 @@ type PaginatedResponse struct {
 	TotalCount int64 `json:"total_count"`
 	Limit      int   `json:"limit"`
 	Offset     int64 `json:"offset"`
+	// Facets summarizes the whole result set, on searches that ask for it.
+	Facets any `json:"facets,omitempty"`
 }
 
 func WriteJSON(w http.ResponseWriter, statusCode int, data any) error {
@@ -46,6 +48,9 @@ func WriteError(w http.ResponseWriter, err error) error {
 		case apperrors.CodeInternal:
 			statusCode = http.StatusInternalServerError
 		default:
+			statusCode = e.HTTPStatus
+		}
+		if statusCode == 0 {
 			statusCode = http.StatusInternalServerError
 		}
 		errResp = ErrorResponse{
@@ -70,6 +75,10 @@ func WriteCreated(w http.ResponseWriter, data any) error {
 	return WriteJSON(w, http.StatusCreated, SuccessResponse{Data: data})
 }
 
+func WriteAccepted(w http.ResponseWriter, data any) error {
+	return WriteJSON(w, http.StatusAccepted, SuccessResponse{Data: data})
+}
+
 func WriteNoContent(w http.ResponseWriter) {
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -82,3 +91,13 @@ func WritePaginated(w http.ResponseWriter, data any, totalCount int64, limit int
 		Offset:     offset,
 	})
 }
+
+func WriteFacetedPaginated(w http.ResponseWriter, data any, facets any, totalCount int64, limit int, offset int64) error {
+	return WriteJSON(w, http.StatusOK, PaginatedResponse{
+		Data:       data,
+		TotalCount: totalCount,
+		Limit:      limit,
+		Offset:     offset,
+		Facets:     facets,
+	})
+}
@@ -0,0 +1,121 @@
+package locale
+
+import (
+	"skeji/pkg/sanitizer"
+	"strings"
+)
+
+type City struct {
+	ID      string   // Canonical value stored on business units and schedules (e.g., "tel_aviv")
+	Country string   // ISO 3166-1 alpha-2 code of the country the city is in
+	Name    string   // Human-readable name
+	Aliases []string // Other spellings, abbreviations and Hebrew/English transliterations
+}
+
+var (
+	Cities = map[string]City{
+		// Israel
+		"tel_aviv":       {ID: "tel_aviv", Country: "IL", Name: "Tel Aviv-Yafo", Aliases: []string{"Tel Aviv Yafo", "Tel Aviv Jaffa", "Tel-Aviv", "TLV", "תל אביב", "תל אביב יפו", `ת"א`}},
+		"jerusalem":      {ID: "jerusalem", Country: "IL", Name: "Jerusalem", Aliases: []string{"Yerushalayim", "Yerushalaim", "JLM", "ירושלים"}},
+		"haifa":          {ID: "haifa", Country: "IL", Name: "Haifa", Aliases: []string{"Hefa", "Heifa", "חיפה"}},
+		"beer_sheva":     {ID: "beer_sheva", Country: "IL", Name: "Beer Sheva", Aliases: []string{"Be'er Sheva", "Beersheba", "Beer Sheba", "Beersheva", "באר שבע", `ב"ש`}},
+		"eilat":          {ID: "eilat", Country: "IL", Name: "Eilat", Aliases: []string{"Elat", "אילת"}},
+		"netanya":        {ID: "netanya", Country: "IL", Name: "Netanya", Aliases: []string{"Natanya", "Netania", "נתניה"}},
+		"ashdod":         {ID: "ashdod", Country: "IL", Name: "Ashdod", Aliases: []string{"אשדוד"}},
+		"ashkelon":       {ID: "ashkelon", Country: "IL", Name: "Ashkelon", Aliases: []string{"Ashqelon", "אשקלון"}},
+		"rishon_lezion":  {ID: "rishon_lezion", Country: "IL", Name: "Rishon LeZion", Aliases: []string{"Rishon Letzion", "Rishon Le Zion", "Rishon", "ראשון לציון", `ראשל"צ`}},
+		"petah_tikva":    {ID: "petah_tikva", Country: "IL", Name: "Petah Tikva", Aliases: []string{"Petach Tikva", "Petah Tiqva", "Petach Tikvah", "פתח תקווה", "פתח תקוה", `פ"ת`}},
+		"holon":          {ID: "holon", Country: "IL", Name: "Holon", Aliases: []string{"חולון"}},
+		"bnei_brak":      {ID: "bnei_brak", Country: "IL", Name: "Bnei Brak", Aliases: []string{"Bnei Berak", "Bene Beraq", "בני ברק"}},
+		"ramat_gan":      {ID: "ramat_gan", Country: "IL", Name: "Ramat Gan", Aliases: []string{"רמת גן", `ר"ג`}},
+		"givatayim":      {ID: "givatayim", Country: "IL", Name: "Givatayim", Aliases: []string{"Givataim", "גבעתיים"}},
+		"bat_yam":        {ID: "bat_yam", Country: "IL", Name: "Bat Yam", Aliases: []string{"בת ים"}},
+		"herzliya":       {ID: "herzliya", Country: "IL", Name: "Herzliya", Aliases: []string{"Herzlia", "Hertzliya", "Herzeliya", "הרצליה"}},
+		"kfar_saba":      {ID: "kfar_saba", Country: "IL", Name: "Kfar Saba", Aliases: []string{"Kfar Sava", "Kefar Sava", "כפר סבא"}},
+		"raanana":        {ID: "raanana", Country: "IL", Name: "Ra'anana", Aliases: []string{"Raanana", "Ranana", "רעננה"}},
+		"hod_hasharon":   {ID: "hod_hasharon", Country: "IL", Name: "Hod HaSharon", Aliases: []string{"הוד השרון"}},
+		"rosh_haayin":    {ID: "rosh_haayin", Country: "IL", Name: "Rosh HaAyin", Aliases: []string{"Rosh Ha'ayin", "ראש העין"}},
+		"rehovot":        {ID: "rehovot", Country: "IL", Name: "Rehovot", Aliases: []string{"Rechovot", "רחובות"}},
+		"modiin":         {ID: "modiin", Country: "IL", Name: "Modi'in", Aliases: []string{"Modiin Maccabim Reut", "מודיעין", "מודיעין מכבים רעות"}},
+		"lod":            {ID: "lod", Country: "IL", Name: "Lod", Aliases: []string{"Lydda", "לוד"}},
+		"ramla":          {ID: "ramla", Country: "IL", Name: "Ramla", Aliases: []string{"Ramle", "רמלה"}},
+		"yavne":          {ID: "yavne", Country: "IL", Name: "Yavne", Aliases: []string{"Yavneh", "יבנה"}},
+		"beit_shemesh":   {ID: "beit_shemesh", Country: "IL", Name: "Beit Shemesh", Aliases: []string{"Bet Shemesh", "בית שמש"}},
+		"mevaseret_zion": {ID: "mevaseret_zion", Country: "IL", Name: "Mevaseret Zion", Aliases: []string{"Mevasseret Zion", "Mevaseret Tsiyon", "מבשרת ציון"}},
+		"hadera":         {ID: "hadera", Country: "IL", Name: "Hadera", Aliases: []string{"Chadera", "חדרה"}},
+		"nazareth":       {ID: "nazareth", Country: "IL", Name: "Nazareth", Aliases: []string{"Natzrat", "Nazrat", "נצרת"}},
+		"tiberias":       {ID: "tiberias", Country: "IL", Name: "Tiberias", Aliases: []string{"Tveria", "Teverya", "טבריה"}},
+		"nahariya":       {ID: "nahariya", Country: "IL", Name: "Nahariya", Aliases: []string{"Naharia", "נהריה"}},
+		"karmiel":        {ID: "karmiel", Country: "IL", Name: "Karmiel", Aliases: []string{"Carmiel", "כרמיאל"}},
+		"afula":          {ID: "afula", Country: "IL", Name: "Afula", Aliases: []string{"עפולה"}},
+		"kiryat_shmona":  {ID: "kiryat_shmona", Country: "IL", Name: "Kiryat Shmona", Aliases: []string{"Qiryat Shemona", "Kiryat Shemona", "קרית שמונה", "קריית שמונה"}},
+		"dimona":         {ID: "dimona", Country: "IL", Name: "Dimona", Aliases: []string{"דימונה"}},
+
+		// United States
+		"new_york":      {ID: "new_york", Country: "US", Name: "New York", Aliases: []string{"New York City", "NYC", "ניו יורק"}},
+		"los_angeles":   {ID: "los_angeles", Country: "US", Name: "Los Angeles", Aliases: []string{"LA", "L.A.", "לוס אנג'לס"}},
+		"chicago":       {ID: "chicago", Country: "US", Name: "Chicago", Aliases: []string{"שיקגו"}},
+		"houston":       {ID: "houston", Country: "US", Name: "Houston", Aliases: []string{"יוסטון"}},
+		"miami":         {ID: "miami", Country: "US", Name: "Miami", Aliases: []string{"מיאמי"}},
+		"san_francisco": {ID: "san_francisco", Country: "US", Name: "San Francisco", Aliases: []string{"SF", "סן פרנסיסקו"}},
+		"boston":        {ID: "boston", Country: "US", Name: "Boston", Aliases: []string{"בוסטון"}},
+		"seattle":       {ID: "seattle", Country: "US", Name: "Seattle", Aliases: []string{"סיאטל"}},
+	}
+
+	// cityIndex maps the lookup key of every city ID, name and alias to the
+	// city ID.
+	cityIndex = buildCityIndex()
+)
+
+func buildCityIndex() map[string]string {
+	idx := map[string]string{}
+	for id, city := range Cities {
+		idx[cityKey(id)] = id
+		idx[cityKey(city.Name)] = id
+		for _, alias := range city.Aliases {
+			idx[cityKey(alias)] = id
+		}
+	}
+	return idx
+}
+
+// cityKey folds case, punctuation and separators away, so "Tel-Aviv",
+// "tel aviv" and "TELAVIV" look the same.
+func cityKey(city string) string {
+	return strings.ReplaceAll(sanitizer.SanitizeCityOrLabel(city), "_", "")
+}
+
+// ResolveCity looks a city up by its ID, name or any alias.
+func ResolveCity(input string) (City, bool) {
+	id, ok := cityIndex[cityKey(input)]
+	if !ok {
+		return City{}, false
+	}
+	return Cities[id], true
+}
+
+// CanonicalCity returns the ID of a known city, or the sanitized input for a
+// city outside the gazetteer.
+func CanonicalCity(input string) string {
+	if city, ok := ResolveCity(input); ok {
+		return city.ID
+	}
+	return sanitizer.SanitizeCityOrLabel(input)
+}
+
+// CanonicalCities resolves the cities to their IDs, dropping empty values and
+// duplicates.
+func CanonicalCities(cities []string) []string {
+	return sanitizer.SanitizeSlice(cities, CanonicalCity)
+}
+
+// UnknownCities returns the cities that are not in the gazetteer.
+func UnknownCities(cities []string) []string {
+	unknown := []string{}
+	for _, city := range cities {
+		if _, ok := ResolveCity(city); !ok {
+			unknown = append(unknown, city)
+		}
+	}
+	return unknown
+}
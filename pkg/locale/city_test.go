@@ -0,0 +1,58 @@
+package locale
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCities_AreConsistent(t *testing.T) {
+	seen := map[string]string{}
+	for id, city := range Cities {
+		if city.ID != id {
+			t.Errorf("city %q is registered as %q", city.ID, id)
+		}
+		if _, ok := Countries[city.Country]; !ok {
+			t.Errorf("city %q is in unsupported country %q", id, city.Country)
+		}
+		for _, value := range append([]string{id, city.Name}, city.Aliases...) {
+			if owner, ok := seen[cityKey(value)]; ok && owner != id {
+				t.Errorf("%q resolves to both %q and %q", value, owner, id)
+			}
+			seen[cityKey(value)] = id
+		}
+	}
+}
+
+func TestCanonicalCity_ResolvesAliases(t *testing.T) {
+	cases := map[string]string{
+		"Tel Aviv":      "tel_aviv",
+		"tel-aviv":      "tel_aviv",
+		"TLV":           "tel_aviv",
+		"תל אביב":       "tel_aviv",
+		"Tel Aviv-Yafo": "tel_aviv",
+		"Be'er Sheva":   "beer_sheva",
+		"NYC":           "new_york",
+		"Springfield":   "springfield",
+		"Kfar  Yona ":   "kfar_yona",
+	}
+	for input, want := range cases {
+		if got := CanonicalCity(input); got != want {
+			t.Errorf("CanonicalCity(%q): expected %q, got %q", input, want, got)
+		}
+	}
+}
+
+func TestCanonicalCities_DropsDuplicates(t *testing.T) {
+	got := CanonicalCities([]string{"Tel Aviv", "TLV", "", "ירושלים", "Jerusalem"})
+	want := []string{"tel_aviv", "jerusalem"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestUnknownCities(t *testing.T) {
+	got := UnknownCities([]string{"haifa", "Atlantis", "Eilat"})
+	if !slices.Equal(got, []string{"Atlantis"}) {
+		t.Errorf("expected [Atlantis], got %v", got)
+	}
+}
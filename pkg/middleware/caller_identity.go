@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"skeji/pkg/auth"
+	"skeji/pkg/logger"
+)
+
+// CallerIdentity attaches the caller named in the X-Caller header to the
+// request context. When secret is set the header must carry a valid
+// X-Caller-Signature; the services using it refuse to start without one (see
+// config.ServicesVerifyingCallers). Requests without X-Caller pass through
+// anonymous and are refused by the endpoints that need a caller.
+func CallerIdentity(secret string, log *logger.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			value := r.Header.Get(auth.HeaderCaller)
+			if value == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			caller, ok := auth.ParseCaller(value)
+			if !ok {
+				rejectCaller(w, log, r, "Malformed X-Caller header")
+				return
+			}
+			if secret != "" && !auth.Verify(secret, value, r.Header.Get(auth.HeaderCallerSignature)) {
+				rejectCaller(w, log, r, "Invalid caller signature")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithCaller(r.Context(), caller)))
+		})
+	}
+}
+
+func rejectCaller(w http.ResponseWriter, log *logger.Logger, r *http.Request, reason string) {
+	requestID := ""
+	if rid := r.Context().Value(RequestIDKey); rid != nil {
+		if id, ok := rid.(string); ok {
+			requestID = id
+		}
+	}
+
+	log.Warn("Caller identity rejected",
+		"request_id", requestID,
+		"reason", reason,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	_, _ = w.Write([]byte(`{"code":"UNAUTHORIZED","message":"Invalid caller identity"}`))
+}
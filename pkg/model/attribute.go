@@ -0,0 +1,33 @@
+package model
+
+// AttributeDefinition describes a structured attribute businesses can set,
+// e.g. "wheelchair_accessible" or "languages". Boolean attributes take true
+// or false, enum attributes one of Options and multi-select attributes any
+// of them. Attributes marked Schedules may also be set on a schedule, where
+// they override the business unit's value for that branch.
+type AttributeDefinition struct {
+	Key         string   `json:"key"`
+	Type        string   `json:"type"`
+	Options     []string `json:"options,omitempty"`
+	Schedules   bool     `json:"schedules"`
+	Description string   `json:"description"`
+}
+
+// Attributes holds attribute values by key: a bool for boolean attributes, a
+// string for enums and a list of strings for multi-select attributes.
+type Attributes map[string]any
+
+// AttributeFilter asks for units having, for every attribute, at least one
+// of the listed values.
+type AttributeFilter map[string][]string
+
+// AttributeFacet counts the results having each value of an attribute.
+type AttributeFacet struct {
+	Key    string       `json:"key"`
+	Values []FacetValue `json:"values"`
+}
+
+type FacetValue struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// Availability lists the bookable slots of a schedule within a time range.
+type Availability struct {
+	ScheduleID string             `json:"schedule_id"`
+	TimeZone   string             `json:"time_zone"`
+	Service    string             `json:"service,omitempty"`
+	StaffID    string             `json:"staff_id,omitempty"`
+	From       time.Time          `json:"from"`
+	To         time.Time          `json:"to"`
+	Slots      []AvailabilitySlot `json:"slots"`
+}
+
+// AvailabilitySlot is a single bookable meeting slot. Remaining is the number
+// of participants that can still be booked into it. On schedules with staff
+// every slot belongs to one staff member.
+type AvailabilitySlot struct {
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Capacity  int       `json:"capacity"`
+	Remaining int       `json:"remaining"`
+	StaffID   string    `json:"staff_id,omitempty"`
+	StaffName string    `json:"staff_name,omitempty"`
+}
@@ -8,6 +8,7 @@ type Booking struct {
 	ID           string            `json:"id,omitempty" bson:"_id,omitempty" validate:"omitempty,mongodb"`
 	BusinessID   string            `json:"business_id" bson:"business_id" validate:"required,mongodb"`
 	ScheduleID   string            `json:"schedule_id" bson:"schedule_id" validate:"required,mongodb"`
+	StaffID      string            `json:"staff_id,omitempty" bson:"staff_id,omitempty" validate:"omitempty,staff_id"`
 	ServiceLabel string            `json:"service_label" bson:"service_label" validate:"omitempty,min=2,max=100"`
 	StartTime    time.Time         `json:"start_time" bson:"start_time" validate:"required"`
 	EndTime      time.Time         `json:"end_time" bson:"end_time" validate:"required,gtfield=StartTime"`
@@ -19,6 +20,8 @@ type Booking struct {
 }
 
 type BookingUpdate struct {
+	ScheduleID   string             `json:"schedule_id,omitempty" validate:"omitempty,mongodb"`
+	StaffID      string             `json:"staff_id,omitempty" validate:"omitempty,staff_id"`
 	ServiceLabel string             `json:"service_label,omitempty" validate:"omitempty,min=2,max=100"`
 	StartTime    *time.Time         `json:"start_time,omitempty" validate:"omitempty"`
 	EndTime      *time.Time         `json:"end_time,omitempty" validate:"omitempty,gtfield=StartTime"`
@@ -27,3 +30,28 @@ type BookingUpdate struct {
 	Status       string             `json:"status,omitempty" validate:"omitempty,oneof=pending confirmed cancelled"`
 	ManagedBy    map[string]string  `json:"managed_by,omitempty" validate:"omitempty,participants_map"`
 }
+
+// BookingArchiveRequest moves a schedule's bookings that ended by Before out
+// of the live collection.
+type BookingArchiveRequest struct {
+	BusinessID string    `json:"business_id" validate:"required,mongodb"`
+	ScheduleID string    `json:"schedule_id" validate:"required,mongodb"`
+	Before     time.Time `json:"before" validate:"required"`
+}
+
+type BookingArchiveResult struct {
+	Archived int64 `json:"archived"`
+}
+
+// BookingReassignRequest moves a schedule's bookings, live and archived, from
+// one business unit to another, e.g. when two duplicate units are merged.
+type BookingReassignRequest struct {
+	FromBusinessID string `json:"from_business_id" validate:"required,mongodb"`
+	ScheduleID     string `json:"schedule_id" validate:"required,mongodb"`
+	ToBusinessID   string `json:"to_business_id" validate:"required,mongodb"`
+}
+
+type BookingReassignResult struct {
+	Reassigned int64 `json:"reassigned"`
+	Archived   int64 `json:"archived"`
+}
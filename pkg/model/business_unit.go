@@ -3,17 +3,25 @@ package model
 import "time"
 
 type BusinessUnit struct {
-	ID             string            `json:"id,omitempty" bson:"_id,omitempty" validate:"omitempty,mongodb"`
-	Name           string            `json:"name" bson:"name" validate:"required,min=2,max=100"`
-	Cities         []string          `json:"cities" bson:"cities" validate:"required,min=1,max=50,required"`
-	Labels         []string          `json:"labels" bson:"labels" validate:"required,min=1,max=10,required"`
-	AdminPhone     string            `json:"admin_phone" bson:"admin_phone" validate:"required,e164,supported_country,valid_phone"`
-	Maintainers    map[string]string `json:"maintainers,omitempty" bson:"maintainers" validate:"omitempty,maintainers_map"`
-	Priority       int64             `json:"priority,omitempty" bson:"priority" validate:"omitempty,min=0"`
-	TimeZone       string            `json:"time_zone,omitempty" bson:"time_zone" validate:"omitempty,timezone"`
-	WebsiteURLs    []string          `json:"website_urls,omitempty" bson:"website_urls,omitempty" validate:"omitempty,max=5,dive,valid_url"`
-	CreatedAt      time.Time         `json:"created_at" bson:"created_at" validate:"omitempty"`
-	CityLabelPairs []string          `json:"-" bson:"city_label_pairs"`
+	ID              string            `json:"id,omitempty" bson:"_id,omitempty" validate:"omitempty,mongodb"`
+	Name            string            `json:"name" bson:"name" validate:"required,min=2,max=100"`
+	Cities          []string          `json:"cities" bson:"cities" validate:"required,min=1,max=50,required"`
+	Labels          []string          `json:"labels" bson:"labels" validate:"required,min=1,max=10,required"`
+	AdminPhone      string            `json:"admin_phone" bson:"admin_phone" validate:"required,e164,supported_country,valid_phone"`
+	Maintainers     map[string]string `json:"maintainers,omitempty" bson:"maintainers" validate:"omitempty,maintainers_map"`
+	Viewers         map[string]string `json:"viewers,omitempty" bson:"viewers,omitempty" validate:"omitempty,maintainers_map"`
+	Priority        int64             `json:"priority,omitempty" bson:"priority" validate:"omitempty,min=0"`
+	TimeZone        string            `json:"time_zone,omitempty" bson:"time_zone" validate:"omitempty,timezone"`
+	WebsiteURLs     []string          `json:"website_urls,omitempty" bson:"website_urls,omitempty" validate:"omitempty,max=5,dive,valid_url"`
+	OrganizationID  string            `json:"organization_id,omitempty" bson:"organization_id,omitempty" validate:"omitempty,mongodb"`
+	Attributes      Attributes        `json:"attributes,omitempty" bson:"attributes,omitempty"`
+	Status          string            `json:"status,omitempty" bson:"status" validate:"omitempty,oneof=draft active suspended archived"`
+	StatusReason    string            `json:"status_reason,omitempty" bson:"status_reason,omitempty"`
+	StatusChangedAt *time.Time        `json:"status_changed_at,omitempty" bson:"status_changed_at,omitempty"`
+	CreatedAt       time.Time         `json:"created_at" bson:"created_at" validate:"omitempty"`
+	CityLabelPairs  []string          `json:"-" bson:"city_label_pairs"`
+	SearchKeys      []string          `json:"-" bson:"search_keys"`
+	AttributeTerms  []string          `json:"-" bson:"attribute_terms,omitempty"`
 }
 
 type BusinessUnitUpdate struct {
@@ -22,8 +30,20 @@ type BusinessUnitUpdate struct {
 	Labels         []string           `json:"labels,omitempty" validate:"omitempty,min=1,max=10,required"`
 	AdminPhone     string             `json:"admin_phone,omitempty" validate:"omitempty,e164,supported_country,valid_phone"`
 	Maintainers    *map[string]string `json:"maintainers,omitempty" validate:"omitempty,maintainers_map"`
+	Viewers        *map[string]string `json:"viewers,omitempty" validate:"omitempty,maintainers_map"`
 	Priority       *int64             `json:"priority,omitempty" validate:"omitempty,min=0"`
 	TimeZone       string             `json:"time_zone,omitempty" validate:"omitempty,timezone"`
 	WebsiteURLs    *[]string          `json:"website_urls,omitempty" validate:"omitempty,max=5,dive,valid_url"`
+	OrganizationID *string            `json:"organization_id,omitempty" validate:"omitempty"`
+	Attributes     *Attributes        `json:"attributes,omitempty"`
 	CityLabelPairs []string           `json:"-" bson:"city_label_pairs"`
 }
+
+// BusinessUnitStatusChange moves a business unit through its lifecycle:
+// draft units are not live yet, suspended units are hidden from search and
+// take no bookings, and archived units are read-only for good. Suspending
+// requires a reason.
+type BusinessUnitStatusChange struct {
+	Status string `json:"status" validate:"required,oneof=draft active suspended archived"`
+	Reason string `json:"reason,omitempty" validate:"omitempty,max=500"`
+}
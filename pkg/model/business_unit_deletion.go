@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// BusinessUnitDeletion tracks the cascade that removes a business unit's
+// schedules and bookings after the unit itself is deleted. Each step is
+// idempotent, so a failed or interrupted run resumes where it stopped.
+type BusinessUnitDeletion struct {
+	ID               string             `json:"id" bson:"_id,omitempty"`
+	BusinessID       string             `json:"business_id" bson:"business_id"`
+	BusinessName     string             `json:"business_name" bson:"business_name"`
+	AdminPhone       string             `json:"-" bson:"admin_phone"`
+	Status           string             `json:"status" bson:"status"`
+	SchedulesListed  bool               `json:"schedules_listed" bson:"schedules_listed"`
+	Schedules        []ScheduleTeardown `json:"schedules" bson:"schedules"`
+	SchedulesDeleted int                `json:"schedules_deleted" bson:"schedules_deleted"`
+	Attempts         int                `json:"attempts" bson:"attempts"`
+	LastError        string             `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	NextAttemptAt    time.Time          `json:"next_attempt_at" bson:"next_attempt_at"`
+	LockedUntil      time.Time          `json:"-" bson:"locked_until"`
+	CreatedAt        time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at" bson:"updated_at"`
+	CompletedAt      *time.Time         `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+}
+
+// ScheduleTeardown is the progress of one schedule within a business unit
+// deletion: the schedule is deleted with its unfinished bookings cancelled,
+// then the bookings that ended are archived.
+type ScheduleTeardown struct {
+	ScheduleID        string `json:"schedule_id" bson:"schedule_id"`
+	Name              string `json:"name" bson:"name"`
+	BookingsArchived  int64  `json:"bookings_archived" bson:"bookings_archived"`
+	Archived          bool   `json:"archived" bson:"archived"`
+	BookingsCancelled int    `json:"bookings_cancelled" bson:"bookings_cancelled"`
+	Deleted           bool   `json:"deleted" bson:"deleted"`
+}
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// DuplicateCandidate is a pair of business units that look like the same
+// business registered twice, with the signals behind its score.
+type DuplicateCandidate struct {
+	Units          []*BusinessUnit `json:"units"`
+	Score          float64         `json:"score"`
+	NameSimilarity float64         `json:"name_similarity"`
+	SharedPhones   []string        `json:"shared_phones,omitempty"`
+	SharedCities   []string        `json:"shared_cities,omitempty"`
+}
+
+// BusinessUnitMergeRequest names the unit a duplicate is merged into.
+type BusinessUnitMergeRequest struct {
+	IntoID string `json:"into_id" validate:"required,mongodb"`
+}
+
+// BusinessUnitMerge tracks moving a duplicate's schedules and bookings to
+// the unit it is merged into. Once completed the duplicate is deleted and
+// the merge stays behind as its tombstone, redirecting the old ID to the
+// surviving unit.
+type BusinessUnitMerge struct {
+	ID             string             `json:"id" bson:"_id,omitempty"`
+	SourceID       string             `json:"source_id" bson:"source_id"`
+	SourceName     string             `json:"source_name" bson:"source_name"`
+	TargetID       string             `json:"target_id" bson:"target_id"`
+	Status         string             `json:"status" bson:"status"`
+	Schedules      []ScheduleHandover `json:"schedules" bson:"schedules"`
+	SchedulesMoved int                `json:"schedules_moved" bson:"schedules_moved"`
+	RequestedBy    string             `json:"requested_by" bson:"requested_by"`
+	LastError      string             `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at" bson:"updated_at"`
+	CompletedAt    *time.Time         `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+}
+
+// ScheduleHandover is the progress of one schedule within a merge: the
+// schedule is moved first, then its bookings. Moved is set once both are.
+type ScheduleHandover struct {
+	ScheduleID       string `json:"schedule_id" bson:"schedule_id"`
+	Name             string `json:"name" bson:"name"`
+	BookingsMoved    int64  `json:"bookings_moved" bson:"bookings_moved"`
+	BookingsArchived int64  `json:"bookings_archived" bson:"bookings_archived"`
+	Moved            bool   `json:"moved" bson:"moved"`
+}
@@ -0,0 +1,56 @@
+package model
+
+import "math"
+
+const (
+	GeoPointType = "Point"
+
+	// EarthRadiusMeters is the mean Earth radius, used for distances and for
+	// converting radii to radians.
+	EarthRadiusMeters = 6371008.8
+)
+
+// GeoPoint is a GeoJSON point. Coordinates are [longitude, latitude], the
+// order MongoDB's 2dsphere index expects.
+type GeoPoint struct {
+	Type        string    `json:"type" bson:"type" validate:"required,eq=Point"`
+	Coordinates []float64 `json:"coordinates" bson:"coordinates" validate:"required,len=2"`
+}
+
+// NewGeoPoint builds a point from a latitude and longitude.
+func NewGeoPoint(lat, lng float64) *GeoPoint {
+	return &GeoPoint{Type: GeoPointType, Coordinates: []float64{lng, lat}}
+}
+
+func (p *GeoPoint) Lat() float64 {
+	return p.Coordinates[1]
+}
+
+func (p *GeoPoint) Lng() float64 {
+	return p.Coordinates[0]
+}
+
+// ValidLatLng reports whether lat and lng are within their ranges.
+func ValidLatLng(lat, lng float64) bool {
+	return lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
+}
+
+// DistanceMeters returns the great-circle distance between two points.
+func (p *GeoPoint) DistanceMeters(other *GeoPoint) float64 {
+	lat1, lat2 := radians(p.Lat()), radians(other.Lat())
+	dLat := lat2 - lat1
+	dLng := radians(other.Lng() - p.Lng())
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * EarthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// NearbySchedule is a schedule found by a distance search, with its distance
+// from the searched point.
+type NearbySchedule struct {
+	Schedule       `bson:",inline"`
+	DistanceMeters float64 `json:"distance_meters" bson:"distance_meters"`
+}
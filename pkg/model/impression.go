@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// SearchFairness asks a business unit search to reorder results that rank
+// equally. With Mode rotate, ties are shuffled in an order that is stable for
+// the requester within a time bucket; with balance, the least shown
+// businesses come first and rotation breaks the remaining ties.
+type SearchFairness struct {
+	Mode      string
+	Requester string
+}
+
+// ImpressionRecord reports businesses that were shown to a customer.
+type ImpressionRecord struct {
+	BusinessIDs []string `json:"business_ids"`
+}
+
+// DailyImpressions is how many times a business was shown on one UTC day.
+type DailyImpressions struct {
+	Day   time.Time `json:"day" bson:"day"`
+	Count int64     `json:"count" bson:"count"`
+}
+
+// ImpressionSummary is a business unit's exposure over the last days.
+type ImpressionSummary struct {
+	BusinessID string             `json:"business_id"`
+	Since      time.Time          `json:"since"`
+	Total      int64              `json:"total"`
+	Daily      []DailyImpressions `json:"daily"`
+}
@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// Organization groups the business units of a franchise or brand. Its admins
+// manage every unit in it, and its defaults fill in new units and their
+// schedules.
+type Organization struct {
+	ID          string             `json:"id,omitempty" bson:"_id,omitempty" validate:"omitempty,mongodb"`
+	Name        string             `json:"name" bson:"name" validate:"required,min=2,max=100"`
+	AdminPhone  string             `json:"admin_phone" bson:"admin_phone" validate:"required,e164,supported_country,valid_phone"`
+	Admins      map[string]string  `json:"admins,omitempty" bson:"admins" validate:"omitempty,maintainers_map"`
+	Labels      []string           `json:"labels,omitempty" bson:"labels,omitempty" validate:"omitempty,max=10"`
+	Services    []string           `json:"services,omitempty" bson:"services,omitempty" validate:"omitempty,max=50,dive,min=2,max=100"`
+	Hours       *OrganizationHours `json:"hours,omitempty" bson:"hours,omitempty" validate:"omitempty"`
+	TimeZone    string             `json:"time_zone,omitempty" bson:"time_zone,omitempty" validate:"omitempty,timezone"`
+	WebsiteURLs []string           `json:"website_urls,omitempty" bson:"website_urls,omitempty" validate:"omitempty,max=5,dive,valid_url"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at" validate:"omitempty"`
+}
+
+// OrganizationHours are the opening hours new branches of the organization
+// start with.
+type OrganizationHours struct {
+	StartOfDay                string   `json:"start_of_day" bson:"start_of_day" validate:"required,datetime=15:04"`
+	EndOfDay                  string   `json:"end_of_day" bson:"end_of_day" validate:"required,datetime=15:04"`
+	WorkingDays               []string `json:"working_days" bson:"working_days" validate:"required,min=1,max=7,dive,oneof=sunday monday tuesday wednesday thursday friday saturday"`
+	DefaultMeetingDurationMin int      `json:"default_meeting_duration_min,omitempty" bson:"default_meeting_duration_min,omitempty" validate:"omitempty,min=5,max=480"`
+}
+
+type OrganizationUpdate struct {
+	Name        string             `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	Admins      *map[string]string `json:"admins,omitempty" validate:"omitempty,maintainers_map"`
+	Labels      *[]string          `json:"labels,omitempty" validate:"omitempty,max=10"`
+	Services    *[]string          `json:"services,omitempty" validate:"omitempty,max=50,dive,min=2,max=100"`
+	Hours       *OrganizationHours `json:"hours,omitempty" validate:"omitempty"`
+	TimeZone    string             `json:"time_zone,omitempty" validate:"omitempty,timezone"`
+	WebsiteURLs *[]string          `json:"website_urls,omitempty" validate:"omitempty,max=5,dive,valid_url"`
+}
+
+// BrandMatch is one brand in a brand search: the branch nearest to the
+// customer and how many branches matched. Units outside organizations are
+// their own brand and have no organization.
+type BrandMatch struct {
+	OrganizationID   string        `json:"organization_id,omitempty"`
+	OrganizationName string        `json:"organization_name,omitempty"`
+	Branch           *BusinessUnit `json:"branch"`
+	MatchedBranches  int           `json:"matched_branches"`
+}
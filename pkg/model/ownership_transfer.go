@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// OwnershipTransfer hands a business unit from its admin to another phone.
+// The admin starts it and the new phone accepts or declines it before
+// ExpiresAt; until then the business unit is unchanged.
+type OwnershipTransfer struct {
+	ID               string     `json:"id" bson:"_id,omitempty"`
+	BusinessID       string     `json:"business_id" bson:"business_id"`
+	BusinessName     string     `json:"business_name" bson:"business_name"`
+	FromPhone        string     `json:"from_phone" bson:"from_phone"`
+	ToPhone          string     `json:"to_phone" bson:"to_phone"`
+	KeepAsMaintainer bool       `json:"keep_as_maintainer" bson:"keep_as_maintainer"`
+	MaintainerName   string     `json:"maintainer_name,omitempty" bson:"maintainer_name,omitempty"`
+	Status           string     `json:"status" bson:"status"`
+	ExpiresAt        time.Time  `json:"expires_at" bson:"expires_at"`
+	CreatedAt        time.Time  `json:"created_at" bson:"created_at"`
+	ResolvedAt       *time.Time `json:"resolved_at,omitempty" bson:"resolved_at,omitempty"`
+}
+
+// OwnershipTransferRequest starts a transfer. With KeepAsMaintainer the
+// current admin becomes a maintainer, named MaintainerName, once it is
+// accepted.
+type OwnershipTransferRequest struct {
+	ToPhone          string `json:"to_phone" validate:"required,e164,supported_country,valid_phone"`
+	KeepAsMaintainer bool   `json:"keep_as_maintainer"`
+	MaintainerName   string `json:"maintainer_name,omitempty" validate:"omitempty,min=2,max=100"`
+}
+
+// AuditEntry records a change to who controls a business unit.
+type AuditEntry struct {
+	ID         string            `json:"id" bson:"_id,omitempty"`
+	BusinessID string            `json:"business_id" bson:"business_id"`
+	Action     string            `json:"action" bson:"action"`
+	Actor      string            `json:"actor" bson:"actor"`
+	Details    map[string]string `json:"details,omitempty" bson:"details,omitempty"`
+	CreatedAt  time.Time         `json:"created_at" bson:"created_at"`
+}
@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// PlanLimits are the quotas of a plan. BusinessUnits counts the units owned
+// by an admin phone outside organizations, or the branches of an
+// organization; the other limits apply to each business unit.
+type PlanLimits struct {
+	BusinessUnits              int `json:"business_units"`
+	SchedulesPerBusinessUnit   int `json:"schedules_per_business_unit"`
+	MaintainersPerBusinessUnit int `json:"maintainers_per_business_unit"`
+	CitiesPerBusinessUnit      int `json:"cities_per_business_unit"`
+}
+
+type Plan struct {
+	Name   string     `json:"name"`
+	Limits PlanLimits `json:"limits"`
+}
+
+// PlanAssignment puts an admin phone or an organization, exactly one of the
+// two, on a plan.
+type PlanAssignment struct {
+	ID             string    `json:"id,omitempty" bson:"_id,omitempty"`
+	Phone          string    `json:"phone,omitempty" bson:"phone,omitempty" validate:"omitempty,e164"`
+	OrganizationID string    `json:"organization_id,omitempty" bson:"organization_id,omitempty" validate:"omitempty,mongodb"`
+	Plan           string    `json:"plan" bson:"plan" validate:"required"`
+	AssignedAt     time.Time `json:"assigned_at" bson:"assigned_at"`
+}
+
+// PlanUsage is the current usage; Schedules is left out when the schedules
+// service cannot be reached.
+type PlanUsage struct {
+	BusinessUnits int64  `json:"business_units"`
+	Schedules     *int64 `json:"schedules,omitempty"`
+	Maintainers   int    `json:"maintainers"`
+	Cities        int    `json:"cities"`
+}
+
+// Quota is the usage of a business unit and its owner against their plan.
+type Quota struct {
+	BusinessID string     `json:"business_id"`
+	Plan       string     `json:"plan"`
+	Limits     PlanLimits `json:"limits"`
+	Usage      PlanUsage  `json:"usage"`
+}
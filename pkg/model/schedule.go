@@ -1,36 +1,197 @@
 package model
 
 import (
+	"strings"
 	"time"
 )
 
 type Schedule struct {
-	ID                        string    `json:"id,omitempty" bson:"_id,omitempty" validate:"omitempty,mongodb"`
-	BusinessID                string    `json:"business_id" bson:"business_id" validate:"required,mongodb"`
-	Name                      string    `json:"name" bson:"name" validate:"required,min=2,max=100"`
-	City                      string    `json:"city" bson:"city" validate:"required,min=2,max=100"`
-	Address                   string    `json:"address" bson:"address" validate:"required,min=2,max=200"`
-	StartOfDay                string    `json:"start_of_day" bson:"start_of_day" validate:"required,valid_time_range"`
-	EndOfDay                  string    `json:"end_of_day" bson:"end_of_day" validate:"required,valid_time_range"`
-	WorkingDays               []string  `json:"working_days" bson:"working_days" validate:"required,min=1,max=7,dive,valid_week_days"`
-	DefaultMeetingDurationMin int       `json:"default_meeting_duration_min" bson:"default_meeting_duration_min" validate:"required,min=5,max=480"`
-	DefaultBreakDurationMin   int       `json:"default_break_duration_min" bson:"default_break_duration_min" validate:"required,min=0,max=480"`
-	MaxParticipantsPerSlot    int       `json:"max_participants_per_slot" bson:"max_participants_per_slot" validate:"required,min=1,max=200"`
-	Exceptions                []string  `json:"exceptions,omitempty" bson:"exceptions" validate:"omitempty,max=10"`
-	CreatedAt                 time.Time `json:"created_at" bson:"created_at" validate:"omitempty"`
-	TimeZone                  string    `json:"time_zone" bson:"time_zone" validate:"required,timezone"`
+	ID                        string              `json:"id,omitempty" bson:"_id,omitempty" validate:"omitempty,mongodb"`
+	BusinessID                string              `json:"business_id" bson:"business_id" validate:"required,mongodb"`
+	Name                      string              `json:"name" bson:"name" validate:"required,min=2,max=100"`
+	City                      string              `json:"city" bson:"city" validate:"required,min=2,max=100"`
+	Address                   string              `json:"address" bson:"address" validate:"required,min=2,max=200"`
+	Location                  *GeoPoint           `json:"location,omitempty" bson:"location,omitempty" validate:"omitempty"`
+	StartOfDay                string              `json:"start_of_day" bson:"start_of_day" validate:"required,valid_time_range"`
+	EndOfDay                  string              `json:"end_of_day" bson:"end_of_day" validate:"required,valid_time_range"`
+	WorkingDays               []string            `json:"working_days" bson:"working_days" validate:"required,min=1,max=7,dive,valid_week_days"`
+	WeeklyHours               []DayHours          `json:"weekly_hours,omitempty" bson:"weekly_hours,omitempty" validate:"omitempty,max=7,dive"`
+	DefaultMeetingDurationMin int                 `json:"default_meeting_duration_min" bson:"default_meeting_duration_min" validate:"required,min=5,max=480"`
+	DefaultBreakDurationMin   int                 `json:"default_break_duration_min" bson:"default_break_duration_min" validate:"required,min=0,max=480"`
+	MaxParticipantsPerSlot    int                 `json:"max_participants_per_slot" bson:"max_participants_per_slot" validate:"required,min=1,max=200"`
+	Exceptions                []ScheduleException `json:"exceptions,omitempty" bson:"exceptions" validate:"omitempty,max=50,dive"`
+	Staff                     []StaffMember       `json:"staff,omitempty" bson:"staff,omitempty" validate:"omitempty,max=50,dive"`
+	Seasons                   []ScheduleSeason    `json:"seasons,omitempty" bson:"seasons,omitempty" validate:"omitempty,max=20,dive"`
+	Attributes                Attributes          `json:"attributes,omitempty" bson:"attributes,omitempty"`
+	CreatedAt                 time.Time           `json:"created_at" bson:"created_at" validate:"omitempty"`
+	TimeZone                  string              `json:"time_zone" bson:"time_zone" validate:"required,timezone"`
 }
 
 type ScheduleUpdate struct {
-	Name                      string    `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
-	City                      string    `json:"city,omitempty" validate:"omitempty,min=2,max=100"`
-	Address                   string    `json:"address,omitempty" validate:"omitempty,min=2,max=200"`
-	StartOfDay                string    `json:"start_of_day,omitempty" validate:"omitempty,valid_time_range"`
-	EndOfDay                  string    `json:"end_of_day,omitempty" validate:"omitempty,valid_time_range"`
-	WorkingDays               []string  `json:"working_days,omitempty" validate:"omitempty,min=1,max=7,dive,valid_week_days"`
-	DefaultMeetingDurationMin *int      `json:"default_meeting_duration_min,omitempty" validate:"omitempty,min=5,max=480"`
-	DefaultBreakDurationMin   *int      `json:"default_break_duration_min,omitempty" validate:"omitempty,min=0,max=480"`
-	MaxParticipantsPerSlot    *int      `json:"max_participants_per_slot,omitempty" validate:"omitempty,min=1,max=200"`
-	Exceptions                *[]string `json:"exceptions,omitempty" validate:"omitempty,max=10"`
-	TimeZone                  string    `json:"time_zone,omitempty" bson:"time_zone,omitempty" validate:"omitempty,timezone"`
+	Name                      string               `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	City                      string               `json:"city,omitempty" validate:"omitempty,min=2,max=100"`
+	Address                   string               `json:"address,omitempty" validate:"omitempty,min=2,max=200"`
+	Location                  *GeoPoint            `json:"location,omitempty" validate:"omitempty"`
+	StartOfDay                string               `json:"start_of_day,omitempty" validate:"omitempty,valid_time_range"`
+	EndOfDay                  string               `json:"end_of_day,omitempty" validate:"omitempty,valid_time_range"`
+	WorkingDays               []string             `json:"working_days,omitempty" validate:"omitempty,min=1,max=7,dive,valid_week_days"`
+	WeeklyHours               *[]DayHours          `json:"weekly_hours,omitempty" validate:"omitempty,min=1,max=7,dive"`
+	DefaultMeetingDurationMin *int                 `json:"default_meeting_duration_min,omitempty" validate:"omitempty,min=5,max=480"`
+	DefaultBreakDurationMin   *int                 `json:"default_break_duration_min,omitempty" validate:"omitempty,min=0,max=480"`
+	MaxParticipantsPerSlot    *int                 `json:"max_participants_per_slot,omitempty" validate:"omitempty,min=1,max=200"`
+	Exceptions                *[]ScheduleException `json:"exceptions,omitempty" validate:"omitempty,max=50,dive"`
+	Staff                     *[]StaffMember       `json:"staff,omitempty" validate:"omitempty,max=50,dive"`
+	Seasons                   *[]ScheduleSeason    `json:"seasons,omitempty" validate:"omitempty,max=20,dive"`
+	Attributes                *Attributes          `json:"attributes,omitempty"`
+	TimeZone                  string               `json:"time_zone,omitempty" bson:"time_zone,omitempty" validate:"omitempty,timezone"`
+}
+
+// DayHours lists the working intervals of a single weekday, e.g. 09:00-13:00
+// and 16:00-20:00 for a split shift. Days without an entry are closed.
+type DayHours struct {
+	Day       string      `json:"day" bson:"day" validate:"required,valid_week_days"`
+	Intervals []TimeRange `json:"intervals" bson:"intervals" validate:"required,min=1,max=4,dive"`
+}
+
+// TimeRange is a wall-clock interval within a day in HH:MM format.
+type TimeRange struct {
+	Start string `json:"start" bson:"start" validate:"required,valid_time_range"`
+	End   string `json:"end" bson:"end" validate:"required,valid_time_range"`
+}
+
+// WeeklyHoursFromFlat expands the legacy single-interval fields into weekly
+// hours, giving every working day the same start and end.
+func WeeklyHoursFromFlat(workingDays []string, startOfDay, endOfDay string) []DayHours {
+	hours := make([]DayHours, 0, len(workingDays))
+	for _, day := range workingDays {
+		hours = append(hours, DayHours{
+			Day:       day,
+			Intervals: []TimeRange{{Start: startOfDay, End: endOfDay}},
+		})
+	}
+	return hours
+}
+
+// HoursOn returns the working intervals for the given weekday. Schedules that
+// predate weekly hours fall back to StartOfDay/EndOfDay on WorkingDays.
+func (sc *Schedule) HoursOn(weekday time.Weekday) []TimeRange {
+	if len(sc.WeeklyHours) > 0 {
+		return intervalsOn(sc.WeeklyHours, weekday)
+	}
+	name := strings.ToLower(weekday.String())
+	for _, d := range sc.WorkingDays {
+		if strings.EqualFold(d, name) {
+			return []TimeRange{{Start: sc.StartOfDay, End: sc.EndOfDay}}
+		}
+	}
+	return nil
+}
+
+// ScheduleSeason is a dated version of a schedule's hours and durations, e.g.
+// summer hours. It applies to the calendar dates from EffectiveFrom through
+// EffectiveUntil inclusive, or indefinitely when EffectiveUntil is empty.
+// Durations left unset are inherited from the schedule.
+type ScheduleSeason struct {
+	Name                      string     `json:"name,omitempty" bson:"name,omitempty" validate:"omitempty,max=100"`
+	EffectiveFrom             string     `json:"effective_from" bson:"effective_from" validate:"required,valid_date"`
+	EffectiveUntil            string     `json:"effective_until,omitempty" bson:"effective_until,omitempty" validate:"omitempty,valid_date"`
+	StartOfDay                string     `json:"start_of_day" bson:"start_of_day" validate:"omitempty,valid_time_range"`
+	EndOfDay                  string     `json:"end_of_day" bson:"end_of_day" validate:"omitempty,valid_time_range"`
+	WorkingDays               []string   `json:"working_days" bson:"working_days" validate:"omitempty,max=7,dive,valid_week_days"`
+	WeeklyHours               []DayHours `json:"weekly_hours,omitempty" bson:"weekly_hours,omitempty" validate:"omitempty,max=7,dive"`
+	DefaultMeetingDurationMin *int       `json:"default_meeting_duration_min,omitempty" bson:"default_meeting_duration_min,omitempty" validate:"omitempty,min=5,max=480"`
+	DefaultBreakDurationMin   *int       `json:"default_break_duration_min,omitempty" bson:"default_break_duration_min,omitempty" validate:"omitempty,min=0,max=480"`
+}
+
+// Covers reports whether the season applies to the given calendar date (YYYY-MM-DD).
+func (s ScheduleSeason) Covers(date string) bool {
+	return date >= s.EffectiveFrom && (s.EffectiveUntil == "" || date <= s.EffectiveUntil)
+}
+
+// SeasonOn returns the season in effect on the calendar date of t, or nil.
+// The caller is responsible for converting t into the schedule's location.
+func (sc *Schedule) SeasonOn(t time.Time) *ScheduleSeason {
+	date := t.Format(time.DateOnly)
+	for i := range sc.Seasons {
+		if sc.Seasons[i].Covers(date) {
+			return &sc.Seasons[i]
+		}
+	}
+	return nil
+}
+
+// EffectiveOn returns the configuration in effect on the calendar date of t:
+// the schedule itself, or a copy carrying the hours and durations of the
+// season covering that date. Exceptions, staff and capacity are shared.
+func (sc *Schedule) EffectiveOn(t time.Time) *Schedule {
+	season := sc.SeasonOn(t)
+	if season == nil {
+		return sc
+	}
+	effective := *sc
+	effective.StartOfDay = season.StartOfDay
+	effective.EndOfDay = season.EndOfDay
+	effective.WorkingDays = season.WorkingDays
+	effective.WeeklyHours = season.WeeklyHours
+	if season.DefaultMeetingDurationMin != nil {
+		effective.DefaultMeetingDurationMin = *season.DefaultMeetingDurationMin
+	}
+	if season.DefaultBreakDurationMin != nil {
+		effective.DefaultBreakDurationMin = *season.DefaultBreakDurationMin
+	}
+	effective.Seasons = nil
+	return &effective
+}
+
+// StaffByID returns the staff member with the given ID, or nil.
+func (sc *Schedule) StaffByID(id string) *StaffMember {
+	for i := range sc.Staff {
+		if sc.Staff[i].ID == id {
+			return &sc.Staff[i]
+		}
+	}
+	return nil
+}
+
+func intervalsOn(hours []DayHours, weekday time.Weekday) []TimeRange {
+	name := strings.ToLower(weekday.String())
+	for _, d := range hours {
+		if strings.EqualFold(d.Day, name) {
+			return d.Intervals
+		}
+	}
+	return nil
+}
+
+// ScheduleException overrides the regular hours of a schedule for a single date
+// or an inclusive date range. Dates are calendar dates (YYYY-MM-DD) in the
+// schedule's time zone.
+type ScheduleException struct {
+	Type       string `json:"type" bson:"type" validate:"required,oneof=closed special_hours"`
+	Date       string `json:"date" bson:"date" validate:"required,valid_date"`
+	EndDate    string `json:"end_date,omitempty" bson:"end_date,omitempty" validate:"omitempty,valid_date"`
+	StartOfDay string `json:"start_of_day,omitempty" bson:"start_of_day,omitempty" validate:"omitempty,valid_time_range"`
+	EndOfDay   string `json:"end_of_day,omitempty" bson:"end_of_day,omitempty" validate:"omitempty,valid_time_range"`
+	Reason     string `json:"reason,omitempty" bson:"reason,omitempty" validate:"omitempty,max=200"`
+}
+
+// Covers reports whether the exception applies to the given calendar date (YYYY-MM-DD).
+func (e ScheduleException) Covers(date string) bool {
+	end := e.EndDate
+	if end == "" {
+		end = e.Date
+	}
+	return date >= e.Date && date <= end
+}
+
+// ExceptionOn returns the exception in effect on the calendar date of t, or nil.
+// The caller is responsible for converting t into the schedule's location.
+func (sc *Schedule) ExceptionOn(t time.Time) *ScheduleException {
+	date := t.Format(time.DateOnly)
+	for i := range sc.Exceptions {
+		if sc.Exceptions[i].Covers(date) {
+			return &sc.Exceptions[i]
+		}
+	}
+	return nil
 }
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// ScheduleDeleteOptions chooses what happens to a schedule's future bookings
+// when it is deleted. Without a mode, deletion is refused while any exist.
+type ScheduleDeleteOptions struct {
+	Mode             string
+	TargetScheduleID string
+}
+
+// ScheduleDeletion lists the future bookings a schedule deletion touched.
+type ScheduleDeletion struct {
+	ScheduleID       string            `json:"schedule_id"`
+	Mode             string            `json:"mode,omitempty"`
+	TargetScheduleID string            `json:"target_schedule_id,omitempty"`
+	AffectedBookings []AffectedBooking `json:"affected_bookings"`
+}
+
+// AffectedBooking is a future booking of a deleted schedule and what was done
+// with it: cancelled, moved, or failed with the reason. When a deletion is
+// abandoned, bookings already handled are restored and marked rolled_back.
+type AffectedBooking struct {
+	ID           string            `json:"id"`
+	StartTime    time.Time         `json:"start_time"`
+	EndTime      time.Time         `json:"end_time"`
+	Participants map[string]string `json:"participants,omitempty"`
+	Outcome      string            `json:"outcome,omitempty"`
+	Error        string            `json:"error,omitempty"`
+}
+
+// NewAffectedBooking summarizes a booking for a deletion report.
+func NewAffectedBooking(b *Booking) AffectedBooking {
+	return AffectedBooking{
+		ID:           b.ID,
+		StartTime:    b.StartTime,
+		EndTime:      b.EndTime,
+		Participants: b.Participants,
+	}
+}
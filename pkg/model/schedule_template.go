@@ -0,0 +1,120 @@
+package model
+
+import "time"
+
+// ScheduleTemplate is a reusable schedule configuration owned by a business
+// unit. It holds everything a schedule needs except its location, which is
+// supplied when a schedule is created from it.
+type ScheduleTemplate struct {
+	ID                        string     `json:"id,omitempty" bson:"_id,omitempty" validate:"omitempty,mongodb"`
+	BusinessID                string     `json:"business_id" bson:"business_id" validate:"required,mongodb"`
+	Name                      string     `json:"name" bson:"name" validate:"required,min=2,max=100"`
+	StartOfDay                string     `json:"start_of_day" bson:"start_of_day" validate:"required,valid_time_range"`
+	EndOfDay                  string     `json:"end_of_day" bson:"end_of_day" validate:"required,valid_time_range"`
+	WorkingDays               []string   `json:"working_days" bson:"working_days" validate:"required,min=1,max=7,dive,valid_week_days"`
+	WeeklyHours               []DayHours `json:"weekly_hours,omitempty" bson:"weekly_hours,omitempty" validate:"omitempty,max=7,dive"`
+	DefaultMeetingDurationMin int        `json:"default_meeting_duration_min" bson:"default_meeting_duration_min" validate:"required,min=5,max=480"`
+	DefaultBreakDurationMin   int        `json:"default_break_duration_min" bson:"default_break_duration_min" validate:"min=0,max=480"`
+	MaxParticipantsPerSlot    int        `json:"max_participants_per_slot" bson:"max_participants_per_slot" validate:"required,min=1,max=200"`
+	TimeZone                  string     `json:"time_zone" bson:"time_zone" validate:"required,timezone"`
+	CreatedAt                 time.Time  `json:"created_at" bson:"created_at" validate:"omitempty"`
+}
+
+// ScheduleClone describes where a copied schedule lives. An empty name keeps
+// the name of the source schedule or template.
+type ScheduleClone struct {
+	Name    string `json:"name,omitempty"`
+	City    string `json:"city"`
+	Address string `json:"address"`
+}
+
+// ScheduleReassign hands a schedule over to another business unit, e.g. the
+// one a duplicate unit is merged into. A dry run only checks that the
+// schedule may move.
+type ScheduleReassign struct {
+	BusinessID string `json:"business_id"`
+	DryRun     bool   `json:"dry_run,omitempty"`
+}
+
+// TemplateFromSchedule captures the configuration of a schedule as a template.
+// Location, exceptions and staff are specific to a branch and are left out.
+func TemplateFromSchedule(sc *Schedule, name string) *ScheduleTemplate {
+	return &ScheduleTemplate{
+		BusinessID:                sc.BusinessID,
+		Name:                      name,
+		StartOfDay:                sc.StartOfDay,
+		EndOfDay:                  sc.EndOfDay,
+		WorkingDays:               append([]string{}, sc.WorkingDays...),
+		WeeklyHours:               copyWeeklyHours(sc.WeeklyHours),
+		DefaultMeetingDurationMin: sc.DefaultMeetingDurationMin,
+		DefaultBreakDurationMin:   sc.DefaultBreakDurationMin,
+		MaxParticipantsPerSlot:    sc.MaxParticipantsPerSlot,
+		TimeZone:                  sc.TimeZone,
+	}
+}
+
+// NewSchedule builds a schedule at the given location from the template.
+func (t *ScheduleTemplate) NewSchedule(target ScheduleClone) *Schedule {
+	name := target.Name
+	if name == "" {
+		name = t.Name
+	}
+	return &Schedule{
+		BusinessID:                t.BusinessID,
+		Name:                      name,
+		City:                      target.City,
+		Address:                   target.Address,
+		StartOfDay:                t.StartOfDay,
+		EndOfDay:                  t.EndOfDay,
+		WorkingDays:               append([]string{}, t.WorkingDays...),
+		WeeklyHours:               copyWeeklyHours(t.WeeklyHours),
+		DefaultMeetingDurationMin: t.DefaultMeetingDurationMin,
+		DefaultBreakDurationMin:   t.DefaultBreakDurationMin,
+		MaxParticipantsPerSlot:    t.MaxParticipantsPerSlot,
+		TimeZone:                  t.TimeZone,
+	}
+}
+
+// CloneTo copies the schedule's configuration, including its exceptions, to a
+// new location. Staff members are not copied since they work at one branch.
+func (sc *Schedule) CloneTo(target ScheduleClone) *Schedule {
+	name := target.Name
+	if name == "" {
+		name = sc.Name
+	}
+	clone := TemplateFromSchedule(sc, name).NewSchedule(ScheduleClone{
+		Name:    name,
+		City:    target.City,
+		Address: target.Address,
+	})
+	clone.Exceptions = append([]ScheduleException{}, sc.Exceptions...)
+	clone.Seasons = copySeasons(sc.Seasons)
+	return clone
+}
+
+func copySeasons(seasons []ScheduleSeason) []ScheduleSeason {
+	if seasons == nil {
+		return nil
+	}
+	out := make([]ScheduleSeason, 0, len(seasons))
+	for _, season := range seasons {
+		season.WorkingDays = append([]string{}, season.WorkingDays...)
+		season.WeeklyHours = copyWeeklyHours(season.WeeklyHours)
+		out = append(out, season)
+	}
+	return out
+}
+
+func copyWeeklyHours(hours []DayHours) []DayHours {
+	if hours == nil {
+		return nil
+	}
+	out := make([]DayHours, 0, len(hours))
+	for _, d := range hours {
+		out = append(out, DayHours{
+			Day:       d.Day,
+			Intervals: append([]TimeRange{}, d.Intervals...),
+		})
+	}
+	return out
+}
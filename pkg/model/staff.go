@@ -0,0 +1,58 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// StaffMember is a person or resource that serves bookings in parallel with
+// the rest of the schedule's staff. Members without weekly hours work the
+// schedule's hours; members without services can perform any service.
+type StaffMember struct {
+	ID          string         `json:"id" bson:"id" validate:"omitempty,mongodb"`
+	Name        string         `json:"name" bson:"name" validate:"required,min=2,max=100"`
+	Services    []string       `json:"services,omitempty" bson:"services,omitempty" validate:"omitempty,max=20,dive,min=2,max=100"`
+	WeeklyHours []DayHours     `json:"weekly_hours,omitempty" bson:"weekly_hours,omitempty" validate:"omitempty,max=7,dive"`
+	TimeOff     []StaffTimeOff `json:"time_off,omitempty" bson:"time_off,omitempty" validate:"omitempty,max=50,dive"`
+}
+
+// StaffTimeOff blocks a staff member between two instants, e.g. a vacation or
+// a doctor's appointment.
+type StaffTimeOff struct {
+	Start  time.Time `json:"start" bson:"start" validate:"required"`
+	End    time.Time `json:"end" bson:"end" validate:"required,gtfield=Start"`
+	Reason string    `json:"reason,omitempty" bson:"reason,omitempty" validate:"omitempty,max=200"`
+}
+
+// HoursOn returns the member's working intervals for the weekday, falling back
+// to the schedule's hours when the member has none of their own.
+func (m *StaffMember) HoursOn(sc *Schedule, weekday time.Weekday) []TimeRange {
+	if len(m.WeeklyHours) == 0 {
+		return sc.HoursOn(weekday)
+	}
+	return intervalsOn(m.WeeklyHours, weekday)
+}
+
+// Offers reports whether the member can perform the service. An empty service
+// or an empty services list matches everything.
+func (m *StaffMember) Offers(service string) bool {
+	if service == "" || len(m.Services) == 0 {
+		return true
+	}
+	for _, s := range m.Services {
+		if strings.EqualFold(s, service) {
+			return true
+		}
+	}
+	return false
+}
+
+// OffDuring reports whether any time off overlaps [start, end).
+func (m *StaffMember) OffDuring(start, end time.Time) bool {
+	for _, off := range m.TimeOff {
+		if off.Start.Before(end) && off.End.After(start) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package plans
+
+import (
+	"skeji/pkg/config"
+	"skeji/pkg/model"
+	"sort"
+)
+
+var Plans = map[string]model.Plan{
+	config.PlanFree: {
+		Name: config.PlanFree,
+		Limits: model.PlanLimits{
+			BusinessUnits:              config.DefaultMaxBusinessUnitsPerAdminPhone,
+			SchedulesPerBusinessUnit:   config.DefaultMaxSchedulesPerBusinessUnits,
+			MaintainersPerBusinessUnit: config.DefaultMaxMaintainersPerBusiness,
+			CitiesPerBusinessUnit:      config.MaxCitiesForBusiness,
+		},
+	},
+	config.PlanPro: {
+		Name: config.PlanPro,
+		Limits: model.PlanLimits{
+			BusinessUnits:              50,
+			SchedulesPerBusinessUnit:   50,
+			MaintainersPerBusinessUnit: 30,
+			CitiesPerBusinessUnit:      config.MaxCitiesForBusiness,
+		},
+	},
+	config.PlanEnterprise: {
+		Name: config.PlanEnterprise,
+		Limits: model.PlanLimits{
+			BusinessUnits:              config.DefaultMaxBusinessUnitsPerOrg,
+			SchedulesPerBusinessUnit:   200,
+			MaintainersPerBusinessUnit: 100,
+			CitiesPerBusinessUnit:      config.MaxCitiesForBusiness,
+		},
+	},
+}
+
+// Get returns the named plan.
+func Get(name string) (model.Plan, bool) {
+	plan, ok := Plans[name]
+	return plan, ok
+}
+
+// Resolve returns the named plan, or fallback when the name is empty or no
+// longer exists.
+func Resolve(name string, fallback string) model.Plan {
+	if plan, ok := Plans[name]; ok {
+		return plan
+	}
+	return Plans[fallback]
+}
+
+// All returns the plans from the smallest to the largest.
+func All() []model.Plan {
+	all := make([]model.Plan, 0, len(Plans))
+	for _, plan := range Plans {
+		all = append(all, plan)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Limits.BusinessUnits < all[j].Limits.BusinessUnits
+	})
+	return all
+}
@@ -0,0 +1,45 @@
+package plans
+
+import (
+	"skeji/pkg/config"
+	"testing"
+)
+
+func TestPlans_AreConsistent(t *testing.T) {
+	for name, plan := range Plans {
+		if plan.Name != name {
+			t.Errorf("plan %q is registered as %q", plan.Name, name)
+		}
+		if plan.Limits.CitiesPerBusinessUnit > config.MaxCitiesForBusiness {
+			t.Errorf("plan %q allows %d cities, above the storage limit of %d", name, plan.Limits.CitiesPerBusinessUnit, config.MaxCitiesForBusiness)
+		}
+	}
+	for _, name := range []string{config.DefaultPlan, config.DefaultOrganizationPlan} {
+		if _, ok := Get(name); !ok {
+			t.Errorf("default plan %q is not defined", name)
+		}
+	}
+}
+
+func TestAll_OrdersBySize(t *testing.T) {
+	all := All()
+	if len(all) != len(Plans) {
+		t.Fatalf("expected %d plans, got %d", len(Plans), len(all))
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i-1].Limits.BusinessUnits > all[i].Limits.BusinessUnits {
+			t.Errorf("plan %q is listed before the smaller %q", all[i-1].Name, all[i].Name)
+		}
+	}
+}
+
+func TestResolve_FallsBack(t *testing.T) {
+	if got := Resolve(config.PlanPro, config.DefaultPlan); got.Name != config.PlanPro {
+		t.Errorf("expected the pro plan, got %q", got.Name)
+	}
+	for _, name := range []string{"", "retired"} {
+		if got := Resolve(name, config.DefaultPlan); got.Name != config.DefaultPlan {
+			t.Errorf("Resolve(%q): expected the default plan, got %q", name, got.Name)
+		}
+	}
+}
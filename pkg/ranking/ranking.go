@@ -0,0 +1,180 @@
+// Package ranking orders search results by a weighted blend of signals:
+// business priority, how soon it is available, how many open slots it has, how
+// well it matches the requested labels and how close it is.
+//
+// Each signal is normalized to [0, 1] within the result set, so weights are
+// relative to each other and do not depend on the scale of priorities or
+// distances. Ties are broken by priority, then by position in the source
+// results, then by key, so the same inputs always produce the same order.
+package ranking
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Weights sets how much each signal contributes to the score. Only their
+// ratios matter; a zero weight disables the signal.
+type Weights struct {
+	Priority     float64
+	Availability float64
+	OpenSlots    float64
+	LabelMatch   float64
+	Distance     float64
+}
+
+func (w Weights) Validate() error {
+	all := []float64{w.Priority, w.Availability, w.OpenSlots, w.LabelMatch, w.Distance}
+	total := 0.0
+	for _, v := range all {
+		if v < 0 {
+			return fmt.Errorf("ranking weights cannot be negative, got %+v", w)
+		}
+		total += v
+	}
+	if total == 0 {
+		return fmt.Errorf("at least one ranking weight must be positive")
+	}
+	return nil
+}
+
+// Candidate holds the raw signals of one result.
+type Candidate struct {
+	// Key breaks ties deterministically, e.g. the business ID.
+	Key      string
+	Priority int64
+	// EarliestSlot is the start of the first open slot; nil when none.
+	EarliestSlot *time.Time
+	OpenSlots    int
+	// LabelMatch is how well the result matches the request, in [0, 1].
+	LabelMatch float64
+	// DistanceMeters to the nearest branch; nil when unknown.
+	DistanceMeters *float64
+	// Position in the source results, which may already rotate ties fairly.
+	Position int
+}
+
+// Ranked is a candidate's position in the input and its score in [0, 1].
+type Ranked struct {
+	Index int
+	Score float64
+}
+
+type Ranker struct {
+	weights  Weights
+	fairness string
+}
+
+func NewRanker(weights Weights) *Ranker {
+	return &Ranker{weights: weights}
+}
+
+// WithFairness sets the fairness mode asked of the searches that feed the
+// ranker, so ties reach it already rotated.
+func (r *Ranker) WithFairness(mode string) *Ranker {
+	r.fairness = mode
+	return r
+}
+
+func (r *Ranker) Fairness() string {
+	return r.fairness
+}
+
+// Rank scores the candidates and returns them best first. Availability is
+// measured against the searched window: a slot at windowStart scores 1 and
+// one at windowEnd scores 0.
+func (r *Ranker) Rank(candidates []Candidate, windowStart, windowEnd time.Time) []Ranked {
+	var maxPriority int64
+	maxSlots := 0
+	maxDistance := 0.0
+	for _, c := range candidates {
+		maxPriority = max(maxPriority, c.Priority)
+		maxSlots = max(maxSlots, c.OpenSlots)
+		if c.DistanceMeters != nil {
+			maxDistance = max(maxDistance, *c.DistanceMeters)
+		}
+	}
+
+	w := r.weights
+	totalWeight := w.Priority + w.Availability + w.OpenSlots + w.LabelMatch + w.Distance
+	ranked := make([]Ranked, len(candidates))
+	for i, c := range candidates {
+		score := w.Priority*ratio(float64(c.Priority), float64(maxPriority)) +
+			w.Availability*availability(c.EarliestSlot, windowStart, windowEnd) +
+			w.OpenSlots*ratio(float64(c.OpenSlots), float64(maxSlots)) +
+			w.LabelMatch*clamp(c.LabelMatch) +
+			w.Distance*proximity(c.DistanceMeters, maxDistance)
+		if totalWeight > 0 {
+			score /= totalWeight
+		}
+		ranked[i] = Ranked{Index: i, Score: score}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := candidates[ranked[i].Index], candidates[ranked[j].Index]
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.Key < b.Key
+	})
+	return ranked
+}
+
+// LabelOverlap is the share of requested labels found in labels, compared
+// case-insensitively.
+func LabelOverlap(requested, labels []string) float64 {
+	if len(requested) == 0 {
+		return 0
+	}
+	have := make(map[string]bool, len(labels))
+	for _, l := range labels {
+		have[strings.ToLower(l)] = true
+	}
+	matched := 0
+	for _, l := range requested {
+		if have[strings.ToLower(l)] {
+			matched++
+		}
+	}
+	return float64(matched) / float64(len(requested))
+}
+
+func availability(earliest *time.Time, start, end time.Time) float64 {
+	if earliest == nil {
+		return 0
+	}
+	window := end.Sub(start)
+	if window <= 0 {
+		return 1
+	}
+	return 1 - clamp(float64(earliest.Sub(start))/float64(window))
+}
+
+func proximity(distance *float64, maxDistance float64) float64 {
+	if distance == nil {
+		return 0
+	}
+	if maxDistance == 0 {
+		return 1
+	}
+	return 1 - clamp(*distance/maxDistance)
+}
+
+func ratio(v, maxV float64) float64 {
+	if maxV <= 0 {
+		return 0
+	}
+	return clamp(v / maxV)
+}
+
+func clamp(v float64) float64 {
+	return min(max(v, 0), 1)
+}
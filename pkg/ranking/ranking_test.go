@@ -0,0 +1,111 @@
+package ranking
+
+import (
+	"testing"
+	"time"
+)
+
+var (
+	windowStart = time.Date(2025, 1, 5, 9, 0, 0, 0, time.UTC)
+	windowEnd   = windowStart.Add(10 * time.Hour)
+)
+
+func at(hours int) *time.Time {
+	t := windowStart.Add(time.Duration(hours) * time.Hour)
+	return &t
+}
+
+func meters(m float64) *float64 {
+	return &m
+}
+
+func order(ranked []Ranked, candidates []Candidate) []string {
+	keys := make([]string, 0, len(ranked))
+	for _, r := range ranked {
+		keys = append(keys, candidates[r.Index].Key)
+	}
+	return keys
+}
+
+func assertOrder(t *testing.T, got []string, want ...string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestRank_WeightsSelectTheSignal(t *testing.T) {
+	candidates := []Candidate{
+		{Key: "high-priority", Priority: 100, EarliestSlot: at(8), OpenSlots: 1},
+		{Key: "available-soon", Priority: 10, EarliestSlot: at(0), OpenSlots: 1},
+	}
+
+	byPriority := NewRanker(Weights{Priority: 1}).Rank(candidates, windowStart, windowEnd)
+	assertOrder(t, order(byPriority, candidates), "high-priority", "available-soon")
+
+	byAvailability := NewRanker(Weights{Availability: 1}).Rank(candidates, windowStart, windowEnd)
+	assertOrder(t, order(byAvailability, candidates), "available-soon", "high-priority")
+}
+
+func TestRank_DistanceAndUnknownDistance(t *testing.T) {
+	candidates := []Candidate{
+		{Key: "unknown"},
+		{Key: "far", DistanceMeters: meters(9000)},
+		{Key: "near", DistanceMeters: meters(500)},
+	}
+	ranked := NewRanker(Weights{Distance: 1}).Rank(candidates, windowStart, windowEnd)
+	assertOrder(t, order(ranked, candidates), "near", "far", "unknown")
+}
+
+func TestRank_TiesAreDeterministic(t *testing.T) {
+	candidates := []Candidate{
+		{Key: "b", Priority: 5},
+		{Key: "c", Priority: 7},
+		{Key: "a", Priority: 5},
+	}
+	ranked := NewRanker(Weights{LabelMatch: 1}).Rank(candidates, windowStart, windowEnd)
+	assertOrder(t, order(ranked, candidates), "c", "a", "b")
+}
+
+func TestRank_ScoresAreNormalized(t *testing.T) {
+	candidates := []Candidate{
+		{Key: "best", Priority: 50, EarliestSlot: at(0), OpenSlots: 9, LabelMatch: 1, DistanceMeters: meters(0)},
+	}
+	ranked := NewRanker(Weights{Priority: 2, Availability: 1, OpenSlots: 1, LabelMatch: 3, Distance: 1}).Rank(candidates, windowStart, windowEnd)
+	if ranked[0].Score < 0.999 || ranked[0].Score > 1.001 {
+		t.Errorf("expected a candidate best on every signal to score 1, got %f", ranked[0].Score)
+	}
+}
+
+func TestWeights_Validate(t *testing.T) {
+	if err := (Weights{}).Validate(); err == nil {
+		t.Error("expected all-zero weights to be rejected")
+	}
+	if err := (Weights{Priority: 1, Distance: -1}).Validate(); err == nil {
+		t.Error("expected a negative weight to be rejected")
+	}
+	if err := (Weights{LabelMatch: 1}).Validate(); err != nil {
+		t.Errorf("expected a single positive weight to be valid, got %v", err)
+	}
+}
+
+func TestLabelOverlap(t *testing.T) {
+	if got := LabelOverlap([]string{"haircut", "styling"}, []string{"Haircut", "coloring"}); got != 0.5 {
+		t.Errorf("expected 0.5, got %f", got)
+	}
+}
+
+func TestRank_TiesFollowPosition(t *testing.T) {
+	candidates := []Candidate{
+		{Key: "a", Priority: 1, Position: 2},
+		{Key: "b", Priority: 1, Position: 0},
+		{Key: "c", Priority: 1, Position: 1},
+	}
+	ranked := NewRanker(Weights{Priority: 1}).Rank(candidates, windowStart, windowEnd)
+	assertOrder(t, order(ranked, candidates), "b", "c", "a")
+}
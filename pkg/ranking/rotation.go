@@ -0,0 +1,30 @@
+package ranking
+
+import (
+	"hash/fnv"
+	"strconv"
+	"time"
+)
+
+// Rotation orders results that rank equally. The order is stable for one
+// requester within one time bucket and changes between requesters and
+// buckets, so ties take turns at the top instead of always following storage
+// order.
+type Rotation struct {
+	seed string
+}
+
+// NewRotation seeds a rotation with the requester, which may be empty, and
+// the bucket that now falls in.
+func NewRotation(requester string, now time.Time, bucket time.Duration) Rotation {
+	return Rotation{seed: requester + "|" + strconv.FormatInt(now.Truncate(bucket).Unix(), 10)}
+}
+
+// Order is the position of key among ties: lower comes first.
+func (r Rotation) Order(key string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(r.seed))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(key))
+	return h.Sum64()
+}
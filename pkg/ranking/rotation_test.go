@@ -0,0 +1,45 @@
+package ranking
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRotation_StableWithinBucket(t *testing.T) {
+	now := time.Date(2025, 1, 5, 9, 10, 0, 0, time.UTC)
+	a := NewRotation("+972501234567", now, time.Hour)
+	b := NewRotation("+972501234567", now.Add(40*time.Minute), time.Hour)
+	if a.Order("x") != b.Order("x") {
+		t.Fatal("expected the same order within one bucket")
+	}
+}
+
+func TestRotation_ChangesAcrossRequestersAndBuckets(t *testing.T) {
+	now := time.Date(2025, 1, 5, 9, 10, 0, 0, time.UTC)
+	keys := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	first := func(r Rotation) string {
+		best := keys[0]
+		for _, k := range keys[1:] {
+			if r.Order(k) < r.Order(best) {
+				best = k
+			}
+		}
+		return best
+	}
+
+	firsts := map[string]bool{}
+	for i := 0; i < 20; i++ {
+		firsts[first(NewRotation("", now.Add(time.Duration(i)*time.Hour), time.Hour))] = true
+	}
+	if len(firsts) < 2 {
+		t.Fatal("expected different buckets to put different keys first")
+	}
+
+	firsts = map[string]bool{}
+	for _, requester := range []string{"+972501111111", "+972502222222", "+972503333333", "+972504444444", "+972505555555"} {
+		firsts[first(NewRotation(requester, now, time.Hour))] = true
+	}
+	if len(firsts) < 2 {
+		t.Fatal("expected different requesters to put different keys first")
+	}
+}
@@ -0,0 +1,169 @@
+package taxonomy
+
+import (
+	"skeji/pkg/sanitizer"
+	"strings"
+)
+
+// Label is a canonical business label. Synonyms are alternative English and
+// Hebrew spellings that resolve to it, Parent is the broader category it
+// belongs to.
+type Label struct {
+	Name     string
+	Parent   string
+	Synonyms []string
+}
+
+var (
+	Labels = map[string]Label{
+		"beauty": {
+			Name:     "beauty",
+			Synonyms: []string{"beauty_salon", "beautician", "יופי", "טיפוח", "מכון_יופי"},
+		},
+		"hair": {
+			Name:     "hair",
+			Parent:   "beauty",
+			Synonyms: []string{"haircut", "haircuts", "hairdresser", "hairdressing", "hair_salon", "hairstylist", "מספרה", "תספורת", "עיצוב_שיער", "מעצב_שיער"},
+		},
+		"barber": {
+			Name:     "barber",
+			Parent:   "hair",
+			Synonyms: []string{"barbers", "barbershop", "barber_shop", "ספר", "ברבר", "מספרת_גברים"},
+		},
+		"nails": {
+			Name:     "nails",
+			Parent:   "beauty",
+			Synonyms: []string{"nail_salon", "manicure", "pedicure", "ציפורניים", "מניקור", "פדיקור"},
+		},
+		"makeup": {
+			Name:     "makeup",
+			Parent:   "beauty",
+			Synonyms: []string{"makeup_artist", "איפור", "מאפרת"},
+		},
+		"cosmetics": {
+			Name:     "cosmetics",
+			Parent:   "beauty",
+			Synonyms: []string{"cosmetician", "facial", "facials", "קוסמטיקה", "קוסמטיקאית", "טיפולי_פנים"},
+		},
+		"hair_removal": {
+			Name:     "hair_removal",
+			Parent:   "beauty",
+			Synonyms: []string{"waxing", "laser_hair_removal", "הסרת_שיער", "שעווה"},
+		},
+		"wellness": {
+			Name:     "wellness",
+			Synonyms: []string{"wellbeing", "רווחה"},
+		},
+		"massage": {
+			Name:     "massage",
+			Parent:   "wellness",
+			Synonyms: []string{"massages", "masseur", "masseuse", "עיסוי", "עיסויים", "מעסה"},
+		},
+		"spa": {
+			Name:     "spa",
+			Parent:   "wellness",
+			Synonyms: []string{"spas", "day_spa", "ספא"},
+		},
+		"fitness": {
+			Name:     "fitness",
+			Synonyms: []string{"gym", "כושר", "חדר_כושר"},
+		},
+		"personal_training": {
+			Name:     "personal_training",
+			Parent:   "fitness",
+			Synonyms: []string{"personal_trainer", "trainer", "אימון_אישי", "מאמן_כושר"},
+		},
+		"yoga": {
+			Name:     "yoga",
+			Parent:   "fitness",
+			Synonyms: []string{"יוגה"},
+		},
+		"pilates": {
+			Name:     "pilates",
+			Parent:   "fitness",
+			Synonyms: []string{"פילאטיס"},
+		},
+		"health": {
+			Name:     "health",
+			Synonyms: []string{"clinic", "בריאות", "מרפאה"},
+		},
+		"physiotherapy": {
+			Name:     "physiotherapy",
+			Parent:   "health",
+			Synonyms: []string{"physio", "physical_therapy", "physiotherapist", "פיזיותרפיה", "פיזיותרפיסט"},
+		},
+		"dentist": {
+			Name:     "dentist",
+			Parent:   "health",
+			Synonyms: []string{"dental", "dentistry", "dental_clinic", "רופא_שיניים", "שיניים"},
+		},
+	}
+
+	// index maps the lookup key of every canonical name and synonym to its
+	// canonical name.
+	index = buildIndex()
+)
+
+func buildIndex() map[string]string {
+	idx := map[string]string{}
+	for name, label := range Labels {
+		idx[key(name)] = name
+		for _, synonym := range label.Synonyms {
+			idx[key(synonym)] = name
+		}
+	}
+	return idx
+}
+
+// key folds separators away, so "hair_cut", "Hair-Cut" and "haircut" look the
+// same.
+func key(label string) string {
+	return strings.ReplaceAll(sanitizer.SanitizeCityOrLabel(label), "_", "")
+}
+
+// Canonical resolves a label or one of its synonyms to the canonical label.
+// Labels outside the taxonomy are returned sanitized, so free labels keep
+// working.
+func Canonical(label string) string {
+	if name, ok := index[key(label)]; ok {
+		return name
+	}
+	return sanitizer.SanitizeCityOrLabel(label)
+}
+
+// Canonicalize sanitizes the labels and resolves them to canonical labels,
+// dropping empty values and duplicates.
+func Canonicalize(labels []string) []string {
+	return sanitizer.SanitizeSlice(labels, Canonical)
+}
+
+// Ancestors returns the parent categories of a canonical label, nearest
+// first.
+func Ancestors(label string) []string {
+	ancestors := []string{}
+	for parent := Labels[label].Parent; parent != ""; parent = Labels[parent].Parent {
+		ancestors = append(ancestors, parent)
+	}
+	return ancestors
+}
+
+// Expand returns the canonical labels together with all their parent
+// categories, without duplicates. A unit labelled "barber" is thereby found
+// by searches for "hair" and "beauty" too.
+func Expand(labels []string) []string {
+	expanded := make([]string, 0, len(labels))
+	seen := map[string]bool{}
+	add := func(label string) {
+		if !seen[label] {
+			seen[label] = true
+			expanded = append(expanded, label)
+		}
+	}
+	for _, label := range labels {
+		add(label)
+		for _, ancestor := range Ancestors(label) {
+			add(ancestor)
+		}
+	}
+	return expanded
+}
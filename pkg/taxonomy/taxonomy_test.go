@@ -0,0 +1,62 @@
+package taxonomy
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTaxonomy_IsConsistent(t *testing.T) {
+	seen := map[string]string{}
+	for name, label := range Labels {
+		if label.Name != name {
+			t.Errorf("label %q is registered as %q", label.Name, name)
+		}
+		if label.Parent != "" {
+			if _, ok := Labels[label.Parent]; !ok {
+				t.Errorf("label %q has unknown parent %q", name, label.Parent)
+			}
+		}
+		if slices.Contains(Ancestors(name), name) {
+			t.Errorf("label %q is its own ancestor", name)
+		}
+		for _, value := range append([]string{name}, label.Synonyms...) {
+			if owner, ok := seen[key(value)]; ok && owner != name {
+				t.Errorf("%q resolves to both %q and %q", value, owner, name)
+			}
+			seen[key(value)] = name
+		}
+	}
+}
+
+func TestCanonical_ResolvesSynonyms(t *testing.T) {
+	cases := map[string]string{
+		"Barbershop": "barber",
+		"barber":     "barber",
+		"Hair-Cut":   "hair",
+		"HAIRCUT":    "hair",
+		"מספרה":      "hair",
+		"עיסוי":      "massage",
+		"Dog Walker": "dog_walker",
+	}
+	for input, want := range cases {
+		if got := Canonical(input); got != want {
+			t.Errorf("Canonical(%q): expected %q, got %q", input, want, got)
+		}
+	}
+}
+
+func TestCanonicalize_DropsDuplicates(t *testing.T) {
+	got := Canonicalize([]string{"Haircut", "hair_salon", "", "Barbershop", "ספר"})
+	want := []string{"hair", "barber"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestExpand_AddsParentCategories(t *testing.T) {
+	got := Expand([]string{"barber", "massage", "dog_walker"})
+	want := []string{"barber", "hair", "beauty", "massage", "wellness", "dog_walker"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
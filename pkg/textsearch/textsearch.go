@@ -0,0 +1,214 @@
+// Package textsearch implements the typo tolerant matching behind business
+// name search.
+//
+// Text is split into lower-case words with Hebrew niqqud dropped and final
+// letters folded (ם→מ). Every word also has a phonetic key: its consonant
+// skeleton after transliterating Hebrew to Latin, so "ספא" and "spa" share the
+// key "sp" and mixed Hebrew/English queries match either spelling.
+//
+// Candidates are fetched from the database by overlapping Keys (trigrams of
+// words and of their phonetic keys) and ranked in process with Relevance.
+package textsearch
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// MinTokenSimilarity is the lowest similarity at which a query word
+	// counts as matching a document word.
+	MinTokenSimilarity = 0.6
+
+	// MinRelevance is the lowest relevance at which a document is a result.
+	MinRelevance = 0.5
+
+	// LabelWeight scales matches on labels relative to matches on the name.
+	LabelWeight = 0.8
+
+	phoneticPrefix = "~"
+)
+
+var hebrewFinals = map[rune]rune{
+	'ך': 'כ',
+	'ם': 'מ',
+	'ן': 'נ',
+	'ף': 'פ',
+	'ץ': 'צ',
+}
+
+var hebrewToLatin = map[rune]string{
+	'א': "a", 'ב': "b", 'ג': "g", 'ד': "d", 'ה': "h",
+	'ו': "o", 'ז': "z", 'ח': "h", 'ט': "t", 'י': "i",
+	'כ': "k", 'ל': "l", 'מ': "m", 'נ': "n", 'ס': "s",
+	'ע': "a", 'פ': "p", 'צ': "tz", 'ק': "k", 'ר': "r",
+	'ש': "sh", 'ת': "t",
+}
+
+// Tokens splits s into normalized words.
+func Tokens(s string) []string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// niqqud and other combining marks
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if folded, ok := hebrewFinals[r]; ok {
+				r = folded
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Fields(b.String())
+}
+
+// Phonetic returns the consonant skeleton of a word in Latin letters.
+func Phonetic(token string) string {
+	var latin strings.Builder
+	for _, r := range token {
+		if s, ok := hebrewToLatin[r]; ok {
+			latin.WriteString(s)
+		} else {
+			latin.WriteRune(r)
+		}
+	}
+
+	var b strings.Builder
+	var last rune
+	for _, r := range latin.String() {
+		switch r {
+		case 'a', 'e', 'i', 'o', 'u', 'y', 'h', 'w':
+			continue
+		case 'c', 'q':
+			r = 'k'
+		case 'f':
+			r = 'p'
+		case 'v':
+			r = 'b'
+		}
+		if r == last {
+			continue
+		}
+		b.WriteRune(r)
+		last = r
+	}
+	return b.String()
+}
+
+// Keys returns the index keys of the given texts: padded trigrams of every
+// word and of its phonetic key. A query and a document sharing keys are
+// likely, not certain, to match; Relevance decides.
+func Keys(texts ...string) []string {
+	seen := map[string]bool{}
+	keys := []string{}
+	add := func(prefix, word string) {
+		for _, gram := range trigrams(word) {
+			key := prefix + gram
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	for _, text := range texts {
+		for _, token := range Tokens(text) {
+			add("", token)
+			if p := Phonetic(token); len(p) >= 2 {
+				add(phoneticPrefix, p)
+			}
+		}
+	}
+	return keys
+}
+
+// Relevance scores how well the query words match a document's name and
+// labels, in [0, 1]: the mean over query words of their best match, with
+// label matches weighted by LabelWeight.
+func Relevance(query []string, name string, labels []string) float64 {
+	if len(query) == 0 {
+		return 0
+	}
+	nameTokens := Tokens(name)
+	labelTokens := []string{}
+	for _, label := range labels {
+		labelTokens = append(labelTokens, Tokens(label)...)
+	}
+
+	total := 0.0
+	for _, q := range query {
+		best := 0.0
+		for _, d := range nameTokens {
+			best = max(best, Similarity(q, d))
+		}
+		for _, d := range labelTokens {
+			best = max(best, LabelWeight*Similarity(q, d))
+		}
+		total += best
+	}
+	return total / float64(len(query))
+}
+
+// Similarity compares a query word with a document word, in [0, 1]. It
+// tolerates typos, a query that is the start of the word ("ocea" for
+// "ocean") and the same word in Hebrew and Latin letters.
+func Similarity(q, d string) float64 {
+	if q == d {
+		return 1
+	}
+	qr, dr := []rune(q), []rune(d)
+	best := ratio(qr, dr)
+
+	if len(qr) >= 3 && len(dr) > len(qr) {
+		best = max(best, 0.9*ratio(qr, dr[:len(qr)]))
+	}
+
+	qp, dp := []rune(Phonetic(q)), []rune(Phonetic(d))
+	if len(qp) >= 2 && len(dp) >= 2 {
+		best = max(best, 0.85*ratio(qp, dp))
+	}
+
+	if best < MinTokenSimilarity {
+		return 0
+	}
+	return best
+}
+
+// ratio is 1 minus the edit distance relative to the longer word.
+func ratio(a, b []rune) float64 {
+	longest := max(len(a), len(b))
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(levenshtein(a, b))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func trigrams(word string) []string {
+	runes := []rune(" " + word + " ")
+	grams := make([]string, 0, len(runes))
+	for i := 0; i+3 <= len(runes); i++ {
+		grams = append(grams, string(runes[i:i+3]))
+	}
+	return grams
+}
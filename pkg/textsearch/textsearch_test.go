@@ -0,0 +1,68 @@
+package textsearch
+
+import (
+	"testing"
+)
+
+func TestTokens_NormalizesHebrewAndSeparators(t *testing.T) {
+	got := Tokens("Blue_Ocean  SPA שָׁלוֹם")
+	want := []string{"blue", "ocean", "spa", "שלומ"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("token %d: expected %q, got %q", i, want[i], got[i])
+		}
+	}
+}
+
+func TestRelevance_ToleratesTypos(t *testing.T) {
+	score := Relevance(Tokens("blue ocen spa"), "blue_ocean_spa", []string{"massage"})
+	if score < 0.9 {
+		t.Errorf("expected a strong match for a one-letter typo, got %.2f", score)
+	}
+
+	score = Relevance(Tokens("blue ocen spa"), "green_valley_salon", []string{"haircut"})
+	if score >= MinRelevance {
+		t.Errorf("expected an unrelated business below %.2f, got %.2f", MinRelevance, score)
+	}
+}
+
+func TestRelevance_PartialName(t *testing.T) {
+	score := Relevance(Tokens("ocea"), "blue_ocean_spa", nil)
+	if score < MinRelevance {
+		t.Errorf("expected a prefix to match, got %.2f", score)
+	}
+}
+
+func TestRelevance_MixedHebrewAndEnglish(t *testing.T) {
+	score := Relevance(Tokens("ספא blue"), "blue_spa", nil)
+	if score < MinRelevance {
+		t.Errorf("expected a Hebrew spelling of spa to match, got %.2f", score)
+	}
+}
+
+func TestRelevance_NameRanksAboveLabel(t *testing.T) {
+	byName := Relevance(Tokens("spa"), "ocean_spa", []string{"massage"})
+	byLabel := Relevance(Tokens("spa"), "ocean_wellness", []string{"spa"})
+	if byName <= byLabel {
+		t.Errorf("expected a name match (%.2f) to outrank a label match (%.2f)", byName, byLabel)
+	}
+}
+
+func TestKeys_QueryWithTypoSharesKeys(t *testing.T) {
+	doc := map[string]bool{}
+	for _, k := range Keys("blue_ocean_spa") {
+		doc[k] = true
+	}
+	shared := 0
+	for _, k := range Keys("ocen") {
+		if doc[k] {
+			shared++
+		}
+	}
+	if shared == 0 {
+		t.Error("expected a misspelled word to share index keys with the document")
+	}
+}
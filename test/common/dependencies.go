@@ -0,0 +1,268 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"skeji/pkg/config"
+	httputil "skeji/pkg/http"
+	"skeji/pkg/model"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DefaultBusinessID is the business the stub's schedules belong to unless a
+// test registers them otherwise.
+const DefaultBusinessID = "507f1f77bcf86cd799439011"
+
+// Dependencies stands in for the services the app under test calls, so one
+// service can be tested on its own. The test runner points the app at it
+// through the service base URLs. Any business unit exists and is active, any
+// schedule belongs to DefaultBusinessID and is open around the clock, and no
+// schedule has bookings; tests register other records as they need them.
+// Registered bookings are found by search and take updates.
+// Schedules created through the stub are kept and found by business search.
+type Dependencies struct {
+	mu            sync.Mutex
+	businessUnits map[string]*model.BusinessUnit
+	schedules     map[string]*model.Schedule
+	bookings      map[string]*model.Booking
+	missing       map[string]bool
+	server        *http.Server
+}
+
+// StartDependencies serves the stub on TEST_DEPENDENCIES_PORT, 8081 by default.
+func StartDependencies() *Dependencies {
+	port := os.Getenv("TEST_DEPENDENCIES_PORT")
+	if port == "" {
+		port = "8081"
+	}
+	d := &Dependencies{}
+	d.Reset()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/business-units/id/{id}", d.getBusinessUnit)
+	mux.HandleFunc("GET /api/v1/schedules/id/{id}", d.getSchedule)
+	mux.HandleFunc("POST /api/v1/schedules", d.createSchedule)
+	mux.HandleFunc("GET /api/v1/schedules/search", d.searchSchedules)
+	mux.HandleFunc("GET /api/v1/bookings/search", d.searchBookings)
+	mux.HandleFunc("PATCH /api/v1/bookings/id/{id}", d.updateBooking)
+	d.server = &http.Server{Addr: ":" + port, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		if err := d.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			panic("dependencies stub: " + err.Error())
+		}
+	}()
+	return d
+}
+
+// Stop shuts the stub down.
+func (d *Dependencies) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = d.server.Shutdown(ctx)
+}
+
+// Reset forgets every registered record.
+func (d *Dependencies) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.businessUnits = map[string]*model.BusinessUnit{}
+	d.schedules = map[string]*model.Schedule{}
+	d.bookings = map[string]*model.Booking{}
+	d.missing = map[string]bool{}
+}
+
+// SetBusinessUnit makes the stub return bu for its ID.
+func (d *Dependencies) SetBusinessUnit(bu *model.BusinessUnit) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.businessUnits[bu.ID] = bu
+	delete(d.missing, bu.ID)
+}
+
+// SetSchedule makes the stub return sc for its ID.
+func (d *Dependencies) SetSchedule(sc *model.Schedule) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.schedules[sc.ID] = sc
+	delete(d.missing, sc.ID)
+}
+
+// SetBooking makes the stub return b from searches for its schedule.
+func (d *Dependencies) SetBooking(b *model.Booking) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.bookings[b.ID] = b
+}
+
+// Booking returns the registered booking with the updates it received.
+func (d *Dependencies) Booking(id string) *model.Booking {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if b, ok := d.bookings[id]; ok {
+		copied := *b
+		return &copied
+	}
+	return nil
+}
+
+// SetMissing makes the stub answer 404 for the business unit or schedule id.
+func (d *Dependencies) SetMissing(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.missing[id] = true
+}
+
+// Schedules returns the schedules registered for businessID.
+func (d *Dependencies) Schedules(businessID string) []*model.Schedule {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var schedules []*model.Schedule
+	for _, sc := range d.schedules {
+		if sc.BusinessID == businessID {
+			schedules = append(schedules, sc)
+		}
+	}
+	return schedules
+}
+
+// OpenSchedule returns a schedule of DefaultBusinessID open every day from
+// 00:00 to 23:59 UTC.
+func OpenSchedule(id string) *model.Schedule {
+	return &model.Schedule{
+		ID:         id,
+		BusinessID: DefaultBusinessID,
+		Name:       "Stub Branch",
+		City:       "Tel Aviv",
+		Address:    "Stub Street 1",
+		StartOfDay: "00:00",
+		EndOfDay:   "23:59",
+		WorkingDays: []string{
+			config.Sunday, config.Monday, config.Tuesday, config.Wednesday,
+			config.Thursday, config.Friday, config.Saturday,
+		},
+		DefaultMeetingDurationMin: 5,
+		MaxParticipantsPerSlot:    10,
+		TimeZone:                  "UTC",
+	}
+}
+
+func (d *Dependencies) getBusinessUnit(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	d.mu.Lock()
+	bu, ok := d.businessUnits[id]
+	missing := d.missing[id]
+	d.mu.Unlock()
+
+	if missing {
+		_ = httputil.WriteJSON(w, http.StatusNotFound, httputil.ErrorResponse{Error: "Business unit not found"})
+		return
+	}
+	if !ok {
+		bu = &model.BusinessUnit{
+			ID:         id,
+			Name:       "Stub Business",
+			Cities:     []string{"Tel Aviv"},
+			Labels:     []string{"Haircut"},
+			AdminPhone: "+972500000000",
+			Status:     config.BusinessStatusActive,
+			TimeZone:   "UTC",
+		}
+	}
+	_ = httputil.WriteSuccess(w, bu)
+}
+
+func (d *Dependencies) getSchedule(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	d.mu.Lock()
+	sc, ok := d.schedules[id]
+	missing := d.missing[id]
+	d.mu.Unlock()
+
+	if missing {
+		_ = httputil.WriteJSON(w, http.StatusNotFound, httputil.ErrorResponse{Error: "Schedule not found"})
+		return
+	}
+	if !ok {
+		sc = OpenSchedule(id)
+	}
+	_ = httputil.WriteSuccess(w, sc)
+}
+
+func (d *Dependencies) createSchedule(w http.ResponseWriter, r *http.Request) {
+	var sc model.Schedule
+	if err := json.NewDecoder(r.Body).Decode(&sc); err != nil {
+		_ = httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{Error: "Invalid JSON"})
+		return
+	}
+	sc.ID = primitive.NewObjectID().Hex()
+	d.SetSchedule(&sc)
+	_ = httputil.WriteCreated(w, &sc)
+}
+
+func (d *Dependencies) searchSchedules(w http.ResponseWriter, r *http.Request) {
+	schedules := d.Schedules(r.URL.Query().Get("business_id"))
+	if schedules == nil {
+		schedules = []*model.Schedule{}
+	}
+	_ = httputil.WritePaginated(w, schedules, int64(len(schedules)), config.DefaultPaginationLimit, 0)
+}
+
+// searchBookings returns the schedule's bookings overlapping the requested
+// range, like the bookings service.
+func (d *Dependencies) searchBookings(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	from, _ := time.Parse(time.RFC3339, query.Get("start_time"))
+	to, _ := time.Parse(time.RFC3339, query.Get("end_time"))
+
+	d.mu.Lock()
+	bookings := []*model.Booking{}
+	for _, b := range d.bookings {
+		if b.ScheduleID != query.Get("schedule_id") {
+			continue
+		}
+		if (!from.IsZero() && !b.EndTime.After(from)) || (!to.IsZero() && !b.StartTime.Before(to)) {
+			continue
+		}
+		copied := *b
+		bookings = append(bookings, &copied)
+	}
+	d.mu.Unlock()
+	_ = httputil.WritePaginated(w, bookings, int64(len(bookings)), config.DefaultPaginationLimit, 0)
+}
+
+// updateBooking applies the fields the schedules service changes when it
+// cancels or moves bookings.
+func (d *Dependencies) updateBooking(w http.ResponseWriter, r *http.Request) {
+	var update model.BookingUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		_ = httputil.WriteJSON(w, http.StatusBadRequest, httputil.ErrorResponse{Error: "Invalid JSON"})
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	b, ok := d.bookings[r.PathValue("id")]
+	if !ok {
+		_ = httputil.WriteJSON(w, http.StatusNotFound, httputil.ErrorResponse{Error: "Booking not found"})
+		return
+	}
+	if update.Status != "" {
+		b.Status = update.Status
+	}
+	if update.ScheduleID != "" {
+		b.ScheduleID = update.ScheduleID
+	}
+	switch update.StaffID {
+	case "":
+	case config.AnyStaff:
+		b.StaffID = ""
+	default:
+		b.StaffID = update.StaffID
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -3,6 +3,7 @@ package integrationtests
 import (
 	"fmt"
 	"os"
+	"skeji/pkg/auth"
 	"skeji/pkg/client"
 	"skeji/pkg/config"
 	"skeji/pkg/model"
@@ -22,6 +23,7 @@ var (
 	cfg            *config.Config
 	httpClient     *client.HttpClient
 	bookingsClient *client.BookingClient
+	dependencies   *common.Dependencies
 )
 
 func TestMain(t *testing.T) {
@@ -83,11 +85,14 @@ func setup() {
 	if serverURL == "" {
 		serverURL = "http://localhost:8080"
 	}
-	httpClient = client.NewHttpClient(serverURL)
-	bookingsClient = client.NewBookingClient(serverURL)
+	// The tests act as another service, which may do anything.
+	httpClient = client.NewHttpClient(serverURL).WithCaller(auth.SystemCaller, cfg.CallerSecret)
+	bookingsClient = client.NewBookingClient(serverURL).WithCaller(auth.SystemCaller, cfg.CallerSecret)
+	dependencies = common.StartDependencies()
 }
 
 func teardown() {
+	dependencies.Stop()
 	cfg.GracefulShutdown()
 }
 
@@ -170,6 +175,9 @@ func testPost(t *testing.T) {
 	testCreateMultipleDaySpan(t)
 	testCreateInvalidBusinessID(t)
 	testCreateInvalidScheduleID(t)
+	testCreateScheduleNotFound(t)
+	testCreateBusinessNotOpen(t)
+	testCreateOutsideWorkingHours(t)
 	testCreateAllStatuses(t)
 	testCreateInvalidStatus(t)
 	testCreateExactSameTime(t)
@@ -189,6 +197,7 @@ func testUpdate(t *testing.T) {
 	testUpdateOnlyTime(t)
 	testUpdateOnlyCapacity(t)
 	testUpdateMultipleFields(t)
+	testUpdateScheduleOfAnotherBusiness(t)
 }
 
 func testDelete(t *testing.T) {
@@ -432,6 +441,40 @@ func testUpdateValid(t *testing.T) {
 	}
 }
 
+func testUpdateScheduleOfAnotherBusiness(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+	const otherScheduleID = "507f1f77bcf86cd799439098"
+	const missingID = "507f1f77bcf86cd799439099"
+	other := common.OpenSchedule(otherScheduleID)
+	other.BusinessID = "507f1f77bcf86cd799439097"
+	dependencies.SetSchedule(other)
+	dependencies.SetMissing(missingID)
+	defer dependencies.Reset()
+
+	start := time.Now().Add(1 * time.Hour)
+	payload := createValidBooking("507f1f77bcf86cd799439011", "507f1f77bcf86cd799439012", "Move Schedule", start, start.Add(1*time.Hour))
+	createResp, err := bookingsClient.Create(payload)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, createResp, 201)
+	created := decodeBooking(t, createResp)
+
+	resp, err := bookingsClient.Update(created.ID, map[string]any{"schedule_id": otherScheduleID})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 422)
+	common.AssertContains(t, resp, "another business")
+
+	resp, err = bookingsClient.Update(created.ID, map[string]any{"schedule_id": missingID})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 422)
+	common.AssertContains(t, resp, "schedule not found")
+}
+
 func testUpdateInvalidID(t *testing.T) {
 	defer common.ClearTestData(t, httpClient, TableName)
 	update := map[string]any{"service_label": "New Label"}
@@ -878,11 +921,13 @@ func testCreateVeryShortDuration(t *testing.T) {
 	end := start.Add(1 * time.Minute)
 	payload := createValidBooking("507f1f77bcf86cd799439011", "507f1f77bcf86cd799439012", "Very Short", start, end)
 
+	// shorter than the schedule's 5 minute meetings
 	resp, err := bookingsClient.Create(payload)
 	if err != nil {
 		t.Fatalf("HTTP request failed: %v", err)
 	}
-	common.AssertStatusCode(t, resp, 201)
+	common.AssertStatusCode(t, resp, 422)
+	common.AssertContains(t, resp, "at least 5 minutes")
 }
 func testCreateVeryLongDuration(t *testing.T) {
 	defer common.ClearTestData(t, httpClient, TableName)
@@ -940,6 +985,121 @@ func testCreateInvalidScheduleID(t *testing.T) {
 	}
 }
 
+func testCreateBusinessNotOpen(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+	const suspendedID = "507f1f77bcf86cd799439095"
+	const missingID = "507f1f77bcf86cd799439094"
+	unit := func(status string) *model.BusinessUnit {
+		return &model.BusinessUnit{
+			ID:         suspendedID,
+			Name:       "Suspended Business",
+			Cities:     []string{"Tel Aviv"},
+			Labels:     []string{"Haircut"},
+			AdminPhone: "+972500000095",
+			Status:     status,
+			TimeZone:   "UTC",
+		}
+	}
+	dependencies.SetMissing(missingID)
+	defer dependencies.Reset()
+
+	start := time.Now().Add(1 * time.Hour)
+	payload := createValidBooking(missingID, "507f1f77bcf86cd799439012", "Missing Business", start, start.Add(time.Hour))
+	resp, err := bookingsClient.Create(payload)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 422)
+	common.AssertContains(t, resp, "business unit not found")
+
+	// a booking made while the business was open cannot be moved once it is
+	// suspended, but can still be cancelled
+	open := common.OpenSchedule("507f1f77bcf86cd799439093")
+	open.BusinessID = suspendedID
+	dependencies.SetSchedule(open)
+	dependencies.SetBusinessUnit(unit(config.BusinessStatusActive))
+	payload = createValidBooking(suspendedID, open.ID, "Suspended Business", start, start.Add(time.Hour))
+	resp, err = bookingsClient.Create(payload)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 201)
+	created := decodeBooking(t, resp)
+
+	dependencies.SetBusinessUnit(unit(config.BusinessStatusSuspended))
+	resp, err = bookingsClient.Update(created.ID, map[string]any{
+		"start_time": start.Add(2 * time.Hour).Format(time.RFC3339),
+		"end_time":   start.Add(3 * time.Hour).Format(time.RFC3339),
+	})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 409)
+
+	resp, err = bookingsClient.Update(created.ID, map[string]any{"status": "cancelled"})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 204)
+}
+
+func testCreateScheduleNotFound(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+	const missingID = "507f1f77bcf86cd799439099"
+	dependencies.SetMissing(missingID)
+	defer dependencies.Reset()
+
+	start := time.Now().Add(1 * time.Hour)
+	end := start.Add(1 * time.Hour)
+	payload := createValidBooking("507f1f77bcf86cd799439011", missingID, "Missing Schedule", start, end)
+
+	resp, err := bookingsClient.Create(payload)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 422)
+	common.AssertContains(t, resp, "schedule not found")
+}
+
+func testCreateOutsideWorkingHours(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+	const scheduleID = "507f1f77bcf86cd799439096"
+	day := time.Now().UTC().AddDate(0, 0, 2).Truncate(24 * time.Hour)
+	sc := common.OpenSchedule(scheduleID)
+	sc.StartOfDay = "09:00"
+	sc.EndOfDay = "17:00"
+	sc.Seasons = []model.ScheduleSeason{{
+		Name:           "Short Day",
+		EffectiveFrom:  day.Format(time.DateOnly),
+		EffectiveUntil: day.Format(time.DateOnly),
+		StartOfDay:     "10:00",
+		EndOfDay:       "12:00",
+		WorkingDays:    sc.WorkingDays,
+	}}
+	dependencies.SetSchedule(sc)
+	defer dependencies.Reset()
+
+	cases := []struct {
+		name     string
+		start    time.Time
+		expected int
+	}{
+		{"before opening", day.Add(24*time.Hour + 8*time.Hour), 422},
+		{"after the season's closing", day.Add(14 * time.Hour), 422},
+		{"within the season's hours", day.Add(10*time.Hour + 30*time.Minute), 201},
+	}
+	for _, tc := range cases {
+		payload := createValidBooking("507f1f77bcf86cd799439011", scheduleID, "Working Hours", tc.start, tc.start.Add(30*time.Minute))
+		resp, err := bookingsClient.Create(payload)
+		if err != nil {
+			t.Fatalf("HTTP request failed: %v", err)
+		}
+		if resp.StatusCode != tc.expected {
+			t.Errorf("%s: expected status %d, got %d: %s", tc.name, tc.expected, resp.StatusCode, resp.ToString())
+		}
+	}
+}
+
 func testCreateAllStatuses(t *testing.T) {
 	defer common.ClearTestData(t, httpClient, TableName)
 	statuses := []string{"pending", "confirmed", "cancelled"}
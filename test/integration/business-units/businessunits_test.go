@@ -3,11 +3,14 @@ package integrationtests
 import (
 	"fmt"
 	"os"
+	"skeji/internal/importer"
+	"skeji/pkg/auth"
 	"skeji/pkg/client"
 	"skeji/pkg/config"
 	"skeji/pkg/model"
 	"skeji/pkg/sanitizer"
 	"skeji/test/common"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -69,6 +72,19 @@ func testAdvanced(t *testing.T) {
 	testMaxBusinessUnitsPerAdminPhoneUpdate(t)
 	testMaxMaintainersPerBusinessCreate(t)
 	testMaxMaintainersPerBusinessUpdate(t)
+	testTextSearch(t)
+	testFairSearch(t)
+	testImpressions(t)
+	testPermissions(t)
+	testOwnershipTransfer(t)
+	testLifecycleStatus(t)
+	testLabelTaxonomy(t)
+	testCityGazetteer(t)
+	testOrganizations(t)
+	testPlansAndQuotas(t)
+	testBulkImport(t)
+	testDuplicatesAndMerge(t)
+	testAttributes(t)
 }
 
 func setup() {
@@ -78,8 +94,9 @@ func setup() {
 	if serverURL == "" {
 		serverURL = "http://localhost:8080"
 	}
-	httpClient = client.NewHttpClient(serverURL)
-	businessUnitsClient = client.NewBusinessUnitClient(serverURL)
+	// The tests act as another service, which may do anything.
+	httpClient = client.NewHttpClient(serverURL).WithCaller(auth.SystemCaller, cfg.CallerSecret)
+	businessUnitsClient = client.NewBusinessUnitClient(serverURL).WithCaller(auth.SystemCaller, cfg.CallerSecret)
 }
 
 func teardown() {
@@ -195,7 +212,8 @@ func testDelete(t *testing.T) {
 	testDeleteNonExistingRecord(t)
 	testDeleteWithInvalidId(t)
 	testDeletedRecord(t)
-
+	testDeletionOperation(t)
+	testDeletionOperationNotFound(t)
 }
 
 func testGetByIdEmptyTable(t *testing.T) {
@@ -964,7 +982,7 @@ func testUpdateDeletedRecord(t *testing.T) {
 	if err != nil {
 		t.Fatalf("HTTP request failed: %v", err)
 	}
-	common.AssertStatusCode(t, deleteResp, 204)
+	common.AssertStatusCode(t, deleteResp, 202)
 
 	updates := map[string]any{
 		"name": "Should Not Update",
@@ -1286,7 +1304,7 @@ func testDeletedRecord(t *testing.T) {
 	if err != nil {
 		t.Fatalf("HTTP request failed: %v", err)
 	}
-	common.AssertStatusCode(t, resp, 204)
+	common.AssertStatusCode(t, resp, 202)
 
 	resp, err = businessUnitsClient.Delete(created.ID)
 	if err != nil {
@@ -1295,6 +1313,90 @@ func testDeletedRecord(t *testing.T) {
 	common.AssertStatusCode(t, resp, 404)
 }
 
+func testDeletionOperation(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+	bu := createValidBusinessUnit("Cascade Delete Test", "+972523335")
+	createResp, err := businessUnitsClient.Create(bu)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, createResp, 201)
+	created := decodeBusinessUnit(t, createResp)
+
+	resp, err := businessUnitsClient.Delete(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 202)
+	op, err := businessUnitsClient.DecodeDeletion(resp)
+	if err != nil {
+		t.Fatalf("failed to decode deletion: %v", err)
+	}
+	if op.ID == "" {
+		t.Fatal("expected an operation ID")
+	}
+	if op.BusinessID != created.ID || op.BusinessName != created.Name {
+		t.Errorf("expected operation for %s (%s), got %s (%s)", created.ID, created.Name, op.BusinessID, op.BusinessName)
+	}
+
+	resp, err = businessUnitsClient.GetByID(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 404)
+
+	resp, err = businessUnitsClient.GetDeletion(op.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	status, err := businessUnitsClient.DecodeDeletion(resp)
+	if err != nil {
+		t.Fatalf("failed to decode deletion: %v", err)
+	}
+	if status.ID != op.ID || status.BusinessID != created.ID {
+		t.Errorf("expected operation %s for %s, got %s for %s", op.ID, created.ID, status.ID, status.BusinessID)
+	}
+	switch status.Status {
+	case config.OperationPending, config.OperationRunning, config.OperationRetrying, config.OperationCompleted:
+	default:
+		t.Errorf("unexpected operation status %q (last error: %s)", status.Status, status.LastError)
+	}
+
+	resp, err = businessUnitsClient.WithCaller(created.AdminPhone, cfg.CallerSecret).GetDeletion(op.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+
+	resp, err = businessUnitsClient.WithCaller("+972523400099", cfg.CallerSecret).GetDeletion(op.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 403)
+}
+
+func testDeletionOperationNotFound(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+	resp, err := businessUnitsClient.GetDeletion("507f1f77bcf86cd799439011")
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 404)
+
+	resp, err = businessUnitsClient.GetDeletion("invalid-id-format")
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 400)
+
+	resp, err = businessUnitsClient.ResumeDeletion("507f1f77bcf86cd799439011")
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 404)
+}
+
 func testGetSearchNormalization(t *testing.T) {
 	defer common.ClearTestData(t, httpClient, TableName)
 	bu := createValidBusinessUnit("Normalization Test", "+972523361")
@@ -2452,8 +2554,8 @@ func testCityLabelNormalizationEdgeCases(t *testing.T) {
 	if len(created.Cities) != 1 || created.Cities[0] != "tel_aviv" {
 		t.Errorf("Cities are not normalized: %v", created.Cities)
 	}
-	if len(created.Labels) != 1 || created.Labels[0] != "hair_cut" {
-		t.Errorf("Labels are not normalized: %v", created.Labels)
+	if len(created.Labels) != 1 || created.Labels[0] != "hair" {
+		t.Errorf("Labels are not resolved to the canonical label: %v", created.Labels)
 	}
 
 	_, err = businessUnitsClient.Delete(created.ID)
@@ -3635,3 +3737,1480 @@ func testMaxMaintainersPerBusinessUpdate(t *testing.T) {
 		t.Fatalf("HTTP request failed: %v", err)
 	}
 }
+
+func testTextSearch(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	spa := createValidBusinessUnit("Blue Ocean Spa", "+972523370")
+	spa["labels"] = []string{"Massage"}
+	spa["priority"] = 5
+	salon := createValidBusinessUnit("Green Valley Salon", "+972523371")
+	spaByLabel := createValidBusinessUnit("Sea Breeze", "+972523372")
+	spaByLabel["labels"] = []string{"Spa"}
+	spaByLabel["cities"] = []string{"Haifa"}
+	for _, bu := range []map[string]any{spa, salon, spaByLabel} {
+		resp, err := businessUnitsClient.Create(bu)
+		if err != nil {
+			t.Fatalf("HTTP request failed: %v", err)
+		}
+		common.AssertStatusCode(t, resp, 201)
+	}
+
+	resp, err := businessUnitsClient.TextSearch("blue ocen spa", nil, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	data, total, _, _ := decodePaginated(t, resp)
+	if total == 0 || len(data) == 0 || data[0].Name != "blue_ocean_spa" {
+		t.Fatalf("expected blue_ocean_spa first for a misspelled query, got %d results", len(data))
+	}
+	for _, bu := range data {
+		if bu.Name == "green_valley_salon" {
+			t.Error("expected an unrelated business to be left out")
+		}
+	}
+
+	resp, err = businessUnitsClient.TextSearch("spa", nil, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	data, _, _, _ = decodePaginated(t, resp)
+	if len(data) != 2 || data[0].Name != "blue_ocean_spa" || data[1].Name != "sea_breeze" {
+		t.Errorf("expected the name match to rank above the label match, got %v", businessNames(data))
+	}
+
+	resp, err = businessUnitsClient.TextSearch("ספא", []string{"Haifa"}, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	data, _, _, _ = decodePaginated(t, resp)
+	if len(data) != 1 || data[0].Name != "sea_breeze" {
+		t.Errorf("expected a Hebrew query narrowed to Haifa to find sea_breeze, got %v", businessNames(data))
+	}
+
+	resp, err = businessUnitsClient.TextSearch("  !! ", nil, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 400)
+}
+
+func testFairSearch(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	for i := 0; i < 6; i++ {
+		bu := createValidBusinessUnit(fmt.Sprintf("Tied Salon %d", i), fmt.Sprintf("+97252338%d", i))
+		resp, err := businessUnitsClient.Create(bu)
+		if err != nil {
+			t.Fatalf("HTTP request failed: %v", err)
+		}
+		common.AssertStatusCode(t, resp, 201)
+	}
+
+	search := func(fairness model.SearchFairness) []string {
+		t.Helper()
+		resp, err := businessUnitsClient.FairSearch([]string{"Tel Aviv"}, []string{"Haircut"}, fairness, 10, 0)
+		if err != nil {
+			t.Fatalf("HTTP request failed: %v", err)
+		}
+		common.AssertStatusCode(t, resp, 200)
+		data, total, _, _ := decodePaginated(t, resp)
+		if total != 6 || len(data) != 6 {
+			t.Fatalf("expected all 6 tied businesses, got %d of %d", len(data), total)
+		}
+		return businessNames(data)
+	}
+
+	first := search(model.SearchFairness{Mode: config.FairnessRotate, Requester: "+972501111111"})
+	again := search(model.SearchFairness{Mode: config.FairnessRotate, Requester: "+972501111111"})
+	if fmt.Sprint(first) != fmt.Sprint(again) {
+		t.Errorf("expected a stable order for the same requester, got %v then %v", first, again)
+	}
+
+	leaders := map[string]bool{}
+	for i := 0; i < 10; i++ {
+		names := search(model.SearchFairness{Mode: config.FairnessRotate, Requester: fmt.Sprintf("+97250222222%d", i)})
+		leaders[names[0]] = true
+	}
+	if len(leaders) < 2 {
+		t.Errorf("expected different requesters to see different businesses first, got %v", leaders)
+	}
+
+	resp, err := businessUnitsClient.FairSearch([]string{"Tel Aviv"}, []string{"Haircut"}, model.SearchFairness{Mode: "shuffle"}, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 400)
+}
+
+func testImpressions(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	ids := []string{}
+	for i := 0; i < 2; i++ {
+		resp, err := businessUnitsClient.Create(createValidBusinessUnit(fmt.Sprintf("Shown Salon %d", i), fmt.Sprintf("+97252339%d", i)))
+		if err != nil {
+			t.Fatalf("HTTP request failed: %v", err)
+		}
+		common.AssertStatusCode(t, resp, 201)
+		created, err := businessUnitsClient.DecodeBusinessUnit(resp)
+		if err != nil {
+			t.Fatalf("failed to decode business unit: %v", err)
+		}
+		ids = append(ids, created.ID)
+	}
+
+	resp, err := businessUnitsClient.RecordImpressions([]string{ids[0], ids[0], ids[0]})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 204)
+
+	resp, err = businessUnitsClient.GetImpressions(ids[0], 7)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	summary, err := businessUnitsClient.DecodeImpressions(resp)
+	if err != nil {
+		t.Fatalf("failed to decode impressions: %v", err)
+	}
+	if summary.Total != 3 || len(summary.Daily) != 1 {
+		t.Errorf("expected 3 impressions today, got %d over %d days", summary.Total, len(summary.Daily))
+	}
+
+	resp, err = businessUnitsClient.FairSearch([]string{"Tel Aviv"}, []string{"Haircut"}, model.SearchFairness{Mode: config.FairnessBalance}, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	data, _, _, _ := decodePaginated(t, resp)
+	if len(data) != 2 || data[0].ID != ids[1] {
+		t.Errorf("expected the business never shown to come first, got %v", businessNames(data))
+	}
+
+	resp, err = businessUnitsClient.RecordImpressions([]string{"not-an-id"})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 400)
+
+	resp, err = businessUnitsClient.GetImpressions(ids[0], 1000)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 400)
+
+	resp, err = businessUnitsClient.GetImpressions("507f1f77bcf86cd799439011", 7)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 404)
+}
+
+func businessNames(units []*model.BusinessUnit) []string {
+	names := make([]string, 0, len(units))
+	for _, bu := range units {
+		names = append(names, bu.Name)
+	}
+	return names
+}
+
+// asCaller returns a client calling as phone. A non-empty plan is assigned to
+// the phone first; assignments outlive the business units.
+func asCaller(t *testing.T, phone, plan string) *client.BusinessUnitClient {
+	t.Helper()
+	if plan != "" {
+		resp, err := businessUnitsClient.AssignPlan(model.PlanAssignment{Phone: phone, Plan: plan})
+		if err != nil {
+			t.Fatalf("HTTP request failed: %v", err)
+		}
+		common.AssertStatusCode(t, resp, 200)
+	}
+	return businessUnitsClient.WithCaller(phone, cfg.CallerSecret)
+}
+
+func createBusinessUnitAs(t *testing.T, caller *client.BusinessUnitClient, body map[string]any) *model.BusinessUnit {
+	t.Helper()
+	resp, err := caller.Create(body)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 201)
+	return decodeBusinessUnit(t, resp)
+}
+
+func testPermissions(t *testing.T) {
+	testCreateRequiresIdentity(t)
+	testCreateForAnotherAdminForbidden(t)
+	testMaintainerCanUpdateDetails(t)
+	testMaintainerCannotChangeMaintainers(t)
+	testViewerCannotUpdate(t)
+	testOwnerCanChangeMaintainers(t)
+	testOnlyOwnerCanDelete(t)
+	testForgedIdentityRejected(t)
+}
+
+const (
+	permissionsOwnerPhone      = "+972523400001"
+	permissionsMaintainerPhone = "+972523400002"
+	permissionsViewerPhone     = "+972523400003"
+	permissionsStrangerPhone   = "+972523400004"
+)
+
+func createStaffedBusinessUnit(t *testing.T) *model.BusinessUnit {
+	t.Helper()
+	bu := createValidBusinessUnit("Permissions Salon", permissionsOwnerPhone)
+	bu["maintainers"] = map[string]string{"Dana": permissionsMaintainerPhone}
+	bu["viewers"] = map[string]string{"Noa": permissionsViewerPhone}
+	return createBusinessUnitAs(t, asCaller(t, permissionsOwnerPhone, ""), bu)
+}
+
+func testCreateRequiresIdentity(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	anonymous := client.NewBusinessUnitClient(httpClient.BaseURL)
+	resp, err := anonymous.Create(createValidBusinessUnit("Anonymous Salon", permissionsOwnerPhone))
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 401)
+}
+
+func testCreateForAnotherAdminForbidden(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	stranger := asCaller(t, permissionsStrangerPhone, "")
+	resp, err := stranger.Create(createValidBusinessUnit("Someone Else's Salon", permissionsOwnerPhone))
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 403)
+	common.AssertContains(t, resp, "FORBIDDEN")
+}
+
+func testMaintainerCanUpdateDetails(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createStaffedBusinessUnit(t)
+	resp, err := asCaller(t, permissionsMaintainerPhone, "").Update(created.ID, map[string]any{"priority": 5})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 204)
+}
+
+func testMaintainerCannotChangeMaintainers(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createStaffedBusinessUnit(t)
+	resp, err := asCaller(t, permissionsMaintainerPhone, "").Update(created.ID, map[string]any{
+		"maintainers": map[string]string{"Dana": permissionsMaintainerPhone, "Tal": permissionsStrangerPhone},
+	})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 403)
+}
+
+func testViewerCannotUpdate(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createStaffedBusinessUnit(t)
+	resp, err := asCaller(t, permissionsViewerPhone, "").Update(created.ID, map[string]any{"priority": 7})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 403)
+}
+
+func testOwnerCanChangeMaintainers(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createStaffedBusinessUnit(t)
+	resp, err := asCaller(t, permissionsOwnerPhone, "").Update(created.ID, map[string]any{
+		"maintainers": map[string]string{"Tal": permissionsStrangerPhone},
+	})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 204)
+}
+
+func testOnlyOwnerCanDelete(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createStaffedBusinessUnit(t)
+	resp, err := asCaller(t, permissionsStrangerPhone, "").Delete(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 403)
+
+	resp, err = asCaller(t, permissionsOwnerPhone, "").Delete(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 202)
+}
+
+func testForgedIdentityRejected(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	// Without a secret every identity is trusted
+	if cfg.CallerSecret == "" {
+		return
+	}
+	created := createStaffedBusinessUnit(t)
+	forged := businessUnitsClient.WithCaller(permissionsOwnerPhone, cfg.CallerSecret+"-forged")
+	resp, err := forged.GetByID(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 401)
+}
+
+func testOwnershipTransfer(t *testing.T) {
+	testOwnerCannotPatchAdminPhone(t)
+	testOnlyOwnerCanStartTransfer(t)
+	testDeclinedTransferKeepsOwner(t)
+	testAcceptedTransferChangesOwner(t)
+	testTransferAuditLog(t)
+}
+
+const (
+	transferOwnerPhone    = "+972523500001"
+	transferNewOwnerPhone = "+972523500002"
+	transferStrangerPhone = "+972523500003"
+)
+
+func decodeTransfer(t *testing.T, resp *client.Response) *model.OwnershipTransfer {
+	t.Helper()
+	transfer, err := businessUnitsClient.DecodeTransfer(resp)
+	if err != nil {
+		t.Fatalf("failed to decode transfer: %v", err)
+	}
+	return transfer
+}
+
+func startTransfer(t *testing.T, id string, request model.OwnershipTransferRequest) *model.OwnershipTransfer {
+	t.Helper()
+	resp, err := asCaller(t, transferOwnerPhone, "").StartTransfer(id, request)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 201)
+	return decodeTransfer(t, resp)
+}
+
+func answerTransfer(t *testing.T, transferID string, accept bool) {
+	t.Helper()
+	newOwner := asCaller(t, transferNewOwnerPhone, "")
+	var resp *client.Response
+	var err error
+	if accept {
+		resp, err = newOwner.AcceptTransfer(transferID)
+	} else {
+		resp, err = newOwner.DeclineTransfer(transferID)
+	}
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+}
+
+func createTransferredBusinessUnit(t *testing.T) *model.BusinessUnit {
+	t.Helper()
+	return createBusinessUnitAs(t, asCaller(t, transferOwnerPhone, ""), createValidBusinessUnit("Handover Salon", transferOwnerPhone))
+}
+
+func testOwnerCannotPatchAdminPhone(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createTransferredBusinessUnit(t)
+	resp, err := asCaller(t, transferOwnerPhone, "").Update(created.ID, map[string]any{"admin_phone": transferNewOwnerPhone})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 403)
+}
+
+func testOnlyOwnerCanStartTransfer(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createTransferredBusinessUnit(t)
+	stranger := asCaller(t, transferStrangerPhone, "")
+	resp, err := stranger.StartTransfer(created.ID, model.OwnershipTransferRequest{ToPhone: transferStrangerPhone})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 403)
+}
+
+func testDeclinedTransferKeepsOwner(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	owner := asCaller(t, transferOwnerPhone, "")
+	newOwner := asCaller(t, transferNewOwnerPhone, "")
+	created := createTransferredBusinessUnit(t)
+	transfer := startTransfer(t, created.ID, model.OwnershipTransferRequest{ToPhone: transferNewOwnerPhone})
+
+	resp, err := owner.StartTransfer(created.ID, model.OwnershipTransferRequest{ToPhone: transferStrangerPhone})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 409)
+
+	resp, err = owner.AcceptTransfer(transfer.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 403)
+
+	resp, err = newOwner.DeclineTransfer(transfer.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	if declined := decodeTransfer(t, resp); declined.Status != config.TransferDeclined {
+		t.Errorf("expected status %s, got %s", config.TransferDeclined, declined.Status)
+	}
+
+	resp, err = newOwner.AcceptTransfer(transfer.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 409)
+
+	resp, err = businessUnitsClient.GetByID(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	if bu := decodeBusinessUnit(t, resp); bu.AdminPhone != transferOwnerPhone {
+		t.Errorf("expected admin phone %s, got %s", transferOwnerPhone, bu.AdminPhone)
+	}
+}
+
+func testAcceptedTransferChangesOwner(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createTransferredBusinessUnit(t)
+	transfer := startTransfer(t, created.ID, model.OwnershipTransferRequest{
+		ToPhone:          transferNewOwnerPhone,
+		KeepAsMaintainer: true,
+		MaintainerName:   "Avi",
+	})
+	if transfer.Status != config.TransferPending || !transfer.ExpiresAt.After(time.Now()) {
+		t.Errorf("expected a pending transfer with a future deadline, got %s until %v", transfer.Status, transfer.ExpiresAt)
+	}
+	answerTransfer(t, transfer.ID, true)
+
+	resp, err := businessUnitsClient.GetByID(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	bu := decodeBusinessUnit(t, resp)
+	if bu.AdminPhone != transferNewOwnerPhone {
+		t.Errorf("expected admin phone %s, got %s", transferNewOwnerPhone, bu.AdminPhone)
+	}
+	if name, ok := bu.Maintainers[transferOwnerPhone]; !ok || name != "Avi" {
+		t.Errorf("expected the previous owner to stay as maintainer Avi, got %v", bu.Maintainers)
+	}
+
+	resp, err = asCaller(t, transferOwnerPhone, "").Delete(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 403)
+}
+
+func testTransferAuditLog(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createTransferredBusinessUnit(t)
+	declined := startTransfer(t, created.ID, model.OwnershipTransferRequest{ToPhone: transferNewOwnerPhone})
+	answerTransfer(t, declined.ID, false)
+	accepted := startTransfer(t, created.ID, model.OwnershipTransferRequest{ToPhone: transferNewOwnerPhone})
+	answerTransfer(t, accepted.ID, true)
+
+	resp, err := asCaller(t, transferStrangerPhone, "").GetAuditLog(created.ID, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 403)
+
+	resp, err = asCaller(t, transferNewOwnerPhone, "").GetAuditLog(created.ID, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	entries, meta, err := businessUnitsClient.DecodeAuditEntries(resp)
+	if err != nil {
+		t.Fatalf("failed to decode audit entries: %v", err)
+	}
+	if meta.TotalCount != 4 || len(entries) != 4 {
+		t.Fatalf("expected 4 audit entries, got %d", meta.TotalCount)
+	}
+	if entries[0].Action != config.AuditTransferAccepted || entries[0].Actor != transferNewOwnerPhone {
+		t.Errorf("expected the newest entry to be the acceptance by %s, got %s by %s", transferNewOwnerPhone, entries[0].Action, entries[0].Actor)
+	}
+}
+
+func testLifecycleStatus(t *testing.T) {
+	testDraftHiddenUntilActivated(t)
+	testCannotCreateArchived(t)
+	testOnlyOwnerChangesStatus(t)
+	testSuspendingRequiresReason(t)
+	testSuspendedHiddenFromSearch(t)
+	testArchivedIsReadOnly(t)
+	testStatusAuditLog(t)
+}
+
+const lifecycleOwnerPhone = "+972523600001"
+
+func isSearchable(t *testing.T, id string) bool {
+	t.Helper()
+	resp, err := businessUnitsClient.Search([]string{"Tel Aviv"}, []string{"Haircut"}, 100, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	for _, bu := range decodeBusinessUnits(t, resp) {
+		if bu.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func changeStatus(t *testing.T, id, status, reason string) *client.Response {
+	t.Helper()
+	resp, err := asCaller(t, lifecycleOwnerPhone, "").ChangeStatus(id, model.BusinessUnitStatusChange{Status: status, Reason: reason})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	return resp
+}
+
+func createLifecycleBusinessUnit(t *testing.T) *model.BusinessUnit {
+	t.Helper()
+	created := createBusinessUnitAs(t, asCaller(t, lifecycleOwnerPhone, ""), createValidBusinessUnit("Lifecycle Salon", lifecycleOwnerPhone))
+	if created.Status != config.BusinessStatusActive {
+		t.Fatalf("expected status %s, got %s", config.BusinessStatusActive, created.Status)
+	}
+	return created
+}
+
+func testDraftHiddenUntilActivated(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	body := createValidBusinessUnit("Draft Salon", lifecycleOwnerPhone)
+	body["status"] = config.BusinessStatusDraft
+	draft := createBusinessUnitAs(t, asCaller(t, lifecycleOwnerPhone, ""), body)
+	if isSearchable(t, draft.ID) {
+		t.Error("expected a draft business unit to be hidden from search")
+	}
+
+	resp := changeStatus(t, draft.ID, config.BusinessStatusActive, "")
+	common.AssertStatusCode(t, resp, 200)
+	if !isSearchable(t, draft.ID) {
+		t.Error("expected an activated business unit to be searchable")
+	}
+}
+
+func testCannotCreateArchived(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	body := createValidBusinessUnit("Archived Salon", lifecycleOwnerPhone)
+	body["status"] = config.BusinessStatusArchived
+	resp, err := asCaller(t, lifecycleOwnerPhone, "").Create(body)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 400)
+}
+
+func testOnlyOwnerChangesStatus(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createLifecycleBusinessUnit(t)
+	resp, err := asCaller(t, "+972523600002", "").ChangeStatus(created.ID, model.BusinessUnitStatusChange{
+		Status: config.BusinessStatusSuspended,
+		Reason: "unpaid invoices",
+	})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 403)
+}
+
+func testSuspendingRequiresReason(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createLifecycleBusinessUnit(t)
+	resp := changeStatus(t, created.ID, config.BusinessStatusSuspended, "")
+	common.AssertStatusCode(t, resp, 400)
+}
+
+func testSuspendedHiddenFromSearch(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createLifecycleBusinessUnit(t)
+	resp := changeStatus(t, created.ID, config.BusinessStatusSuspended, "unpaid invoices")
+	common.AssertStatusCode(t, resp, 200)
+	bu := decodeBusinessUnit(t, resp)
+	if bu.Status != config.BusinessStatusSuspended || bu.StatusReason != "unpaid invoices" || bu.StatusChangedAt == nil {
+		t.Errorf("expected a suspension with its reason, got %s %q", bu.Status, bu.StatusReason)
+	}
+	if isSearchable(t, created.ID) {
+		t.Error("expected a suspended business unit to be hidden from search")
+	}
+
+	resp = changeStatus(t, created.ID, config.BusinessStatusActive, "")
+	common.AssertStatusCode(t, resp, 200)
+	if bu := decodeBusinessUnit(t, resp); bu.StatusReason != "" {
+		t.Errorf("expected the suspension reason to be cleared, got %q", bu.StatusReason)
+	}
+}
+
+func testArchivedIsReadOnly(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createLifecycleBusinessUnit(t)
+	resp := changeStatus(t, created.ID, config.BusinessStatusArchived, "closed down")
+	common.AssertStatusCode(t, resp, 200)
+
+	resp, err := asCaller(t, lifecycleOwnerPhone, "").Update(created.ID, map[string]any{"name": "Reopened Salon"})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 409)
+
+	resp = changeStatus(t, created.ID, config.BusinessStatusActive, "")
+	common.AssertStatusCode(t, resp, 409)
+
+	resp, err = businessUnitsClient.GetByPhone(lifecycleOwnerPhone, nil, nil, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	for _, bu := range decodeBusinessUnits(t, resp) {
+		if bu.ID == created.ID {
+			t.Error("expected an archived business unit to be left out of the phone lookup")
+		}
+	}
+}
+
+func testStatusAuditLog(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createLifecycleBusinessUnit(t)
+	common.AssertStatusCode(t, changeStatus(t, created.ID, config.BusinessStatusSuspended, "unpaid invoices"), 200)
+	common.AssertStatusCode(t, changeStatus(t, created.ID, config.BusinessStatusActive, ""), 200)
+	common.AssertStatusCode(t, changeStatus(t, created.ID, config.BusinessStatusArchived, "closed down"), 200)
+
+	resp, err := asCaller(t, lifecycleOwnerPhone, "").GetAuditLog(created.ID, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	entries, _, err := businessUnitsClient.DecodeAuditEntries(resp)
+	if err != nil {
+		t.Fatalf("failed to decode audit entries: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 audit entries, got %d", len(entries))
+	}
+	if entries[0].Action != config.AuditStatusChanged || entries[0].Details["to"] != config.BusinessStatusArchived {
+		t.Errorf("expected the newest entry to be the archiving, got %s %v", entries[0].Action, entries[0].Details)
+	}
+}
+
+func testLabelTaxonomy(t *testing.T) {
+	testLabelsResolvedOnCreate(t)
+	testSearchByLabelSynonyms(t)
+	testSiblingLabelDoesNotMatch(t)
+	testUpdateResolvesLabels(t)
+}
+
+func createBarber(t *testing.T) *model.BusinessUnit {
+	t.Helper()
+	bu := createValidBusinessUnit("Taxonomy Barbers", "+972523700001")
+	bu["labels"] = []string{"Barbershop", "ספר", "Dog Walker"}
+	return createBusinessUnitAs(t, businessUnitsClient, bu)
+}
+
+func testLabelsResolvedOnCreate(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createBarber(t)
+	if len(created.Labels) != 2 || created.Labels[0] != "barber" || created.Labels[1] != "dog_walker" {
+		t.Errorf("expected labels [barber dog_walker], got %v", created.Labels)
+	}
+}
+
+func testSearchByLabelSynonyms(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createBarber(t)
+	for _, label := range []string{"barber", "Barbers", "haircut", "מספרה", "beauty", "dog walker"} {
+		resp, err := businessUnitsClient.Search([]string{"Tel Aviv"}, []string{label}, 10, 0)
+		if err != nil {
+			t.Fatalf("HTTP request failed: %v", err)
+		}
+		common.AssertStatusCode(t, resp, 200)
+		units := decodeBusinessUnits(t, resp)
+		if len(units) != 1 || units[0].ID != created.ID {
+			t.Errorf("expected the barber to be found by %q, got %d results", label, len(units))
+		}
+	}
+}
+
+func testSiblingLabelDoesNotMatch(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	createBarber(t)
+	resp, err := businessUnitsClient.Search([]string{"Tel Aviv"}, []string{"manicure"}, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	if units := decodeBusinessUnits(t, resp); len(units) != 0 {
+		t.Errorf("expected no results for a sibling category, got %d", len(units))
+	}
+}
+
+func testUpdateResolvesLabels(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createBarber(t)
+	resp, err := businessUnitsClient.Update(created.ID, map[string]any{"labels": []string{"Massages"}})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 204)
+
+	resp, err = businessUnitsClient.GetByID(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	if updated := decodeBusinessUnit(t, resp); len(updated.Labels) != 1 || updated.Labels[0] != "massage" {
+		t.Errorf("expected labels [massage], got %v", updated.Labels)
+	}
+}
+
+func testCityGazetteer(t *testing.T) {
+	testCitiesResolvedOnCreate(t)
+	testSearchByCityAliases(t)
+}
+
+func createGazetteerBusinessUnit(t *testing.T) *model.BusinessUnit {
+	t.Helper()
+	bu := createValidBusinessUnit("Gazetteer Salon", "+972523800001")
+	bu["cities"] = []string{"TLV", "Tel-Aviv", "ירושלים", "Kfar Yona"}
+	return createBusinessUnitAs(t, businessUnitsClient, bu)
+}
+
+func testCitiesResolvedOnCreate(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createGazetteerBusinessUnit(t)
+	want := []string{"tel_aviv", "jerusalem", "kfar_yona"}
+	if !slices.Equal(created.Cities, want) {
+		t.Errorf("expected cities %v, got %v", want, created.Cities)
+	}
+}
+
+func testSearchByCityAliases(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createGazetteerBusinessUnit(t)
+	for _, alias := range []string{"Tel Aviv", "תל אביב", "Tel Aviv-Yafo", "Yerushalayim"} {
+		resp, err := businessUnitsClient.Search([]string{alias}, []string{"Haircut"}, 10, 0)
+		if err != nil {
+			t.Fatalf("HTTP request failed: %v", err)
+		}
+		common.AssertStatusCode(t, resp, 200)
+		units := decodeBusinessUnits(t, resp)
+		if len(units) != 1 || units[0].ID != created.ID {
+			t.Errorf("expected the business unit to be found by %q, got %d results", alias, len(units))
+		}
+	}
+}
+
+func testOrganizations(t *testing.T) {
+	testOnlyOwnersCreateOrganizations(t)
+	testOrganizationDefaults(t)
+	testOnlyOrganizationAdminsOpenBranches(t)
+	testOrganizationAdminsManageBranches(t)
+	testListOrganizationBranches(t)
+	testSearchOneResultPerBrand(t)
+	testOrganizationWithBranchesCannotBeDeleted(t)
+}
+
+const (
+	organizationOwnerPhone      = "+972523900001"
+	organizationAdminPhone      = "+972523900002"
+	organizationFranchiseePhone = "+972523900003"
+	organizationStrangerPhone   = "+972523900004"
+)
+
+func validOrganization() map[string]any {
+	return map[string]any{
+		"name":        "Cutters Franchise",
+		"admin_phone": organizationOwnerPhone,
+		"admins":      map[string]string{organizationAdminPhone: "Regional Manager"},
+		"labels":      []string{"Barbershop"},
+		"services":    []string{"Haircut", "Beard Trim"},
+		"hours": map[string]any{
+			"start_of_day": "09:00",
+			"end_of_day":   "18:00",
+			"working_days": []string{"Sunday", "Monday", "Tuesday"},
+		},
+	}
+}
+
+func createOrganization(t *testing.T) *model.Organization {
+	t.Helper()
+	resp, err := asCaller(t, organizationOwnerPhone, "").CreateOrganization(validOrganization())
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 201)
+	created, err := businessUnitsClient.DecodeOrganization(resp)
+	if err != nil {
+		t.Fatalf("failed to decode organization: %v", err)
+	}
+	return created
+}
+
+// deleteOrganization removes the organization once its branches are cleared.
+func deleteOrganization(t *testing.T, id string) {
+	t.Helper()
+	common.ClearTestData(t, httpClient, TableName)
+	resp, err := asCaller(t, organizationOwnerPhone, "").DeleteOrganization(id)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 204)
+}
+
+func createBranch(t *testing.T, caller *client.BusinessUnitClient, organizationID, name, city string) *client.Response {
+	t.Helper()
+	resp, err := caller.Create(map[string]any{
+		"name":            name,
+		"cities":          []string{city},
+		"admin_phone":     organizationFranchiseePhone,
+		"organization_id": organizationID,
+	})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	return resp
+}
+
+// createBranches opens a branch in Tel Aviv as an organization admin and one
+// in Jerusalem as the owner, and returns the Tel Aviv branch.
+func createBranches(t *testing.T, organizationID string) *model.BusinessUnit {
+	t.Helper()
+	resp := createBranch(t, asCaller(t, organizationAdminPhone, ""), organizationID, "Cutters Tel Aviv", "Tel Aviv")
+	common.AssertStatusCode(t, resp, 201)
+	telAviv := decodeBusinessUnit(t, resp)
+	resp = createBranch(t, asCaller(t, organizationOwnerPhone, ""), organizationID, "Cutters Jerusalem", "Jerusalem")
+	common.AssertStatusCode(t, resp, 201)
+	return telAviv
+}
+
+func testOnlyOwnersCreateOrganizations(t *testing.T) {
+	resp, err := asCaller(t, organizationStrangerPhone, "").CreateOrganization(validOrganization())
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 403)
+}
+
+func testOrganizationDefaults(t *testing.T) {
+	created := createOrganization(t)
+	defer deleteOrganization(t, created.ID)
+
+	if len(created.Labels) != 1 || created.Labels[0] != "barber" {
+		t.Errorf("expected labels [barber], got %v", created.Labels)
+	}
+	if created.TimeZone != "Asia/Jerusalem" {
+		t.Errorf("expected the time zone to be inferred from the admin phone, got %q", created.TimeZone)
+	}
+}
+
+func testOnlyOrganizationAdminsOpenBranches(t *testing.T) {
+	created := createOrganization(t)
+	defer deleteOrganization(t, created.ID)
+
+	resp := createBranch(t, asCaller(t, organizationStrangerPhone, ""), created.ID, "Cutters Haifa", "Haifa")
+	common.AssertStatusCode(t, resp, 403)
+
+	telAviv := createBranches(t, created.ID)
+	if telAviv.OrganizationID != created.ID {
+		t.Errorf("expected organization %s, got %q", created.ID, telAviv.OrganizationID)
+	}
+	if len(telAviv.Labels) != 1 || telAviv.Labels[0] != "barber" {
+		t.Errorf("expected the organization labels, got %v", telAviv.Labels)
+	}
+}
+
+func testOrganizationAdminsManageBranches(t *testing.T) {
+	created := createOrganization(t)
+	defer deleteOrganization(t, created.ID)
+
+	telAviv := createBranches(t, created.ID)
+	resp, err := asCaller(t, organizationAdminPhone, "").Update(telAviv.ID, map[string]any{"priority": 5})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 204)
+
+	resp, err = asCaller(t, organizationStrangerPhone, "").Update(telAviv.ID, map[string]any{"priority": 6})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 403)
+}
+
+func testListOrganizationBranches(t *testing.T) {
+	created := createOrganization(t)
+	defer deleteOrganization(t, created.ID)
+
+	createBranches(t, created.ID)
+	resp, err := businessUnitsClient.GetOrganizationUnits(created.ID, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	if units := decodeBusinessUnits(t, resp); len(units) != 2 {
+		t.Errorf("expected 2 branches, got %d", len(units))
+	}
+}
+
+func testSearchOneResultPerBrand(t *testing.T) {
+	created := createOrganization(t)
+	defer deleteOrganization(t, created.ID)
+
+	createBranches(t, created.ID)
+	independent := createValidBusinessUnit("Independent Barber", "+972523900005")
+	independent["labels"] = []string{"barber"}
+	createBusinessUnitAs(t, businessUnitsClient, independent)
+
+	resp, err := businessUnitsClient.SearchBrands([]string{"Jerusalem", "Tel Aviv"}, []string{"hair"}, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	matches, _, err := businessUnitsClient.DecodeBrandMatches(resp)
+	if err != nil {
+		t.Fatalf("failed to decode brand matches: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected the brand and the independent unit, got %d results", len(matches))
+	}
+	for _, match := range matches {
+		if match.OrganizationID == "" {
+			continue
+		}
+		if match.OrganizationName != "Cutters Franchise" || match.MatchedBranches != 2 {
+			t.Errorf("expected Cutters Franchise with 2 branches, got %q with %d", match.OrganizationName, match.MatchedBranches)
+		}
+		if match.Branch.Name != "Cutters Jerusalem" {
+			t.Errorf("expected the branch in the first listed city, got %q", match.Branch.Name)
+		}
+	}
+}
+
+func testOrganizationWithBranchesCannotBeDeleted(t *testing.T) {
+	created := createOrganization(t)
+	defer deleteOrganization(t, created.ID)
+
+	createBranches(t, created.ID)
+	resp, err := asCaller(t, organizationOwnerPhone, "").DeleteOrganization(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 409)
+}
+
+func testPlansAndQuotas(t *testing.T) {
+	testFreePlanLimitsMaintainers(t)
+	testOnlyServicesAssignPlans(t)
+	testAssignUnknownPlan(t)
+	testQuotaReportsUsage(t)
+	testStrangersCannotReadQuota(t)
+}
+
+const quotaPhone = "+972524000001"
+
+func createQuotaSalon() map[string]any {
+	maintainers := map[string]string{}
+	for i := 0; i < 11; i++ {
+		maintainers[fmt.Sprintf("+97252400%04d", 100+i)] = fmt.Sprintf("Maintainer%d", i)
+	}
+	bu := createValidBusinessUnit("Quota Salon", quotaPhone)
+	bu["maintainers"] = maintainers
+	return bu
+}
+
+func testFreePlanLimitsMaintainers(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	resp, err := asCaller(t, quotaPhone, config.PlanFree).Create(createQuotaSalon())
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 400)
+	common.AssertContains(t, resp, "free plan")
+}
+
+func testOnlyServicesAssignPlans(t *testing.T) {
+	resp, err := asCaller(t, quotaPhone, "").AssignPlan(model.PlanAssignment{Phone: quotaPhone, Plan: config.PlanPro})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 403)
+}
+
+func testAssignUnknownPlan(t *testing.T) {
+	resp, err := businessUnitsClient.AssignPlan(model.PlanAssignment{Phone: quotaPhone, Plan: "platinum"})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 400)
+}
+
+func testQuotaReportsUsage(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	// Plan names are matched case-insensitively
+	owner := asCaller(t, quotaPhone, "Pro")
+	created := createBusinessUnitAs(t, owner, createQuotaSalon())
+
+	resp, err := owner.GetQuota(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	quota, err := businessUnitsClient.DecodeQuota(resp)
+	if err != nil {
+		t.Fatalf("failed to decode quota: %v", err)
+	}
+	if quota.Plan != config.PlanPro {
+		t.Errorf("expected the pro plan, got %q", quota.Plan)
+	}
+	if quota.Usage.BusinessUnits != 1 || quota.Usage.Maintainers != 11 || quota.Usage.Cities != 2 {
+		t.Errorf("unexpected usage %+v", quota.Usage)
+	}
+	if quota.Limits.MaintainersPerBusinessUnit <= config.DefaultMaxMaintainersPerBusiness {
+		t.Errorf("expected the pro plan to allow more than %d maintainers, got %d", config.DefaultMaxMaintainersPerBusiness, quota.Limits.MaintainersPerBusinessUnit)
+	}
+}
+
+func testStrangersCannotReadQuota(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	created := createBusinessUnitAs(t, asCaller(t, quotaPhone, config.PlanPro), createValidBusinessUnit("Quota Salon", quotaPhone))
+	resp, err := asCaller(t, "+972524000099", "").GetQuota(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 403)
+}
+
+func testBulkImport(t *testing.T) {
+	testImportDryRun(t)
+	testImportCreatesValidRows(t)
+	testImportRerunCreatesNoDuplicates(t)
+}
+
+const importFile = `name,admin_phone,cities,labels,maintainers,priority,schedule_name,schedule_city,schedule_address,start_of_day
+Import Salon,+972525000001,Tel Aviv|Haifa,Haircut,+972525000002:Dana,3,Center,,Dizengoff 1,
+Import Salon,+972525000001,,,,,North,Haifa,Herzl 5,10:00
+Import Spa,+972525000003,Jerusalem,Massage,,,,,,
+Broken Salon,+972525000004,,Haircut,,,,,,
+Bad Priority,+972525000005,Tel Aviv,Haircut,,high,,,,`
+
+// runImport imports importFile, writing schedules to the dependencies stub,
+// which stands in for the schedules service.
+func runImport(t *testing.T, dryRun bool) *importer.Report {
+	t.Helper()
+	records, rowErrors, err := importer.Parse(strings.NewReader(importFile), importer.FormatCSV)
+	if err != nil {
+		t.Fatalf("failed to parse import file: %v", err)
+	}
+	dependenciesPort := os.Getenv("TEST_DEPENDENCIES_PORT")
+	if dependenciesPort == "" {
+		dependenciesPort = "8081"
+	}
+	schedulesClient := client.NewScheduleClient("http://localhost:"+dependenciesPort).WithCaller(auth.SystemCaller, cfg.CallerSecret)
+	return importer.NewImporter(cfg, businessUnitsClient, schedulesClient).Run(records, rowErrors, dryRun)
+}
+
+func testImportDryRun(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+	dependencies := common.StartDependencies()
+	defer dependencies.Stop()
+
+	report := runImport(t, true)
+	if report.WouldCreate != 2 || report.Failed != 1 || len(report.RowErrors) != 1 {
+		t.Fatalf("unexpected dry run report %+v", report)
+	}
+	if report.Records[0].SchedulesCreated != 2 {
+		t.Errorf("expected 2 schedules to be created, got %+v", report.Records[0])
+	}
+	if report.RowErrors[0].Line != 6 {
+		t.Errorf("expected the bad priority on line 6, got line %d", report.RowErrors[0].Line)
+	}
+	failed := report.Records[2]
+	if failed.Status != importer.StatusFailed || failed.Line != 5 || len(failed.Errors) == 0 {
+		t.Errorf("expected line 5 to fail validation, got %+v", failed)
+	}
+
+	resp, err := businessUnitsClient.GetByPhone("+972525000001", nil, nil, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	if units := decodeBusinessUnits(t, resp); len(units) != 0 {
+		t.Errorf("dry run created %d business units", len(units))
+	}
+}
+
+func testImportCreatesValidRows(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+	dependencies := common.StartDependencies()
+	defer dependencies.Stop()
+
+	report := runImport(t, false)
+	if report.Created != 2 || report.Failed != 1 {
+		t.Fatalf("unexpected import report %+v", report)
+	}
+	if report.Records[0].BusinessID == "" {
+		t.Fatalf("expected the created business ID in the report")
+	}
+
+	resp, err := businessUnitsClient.GetByID(report.Records[0].BusinessID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	bu := decodeBusinessUnit(t, resp)
+	if bu.Name != "Import Salon" || len(bu.Cities) != 2 || bu.Priority != 3 || bu.Maintainers["+972525000002"] != "Dana" {
+		t.Errorf("imported business unit does not match its row: %+v", bu)
+	}
+
+	if report.Records[0].SchedulesCreated != 2 {
+		t.Fatalf("expected 2 schedules created for the salon, got %+v", report.Records[0])
+	}
+	schedules := dependencies.Schedules(bu.ID)
+	if len(schedules) != 2 {
+		t.Fatalf("expected the schedules service to receive 2 schedules, got %d", len(schedules))
+	}
+	for _, sc := range schedules {
+		if len(sc.WorkingDays) == 0 || len(sc.WeeklyHours) == 0 || sc.DefaultMeetingDurationMin != cfg.DefaultMeetingDurationMin || sc.TimeZone != bu.TimeZone {
+			t.Errorf("expected schedule %q to get the schedules service defaults, got %+v", sc.Name, sc)
+		}
+		switch sc.Name {
+		case sanitizer.SanitizeNameOrAddress("Center"):
+			if sc.City != bu.Cities[0] || sc.StartOfDay != cfg.DefaultStartOfDay {
+				t.Errorf("expected Center in the business' first city with default hours, got %+v", sc)
+			}
+		case sanitizer.SanitizeNameOrAddress("North"):
+			if sc.City != bu.Cities[1] || sc.StartOfDay != "10:00" {
+				t.Errorf("expected North to keep its city and hours, got %+v", sc)
+			}
+		default:
+			t.Errorf("unexpected schedule %q", sc.Name)
+		}
+	}
+}
+
+func testImportRerunCreatesNoDuplicates(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+	dependencies := common.StartDependencies()
+	defer dependencies.Stop()
+
+	if report := runImport(t, false); report.Created != 2 {
+		t.Fatalf("unexpected import report %+v", report)
+	}
+	report := runImport(t, false)
+	if report.Created != 0 || report.Existing != 2 {
+		t.Fatalf("unexpected report for a re-run %+v", report)
+	}
+	if report.Records[0].SchedulesCreated != 0 || report.Records[0].SchedulesExisting != 2 {
+		t.Errorf("expected the salon's schedules to be found, got %+v", report.Records[0])
+	}
+	if schedules := dependencies.Schedules(report.Records[0].BusinessID); len(schedules) != 2 {
+		t.Errorf("expected 2 schedules after re-running the import, got %d", len(schedules))
+	}
+
+	resp, err := businessUnitsClient.GetByPhone("+972525000001", nil, nil, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	if units := decodeBusinessUnits(t, resp); len(units) != 1 {
+		t.Errorf("expected a single business unit after re-running the import, got %d", len(units))
+	}
+}
+
+func testDuplicatesAndMerge(t *testing.T) {
+	testDuplicatesPairLookAlikes(t)
+	testDuplicatesOfAnotherPhoneForbidden(t)
+	testDuplicatesInvalidMinScore(t)
+	testMergeIntoItself(t)
+	testMergeIntoMissingUnit(t)
+	testOnlyOwnersMerge(t)
+	testMergeBeyondTargetPlan(t)
+}
+
+const duplicatesPhone = "+972526000001"
+
+// createLookAlikes creates two look-alike units and an unrelated one, as a
+// phone on the pro plan.
+func createLookAlikes(t *testing.T) (original, duplicate, other *model.BusinessUnit) {
+	t.Helper()
+	owner := asCaller(t, duplicatesPhone, config.PlanPro)
+	original = createBusinessUnitAs(t, owner, createValidBusinessUnit("Dedup Barber Shop", duplicatesPhone))
+	duplicate = createBusinessUnitAs(t, owner, createValidBusinessUnit("Dedup Barbr Shop", duplicatesPhone))
+	other = createBusinessUnitAs(t, owner, createValidBusinessUnit("Quiet Yoga Studio", duplicatesPhone))
+	return original, duplicate, other
+}
+
+func testDuplicatesPairLookAlikes(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	original, duplicate, other := createLookAlikes(t)
+	resp, err := asCaller(t, duplicatesPhone, "").FindDuplicates("", nil, 0, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	candidates, _, err := businessUnitsClient.DecodeDuplicates(resp)
+	if err != nil {
+		t.Fatalf("failed to decode duplicates: %v", err)
+	}
+	if len(candidates) == 0 {
+		t.Fatal("expected the look-alike units to be reported")
+	}
+	top := candidates[0]
+	ids := []string{top.Units[0].ID, top.Units[1].ID}
+	if !slices.Contains(ids, original.ID) || !slices.Contains(ids, duplicate.ID) {
+		t.Errorf("expected %s and %s as the top pair, got %v", original.ID, duplicate.ID, ids)
+	}
+	for _, candidate := range candidates {
+		if candidate.Units[0].ID == other.ID || candidate.Units[1].ID == other.ID {
+			if candidate.Score >= 0.9 {
+				t.Errorf("unrelated unit scored %.2f", candidate.Score)
+			}
+		}
+	}
+}
+
+func testDuplicatesOfAnotherPhoneForbidden(t *testing.T) {
+	resp, err := asCaller(t, "+972526000099", "").FindDuplicates(duplicatesPhone, nil, 0, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 403)
+}
+
+func testDuplicatesInvalidMinScore(t *testing.T) {
+	resp, err := asCaller(t, duplicatesPhone, "").FindDuplicates("", nil, 1.5, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 400)
+}
+
+func testMergeIntoItself(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	_, duplicate, _ := createLookAlikes(t)
+	resp, err := asCaller(t, duplicatesPhone, "").Merge(duplicate.ID, duplicate.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 400)
+}
+
+func testMergeIntoMissingUnit(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	_, duplicate, _ := createLookAlikes(t)
+	resp, err := asCaller(t, duplicatesPhone, "").Merge(duplicate.ID, "507f1f77bcf86cd799439011")
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 404)
+}
+
+func testOnlyOwnersMerge(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	original, duplicate, _ := createLookAlikes(t)
+	resp, err := asCaller(t, "+972526000099", "").Merge(duplicate.ID, original.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 403)
+}
+
+func testMergeBeyondTargetPlan(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+	// The stub stands in for the schedules service
+	dependencies := common.StartDependencies()
+	defer dependencies.Stop()
+
+	original, duplicate, _ := createLookAlikes(t)
+	for i := range 30 {
+		for j, businessID := range []string{duplicate.ID, original.ID} {
+			sc := common.OpenSchedule(fmt.Sprintf("%024x", (j+1)*1000+i))
+			sc.BusinessID = businessID
+			sc.Name = fmt.Sprintf("Branch %d", i)
+			dependencies.SetSchedule(sc)
+		}
+	}
+
+	owner := asCaller(t, duplicatesPhone, "")
+	resp, err := owner.Merge(duplicate.ID, original.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 409)
+	common.AssertContains(t, resp, "room for 20 more schedules")
+
+	resp, err = owner.GetByID(duplicate.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	if schedules := dependencies.Schedules(duplicate.ID); len(schedules) != 30 {
+		t.Errorf("expected the duplicate to keep its 30 schedules, got %d", len(schedules))
+	}
+}
+
+func testAttributes(t *testing.T) {
+	testAttributesNormalized(t)
+	testInvalidAttributesRejected(t)
+	testSearchFiltersOnAttributes(t)
+	testSearchFacetCounts(t)
+	testInvalidAttributeFilter(t)
+	testAttributeDefinitionsListed(t)
+}
+
+const attributesPhone = "+972527000001"
+
+func createWithAttributes(t *testing.T, name string, attrs map[string]any) *model.BusinessUnit {
+	t.Helper()
+	bu := createValidBusinessUnit(name, attributesPhone)
+	bu["attributes"] = attrs
+	return createBusinessUnitAs(t, asCaller(t, attributesPhone, config.PlanPro), bu)
+}
+
+// createAttributedSalons creates an accessible salon and one upstairs, and
+// returns the accessible one.
+func createAttributedSalons(t *testing.T) *model.BusinessUnit {
+	t.Helper()
+	accessible := createWithAttributes(t, "Accessible Salon", map[string]any{
+		"wheelchair_accessible": true,
+		"languages":             []string{"English", "hebrew"},
+		"parking":               "free",
+	})
+	createWithAttributes(t, "Upstairs Salon", map[string]any{
+		"wheelchair_accessible": "no",
+		"languages":             []string{"hebrew"},
+	})
+	return accessible
+}
+
+func testAttributesNormalized(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	accessible := createAttributedSalons(t)
+	if accessible.Attributes["wheelchair_accessible"] != true {
+		t.Errorf("expected wheelchair_accessible true, got %v", accessible.Attributes["wheelchair_accessible"])
+	}
+	if languages, ok := accessible.Attributes["languages"].([]any); !ok || len(languages) != 2 || languages[0] != "hebrew" {
+		t.Errorf("expected languages in option order, got %v", accessible.Attributes["languages"])
+	}
+}
+
+func testInvalidAttributesRejected(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	bu := createValidBusinessUnit("Invalid Attributes Salon", attributesPhone)
+	bu["attributes"] = map[string]any{"parking": "valet", "wifi": true}
+	resp, err := asCaller(t, attributesPhone, config.PlanPro).Create(bu)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 422)
+	common.AssertContains(t, resp, "parking")
+	common.AssertContains(t, resp, "wifi")
+}
+
+func testSearchFiltersOnAttributes(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	accessible := createAttributedSalons(t)
+	filter := model.AttributeFilter{"wheelchair_accessible": {"true"}, "languages": {"english", "russian"}}
+	resp, err := businessUnitsClient.FilteredSearch([]string{"Tel Aviv"}, []string{"Haircut"}, filter, model.SearchFairness{}, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	units := decodeBusinessUnits(t, resp)
+	if len(units) != 1 || units[0].ID != accessible.ID {
+		t.Errorf("expected only %s, got %d units", accessible.ID, len(units))
+	}
+}
+
+func testSearchFacetCounts(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	createAttributedSalons(t)
+	resp, err := businessUnitsClient.FacetedSearch([]string{"Tel Aviv"}, []string{"Haircut"}, nil, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	facets, err := businessUnitsClient.DecodeFacets(resp)
+	if err != nil {
+		t.Fatalf("failed to decode facets: %v", err)
+	}
+	counts := map[string]int64{}
+	for _, facet := range facets {
+		for _, value := range facet.Values {
+			counts[facet.Key+"="+value.Value] = value.Count
+		}
+	}
+	if counts["languages=hebrew"] != 2 || counts["languages=english"] != 1 || counts["wheelchair_accessible=false"] != 1 {
+		t.Errorf("unexpected facet counts %v", counts)
+	}
+}
+
+func testInvalidAttributeFilter(t *testing.T) {
+	resp, err := businessUnitsClient.FilteredSearch([]string{"Tel Aviv"}, []string{"Haircut"}, model.AttributeFilter{"parking": {"valet"}}, model.SearchFairness{}, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 400)
+}
+
+func testAttributeDefinitionsListed(t *testing.T) {
+	resp, err := businessUnitsClient.ListAttributes()
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	common.AssertContains(t, resp, "wheelchair_accessible")
+}
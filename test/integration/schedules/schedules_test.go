@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
+	"skeji/pkg/auth"
 	"skeji/pkg/client"
 	"skeji/pkg/config"
 	"skeji/pkg/model"
@@ -24,6 +25,7 @@ var (
 	cfg             *config.Config
 	httpClient      *client.HttpClient
 	schedulesClient *client.ScheduleClient
+	dependencies    *common.Dependencies
 )
 
 func TestMain(t *testing.T) {
@@ -56,6 +58,10 @@ func testAdvanced(t *testing.T) {
 	testExceptionsDuplicateDates(t)
 	testExceptionsInvalidFormat(t)
 	testExceptionsPastDates(t)
+	testExceptionsSpecialHours(t)
+	testPostWeeklyHoursSplitShift(t)
+	testPostWeeklyHoursInvalid(t)
+	testAvailability(t)
 	testTimeZoneDSTTransition(t)
 	testMultipleSchedulesSameCity(t)
 	testScheduleWithLongAddress(t)
@@ -70,6 +76,10 @@ func testAdvanced(t *testing.T) {
 	testMaxSchedulesPerBusinessUnit(t)
 	testMaxSchedulesPerBusinessPerCityCreate(t)
 	testMaxSchedulesPerBusinessPerCityUpdate(t)
+	testNearSearch(t)
+	testCloneSchedule(t)
+	testScheduleTemplates(t)
+	testCityAliases(t)
 }
 
 func setup() {
@@ -79,11 +89,14 @@ func setup() {
 	if serverURL == "" {
 		serverURL = "http://localhost:8080"
 	}
-	httpClient = client.NewHttpClient(serverURL)
-	schedulesClient = client.NewScheduleClient(serverURL)
+	// The tests act as another service, which may do anything.
+	httpClient = client.NewHttpClient(serverURL).WithCaller(auth.SystemCaller, cfg.CallerSecret)
+	schedulesClient = client.NewScheduleClient(serverURL).WithCaller(auth.SystemCaller, cfg.CallerSecret)
+	dependencies = common.StartDependencies()
 }
 
 func teardown() {
+	dependencies.Stop()
 	cfg.GracefulShutdown()
 }
 
@@ -104,6 +117,14 @@ func createValidSchedule(name string) map[string]any {
 	}
 }
 
+func closedDates(dates ...string) []map[string]any {
+	exceptions := make([]map[string]any, 0, len(dates))
+	for _, date := range dates {
+		exceptions = append(exceptions, map[string]any{"type": "closed", "date": date})
+	}
+	return exceptions
+}
+
 func decodeSchedule(t *testing.T, resp *client.Response) *model.Schedule {
 	t.Helper()
 	schedule, err := schedulesClient.DecodeSchedule(resp)
@@ -178,6 +199,9 @@ func testUpdate(t *testing.T) {
 	testUpdateTimeZone(t)
 	testUpdateAddExceptions(t)
 	testUpdateRemoveExceptions(t)
+	testUpdateWeeklyHours(t)
+	testUpdateStaff(t)
+	testUpdateSeasons(t)
 	testUpdateAllFieldsAtOnce(t)
 	testUpdateOnlyName(t)
 	testUpdateOnlyTimeRange(t)
@@ -188,6 +212,9 @@ func testDelete(t *testing.T) {
 	testDeleteNonExistingRecord(t)
 	testDeleteWithInvalidId(t)
 	testDeletedRecord(t)
+	testDeleteWithMode(t)
+	testDeleteWithOngoingBooking(t)
+	testArchivedBusinessReadOnly(t)
 }
 
 func testGetByIdEmptyTable(t *testing.T) {
@@ -876,6 +903,168 @@ func testDeletedRecord(t *testing.T) {
 	common.AssertStatusCode(t, resp, 404)
 }
 
+func testDeleteWithMode(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+	createResp, err := schedulesClient.Create(createValidSchedule("Delete Mode Branch"))
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, createResp, 201)
+	created := decodeSchedule(t, createResp)
+
+	other := createValidSchedule("Other Business Branch")
+	other["business_id"] = "507f1f77bcf86cd799439088"
+	otherResp, err := schedulesClient.Create(other)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, otherResp, 201)
+	otherBusiness := decodeSchedule(t, otherResp)
+
+	cases := []struct {
+		name     string
+		mode     string
+		target   string
+		expected int
+	}{
+		{"unknown mode", "archive", "", 400},
+		{"move without target", "move", "", 400},
+		{"move to itself", "move", created.ID, 400},
+		{"move to missing schedule", "move", "507f1f77bcf86cd799439099", 404},
+		{"move to another business", "move", otherBusiness.ID, 400},
+	}
+	for _, tc := range cases {
+		resp, err := schedulesClient.DeleteWithMode(created.ID, tc.mode, tc.target)
+		if err != nil {
+			t.Fatalf("HTTP request failed: %v", err)
+		}
+		if resp.StatusCode != tc.expected {
+			t.Errorf("%s: expected status %d, got %d: %s", tc.name, tc.expected, resp.StatusCode, resp.ToString())
+		}
+	}
+
+	// nothing was deleted by the rejected requests
+	resp, err := schedulesClient.GetByID(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+}
+
+func testDeleteWithOngoingBooking(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+	defer dependencies.Reset()
+
+	create := func(name string) *model.Schedule {
+		t.Helper()
+		resp, err := schedulesClient.Create(createValidSchedule(name))
+		if err != nil {
+			t.Fatalf("HTTP request failed: %v", err)
+		}
+		common.AssertStatusCode(t, resp, 201)
+		return decodeSchedule(t, resp)
+	}
+	source := create("Closing Branch")
+	target := create("Surviving Branch")
+
+	now := time.Now().UTC()
+	booking := func(id string, start, end time.Time) *model.Booking {
+		b := &model.Booking{
+			ID:           id,
+			BusinessID:   source.BusinessID,
+			ScheduleID:   source.ID,
+			ServiceLabel: "haircut",
+			StartTime:    start,
+			EndTime:      end,
+			Capacity:     1,
+			Participants: map[string]string{"dana": "+972501234567"},
+			Status:       config.Confirmed,
+		}
+		dependencies.SetBooking(b)
+		return b
+	}
+	ongoing := booking("64b000000000000000000001", now.Add(-30*time.Minute), now.Add(30*time.Minute))
+	upcoming := booking("64b000000000000000000002", now.Add(2*time.Hour), now.Add(3*time.Hour))
+	finished := booking("64b000000000000000000003", now.Add(-3*time.Hour), now.Add(-2*time.Hour))
+
+	resp, err := schedulesClient.Delete(source.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 409)
+	common.AssertContains(t, resp, "2 upcoming or ongoing booking(s)")
+
+	resp, err = schedulesClient.DeleteWithMode(source.ID, config.DeleteModeMove, target.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+
+	for _, b := range []*model.Booking{ongoing, upcoming} {
+		if moved := dependencies.Booking(b.ID); moved.ScheduleID != target.ID {
+			t.Errorf("expected booking %s to move to the surviving branch, got schedule %s", b.ID, moved.ScheduleID)
+		}
+	}
+	if kept := dependencies.Booking(finished.ID); kept.ScheduleID != source.ID {
+		t.Errorf("expected the finished booking to stay on the deleted schedule, got schedule %s", kept.ScheduleID)
+	}
+}
+
+func testArchivedBusinessReadOnly(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+	req := createValidSchedule("Archived Business Branch")
+	req["business_id"] = "507f1f77bcf86cd799439077"
+	createResp, err := schedulesClient.Create(req)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, createResp, 201)
+	created := decodeSchedule(t, createResp)
+
+	dependencies.SetBusinessUnit(&model.BusinessUnit{
+		ID:         "507f1f77bcf86cd799439077",
+		Name:       "Archived Business",
+		Cities:     []string{"Tel Aviv"},
+		Labels:     []string{"Haircut"},
+		AdminPhone: "+972500000077",
+		Status:     config.BusinessStatusArchived,
+		TimeZone:   "Asia/Jerusalem",
+	})
+	defer dependencies.Reset()
+
+	resp, err := schedulesClient.Create(createValidSchedule("Another Archived Branch"))
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 201)
+
+	another := createValidSchedule("Another Archived Branch")
+	another["business_id"] = "507f1f77bcf86cd799439077"
+	resp, err = schedulesClient.Create(another)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 409)
+
+	resp, err = schedulesClient.Update(created.ID, map[string]any{"name": "Renamed Archived Branch"})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 409)
+
+	resp, err = schedulesClient.Delete(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 409)
+
+	resp, err = schedulesClient.GetByID(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+}
+
 func testPostWorkingDaysSingleDay(t *testing.T) {
 	defer common.ClearTestData(t, httpClient, TableName)
 	req := createValidSchedule("Single Day Branch")
@@ -1174,7 +1363,7 @@ func testPostExceptionsArray(t *testing.T) {
 	defer common.ClearTestData(t, httpClient, TableName)
 
 	req := createValidSchedule("Exceptions Branch")
-	req["exceptions"] = []string{"2025-12-25", "2025-12-26", "2026-01-01"}
+	req["exceptions"] = closedDates("2025-12-25", "2025-12-26", "2026-01-01")
 
 	resp, err := schedulesClient.Create(req)
 	if err != nil {
@@ -1191,7 +1380,7 @@ func testPostExceptionsArray(t *testing.T) {
 	}
 
 	req2 := createValidSchedule("No Exceptions Branch")
-	req2["exceptions"] = []string{}
+	req2["exceptions"] = []map[string]any{}
 	resp2, err := schedulesClient.Create(req2)
 	if err != nil {
 		t.Fatalf("HTTP request failed: %v", err)
@@ -1199,7 +1388,7 @@ func testPostExceptionsArray(t *testing.T) {
 	common.AssertStatusCode(t, resp2, 201)
 
 	req3 := createValidSchedule("Invalid Exception")
-	req3["exceptions"] = []string{"not-a-date"}
+	req3["exceptions"] = closedDates("not-a-date")
 	resp3, err := schedulesClient.Create(req3)
 	if err != nil {
 		t.Fatalf("HTTP request failed: %v", err)
@@ -1216,7 +1405,7 @@ func testPostAllOptionalFields(t *testing.T) {
 	req["default_meeting_duration_min"] = 30
 	req["default_break_duration_min"] = 10
 	req["max_participants_per_slot"] = 5
-	req["exceptions"] = []string{"2025-12-25"}
+	req["exceptions"] = closedDates("2025-12-25")
 
 	resp, err := schedulesClient.Create(req)
 	if err != nil {
@@ -1311,7 +1500,7 @@ func testUpdateTimeZone(t *testing.T) {
 func testUpdateAddExceptions(t *testing.T) {
 	defer common.ClearTestData(t, httpClient, TableName)
 	req := createValidSchedule("Add Exceptions")
-	req["exceptions"] = []string{}
+	req["exceptions"] = []map[string]any{}
 	createResp, err := schedulesClient.Create(req)
 	if err != nil {
 		t.Fatalf("HTTP request failed: %v", err)
@@ -1320,7 +1509,7 @@ func testUpdateAddExceptions(t *testing.T) {
 	created := decodeSchedule(t, createResp)
 
 	update := map[string]any{
-		"exceptions": []string{"2025-12-25", "2025-12-26"},
+		"exceptions": closedDates("2025-12-25", "2025-12-26"),
 	}
 	resp, err := schedulesClient.Update(created.ID, update)
 	if err != nil {
@@ -1343,10 +1532,201 @@ func testUpdateAddExceptions(t *testing.T) {
 	}
 }
 
+func testUpdateWeeklyHours(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+	req := createValidSchedule("Update Weekly Hours")
+	createResp, err := schedulesClient.Create(req)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, createResp, 201)
+	created := decodeSchedule(t, createResp)
+	if len(created.WeeklyHours) != 5 {
+		t.Fatalf("expected weekly hours derived for 5 working days, got %d", len(created.WeeklyHours))
+	}
+
+	update := map[string]any{
+		"weekly_hours": []map[string]any{
+			{"day": "monday", "intervals": []map[string]any{{"start": "10:00", "end": "14:00"}, {"start": "15:00", "end": "19:00"}}},
+			{"day": "thursday", "intervals": []map[string]any{{"start": "08:00", "end": "12:00"}}},
+		},
+	}
+	resp, err := schedulesClient.Update(created.ID, update)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 204)
+
+	getResp, err := schedulesClient.GetByID(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	fetched := decodeSchedule(t, getResp)
+	if len(fetched.WeeklyHours) != 2 || len(fetched.WeeklyHours[0].Intervals) != 2 {
+		t.Errorf("unexpected weekly hours after update: %+v", fetched.WeeklyHours)
+	}
+	if len(fetched.WorkingDays) != 2 || fetched.StartOfDay != "08:00" || fetched.EndOfDay != "19:00" {
+		t.Errorf("flat fields not derived from weekly hours: days=%v start=%s end=%s", fetched.WorkingDays, fetched.StartOfDay, fetched.EndOfDay)
+	}
+
+	resp, err = schedulesClient.Update(created.ID, map[string]any{"end_of_day": "17:00"})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 204)
+	getResp, err = schedulesClient.GetByID(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	fetched = decodeSchedule(t, getResp)
+	for _, d := range fetched.WeeklyHours {
+		if len(d.Intervals) != 1 || d.Intervals[0].Start != "08:00" || d.Intervals[0].End != "17:00" {
+			t.Errorf("expected uniform hours after flat update, got %+v", d)
+		}
+	}
+}
+
+func testUpdateSeasons(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+	createResp, err := schedulesClient.Create(createValidSchedule("Update Seasons"))
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, createResp, 201)
+	created := decodeSchedule(t, createResp)
+
+	overlapping := map[string]any{
+		"seasons": []map[string]any{
+			{"effective_from": "2030-06-01", "effective_until": "2030-08-31", "working_days": []string{"sunday"}, "start_of_day": "08:00", "end_of_day": "14:00"},
+			{"effective_from": "2030-08-01", "working_days": []string{"sunday"}, "start_of_day": "10:00", "end_of_day": "16:00"},
+		},
+	}
+	resp, err := schedulesClient.Update(created.ID, overlapping)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 422)
+
+	reversed := map[string]any{
+		"seasons": []map[string]any{
+			{"effective_from": "2030-08-31", "effective_until": "2030-06-01", "working_days": []string{"sunday"}, "start_of_day": "08:00", "end_of_day": "14:00"},
+		},
+	}
+	resp, err = schedulesClient.Update(created.ID, reversed)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 422)
+
+	update := map[string]any{
+		"seasons": []map[string]any{
+			{
+				"name":                         "Winter",
+				"effective_from":               "2030-11-01",
+				"working_days":                 []string{"Sunday", "Monday"},
+				"start_of_day":                 "10:00",
+				"end_of_day":                   "16:00",
+				"default_meeting_duration_min": 30,
+			},
+			{
+				"name":            "Summer",
+				"effective_from":  "2030-06-01",
+				"effective_until": "2030-08-31",
+				"weekly_hours":    []map[string]any{{"day": "friday", "intervals": []map[string]any{{"start": "08:00", "end": "13:00"}}}},
+			},
+		},
+	}
+	resp, err = schedulesClient.Update(created.ID, update)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 204)
+
+	getResp, err := schedulesClient.GetByID(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, getResp, 200)
+	updated := decodeSchedule(t, getResp)
+	if len(updated.Seasons) != 2 {
+		t.Fatalf("expected 2 seasons, got %+v", updated.Seasons)
+	}
+	summer, winter := updated.Seasons[0], updated.Seasons[1]
+	if summer.EffectiveFrom != "2030-06-01" || len(summer.WorkingDays) != 1 || summer.WorkingDays[0] != "friday" || summer.StartOfDay != "08:00" || summer.EndOfDay != "13:00" {
+		t.Errorf("unexpected summer season: %+v", summer)
+	}
+	if winter.EffectiveFrom != "2030-11-01" || len(winter.WeeklyHours) != 2 || winter.DefaultMeetingDurationMin == nil || *winter.DefaultMeetingDurationMin != 30 {
+		t.Errorf("unexpected winter season: %+v", winter)
+	}
+	if updated.StartOfDay != created.StartOfDay || updated.EndOfDay != created.EndOfDay {
+		t.Errorf("base hours should be unchanged, got %s-%s", updated.StartOfDay, updated.EndOfDay)
+	}
+
+	resp, err = schedulesClient.Update(created.ID, map[string]any{"seasons": []map[string]any{}})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 204)
+}
+
+func testUpdateStaff(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+	createResp, err := schedulesClient.Create(createValidSchedule("Update Staff"))
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, createResp, 201)
+	created := decodeSchedule(t, createResp)
+
+	duplicate := map[string]any{
+		"staff": []map[string]any{{"name": "Dana Levi"}, {"name": "dana levi"}},
+	}
+	resp, err := schedulesClient.Update(created.ID, duplicate)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 422)
+
+	update := map[string]any{
+		"staff": []map[string]any{
+			{"name": "Dana Levi", "services": []string{"haircut"}},
+			{
+				"name":         "Noa Cohen",
+				"weekly_hours": []map[string]any{{"day": "monday", "intervals": []map[string]any{{"start": "12:00", "end": "16:00"}}}},
+			},
+		},
+	}
+	resp, err = schedulesClient.Update(created.ID, update)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 204)
+
+	getResp, err := schedulesClient.GetByID(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	fetched := decodeSchedule(t, getResp)
+	if len(fetched.Staff) != 2 {
+		t.Fatalf("expected 2 staff members, got %d", len(fetched.Staff))
+	}
+	for _, member := range fetched.Staff {
+		if len(member.ID) != 24 {
+			t.Errorf("expected generated staff ID, got %q", member.ID)
+		}
+	}
+
+	resp, err = schedulesClient.GetAvailability(created.ID, "", "", "", "507f1f77bcf86cd799439099")
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 404)
+}
+
 func testUpdateRemoveExceptions(t *testing.T) {
 	defer common.ClearTestData(t, httpClient, TableName)
 	req := createValidSchedule("Remove Exceptions")
-	req["exceptions"] = []string{"2025-12-25", "2025-12-26"}
+	req["exceptions"] = closedDates("2025-12-25", "2025-12-26")
 	createResp, err := schedulesClient.Create(req)
 	if err != nil {
 		t.Fatalf("HTTP request failed: %v", err)
@@ -1355,7 +1735,7 @@ func testUpdateRemoveExceptions(t *testing.T) {
 	created := decodeSchedule(t, createResp)
 
 	update := map[string]any{
-		"exceptions": []string{},
+		"exceptions": []map[string]any{},
 	}
 	resp, err := schedulesClient.Update(created.ID, update)
 	if err != nil {
@@ -1632,7 +2012,7 @@ func testExceptionsEdgeCases(t *testing.T) {
 	for i := 1; i <= 365; i++ {
 		exceptions = append(exceptions, fmt.Sprintf("2025-%02d-%02d", (i%12)+1, (i%28)+1))
 	}
-	req["exceptions"] = exceptions
+	req["exceptions"] = closedDates(exceptions...)
 
 	resp, err := schedulesClient.Create(req)
 	if err != nil {
@@ -1643,7 +2023,7 @@ func testExceptionsEdgeCases(t *testing.T) {
 	}
 
 	req2 := createValidSchedule("Duplicate Exceptions")
-	req2["exceptions"] = []string{"2025-12-25", "2025-12-25", "2025-12-26"}
+	req2["exceptions"] = closedDates("2025-12-25", "2025-12-25", "2025-12-26")
 	resp, err = schedulesClient.Create(req2)
 	if err != nil {
 		t.Error(err.Error())
@@ -2052,7 +2432,7 @@ func testExceptionsDuplicateDates(t *testing.T) {
 	defer common.ClearTestData(t, httpClient, TableName)
 
 	req := createValidSchedule("Duplicate Exceptions")
-	req["exceptions"] = []string{"2025-12-25", "2025-12-25", "2025-12-26"}
+	req["exceptions"] = closedDates("2025-12-25", "2025-12-25", "2025-12-26")
 
 	resp, err := schedulesClient.Create(req)
 	if err != nil {
@@ -2076,7 +2456,7 @@ func testExceptionsInvalidFormat(t *testing.T) {
 	defer common.ClearTestData(t, httpClient, TableName)
 
 	req := createValidSchedule("Invalid Exception Format")
-	req["exceptions"] = []string{"12/25/2025", "not-a-date", "2025-13-45"}
+	req["exceptions"] = closedDates("12/25/2025", "not-a-date", "2025-13-45")
 
 	resp, err := schedulesClient.Create(req)
 	if err != nil {
@@ -2093,7 +2473,7 @@ func testExceptionsPastDates(t *testing.T) {
 	defer common.ClearTestData(t, httpClient, TableName)
 
 	req := createValidSchedule("Past Exceptions")
-	req["exceptions"] = []string{"2020-01-01", "2021-12-25", "2022-06-15"}
+	req["exceptions"] = closedDates("2020-01-01", "2021-12-25", "2022-06-15")
 
 	resp, err := schedulesClient.Create(req)
 	if err != nil {
@@ -2112,6 +2492,199 @@ func testExceptionsPastDates(t *testing.T) {
 	}
 }
 
+func testExceptionsSpecialHours(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	req := createValidSchedule("Special Hours")
+	req["exceptions"] = []map[string]any{
+		{"type": "special_hours", "date": "2025-12-24", "start_of_day": "09:00", "end_of_day": "13:00", "reason": "Christmas Eve"},
+		{"type": "closed", "date": "2025-12-25", "end_date": "2025-12-26"},
+	}
+	resp, err := schedulesClient.Create(req)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 201)
+	created := decodeSchedule(t, resp)
+	if len(created.Exceptions) != 2 {
+		t.Fatalf("expected 2 exceptions, got %d", len(created.Exceptions))
+	}
+	if created.Exceptions[0].Type != "special_hours" || created.Exceptions[0].EndOfDay != "13:00" {
+		t.Errorf("unexpected special hours exception: %+v", created.Exceptions[0])
+	}
+
+	missingHours := createValidSchedule("Special Hours Missing")
+	missingHours["exceptions"] = []map[string]any{{"type": "special_hours", "date": "2025-12-24"}}
+	resp, err = schedulesClient.Create(missingHours)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 422)
+
+	overlapping := createValidSchedule("Overlapping Exceptions")
+	overlapping["exceptions"] = []map[string]any{
+		{"type": "closed", "date": "2025-12-24", "end_date": "2025-12-31"},
+		{"type": "special_hours", "date": "2025-12-28", "start_of_day": "10:00", "end_of_day": "12:00"},
+	}
+	resp, err = schedulesClient.Create(overlapping)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 422)
+
+	unknownType := createValidSchedule("Unknown Exception Type")
+	unknownType["exceptions"] = []map[string]any{{"type": "holiday", "date": "2025-12-24"}}
+	resp, err = schedulesClient.Create(unknownType)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 422)
+}
+
+func testPostWeeklyHoursSplitShift(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	req := createValidSchedule("Split Shift")
+	delete(req, "working_days")
+	delete(req, "start_of_day")
+	delete(req, "end_of_day")
+	req["weekly_hours"] = []map[string]any{
+		{"day": "Thursday", "intervals": []map[string]any{{"start": "09:00", "end": "13:00"}}},
+		{"day": "Sunday", "intervals": []map[string]any{{"start": "16:00", "end": "20:00"}, {"start": "09:00", "end": "13:00"}}},
+	}
+	resp, err := schedulesClient.Create(req)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 201)
+	created := decodeSchedule(t, resp)
+
+	if len(created.WeeklyHours) != 2 {
+		t.Fatalf("expected 2 weekly hours entries, got %d", len(created.WeeklyHours))
+	}
+	sunday := created.WeeklyHours[0]
+	if sunday.Day != "sunday" || len(sunday.Intervals) != 2 || sunday.Intervals[0].Start != "09:00" {
+		t.Errorf("expected sunday first with sorted intervals, got %+v", sunday)
+	}
+	if len(created.WorkingDays) != 2 || created.StartOfDay != "09:00" || created.EndOfDay != "20:00" {
+		t.Errorf("flat fields not derived from weekly hours: days=%v start=%s end=%s", created.WorkingDays, created.StartOfDay, created.EndOfDay)
+	}
+}
+
+func testPostWeeklyHoursInvalid(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	cases := map[string][]map[string]any{
+		"overlapping intervals": {
+			{"day": "monday", "intervals": []map[string]any{{"start": "09:00", "end": "13:00"}, {"start": "12:00", "end": "16:00"}}},
+		},
+		"end before start": {
+			{"day": "monday", "intervals": []map[string]any{{"start": "13:00", "end": "09:00"}}},
+		},
+		"duplicate day": {
+			{"day": "monday", "intervals": []map[string]any{{"start": "09:00", "end": "13:00"}}},
+			{"day": "Monday", "intervals": []map[string]any{{"start": "16:00", "end": "20:00"}}},
+		},
+		"invalid day": {
+			{"day": "funday", "intervals": []map[string]any{{"start": "09:00", "end": "13:00"}}},
+		},
+		"no intervals": {
+			{"day": "monday", "intervals": []map[string]any{}},
+		},
+	}
+	for name, hours := range cases {
+		req := createValidSchedule("Invalid Weekly Hours")
+		req["weekly_hours"] = hours
+		resp, err := schedulesClient.Create(req)
+		if err != nil {
+			t.Fatalf("HTTP request failed: %v", err)
+		}
+		if resp.StatusCode != 422 {
+			t.Errorf("%s: expected 422, got %d", name, resp.StatusCode)
+		}
+	}
+}
+
+func testAvailability(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	createResp, err := schedulesClient.Create(createValidSchedule("Availability"))
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, createResp, 201)
+	created := decodeSchedule(t, createResp)
+
+	now := time.Now().UTC()
+
+	resp, err := schedulesClient.GetAvailability(created.ID, "not-a-time", "", "", "")
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 400)
+
+	resp, err = schedulesClient.GetAvailability(created.ID, now.Format(time.RFC3339), now.Add(-time.Hour).Format(time.RFC3339), "", "")
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 400)
+
+	resp, err = schedulesClient.GetAvailability(created.ID, now.Format(time.RFC3339), now.Add(400*24*time.Hour).Format(time.RFC3339), "", "")
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 400)
+
+	resp, err = schedulesClient.GetAvailability("507f1f77bcf86cd799439099", "", "", "", "")
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 404)
+
+	// a range entirely in the past has nothing bookable
+	resp, err = schedulesClient.GetAvailability(created.ID, now.Add(-48*time.Hour).Format(time.RFC3339), now.Add(-24*time.Hour).Format(time.RFC3339), "haircut", "")
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	result, err := schedulesClient.DecodeAvailability(resp)
+	if err != nil {
+		t.Fatalf("failed to decode availability: %v", err)
+	}
+	if result.ScheduleID != created.ID || len(result.Slots) != 0 || result.Service != "haircut" {
+		t.Errorf("unexpected availability for past range: %+v", result)
+	}
+
+	// slots need the bookings service; when it is not running the endpoint reports 503
+	resp, err = schedulesClient.GetAvailability(created.ID, now.Format(time.RFC3339), now.Add(7*24*time.Hour).Format(time.RFC3339), "", "")
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	if resp.StatusCode == 503 {
+		return
+	}
+	common.AssertStatusCode(t, resp, 200)
+	result, err = schedulesClient.DecodeAvailability(resp)
+	if err != nil {
+		t.Fatalf("failed to decode availability: %v", err)
+	}
+	if len(result.Slots) == 0 {
+		t.Fatalf("expected open slots in the next week")
+	}
+	loc, _ := time.LoadLocation(created.TimeZone)
+	for _, slot := range result.Slots {
+		if slot.End.Sub(slot.Start) != time.Duration(created.DefaultMeetingDurationMin)*time.Minute {
+			t.Errorf("slot %v-%v does not match meeting duration", slot.Start, slot.End)
+		}
+		if created.HoursOn(slot.Start.In(loc).Weekday()) == nil {
+			t.Errorf("slot %v falls on a non-working day", slot.Start)
+		}
+		if slot.Remaining <= 0 || slot.Remaining > slot.Capacity {
+			t.Errorf("slot %v has invalid remaining capacity %d/%d", slot.Start, slot.Remaining, slot.Capacity)
+		}
+	}
+}
+
 func testTimeZoneDSTTransition(t *testing.T) {
 	defer common.ClearTestData(t, httpClient, TableName)
 
@@ -2665,3 +3238,279 @@ func testMaxSchedulesPerBusinessPerCityUpdate(t *testing.T) {
 		t.Error(err.Error())
 	}
 }
+
+func testNearSearch(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	// Dizengoff Center, Tel Aviv; Azrieli is ~1.8km away, Haifa ~80km
+	locations := map[string][]float64{
+		"Azrieli":  {34.7918, 32.0745},
+		"Dizengof": {34.7753, 32.0754},
+		"Haifa":    {34.9896, 32.7940},
+	}
+	ids := map[string]string{}
+	for name, coords := range locations {
+		req := createValidSchedule(name)
+		req["location"] = map[string]any{"type": "Point", "coordinates": coords}
+		resp, err := schedulesClient.Create(req)
+		if err != nil {
+			t.Fatalf("HTTP request failed: %v", err)
+		}
+		common.AssertStatusCode(t, resp, 201)
+		ids[name] = decodeSchedule(t, resp).ID
+	}
+	resp, err := schedulesClient.Create(createValidSchedule("No Location"))
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 201)
+
+	resp, err = schedulesClient.Near("", 32.0754, 34.7753, 10000, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	nearby, metadata, err := schedulesClient.DecodeNearbySchedules(resp)
+	if err != nil {
+		t.Fatalf("failed to decode nearby schedules: %v", err)
+	}
+	if metadata.TotalCount != 2 || len(nearby) != 2 {
+		t.Fatalf("expected 2 schedules within 10km, got total=%d, data=%d", metadata.TotalCount, len(nearby))
+	}
+	if nearby[0].ID != ids["Dizengof"] || nearby[1].ID != ids["Azrieli"] {
+		t.Errorf("expected nearest first, got %s then %s", nearby[0].Name, nearby[1].Name)
+	}
+	if nearby[0].DistanceMeters > 1 || nearby[1].DistanceMeters < 1000 || nearby[1].DistanceMeters > 3000 {
+		t.Errorf("unexpected distances: %f, %f", nearby[0].DistanceMeters, nearby[1].DistanceMeters)
+	}
+
+	resp, err = schedulesClient.Near("507f1f77bcf86cd799439099", 32.0754, 34.7753, 10000, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	_, count, _, _ := decodePaginated(t, resp)
+	if count != 0 {
+		t.Errorf("expected no schedules for another business, got %d", count)
+	}
+
+	for _, tc := range []struct {
+		name string
+		lat  float64
+		lng  float64
+		r    float64
+	}{
+		{"latitude out of range", 91, 34.7753, 1000},
+		{"longitude out of range", 32.0754, 181, 1000},
+		{"radius too large", 32.0754, 34.7753, 1000000},
+	} {
+		resp, err = schedulesClient.Near("", tc.lat, tc.lng, tc.r, 10, 0)
+		if err != nil {
+			t.Fatalf("HTTP request failed: %v", err)
+		}
+		if resp.StatusCode != 400 {
+			t.Errorf("%s: expected status 400, got %d", tc.name, resp.StatusCode)
+		}
+	}
+
+	invalid := createValidSchedule("Bad Location")
+	invalid["location"] = map[string]any{"type": "Point", "coordinates": []float64{200, 32}}
+	resp, err = schedulesClient.Create(invalid)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 422)
+}
+
+func testCloneSchedule(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	req := createValidSchedule("Clone Source")
+	req["exceptions"] = closedDates("2030-12-25")
+	req["weekly_hours"] = []map[string]any{
+		{"day": "sunday", "intervals": []map[string]any{{"start": "09:00", "end": "13:00"}, {"start": "16:00", "end": "20:00"}}},
+		{"day": "monday", "intervals": []map[string]any{{"start": "10:00", "end": "18:00"}}},
+	}
+	createResp, err := schedulesClient.Create(req)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, createResp, 201)
+	source := decodeSchedule(t, createResp)
+
+	resp, err := schedulesClient.Clone(source.ID, map[string]any{"city": "Haifa", "address": "Herzl 1"})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 201)
+	clone := decodeSchedule(t, resp)
+	if clone.ID == source.ID || clone.Name != source.Name || clone.City != "haifa" || clone.Address != "Herzl 1" {
+		t.Errorf("unexpected clone identity: %+v", clone)
+	}
+	if len(clone.WeeklyHours) != 2 || len(clone.WeeklyHours[0].Intervals) != 2 {
+		t.Errorf("weekly hours not copied: %+v", clone.WeeklyHours)
+	}
+	if len(clone.Exceptions) != 1 || clone.TimeZone != source.TimeZone || clone.DefaultMeetingDurationMin != source.DefaultMeetingDurationMin {
+		t.Errorf("configuration not copied: %+v", clone)
+	}
+
+	// the same address is rejected by the duplication check
+	resp, err = schedulesClient.Clone(source.ID, map[string]any{"city": "Haifa", "address": "Herzl 1"})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 409)
+
+	resp, err = schedulesClient.Clone(source.ID, map[string]any{"city": "Haifa"})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 422)
+
+	resp, err = schedulesClient.Clone("507f1f77bcf86cd799439099", map[string]any{"city": "Haifa", "address": "Herzl 2"})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 404)
+}
+
+func testScheduleTemplates(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	businessID := "507f1f77bcf86cd799439011"
+	template := map[string]any{
+		"business_id":  businessID,
+		"name":         fmt.Sprintf("Template %d", time.Now().UnixNano()),
+		"working_days": []string{"Sunday", "Tuesday"},
+		"start_of_day": "08:00",
+		"end_of_day":   "14:00",
+		"time_zone":    "Asia/Jerusalem",
+	}
+	resp, err := schedulesClient.CreateTemplate(template)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 201)
+	created, err := schedulesClient.DecodeScheduleTemplate(resp)
+	if err != nil {
+		t.Fatalf("failed to decode schedule template: %v", err)
+	}
+	defer schedulesClient.DeleteTemplate(created.ID)
+	if created.ID == "" || len(created.WeeklyHours) != 2 || created.DefaultMeetingDurationMin == 0 {
+		t.Errorf("template not normalized: %+v", created)
+	}
+
+	resp, err = schedulesClient.CreateTemplate(template)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 409)
+
+	invalid := map[string]any{
+		"business_id":  businessID,
+		"name":         "Invalid Template",
+		"working_days": []string{"Sunday"},
+		"start_of_day": "14:00",
+		"end_of_day":   "08:00",
+		"time_zone":    "Asia/Jerusalem",
+	}
+	resp, err = schedulesClient.CreateTemplate(invalid)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 422)
+
+	resp, err = schedulesClient.SearchTemplates(businessID, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	_, count, _, _ := decodePaginated(t, resp)
+	if count < 1 {
+		t.Errorf("expected the template in search results, got %d", count)
+	}
+
+	resp, err = schedulesClient.CreateFromTemplate(created.ID, map[string]any{"city": "Eilat", "address": "Hatmarim 5"})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 201)
+	sc := decodeSchedule(t, resp)
+	if sc.Name != created.Name || sc.StartOfDay != "08:00" || sc.EndOfDay != "14:00" || len(sc.WorkingDays) != 2 {
+		t.Errorf("schedule does not match template: %+v", sc)
+	}
+
+	resp, err = schedulesClient.CreateFromTemplate(created.ID, map[string]any{"city": "Eilat", "address": "Hatmarim 5"})
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 409)
+
+	resp, err = schedulesClient.DeleteTemplate(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 204)
+
+	resp, err = schedulesClient.GetTemplateByID(created.ID)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 404)
+}
+
+func testCityAliases(t *testing.T) {
+	defer common.ClearTestData(t, httpClient, TableName)
+
+	businessID := "507f1f77bcf86cd799439555"
+	req := createValidSchedule("Gazetteer")
+	req["business_id"] = businessID
+	req["city"] = "TLV"
+	resp, err := schedulesClient.Create(req)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 201)
+	created := decodeSchedule(t, resp)
+	if created.City != "tel_aviv" {
+		t.Errorf("expected city tel_aviv, got %s", created.City)
+	}
+
+	req = createValidSchedule("Gazetteer Haifa")
+	req["business_id"] = businessID
+	req["city"] = "חיפה"
+	resp, err = schedulesClient.Create(req)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 201)
+
+	for _, alias := range []string{"Tel Aviv", "tel-aviv", "תל אביב", "Tel Aviv-Yafo"} {
+		resp, err := schedulesClient.Search(businessID, alias, 10, 0)
+		if err != nil {
+			t.Fatalf("HTTP request failed: %v", err)
+		}
+		common.AssertStatusCode(t, resp, 200)
+		if results := decodeSchedules(t, resp); len(results) != 1 || results[0].ID != created.ID {
+			t.Errorf("expected the Tel Aviv schedule for %q, got %d results", alias, len(results))
+		}
+	}
+
+	resp, err = schedulesClient.BatchSearch(businessID, []string{"TLV", "Hefa"}, 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	if results := decodeSchedules(t, resp); len(results) != 2 {
+		t.Errorf("expected both schedules by their aliases, got %d", len(results))
+	}
+
+	resp, err = schedulesClient.Search(businessID, "Tel", 10, 0)
+	if err != nil {
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	common.AssertStatusCode(t, resp, 200)
+	if results := decodeSchedules(t, resp); len(results) != 0 {
+		t.Errorf("expected a partial city name to match nothing, got %d results", len(results))
+	}
+}
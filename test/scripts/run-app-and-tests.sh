@@ -56,6 +56,9 @@ set_vars() {
     TEST_SERVER_PORT=${TEST_SERVER_PORT:-8080}
     TEST_MONGO_URI=${TEST_MONGO_URI:-"mongodb://localhost:27017/?directConnection=true"}
     TEST_DB_NAME=${TEST_DB_NAME:-"skeji_test"}
+    TEST_DEPENDENCIES_PORT=${TEST_DEPENDENCIES_PORT:-8081}
+    # shared by the app and the tests, which sign their calls as the system
+    export CALLER_IDENTITY_SECRET=${CALLER_IDENTITY_SECRET:-"test-caller-identity-secret"}
 
     APP_BINARY="$PROJECT_ROOT/bin/$APP_NAME"
     APP_PID_FILE="/tmp/${APP_NAME}-test.pid"
@@ -84,6 +87,10 @@ start_app() {
     export MONGO_DATABASE_NAME="$TEST_DB_NAME"
     export PORT="$TEST_SERVER_PORT"
     export LOG_LEVEL="${LOG_LEVEL:-info}"
+    # the other services are stubbed by the test suite on this port
+    export BUSINESS_UNIT_BASE_URL="http://localhost:$TEST_DEPENDENCIES_PORT"
+    export SCHEDULE_BASE_URL="http://localhost:$TEST_DEPENDENCIES_PORT"
+    export BOOKING_BASE_URL="http://localhost:$TEST_DEPENDENCIES_PORT"
 
     if $VERBOSE; then
         ("$APP_BINARY") 2>&1 | tee "$LOG_FILE" &
@@ -127,6 +134,7 @@ run_tests() {
     TEST_SERVER_URL="http://localhost:$TEST_SERVER_PORT" \
     TEST_MONGO_URI="$TEST_MONGO_URI" \
     TEST_DB_NAME="$TEST_DB_NAME" \
+    TEST_DEPENDENCIES_PORT="$TEST_DEPENDENCIES_PORT" \
     bash -c "$test_cmd"
 }
 